@@ -1,13 +1,50 @@
 // Package ast defines the Abstract Syntax Tree node types for the Cow language.
 package ast
 
+import "fmt"
+
 // Node is the base interface for all AST nodes.
 type Node interface {
 	// TokenLiteral returns the literal value of the token that produced this node.
 	// Useful for debugging and error messages.
 	TokenLiteral() string
+
+	// Pos returns where the node starts in the source code.
+	Pos() Position
+
+	// End returns the position just past the node's last character.
+	End() Position
 }
 
+// Position is a location in source code.
+// The zero Position means the location is unknown (e.g., for nodes built by
+// hand in tests).
+type Position struct {
+	Line   int // Line number (1-indexed)
+	Column int // Column number (1-indexed)
+}
+
+// IsValid reports whether the position is known.
+func (p Position) IsValid() bool { return p.Line > 0 }
+
+// String formats the position as line:column.
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Span is the source range of a node, from its first token to the end of its
+// last one. Every node type embeds a Span, set by the converter.
+type Span struct {
+	Start Position // Position of the first character
+	Stop  Position // Position just past the last character
+}
+
+// Pos returns the start of the span.
+func (s Span) Pos() Position { return s.Start }
+
+// End returns the position just past the end of the span.
+func (s Span) End() Position { return s.Stop }
+
 // Statement represents a statement in the program.
 // Statements do not produce values (or produce unit/void).
 type Statement interface {
@@ -25,6 +62,7 @@ type Expression interface {
 // Program is the root node of the AST.
 // It contains a list of statements that make up the program.
 type Program struct {
+	Span
 	Statements []Statement
 }
 
@@ -38,6 +76,7 @@ func (p *Program) TokenLiteral() string {
 // ExpressionStatement wraps an expression as a statement.
 // Used for expressions that are evaluated for their side effects.
 type ExpressionStatement struct {
+	Span
 	Token      string     // The first token of the expression
 	Expression Expression // The expression being evaluated
 }
@@ -46,11 +85,17 @@ func (es *ExpressionStatement) statementNode()       {}
 func (es *ExpressionStatement) TokenLiteral() string { return es.Token }
 
 // LetStatement represents a variable declaration with initialization.
-// Syntax: let <name> = <value>
+// Syntax: let <name> = <value> or let mut <name> = <value>
+// Syntax: let (<pattern>, ...) = <value> destructures a tuple
+// Syntax: let <name>: <type> = <value> annotates the binding's type
 type LetStatement struct {
-	Token string     // The 'let' token
-	Name  string     // The variable name
-	Value Expression // The initialization expression
+	Span
+	Token   string         // The 'let' token
+	Name    string         // The variable name (empty when Pattern is set)
+	Pattern Pattern        // The destructuring pattern (nil for a plain name)
+	Mutable bool           // True for 'let mut' bindings, which may be reassigned
+	Type    TypeExpression // The annotated type (nil if unannotated)
+	Value   Expression     // The initialization expression
 }
 
 func (ls *LetStatement) statementNode()       {}
@@ -58,6 +103,7 @@ func (ls *LetStatement) TokenLiteral() string { return ls.Token }
 
 // IntLiteral represents an integer literal.
 type IntLiteral struct {
+	Span
 	Token string // The token text (e.g., "42", "0xFF")
 	Value int64  // The parsed integer value
 }
@@ -67,6 +113,7 @@ func (il *IntLiteral) TokenLiteral() string { return il.Token }
 
 // FloatLiteral represents a floating-point literal.
 type FloatLiteral struct {
+	Span
 	Token string  // The token text (e.g., "3.14", "1.5e10")
 	Value float64 // The parsed float value
 }
@@ -76,6 +123,7 @@ func (fl *FloatLiteral) TokenLiteral() string { return fl.Token }
 
 // BoolLiteral represents a boolean literal (true or false).
 type BoolLiteral struct {
+	Span
 	Token string // The token text ("true" or "false")
 	Value bool   // The boolean value
 }
@@ -87,6 +135,7 @@ func (bl *BoolLiteral) TokenLiteral() string { return bl.Token }
 // For regular strings ("..."), escape sequences are processed.
 // For raw strings (`...`), the value is taken as-is.
 type StringLiteral struct {
+	Span
 	Token string // The token text (e.g., "hello", `world`)
 	Value string // The processed string value
 }
@@ -96,6 +145,7 @@ func (sl *StringLiteral) TokenLiteral() string { return sl.Token }
 
 // FunctionCall represents a function call expression.
 type FunctionCall struct {
+	Span
 	Token     string       // The function name token
 	Name      string       // The function name (e.g., "println")
 	Arguments []Expression // The function arguments
@@ -106,6 +156,7 @@ func (fc *FunctionCall) TokenLiteral() string { return fc.Token }
 
 // Identifier represents a variable reference in an expression.
 type Identifier struct {
+	Span
 	Token string // The identifier token
 	Name  string // The variable name
 }
@@ -117,6 +168,7 @@ func (i *Identifier) TokenLiteral() string { return i.Token }
 // Handles arithmetic (+, -, *, /, %), comparison (<, >, <=, >=),
 // equality (==, !=), and logical (&&, ||) operators.
 type BinaryExpression struct {
+	Span
 	Token    string     // The operator token
 	Left     Expression // The left operand
 	Operator string     // The operator
@@ -128,6 +180,7 @@ func (be *BinaryExpression) TokenLiteral() string { return be.Token }
 
 // UnaryExpression represents a unary operation (e.g., !true, -5).
 type UnaryExpression struct {
+	Span
 	Token    string     // The operator token
 	Operator string     // The operator (!, -)
 	Operand  Expression // The operand
@@ -138,11 +191,16 @@ func (ue *UnaryExpression) TokenLiteral() string { return ue.Token }
 
 // FunctionDef represents a named function definition statement.
 // Syntax: fn name(params) { body }
+// Syntax: fn name<T>(x: T, y: i32) -> T { body } with type annotations
 type FunctionDef struct {
-	Token      string   // The 'fn' token
-	Name       string   // The function name
-	Parameters []string // Parameter names
-	Body       *Block   // Function body
+	Span
+	Token      string           // The 'fn' token
+	Name       string           // The function name
+	TypeParams []string         // Type parameter names (empty if not generic)
+	Parameters []string         // Parameter names
+	ParamTypes []TypeExpression // Annotated parameter types (nil entries for unannotated parameters)
+	ReturnType TypeExpression   // Annotated return type (nil if unannotated)
+	Body       *Block           // Function body
 }
 
 func (fd *FunctionDef) statementNode()       {}
@@ -151,6 +209,7 @@ func (fd *FunctionDef) TokenLiteral() string { return fd.Token }
 // Block represents a block of statements enclosed in braces.
 // Used for function bodies and other block contexts.
 type Block struct {
+	Span
 	Token      string      // The '{' token
 	Statements []Statement // Statements in the block
 }
@@ -161,6 +220,7 @@ func (b *Block) TokenLiteral() string { return b.Token }
 // ReturnStatement represents a return statement in a function.
 // Syntax: return expression
 type ReturnStatement struct {
+	Span
 	Token string     // The 'return' token
 	Value Expression // The value to return
 }
@@ -171,9 +231,10 @@ func (rs *ReturnStatement) TokenLiteral() string { return rs.Token }
 // ForStatement represents a for loop.
 // Syntax: for { body } (infinite) or for condition { body } (while-style)
 type ForStatement struct {
-	Token     string      // The 'for' token
-	Condition Expression  // Optional condition (nil for infinite loops)
-	Body      *Block      // Loop body
+	Span
+	Token     string     // The 'for' token
+	Condition Expression // Optional condition (nil for infinite loops)
+	Body      *Block     // Loop body
 }
 
 func (fs *ForStatement) statementNode()       {}
@@ -182,6 +243,7 @@ func (fs *ForStatement) TokenLiteral() string { return fs.Token }
 // BreakStatement represents a break statement to exit a loop.
 // Syntax: break
 type BreakStatement struct {
+	Span
 	Token string // The 'break' token
 }
 
@@ -191,6 +253,7 @@ func (bs *BreakStatement) TokenLiteral() string { return bs.Token }
 // ContinueStatement represents a continue statement to skip to next iteration.
 // Syntax: continue
 type ContinueStatement struct {
+	Span
 	Token string // The 'continue' token
 }
 
@@ -201,17 +264,142 @@ func (cs *ContinueStatement) TokenLiteral() string { return cs.Token }
 // Syntax: fn(params) { body }
 // Enables first-class functions (assignable to variables, passable as arguments).
 type FunctionLiteral struct {
-	Token      string   // The 'fn' token
-	Parameters []string // Parameter names
-	Body       *Block   // Function body
+	Span
+	Token      string           // The 'fn' token
+	Parameters []string         // Parameter names
+	ParamTypes []TypeExpression // Annotated parameter types (nil entries for unannotated parameters)
+	ReturnType TypeExpression   // Annotated return type (nil if unannotated)
+	Body       *Block           // Function body
 }
 
 func (fl *FunctionLiteral) expressionNode()      {}
 func (fl *FunctionLiteral) TokenLiteral() string { return fl.Token }
 
+// IfExpression represents a conditional expression.
+// Syntax: if condition { ... } else if condition { ... } else { ... }
+// The value of the expression is the value of the block that was chosen.
+type IfExpression struct {
+	Span
+	Token       string     // The 'if' token
+	Condition   Expression // The condition (must evaluate to a boolean)
+	Consequence *Block     // Block evaluated when the condition is true
+	Alternative *Block     // Optional else block (nil if absent); "else if" is a block holding a nested IfExpression
+}
+
+func (ie *IfExpression) expressionNode()      {}
+func (ie *IfExpression) TokenLiteral() string { return ie.Token }
+
+// MatchExpression represents pattern matching over a value.
+// Syntax: match subject { Pattern => body, ... }
+// The value of the expression is the value of the first arm whose pattern matches.
+type MatchExpression struct {
+	Span
+	Token   string      // The 'match' token
+	Subject Expression  // The value being matched
+	Arms    []*MatchArm // The arms, tried in order
+}
+
+func (me *MatchExpression) expressionNode()      {}
+func (me *MatchExpression) TokenLiteral() string { return me.Token }
+
+// MatchArm represents one arm of a match expression.
+// Syntax: Pattern => expression or Pattern => { ... }
+type MatchArm struct {
+	Span
+	Token   string  // The first token of the pattern
+	Pattern Pattern // The pattern to match against
+	Body    *Block  // The arm body; an expression body is a block holding one ExpressionStatement
+}
+
+func (ma *MatchArm) TokenLiteral() string { return ma.Token }
+
+// Pattern represents a pattern in a match arm.
+type Pattern interface {
+	Node
+	patternNode()
+}
+
+// WildcardPattern matches any value without binding it.
+// Syntax: _
+type WildcardPattern struct {
+	Span
+	Token string // The '_' token
+}
+
+func (wp *WildcardPattern) patternNode()         {}
+func (wp *WildcardPattern) TokenLiteral() string { return wp.Token }
+
+// BindingPattern matches any value and binds it to a name.
+// Syntax: a lowercase identifier, e.g. x
+type BindingPattern struct {
+	Span
+	Token string // The identifier token
+	Name  string // The name to bind
+}
+
+func (bp *BindingPattern) patternNode()         {}
+func (bp *BindingPattern) TokenLiteral() string { return bp.Token }
+
+// LiteralPattern matches a value equal to a literal.
+// Syntax: 42, -1, 3.14, "text", true
+type LiteralPattern struct {
+	Span
+	Token string     // The literal token
+	Value Expression // The literal (IntLiteral, FloatLiteral, StringLiteral or BoolLiteral)
+}
+
+func (lp *LiteralPattern) patternNode()         {}
+func (lp *LiteralPattern) TokenLiteral() string { return lp.Token }
+
+// ConstructorPattern matches a variant built by a constructor, with patterns for its fields.
+// Syntax: None, Some(x), Node(left, _, right)
+type ConstructorPattern struct {
+	Span
+	Token string    // The constructor name token
+	Name  string    // The constructor name
+	Args  []Pattern // Patterns for the constructor's fields
+}
+
+func (cp *ConstructorPattern) patternNode()         {}
+func (cp *ConstructorPattern) TokenLiteral() string { return cp.Token }
+
+// ArrayPattern matches an array of exactly the given length, element by element.
+// Syntax: [], [x], [first, _, 3]
+type ArrayPattern struct {
+	Span
+	Token    string    // The '[' token
+	Elements []Pattern // Patterns for the elements
+}
+
+func (ap *ArrayPattern) patternNode()         {}
+func (ap *ArrayPattern) TokenLiteral() string { return ap.Token }
+
+// TuplePattern matches a tuple element by element.
+// Syntax: (a, b), (x, _, 3); () matches the unit value
+type TuplePattern struct {
+	Span
+	Token    string    // The '(' token
+	Elements []Pattern // Patterns for the elements
+}
+
+func (tp *TuplePattern) patternNode()         {}
+func (tp *TuplePattern) TokenLiteral() string { return tp.Token }
+
+// TupleLiteral represents a tuple expression.
+// Syntax: (a, b), (a,) for a single element, or () for the unit value
+type TupleLiteral struct {
+	Span
+	Token    string       // The '(' token
+	Elements []Expression // The tuple elements (empty for unit)
+}
+
+func (tl *TupleLiteral) expressionNode()      {}
+func (tl *TupleLiteral) TokenLiteral() string { return tl.Token }
+
 // ArrayLiteral represents an array literal expression.
 // Syntax: [elem1, elem2, ...] or []
 type ArrayLiteral struct {
+	Span
 	Token    string       // The '[' token
 	Elements []Expression // The array elements
 }
@@ -219,10 +407,59 @@ type ArrayLiteral struct {
 func (al *ArrayLiteral) expressionNode()      {}
 func (al *ArrayLiteral) TokenLiteral() string { return al.Token }
 
+// RecordLiteral represents a record value built from named fields.
+// Syntax: { x: 1.0, y: 2.0 }
+// The record type is the declared record type with exactly these fields.
+type RecordLiteral struct {
+	Span
+	Token  string        // The '{' token
+	Fields []*FieldValue // The field values, in source order
+}
+
+func (rl *RecordLiteral) expressionNode()      {}
+func (rl *RecordLiteral) TokenLiteral() string { return rl.Token }
+
+// RecordUpdate represents a copy of a record with some fields replaced.
+// Syntax: { p with x: 1.0, y: 2.0 }
+// The base record is not modified.
+type RecordUpdate struct {
+	Span
+	Token  string        // The '{' token
+	Base   Expression    // The record being copied
+	Fields []*FieldValue // The replaced fields
+}
+
+func (ru *RecordUpdate) expressionNode()      {}
+func (ru *RecordUpdate) TokenLiteral() string { return ru.Token }
+
+// FieldValue represents one named field in a record literal or update.
+// Syntax: name: value
+type FieldValue struct {
+	Span
+	Token string     // The field name token
+	Name  string     // The field name
+	Value Expression // The field value
+}
+
+func (fv *FieldValue) TokenLiteral() string { return fv.Token }
+
+// BlockExpression represents a block used as an expression.
+// Syntax: { statements... }
+// The value is the value of the block's trailing expression statement.
+type BlockExpression struct {
+	Span
+	Token string // The '{' token
+	Block *Block // The block
+}
+
+func (be *BlockExpression) expressionNode()      {}
+func (be *BlockExpression) TokenLiteral() string { return be.Token }
+
 // IndexAccess represents array/collection indexing.
 // Syntax: arr[index]
 // The Object will typically be an Identifier or another IndexAccess (for multi-dimensional arrays).
 type IndexAccess struct {
+	Span
 	Token  string     // The '[' token
 	Object Expression // The array/object being indexed
 	Index  Expression // The index expression
@@ -233,8 +470,9 @@ func (ia *IndexAccess) TokenLiteral() string { return ia.Token }
 
 // MemberAccess represents accessing a member/method of an object.
 // Syntax: obj.member
-// Used for array methods like arr.len(), arr.push(item), arr.pop()
+// Used for record fields like p.x and array methods like arr.len(), arr.push(item), arr.pop()
 type MemberAccess struct {
+	Span
 	Token  string     // The '.' token
 	Object Expression // The object being accessed
 	Member string     // The member name
@@ -243,13 +481,119 @@ type MemberAccess struct {
 func (ma *MemberAccess) expressionNode()      {}
 func (ma *MemberAccess) TokenLiteral() string { return ma.Token }
 
-// IndexAssignment represents assignment to an array index.
-// Syntax: arr[index] = value or arr[i][j] = value
+// TypeDeclaration represents an algebraic data type or record type declaration.
+// Syntax: type Name<T, ...> = Variant1 of Type | Variant2 | ...
+// Syntax: type Name = { field1: Type, field2: Type, ... }
+// Each variant becomes a constructor; variants without a payload are values.
+// A record type has Fields and no Variants.
+type TypeDeclaration struct {
+	Span
+	Token      string         // The 'type' token
+	Name       string         // The type name
+	TypeParams []string       // Type parameter names (e.g., T in Option<T>)
+	Variants   []*VariantDecl // The variants (constructors) of the type (nil for record types)
+	Fields     []*FieldDecl   // The fields of a record type (nil for variant types)
+}
+
+func (td *TypeDeclaration) statementNode()       {}
+func (td *TypeDeclaration) TokenLiteral() string { return td.Token }
+
+// VariantDecl represents one variant of a type declaration.
+// Syntax: Name or Name of Type or Name of (Type1, Type2, ...)
+type VariantDecl struct {
+	Span
+	Token  string           // The variant name token
+	Name   string           // The constructor name
+	Fields []TypeExpression // Field types (empty for constructors without a payload)
+}
+
+func (vd *VariantDecl) TokenLiteral() string { return vd.Token }
+
+// FieldDecl represents one field of a record type declaration.
+// Syntax: name: Type
+type FieldDecl struct {
+	Span
+	Token string         // The field name token
+	Name  string         // The field name
+	Type  TypeExpression // The field type
+}
+
+func (fd *FieldDecl) TokenLiteral() string { return fd.Token }
+
+// TypeExpression represents a type written in source code.
+type TypeExpression interface {
+	Node
+	typeNode()
+}
+
+// NamedType represents a type referenced by name, with optional type arguments.
+// Syntax: i32, T, Option<T>, Result<T, E>
+type NamedType struct {
+	Span
+	Token string           // The type name token
+	Name  string           // The type name
+	Args  []TypeExpression // Type arguments (empty if none)
+}
+
+func (nt *NamedType) typeNode()            {}
+func (nt *NamedType) TokenLiteral() string { return nt.Token }
+
+// TupleType represents a parenthesized list of types.
+// Syntax: (Type1, Type2, ...) or () for the unit type
+type TupleType struct {
+	Span
+	Token    string           // The '(' token
+	Elements []TypeExpression // The element types
+}
+
+func (tt *TupleType) typeNode()            {}
+func (tt *TupleType) TokenLiteral() string { return tt.Token }
+
+// ArrayType represents the type of arrays with a given element type.
+// Syntax: [Type]
+type ArrayType struct {
+	Span
+	Token   string         // The '[' token
+	Element TypeExpression // The element type
+}
+
+func (at *ArrayType) typeNode()            {}
+func (at *ArrayType) TokenLiteral() string { return at.Token }
+
+// FunctionType represents the type of functions.
+// Syntax: fn(Type1, Type2, ...) -> ReturnType
+type FunctionType struct {
+	Span
+	Token  string           // The 'fn' token
+	Params []TypeExpression // The parameter types
+	Return TypeExpression   // The return type
+}
+
+func (ft *FunctionType) typeNode()            {}
+func (ft *FunctionType) TokenLiteral() string { return ft.Token }
+
+// Assignment represents reassignment of a variable.
+// Syntax: name = value
+// Only bindings declared with 'let mut' may be reassigned.
+type Assignment struct {
+	Span
+	Token string     // The identifier token
+	Name  string     // The variable name
+	Value Expression // The value to assign
+}
+
+func (a *Assignment) statementNode()       {}
+func (a *Assignment) TokenLiteral() string { return a.Token }
+
+// IndexAssignment represents assignment to an array element or record field
+// inside a variable.
+// Syntax: arr[index] = value, arr[i][j] = value or p.items[0] = value
 type IndexAssignment struct {
-	Token   string       // The identifier token
-	Name    string       // The array variable name
-	Indices []Expression // The index expressions (one for arr[0], multiple for arr[i][j])
-	Value   Expression   // The value to assign
+	Span
+	Token  string     // The identifier token
+	Name   string     // The variable holding the array or record
+	Target Expression // The place assigned: an IndexAccess or MemberAccess chain starting at Name
+	Value  Expression // The value to assign
 }
 
 func (ia *IndexAssignment) statementNode()       {}
@@ -10,6 +10,7 @@ import (
 func main() {
 	config := cli.Config{
 		Args:   os.Args,
+		Input:  os.Stdin,
 		Output: os.Stdout,
 	}
 
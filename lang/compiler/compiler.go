@@ -0,0 +1,661 @@
+// Package compiler lowers a Cow program to bytecode for the stack machine in
+// package vm.
+//
+// Local variables are resolved to numbered slots in their function's frame
+// when the program is compiled, so the VM never looks names up at run time.
+// Top-level variables are globals, numbered too; as in the tree-walking
+// evaluator, a function sees the globals defined when it runs, so it may use
+// functions defined after it. A local variable used by a nested function is
+// kept in a cell, which closures share.
+//
+// Scoping follows the evaluator: function bodies, if and else blocks, match
+// arms, block expressions and each iteration of a loop body start a new
+// scope; bare blocks do not.
+package compiler
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+	"github.com/shadowCow/cow-lang-go/lang/eval"
+)
+
+// compiler holds the state shared by the functions of a program.
+type compiler struct {
+	globals     map[string]int         // Global variable indices by name
+	globalNames []string               // Global variable names by index
+	records     []*ast.TypeDeclaration // Record types, in declaration order
+}
+
+// funcCompiler compiles one function.
+type funcCompiler struct {
+	*compiler
+	enclosing *funcCompiler   // The function this one is nested in (nil for the top level)
+	fn        *Function       // The function being compiled
+	scope     *scope          // The innermost scope
+	captured  map[string]bool // Names used by nested functions; locals with these names get cells
+	constants map[constKey]int
+	upvalues  map[string]int // Upvalue indices by captured variable name
+	mutable   []bool         // Whether each upvalue was declared with 'let mut'
+	loops     []*loop        // The loops being compiled, innermost last
+	depth     int            // Number of operands on the stack
+	statement ast.Span       // The top-level statement being compiled, for the top level's errors
+}
+
+// scope is a scope of local variables.
+type scope struct {
+	parent *scope
+	locals map[string]*local
+	cells  []int // Slots of the locals in cells, created on entry to the scope
+	global bool  // The top-level scope, whose variables are globals
+}
+
+// local is a local variable.
+type local struct {
+	slot    int
+	mutable bool
+	cell    bool // True if nested functions use the variable, so it is kept in a cell
+}
+
+// loop is a loop being compiled.
+type loop struct {
+	start  int   // Code offset of the condition
+	depth  int   // Operand depth outside the loop
+	breaks []int // Offsets of the jumps out of the loop, patched at its end
+}
+
+// constKey identifies a constant in a function's pool. Floats are keyed by
+// their bits, so that 0.0 and -0.0 are different constants.
+type constKey struct {
+	kind  string
+	value interface{}
+}
+
+// Compile compiles a program.
+func Compile(program *ast.Program) (*Program, error) {
+	c := &compiler{globals: make(map[string]int)}
+
+	// Record literals are resolved when compiled, against all record types
+	// in the program, as the type checker does
+	for _, stmt := range program.Statements {
+		if decl, ok := stmt.(*ast.TypeDeclaration); ok && decl.Fields != nil {
+			c.records = append(c.records, decl)
+		}
+	}
+
+	f := c.newFunction(nil, "")
+	f.scope = &scope{locals: make(map[string]*local), global: true}
+	f.captured = capturedNames(program.Statements)
+	for _, stmt := range program.Statements {
+		f.statement = spanOf(stmt)
+		if err := f.compileStatement(stmt); err != nil {
+			return nil, err
+		}
+	}
+	f.emit(program.Span, OpConstant, f.constant(eval.Unit{}))
+	f.emit(program.Span, OpReturn)
+
+	return &Program{Main: f.fn, Globals: c.globalNames}, nil
+}
+
+// newFunction starts compiling a function nested in enclosing.
+func (c *compiler) newFunction(enclosing *funcCompiler, name string) *funcCompiler {
+	return &funcCompiler{
+		compiler:  c,
+		enclosing: enclosing,
+		fn:        &Function{Name: name},
+		constants: make(map[constKey]int),
+		upvalues:  make(map[string]int),
+	}
+}
+
+// global returns the index of a global variable.
+func (c *compiler) global(name string) int {
+	if index, ok := c.globals[name]; ok {
+		return index
+	}
+	c.globals[name] = len(c.globalNames)
+	c.globalNames = append(c.globalNames, name)
+	return c.globals[name]
+}
+
+// compileFunction compiles a function definition or literal.
+func (f *funcCompiler) compileFunction(name string, params []string, body *ast.Block) (*Function, error) {
+	g := f.newFunction(f, name)
+	g.fn.Arity = len(params)
+	g.captured = capturedNames(body.Statements)
+	g.scope = &scope{locals: make(map[string]*local)}
+
+	// Parameters take the first slots, in order
+	for _, param := range params {
+		slot := g.newSlot(param)
+		l := &local{slot: slot, cell: g.captured[param]}
+		if l.cell {
+			g.fn.Boxed = append(g.fn.Boxed, slot)
+		}
+		g.scope.locals[param] = l
+	}
+
+	cells := g.makeCells(body.Span)
+	if err := g.compileBlockValue(body); err != nil {
+		return nil, err
+	}
+	g.fillCells(cells)
+	g.emit(body.Span, OpReturn)
+	return g.fn, nil
+}
+
+// beginScope starts a new scope of local variables.
+// Returns the constant listing the scope's cells, to pass to endScope.
+func (f *funcCompiler) beginScope(span ast.Span) int {
+	f.scope = &scope{parent: f.scope, locals: make(map[string]*local)}
+	return f.makeCells(span)
+}
+
+// endScope ends the innermost scope.
+func (f *funcCompiler) endScope(cells int) {
+	f.fillCells(cells)
+	f.scope = f.scope.parent
+}
+
+// makeCells emits the instruction creating the cells of the innermost
+// scope, whose slots are only known once the scope is compiled.
+// Returns the constant to fill with them, or -1 if no local needs a cell.
+func (f *funcCompiler) makeCells(span ast.Span) int {
+	if len(f.captured) == 0 {
+		return -1
+	}
+	index := len(f.fn.Constants)
+	f.fn.Constants = append(f.fn.Constants, []int(nil))
+	f.emit(span, OpMakeCells, index)
+	return index
+}
+
+// fillCells fills the constant emitted by makeCells.
+func (f *funcCompiler) fillCells(cells int) {
+	if cells >= 0 {
+		f.fn.Constants[cells] = f.scope.cells
+	}
+}
+
+// newSlot allocates a local slot.
+func (f *funcCompiler) newSlot(name string) int {
+	f.fn.NumLocals++
+	f.fn.LocalNames = append(f.fn.LocalNames, name)
+	return f.fn.NumLocals - 1
+}
+
+// declare declares a local variable in the innermost scope, which must not
+// be the top-level scope. Redeclaring a name in the same scope reuses its
+// slot, as the evaluator rebinds it.
+func (f *funcCompiler) declare(name string, mutable bool) *local {
+	if l, ok := f.scope.locals[name]; ok {
+		l.mutable = mutable
+		return l
+	}
+	l := &local{slot: f.newSlot(name), mutable: mutable, cell: f.captured[name]}
+	if l.cell {
+		f.scope.cells = append(f.scope.cells, l.slot)
+	}
+	f.scope.locals[name] = l
+	return l
+}
+
+// variable is a resolved variable reference.
+type variable struct {
+	kind    int // CalleeLocal, CalleeCell, CalleeUpvalue or CalleeGlobal
+	index   int
+	mutable bool // For locals and upvalues, whether the variable was declared with 'let mut'
+}
+
+// resolve resolves a variable name: to a local of this function, a cell
+// captured from an enclosing function, or else a global.
+func (f *funcCompiler) resolve(name string) (variable, error) {
+	if l := f.lookupLocal(name); l != nil {
+		if l.cell {
+			return variable{kind: CalleeCell, index: l.slot, mutable: l.mutable}, nil
+		}
+		return variable{kind: CalleeLocal, index: l.slot, mutable: l.mutable}, nil
+	}
+
+	index, err := f.resolveUpvalue(name)
+	if err != nil {
+		return variable{}, err
+	}
+	if index >= 0 {
+		return variable{kind: CalleeUpvalue, index: index, mutable: f.mutable[index]}, nil
+	}
+	return variable{kind: CalleeGlobal, index: f.global(name)}, nil
+}
+
+// lookupLocal finds a local variable of this function in scope.
+func (f *funcCompiler) lookupLocal(name string) *local {
+	for s := f.scope; s != nil && !s.global; s = s.parent {
+		if l, ok := s.locals[name]; ok {
+			return l
+		}
+	}
+	return nil
+}
+
+// resolveUpvalue finds a local variable of an enclosing function, adding
+// the upvalues that capture its cell. Returns -1 if there is none.
+func (f *funcCompiler) resolveUpvalue(name string) (int, error) {
+	if index, ok := f.upvalues[name]; ok {
+		return index, nil
+	}
+	if f.enclosing == nil {
+		return -1, nil
+	}
+
+	upvalue := Upvalue{Name: name}
+	var mutable bool
+	if l := f.enclosing.lookupLocal(name); l != nil {
+		if !l.cell {
+			return -1, fmt.Errorf("internal error: captured variable %s has no cell", name)
+		}
+		upvalue.FromLocal, upvalue.Index, mutable = true, l.slot, l.mutable
+	} else {
+		index, err := f.enclosing.resolveUpvalue(name)
+		if err != nil || index < 0 {
+			return index, err
+		}
+		upvalue.Index, mutable = index, f.enclosing.mutable[index]
+	}
+
+	f.upvalues[name] = len(f.fn.Upvalues)
+	f.fn.Upvalues = append(f.fn.Upvalues, upvalue)
+	f.mutable = append(f.mutable, mutable)
+	return f.upvalues[name], nil
+}
+
+// emit appends an instruction, recording the span that locates its errors.
+// Returns the offset of the instruction.
+func (f *funcCompiler) emit(span ast.Span, op Opcode, operands ...int) int {
+	offset := len(f.fn.Code)
+	if n := len(f.fn.Spans); n == 0 || f.fn.Spans[n-1].Span != span {
+		f.fn.Spans = append(f.fn.Spans, SpanEntry{Offset: offset, Span: span})
+	}
+
+	f.fn.Code = append(f.fn.Code, byte(op))
+	for _, operand := range operands {
+		f.fn.Code = append(f.fn.Code, byte(operand>>8), byte(operand))
+	}
+
+	f.depth += f.stackEffect(op, operands)
+	if f.depth > f.fn.MaxStack {
+		f.fn.MaxStack = f.depth
+	}
+	return offset
+}
+
+// stackEffect returns the change in the number of operands on the stack
+// when an instruction runs to completion.
+func (f *funcCompiler) stackEffect(op Opcode, operands []int) int {
+	switch op {
+	case OpConstant, OpLoadLocal, OpLoadCell, OpLoadUpvalue, OpLoadGlobal, OpCallee, OpClosure:
+		return 1
+	case OpPop, OpStoreLocal, OpStoreCell, OpStoreUpvalue, OpDefineGlobal, OpSetGlobal, OpAssignGlobal,
+		OpAdd, OpSub, OpMul, OpDiv, OpMod, OpEqual, OpNotEqual, OpLess, OpLessEqual, OpGreater, OpGreaterEqual,
+		OpJumpIfFalse, OpJumpIfFalseKeep, OpJumpIfTrueKeep, OpReturn, OpPrint, OpIndex, OpDestructure:
+		return -1
+	case OpPopN, OpCall:
+		return -operands[0]
+	case OpArray, OpTuple:
+		return 1 - operands[0]
+	case OpRecord:
+		return 1 - len(f.fn.Constants[operands[0]].(*RecordShape).Fields)
+	case OpUpdateRecord:
+		return -len(f.fn.Constants[operands[0]].([]string))
+	case OpSetPath, OpPushPath:
+		return -(f.fn.Constants[operands[0]].(*Path).Indices() + 1)
+	case OpPopPath:
+		return 1 - f.fn.Constants[operands[0]].(*Path).Indices()
+	default:
+		return 0
+	}
+}
+
+// patchJump sets the target of the jump at offset to the current offset.
+func (f *funcCompiler) patchJump(offset int) {
+	target := len(f.fn.Code)
+	f.fn.Code[offset+1] = byte(target >> 8)
+	f.fn.Code[offset+2] = byte(target)
+}
+
+// checkSize reports an error if the function's code no longer fits the
+// 16-bit operands of jumps.
+func (f *funcCompiler) checkSize(node ast.Node) error {
+	if len(f.fn.Code) > math.MaxUint16 || len(f.fn.Constants) > math.MaxUint16 || f.fn.NumLocals > math.MaxUint16 {
+		return fmt.Errorf("%s: function is too large to compile", node.Pos())
+	}
+	return nil
+}
+
+// constant returns the index of a constant, adding it to the pool.
+// Numbers, strings, booleans and unit are pooled once per function.
+func (f *funcCompiler) constant(value interface{}) int {
+	var key constKey
+	switch v := value.(type) {
+	case int64, string, bool, eval.Unit:
+		key = constKey{kind: fmt.Sprintf("%T", v), value: v}
+	case float64:
+		key = constKey{kind: "float64", value: math.Float64bits(v)}
+	default:
+		f.fn.Constants = append(f.fn.Constants, value)
+		return len(f.fn.Constants) - 1
+	}
+
+	if index, ok := f.constants[key]; ok {
+		return index
+	}
+	f.fn.Constants = append(f.fn.Constants, value)
+	f.constants[key] = len(f.fn.Constants) - 1
+	return f.constants[key]
+}
+
+// fail emits an instruction failing with a message.
+func (f *funcCompiler) fail(span ast.Span, format string, args ...interface{}) {
+	f.emit(span, OpFail, f.constant(fmt.Sprintf(format, args...)))
+}
+
+// escape emits an instruction failing because a return, break or continue
+// has nothing to leave. In a function, the error is located at the call,
+// as in the evaluator; at the top level, at the statement.
+func (f *funcCompiler) escape(span ast.Span, message string) {
+	if f.enclosing == nil {
+		f.emit(f.statement, OpFail, f.constant(message))
+		return
+	}
+	f.emit(span, OpEscape, f.constant(message))
+}
+
+// compileStatement compiles a statement.
+func (f *funcCompiler) compileStatement(stmt ast.Statement) error {
+	span := spanOf(stmt)
+	switch s := stmt.(type) {
+	case *ast.LetStatement:
+		if err := f.compileExpression(s.Value); err != nil {
+			return err
+		}
+		if s.Pattern != nil {
+			pattern, err := f.compilePattern(s.Pattern, s.Mutable)
+			if err != nil {
+				return err
+			}
+			f.emit(span, OpDestructure, f.constant(pattern))
+			return nil
+		}
+		f.define(span, s.Name, s.Mutable)
+
+	case *ast.ExpressionStatement:
+		if err := f.compileExpression(s.Expression); err != nil {
+			return err
+		}
+		f.emit(span, OpPop)
+
+	case *ast.FunctionDef:
+		if !f.scope.global {
+			// Declared first, so the body can call it
+			f.declareKept(s.Name)
+		}
+		fn, err := f.compileFunction(s.Name, s.Parameters, s.Body)
+		if err != nil {
+			return err
+		}
+		f.emit(span, OpClosure, f.constant(fn))
+		f.set(span, s.Name)
+
+	case *ast.TypeDeclaration:
+		f.compileTypeDeclaration(s)
+
+	case *ast.ReturnStatement:
+		if err := f.compileExpression(s.Value); err != nil {
+			return err
+		}
+		if f.enclosing == nil {
+			f.escape(span, "return statement outside function")
+			f.emit(span, OpPop)
+			return nil
+		}
+		f.emit(span, OpReturn)
+
+	case *ast.Block:
+		for _, inner := range s.Statements {
+			if err := f.compileStatement(inner); err != nil {
+				return err
+			}
+		}
+
+	case *ast.Assignment:
+		if err := f.compileExpression(s.Value); err != nil {
+			return err
+		}
+		return f.assign(span, s.Name)
+
+	case *ast.IndexAssignment:
+		return f.compileIndexAssignment(s)
+
+	case *ast.ForStatement:
+		return f.compileFor(s)
+
+	case *ast.BreakStatement:
+		if len(f.loops) == 0 {
+			f.escape(span, "break statement outside loop")
+			return nil
+		}
+		innermost := f.loops[len(f.loops)-1]
+		f.popTo(span, innermost.depth)
+		innermost.breaks = append(innermost.breaks, f.emit(span, OpJump, 0))
+
+	case *ast.ContinueStatement:
+		if len(f.loops) == 0 {
+			f.escape(span, "continue statement outside loop")
+			return nil
+		}
+		innermost := f.loops[len(f.loops)-1]
+		f.popTo(span, innermost.depth)
+		f.emit(span, OpJump, innermost.start)
+
+	default:
+		return fmt.Errorf("%s: unknown statement type: %T", stmt.Pos(), stmt)
+	}
+	return f.checkSize(stmt)
+}
+
+// popTo emits an instruction popping the operands above depth, for a jump
+// out of the expressions being evaluated. The depth after the jump is
+// unchanged, as the code that follows continues from before it.
+func (f *funcCompiler) popTo(span ast.Span, depth int) {
+	if n := f.depth - depth; n > 0 {
+		f.emit(span, OpPopN, n)
+		f.depth += n
+	}
+}
+
+// define emits the instructions binding the value on top of the stack to a
+// new variable.
+func (f *funcCompiler) define(span ast.Span, name string, mutable bool) {
+	if f.scope.global {
+		flag := 0
+		if mutable {
+			flag = 1
+		}
+		f.emit(span, OpDefineGlobal, f.global(name), flag)
+		return
+	}
+	f.store(span, f.declare(name, mutable))
+}
+
+// declareKept declares a local variable for a function or constructor,
+// which keeps the mutability of a variable it replaces in the same scope.
+func (f *funcCompiler) declareKept(name string) *local {
+	if l, ok := f.scope.locals[name]; ok {
+		return l
+	}
+	return f.declare(name, false)
+}
+
+// set emits the instructions binding the value on top of the stack to a
+// function or constructor name, as the evaluator's Environment.Set does.
+func (f *funcCompiler) set(span ast.Span, name string) {
+	if f.scope.global {
+		f.emit(span, OpSetGlobal, f.global(name))
+		return
+	}
+	f.store(span, f.declareKept(name))
+}
+
+// store emits the instruction storing the value on top of the stack in a local.
+func (f *funcCompiler) store(span ast.Span, l *local) {
+	if l.cell {
+		f.emit(span, OpStoreCell, l.slot)
+	} else {
+		f.emit(span, OpStoreLocal, l.slot)
+	}
+}
+
+// assign emits the instructions assigning the value on top of the stack to
+// an existing variable, which must have been declared with 'let mut'.
+func (f *funcCompiler) assign(span ast.Span, name string) error {
+	v, err := f.resolve(name)
+	if err != nil {
+		return err
+	}
+	if v.kind != CalleeGlobal && !v.mutable {
+		f.fail(span, "cannot assign to immutable variable '%s' (declare it with 'let mut')", name)
+		f.emit(span, OpPop)
+		return nil
+	}
+
+	switch v.kind {
+	case CalleeLocal:
+		f.emit(span, OpStoreLocal, v.index)
+	case CalleeCell:
+		f.emit(span, OpStoreCell, v.index)
+	case CalleeUpvalue:
+		f.emit(span, OpStoreUpvalue, v.index)
+	default:
+		f.emit(span, OpAssignGlobal, v.index)
+	}
+	return nil
+}
+
+// compileTypeDeclaration binds the constructors of an algebraic data type.
+// Record types need no code: record literals are resolved when compiled.
+func (f *funcCompiler) compileTypeDeclaration(decl *ast.TypeDeclaration) {
+	if decl.Fields != nil {
+		return
+	}
+
+	span := spanOf(decl)
+	seen := make(map[string]bool, len(decl.Variants))
+	for tag, variant := range decl.Variants {
+		if seen[variant.Name] {
+			f.fail(span, "duplicate constructor %s in type %s", variant.Name, decl.Name)
+			return
+		}
+		seen[variant.Name] = true
+
+		var value interface{} = &eval.Constructor{
+			TypeName: decl.Name,
+			Name:     variant.Name,
+			Tag:      tag,
+			Arity:    len(variant.Fields),
+		}
+		if len(variant.Fields) == 0 {
+			value = &eval.Variant{
+				TypeName:    decl.Name,
+				Constructor: variant.Name,
+				Tag:         tag,
+				Fields:      []interface{}{},
+			}
+		}
+		f.emit(span, OpConstant, f.constant(value))
+		f.set(span, variant.Name)
+	}
+}
+
+// compileFor compiles a loop. Each iteration of its body is a new scope, so
+// its cells are made afresh and closures from different iterations do not
+// share them.
+func (f *funcCompiler) compileFor(stmt *ast.ForStatement) error {
+	span := spanOf(stmt)
+	l := &loop{start: len(f.fn.Code), depth: f.depth}
+
+	exit := -1
+	if stmt.Condition != nil {
+		if err := f.compileExpression(stmt.Condition); err != nil {
+			return err
+		}
+		exit = f.emit(span, OpJumpIfFalse, 0, ConditionLoop)
+	}
+
+	f.loops = append(f.loops, l)
+	cells := f.beginScope(stmt.Body.Span)
+	for _, inner := range stmt.Body.Statements {
+		if err := f.compileStatement(inner); err != nil {
+			return err
+		}
+	}
+	f.endScope(cells)
+	f.loops = f.loops[:len(f.loops)-1]
+	f.emit(span, OpJump, l.start)
+
+	if exit >= 0 {
+		f.patchJump(exit)
+	}
+	for _, jump := range l.breaks {
+		f.patchJump(jump)
+	}
+	return nil
+}
+
+// compileBlockValue compiles the statements of a block in the current
+// scope, leaving the block's value on the stack: the value of its trailing
+// expression statement, or unit.
+func (f *funcCompiler) compileBlockValue(block *ast.Block) error {
+	if len(block.Statements) == 0 {
+		f.emit(block.Span, OpConstant, f.constant(eval.Unit{}))
+		return nil
+	}
+
+	for i, stmt := range block.Statements {
+		last := i == len(block.Statements)-1
+		if exprStmt, ok := stmt.(*ast.ExpressionStatement); ok && last {
+			return f.compileExpression(exprStmt.Expression)
+		}
+		if err := f.compileStatement(stmt); err != nil {
+			return err
+		}
+		if last {
+			f.emit(spanOf(stmt), OpConstant, f.constant(eval.Unit{}))
+		}
+	}
+	return nil
+}
+
+// compileScopedBlockValue compiles a block in a new scope, leaving its value
+// on the stack.
+func (f *funcCompiler) compileScopedBlockValue(block *ast.Block) error {
+	cells := f.beginScope(block.Span)
+	err := f.compileBlockValue(block)
+	f.endScope(cells)
+	return err
+}
+
+// spanOf returns the source span of a node.
+func spanOf(node ast.Node) ast.Span {
+	return ast.Span{Start: node.Pos(), Stop: node.End()}
+}
+
+// fieldList formats field names for error messages, as the evaluator does.
+func fieldList(fields []*ast.FieldValue) string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	return strings.Join(names, ", ")
+}
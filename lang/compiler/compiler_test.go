@@ -0,0 +1,181 @@
+package compiler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+)
+
+// addProgram builds:
+//
+//	fn add(a, b) {
+//	    let c = a + b
+//	    c + 1
+//	}
+//	let x = add(1, 1)
+func addProgram() *ast.Program {
+	one := func() ast.Expression { return &ast.IntLiteral{Token: "1", Value: 1} }
+	return &ast.Program{
+		Statements: []ast.Statement{
+			&ast.FunctionDef{
+				Token:      "fn",
+				Name:       "add",
+				Parameters: []string{"a", "b"},
+				Body: &ast.Block{
+					Token: "{",
+					Statements: []ast.Statement{
+						&ast.LetStatement{
+							Token: "let",
+							Name:  "c",
+							Value: &ast.BinaryExpression{
+								Token:    "+",
+								Left:     &ast.Identifier{Token: "a", Name: "a"},
+								Operator: "+",
+								Right:    &ast.Identifier{Token: "b", Name: "b"},
+							},
+						},
+						&ast.ExpressionStatement{
+							Token: "c",
+							Expression: &ast.BinaryExpression{
+								Token:    "+",
+								Left:     &ast.Identifier{Token: "c", Name: "c"},
+								Operator: "+",
+								Right:    one(),
+							},
+						},
+					},
+				},
+			},
+			&ast.LetStatement{
+				Token: "let",
+				Name:  "x",
+				Value: &ast.FunctionCall{Token: "add", Name: "add", Arguments: []ast.Expression{one(), one()}},
+			},
+		},
+	}
+}
+
+// findFunction returns the function among a function's constants with the given name.
+func findFunction(t *testing.T, fn *Function, name string) *Function {
+	t.Helper()
+	for _, constant := range fn.Constants {
+		if inner, ok := constant.(*Function); ok && inner.Name == name {
+			return inner
+		}
+	}
+	t.Fatalf("Function %q not found in constants of %q", name, fn.Name)
+	return nil
+}
+
+// TestCompileResolvesLocalsToSlots tests that parameters and local
+// variables are given slots and top-level names are globals.
+func TestCompileResolvesLocalsToSlots(t *testing.T) {
+	program, err := Compile(addProgram())
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	if strings.Join(program.Globals, ",") != "add,x" {
+		t.Errorf("Expected globals add,x, got %v", program.Globals)
+	}
+
+	add := findFunction(t, program.Main, "add")
+	if add.Arity != 2 {
+		t.Errorf("Expected arity 2, got %d", add.Arity)
+	}
+	if add.NumLocals != 3 {
+		t.Errorf("Expected 3 locals (a, b, c), got %d", add.NumLocals)
+	}
+	if len(add.Upvalues) != 0 || len(add.Boxed) != 0 {
+		t.Errorf("Expected no upvalues or cells, got %v and %v", add.Upvalues, add.Boxed)
+	}
+
+	var listing bytes.Buffer
+	Disassemble(add, &listing)
+	for _, want := range []string{"LOAD_LOCAL         0", "LOAD_LOCAL         1", "STORE_LOCAL        2", "ADD", "RETURN"} {
+		if !strings.Contains(listing.String(), want) {
+			t.Errorf("Expected listing to contain %q, got:\n%s", want, listing.String())
+		}
+	}
+}
+
+// TestCompilePoolsConstants tests that equal constants share one entry.
+func TestCompilePoolsConstants(t *testing.T) {
+	program, err := Compile(addProgram())
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	ones := 0
+	for _, constant := range program.Main.Constants {
+		if constant == int64(1) {
+			ones++
+		}
+	}
+	if ones != 1 {
+		t.Errorf("Expected the constant 1 once in the pool, got %d times in %v", ones, program.Main.Constants)
+	}
+}
+
+// TestCompileCapturedLocalsUseCells tests that a local variable used by a
+// nested function is kept in a cell and reached through an upvalue.
+func TestCompileCapturedLocalsUseCells(t *testing.T) {
+	// fn counter() { let mut n = 0; fn() { n = n + 1; n } }
+	program := &ast.Program{
+		Statements: []ast.Statement{
+			&ast.FunctionDef{
+				Token: "fn",
+				Name:  "counter",
+				Body: &ast.Block{
+					Token: "{",
+					Statements: []ast.Statement{
+						&ast.LetStatement{Token: "let", Name: "n", Mutable: true, Value: &ast.IntLiteral{Token: "0", Value: 0}},
+						&ast.ExpressionStatement{
+							Token: "fn",
+							Expression: &ast.FunctionLiteral{
+								Token: "fn",
+								Body: &ast.Block{
+									Token: "{",
+									Statements: []ast.Statement{
+										&ast.Assignment{
+											Token: "n",
+											Name:  "n",
+											Value: &ast.BinaryExpression{
+												Token:    "+",
+												Left:     &ast.Identifier{Token: "n", Name: "n"},
+												Operator: "+",
+												Right:    &ast.IntLiteral{Token: "1", Value: 1},
+											},
+										},
+										&ast.ExpressionStatement{Token: "n", Expression: &ast.Identifier{Token: "n", Name: "n"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	compiled, err := Compile(program)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	counter := findFunction(t, compiled.Main, "counter")
+	literal := findFunction(t, counter, "")
+	if len(literal.Upvalues) != 1 || literal.Upvalues[0].Name != "n" || !literal.Upvalues[0].FromLocal {
+		t.Fatalf("Expected one upvalue for local n, got %+v", literal.Upvalues)
+	}
+
+	var listing bytes.Buffer
+	Disassemble(counter, &listing)
+	for _, want := range []string{"MAKE_CELLS", "STORE_CELL", "LOAD_UPVALUE", "STORE_UPVALUE"} {
+		if !strings.Contains(listing.String(), want) {
+			t.Errorf("Expected listing to contain %q, got:\n%s", want, listing.String())
+		}
+	}
+}
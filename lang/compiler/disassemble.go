@@ -0,0 +1,128 @@
+package compiler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shadowCow/cow-lang-go/lang/eval"
+)
+
+// Disassemble writes a readable listing of a function's instructions, then
+// of the functions nested in it.
+func Disassemble(fn *Function, w io.Writer) {
+	name := fn.Name
+	if name == "" {
+		name = "<fn>"
+	}
+	fmt.Fprintf(w, "== %s (arity %d, locals %d, stack %d) ==\n", name, fn.Arity, fn.NumLocals, fn.MaxStack)
+
+	var nested []*Function
+	for offset := 0; offset < len(fn.Code); {
+		op := Opcode(fn.Code[offset])
+		operands := make([]string, op.Operands())
+		for i := range operands {
+			at := offset + 1 + 2*i
+			operands[i] = fmt.Sprint(int(fn.Code[at])<<8 | int(fn.Code[at+1]))
+		}
+
+		line := fmt.Sprintf("%04d %4s %-18s %s", offset, fn.SpanAt(offset).Start, op, strings.Join(operands, " "))
+		if operand := constantOperand(op); operand >= 0 {
+			at := offset + 1 + 2*operand
+			constant := fn.Constants[int(fn.Code[at])<<8|int(fn.Code[at+1])]
+			if inner, ok := constant.(*Function); ok {
+				nested = append(nested, inner)
+				constant = "<fn " + inner.Name + ">"
+			}
+			line += fmt.Sprintf("    ; %v", describeConstant(constant))
+		}
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+
+		offset += 1 + 2*op.Operands()
+	}
+
+	for _, inner := range nested {
+		fmt.Fprintln(w)
+		Disassemble(inner, w)
+	}
+}
+
+// constantOperand returns which of an opcode's operands is a constant
+// index, or -1 if none is.
+func constantOperand(op Opcode) int {
+	switch op {
+	case OpConstant, OpMakeCells, OpFail, OpEscape, OpClosure, OpRecord, OpUpdateRecord, OpField,
+		OpArrayMethod, OpCheckMethod, OpSetPath, OpPushPath, OpPopPath, OpDestructure:
+		return 0
+	case OpMatch:
+		return 1
+	case OpCallee:
+		return 3
+	default:
+		return -1
+	}
+}
+
+// describeConstant formats a constant for the listing.
+func describeConstant(constant interface{}) string {
+	switch c := constant.(type) {
+	case *eval.Constructor:
+		return c.Name
+	case *Pattern:
+		return c.String()
+	case *RecordShape:
+		return fmt.Sprintf("%s{%s}", c.TypeName, strings.Join(c.Fields, ", "))
+	case *Path:
+		return c.String()
+	case []int:
+		return fmt.Sprint(c)
+	default:
+		return eval.FormatElement(c)
+	}
+}
+
+// String formats the pattern the way it is written in source code, with
+// the slot or global index each name is bound to.
+func (p *Pattern) String() string {
+	elements := make([]string, len(p.Elements))
+	for i, element := range p.Elements {
+		elements[i] = element.String()
+	}
+	list := strings.Join(elements, ", ")
+
+	switch p.Kind {
+	case PatternWildcard:
+		return "_"
+	case PatternBinding:
+		kinds := map[int]string{TargetLocal: "local", TargetCell: "cell", TargetGlobal: "global"}
+		return fmt.Sprintf("%s %d", kinds[p.Target.Kind], p.Target.Index)
+	case PatternLiteral:
+		return eval.FormatElement(p.Value)
+	case PatternConstructor:
+		if len(p.Elements) == 0 {
+			return p.Name
+		}
+		return p.Name + "(" + list + ")"
+	case PatternArray:
+		return "[" + list + "]"
+	default:
+		return "(" + list + ")"
+	}
+}
+
+// String formats the path the way it is written after the variable, with
+// _ for each index taken from the stack.
+func (p *Path) String() string {
+	var b strings.Builder
+	for _, step := range p.Steps {
+		if step.Index {
+			b.WriteString("[_]")
+		} else {
+			b.WriteString("." + step.Field)
+		}
+	}
+	if p.Method != "" {
+		b.WriteString("." + p.Method + "()")
+	}
+	return b.String()
+}
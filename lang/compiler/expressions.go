@@ -0,0 +1,505 @@
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+	"github.com/shadowCow/cow-lang-go/lang/eval"
+)
+
+// arrayMethods are the methods of arrays, called as xs.len(), xs.push(x),
+// xs.pop() and xs.sorted().
+var arrayMethods = map[string]bool{"len": true, "push": true, "pop": true, "sorted": true}
+
+// binaryOps maps binary operators to their instructions.
+var binaryOps = map[string]Opcode{
+	"+": OpAdd, "PLUS": OpAdd,
+	"-": OpSub, "MINUS": OpSub,
+	"*": OpMul, "MULTIPLY": OpMul,
+	"/": OpDiv, "DIVIDE": OpDiv,
+	"%": OpMod, "MODULO": OpMod,
+	"==": OpEqual, "EQUAL_EQUAL": OpEqual,
+	"!=": OpNotEqual, "NOT_EQUAL": OpNotEqual,
+	"<": OpLess, "LESS_THAN": OpLess,
+	"<=": OpLessEqual, "LESS_EQUAL": OpLessEqual,
+	">": OpGreater, "GREATER_THAN": OpGreater,
+	">=": OpGreaterEqual, "GREATER_EQUAL": OpGreaterEqual,
+}
+
+// compileExpression compiles an expression, leaving its value on the stack.
+func (f *funcCompiler) compileExpression(expr ast.Expression) error {
+	span := spanOf(expr)
+	switch e := expr.(type) {
+	case *ast.IntLiteral:
+		f.emit(span, OpConstant, f.constant(e.Value))
+
+	case *ast.FloatLiteral:
+		f.emit(span, OpConstant, f.constant(e.Value))
+
+	case *ast.BoolLiteral:
+		f.emit(span, OpConstant, f.constant(e.Value))
+
+	case *ast.StringLiteral:
+		f.emit(span, OpConstant, f.constant(e.Value))
+
+	case *ast.Identifier:
+		return f.load(span, e.Name)
+
+	case *ast.FunctionCall:
+		return f.compileCall(e)
+
+	case *ast.UnaryExpression:
+		if err := f.compileExpression(e.Operand); err != nil {
+			return err
+		}
+		switch e.Operator {
+		case "NOT", "!":
+			f.emit(span, OpNot)
+		case "MINUS", "-":
+			f.emit(span, OpNeg)
+		default:
+			return fmt.Errorf("%s: unknown unary operator: %s", e.Pos(), e.Operator)
+		}
+
+	case *ast.BinaryExpression:
+		return f.compileBinary(e)
+
+	case *ast.FunctionLiteral:
+		fn, err := f.compileFunction("", e.Parameters, e.Body)
+		if err != nil {
+			return err
+		}
+		f.emit(span, OpClosure, f.constant(fn))
+
+	case *ast.ArrayLiteral:
+		for _, elem := range e.Elements {
+			if err := f.compileExpression(elem); err != nil {
+				return err
+			}
+		}
+		f.emit(span, OpArray, len(e.Elements))
+
+	case *ast.TupleLiteral:
+		if len(e.Elements) == 0 {
+			f.emit(span, OpConstant, f.constant(eval.Unit{}))
+			return nil
+		}
+		for _, elem := range e.Elements {
+			if err := f.compileExpression(elem); err != nil {
+				return err
+			}
+		}
+		f.emit(span, OpTuple, len(e.Elements))
+
+	case *ast.IndexAccess:
+		if err := f.compileExpression(e.Object); err != nil {
+			return err
+		}
+		if err := f.compileExpression(e.Index); err != nil {
+			return err
+		}
+		f.emit(span, OpIndex)
+
+	case *ast.MemberAccess:
+		if err := f.compileExpression(e.Object); err != nil {
+			return err
+		}
+		f.emit(span, OpField, f.constant(e.Member))
+
+	case *ast.IfExpression:
+		return f.compileIf(e)
+
+	case *ast.MatchExpression:
+		return f.compileMatch(e)
+
+	case *ast.RecordLiteral:
+		return f.compileRecordLiteral(e)
+
+	case *ast.RecordUpdate:
+		if err := f.compileExpression(e.Base); err != nil {
+			return err
+		}
+		names := make([]string, len(e.Fields))
+		for i, field := range e.Fields {
+			names[i] = field.Name
+			if err := f.compileExpression(field.Value); err != nil {
+				return err
+			}
+		}
+		f.emit(span, OpUpdateRecord, f.constant(names))
+
+	case *ast.BlockExpression:
+		return f.compileScopedBlockValue(e.Block)
+
+	default:
+		return fmt.Errorf("%s: unknown expression type: %T", expr.Pos(), expr)
+	}
+	return nil
+}
+
+// load emits the instruction pushing the value of a variable.
+func (f *funcCompiler) load(span ast.Span, name string) error {
+	v, err := f.resolve(name)
+	if err != nil {
+		return err
+	}
+	switch v.kind {
+	case CalleeLocal:
+		f.emit(span, OpLoadLocal, v.index)
+	case CalleeCell:
+		f.emit(span, OpLoadCell, v.index)
+	case CalleeUpvalue:
+		f.emit(span, OpLoadUpvalue, v.index)
+	default:
+		f.emit(span, OpLoadGlobal, v.index)
+	}
+	return nil
+}
+
+// compileBinary compiles a binary expression. && and || evaluate their
+// right operand only when it decides the result.
+func (f *funcCompiler) compileBinary(expr *ast.BinaryExpression) error {
+	span := spanOf(expr)
+	if err := f.compileExpression(expr.Left); err != nil {
+		return err
+	}
+
+	switch expr.Operator {
+	case "AND", "&&", "OR", "||":
+		jump, condition := OpJumpIfFalseKeep, ConditionAnd
+		if expr.Operator == "OR" || expr.Operator == "||" {
+			jump, condition = OpJumpIfTrueKeep, ConditionOr
+		}
+		end := f.emit(span, jump, 0, condition)
+		if err := f.compileExpression(expr.Right); err != nil {
+			return err
+		}
+		f.emit(span, OpCheckBool, condition)
+		f.patchJump(end)
+		return nil
+	}
+
+	op, ok := binaryOps[expr.Operator]
+	if !ok {
+		return fmt.Errorf("%s: unknown binary operator: %s", expr.Pos(), expr.Operator)
+	}
+	if err := f.compileExpression(expr.Right); err != nil {
+		return err
+	}
+	f.emit(span, op)
+	return nil
+}
+
+// compileCall compiles a call: of println, of an array method, or of a
+// function or constructor.
+func (f *funcCompiler) compileCall(call *ast.FunctionCall) error {
+	span := spanOf(call)
+	if call.Name == "println" {
+		for _, arg := range call.Arguments {
+			if err := f.compileExpression(arg); err != nil {
+				return err
+			}
+			f.emit(span, OpPrint)
+		}
+		f.emit(span, OpConstant, f.constant(eval.Unit{}))
+		return nil
+	}
+
+	// xs.push(x) is a call of push whose first argument is xs.push
+	if len(call.Arguments) > 0 {
+		if member, ok := call.Arguments[0].(*ast.MemberAccess); ok && member.Member == call.Name {
+			if arrayMethods[call.Name] {
+				return f.compileMethodCall(call, member)
+			}
+			// The evaluator evaluates the member once to see whether it is
+			// an array method, before calling the function
+			if err := f.compileExpression(member); err != nil {
+				return err
+			}
+			f.emit(span, OpPop)
+		}
+	}
+
+	callee, err := f.resolve(call.Name)
+	if err != nil {
+		return err
+	}
+	f.emit(span, OpCallee, callee.kind, callee.index, len(call.Arguments), f.constant(call.Name))
+	for _, arg := range call.Arguments {
+		if err := f.compileExpression(arg); err != nil {
+			return err
+		}
+	}
+	f.emit(span, OpCall, len(call.Arguments))
+	return nil
+}
+
+// compileMethodCall compiles a call of an array method. push and pop
+// update the variable, element or field holding the array.
+func (f *funcCompiler) compileMethodCall(call *ast.FunctionCall, member *ast.MemberAccess) error {
+	span := spanOf(call)
+	args := call.Arguments[1:]
+
+	if call.Name == "len" || call.Name == "sorted" {
+		if err := f.compileExpression(member.Object); err != nil {
+			return err
+		}
+		if len(args) != 0 {
+			f.fail(span, "%s() takes no arguments, got %d", call.Name, len(args))
+			return nil
+		}
+		f.emit(span, OpArrayMethod, f.constant(call.Name))
+		return nil
+	}
+
+	root, path, indices := placeOf(member.Object)
+	if root == nil {
+		if err := f.compileExpression(member.Object); err != nil {
+			return err
+		}
+		f.emit(span, OpPop)
+	} else {
+		path.Method = call.Name
+		if err := f.compilePlace(root, indices); err != nil {
+			return err
+		}
+		f.emit(spanOf(member), OpCheckMethod, f.constant(path))
+	}
+
+	want := 0
+	if call.Name == "push" {
+		want = 1
+	}
+	switch {
+	case len(args) != want && want == 1:
+		f.fail(span, "push() takes exactly 1 argument, got %d", len(args))
+	case len(args) != want:
+		f.fail(span, "pop() takes no arguments, got %d", len(args))
+	case root == nil:
+		f.fail(span, "%s() needs an array stored in a variable, element or field", call.Name)
+	}
+	if len(args) != want || root == nil {
+		f.depth = f.depthAfterFail(root, path)
+		return nil
+	}
+
+	if call.Name == "push" {
+		if err := f.compileExpression(args[0]); err != nil {
+			return err
+		}
+		f.emit(span, OpPushPath, f.constant(path))
+		if err := f.assign(spanOf(root), root.Name); err != nil {
+			return err
+		}
+		f.emit(span, OpConstant, f.constant(eval.Unit{}))
+		return nil
+	}
+
+	f.emit(span, OpPopPath, f.constant(path))
+	return f.assign(spanOf(root), root.Name)
+}
+
+// depthAfterFail returns the operand depth after a method call that always
+// fails, as if it had left its result in place of the place's operands.
+func (f *funcCompiler) depthAfterFail(root *ast.Identifier, path *Path) int {
+	if root == nil {
+		return f.depth + 1
+	}
+	return f.depth - path.Indices()
+}
+
+// compileIndexAssignment compiles assignment to an array element or record
+// field inside a variable. The indices are evaluated before the value.
+func (f *funcCompiler) compileIndexAssignment(stmt *ast.IndexAssignment) error {
+	span := spanOf(stmt)
+	root, path, indices := placeOf(stmt.Target)
+	if root == nil {
+		f.fail(spanOf(stmt.Target), "cannot assign to %T: expected a variable, array element or record field", stmt.Target)
+		return nil
+	}
+
+	for _, index := range indices {
+		if err := f.compileExpression(index); err != nil {
+			return err
+		}
+	}
+	if err := f.compileExpression(stmt.Value); err != nil {
+		return err
+	}
+	if err := f.load(spanOf(root), root.Name); err != nil {
+		return err
+	}
+	f.emit(span, OpSetPath, f.constant(path))
+	return f.assign(spanOf(root), root.Name)
+}
+
+// compilePlace pushes the index values and variable value of a place.
+func (f *funcCompiler) compilePlace(root *ast.Identifier, indices []ast.Expression) error {
+	for _, index := range indices {
+		if err := f.compileExpression(index); err != nil {
+			return err
+		}
+	}
+	return f.load(spanOf(root), root.Name)
+}
+
+// placeOf splits a place expression (a variable followed by any number of
+// index and field accesses) into the variable, the path to the place and
+// the index expressions. The variable is nil if expr is not a place.
+func placeOf(expr ast.Expression) (*ast.Identifier, *Path, []ast.Expression) {
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		return e, &Path{}, nil
+
+	case *ast.IndexAccess:
+		root, path, indices := placeOf(e.Object)
+		if root == nil {
+			return nil, nil, nil
+		}
+		path.Steps = append(path.Steps, PathStep{Index: true, Span: spanOf(e)})
+		return root, path, append(indices, e.Index)
+
+	case *ast.MemberAccess:
+		root, path, indices := placeOf(e.Object)
+		if root == nil {
+			return nil, nil, nil
+		}
+		path.Steps = append(path.Steps, PathStep{Field: e.Member, Span: spanOf(e)})
+		return root, path, indices
+
+	default:
+		return nil, nil, nil
+	}
+}
+
+// compileIf compiles an if expression. Each branch is a new scope.
+func (f *funcCompiler) compileIf(expr *ast.IfExpression) error {
+	span := spanOf(expr)
+	if err := f.compileExpression(expr.Condition); err != nil {
+		return err
+	}
+	otherwise := f.emit(span, OpJumpIfFalse, 0, ConditionIf)
+	depth := f.depth
+
+	if err := f.compileScopedBlockValue(expr.Consequence); err != nil {
+		return err
+	}
+	if expr.Alternative == nil {
+		// Without an else, the value is unit whichever branch runs
+		f.emit(span, OpPop)
+		f.emit(span, OpConstant, f.constant(eval.Unit{}))
+	}
+	end := f.emit(span, OpJump, 0)
+
+	f.patchJump(otherwise)
+	f.depth = depth
+	if expr.Alternative != nil {
+		if err := f.compileScopedBlockValue(expr.Alternative); err != nil {
+			return err
+		}
+	} else {
+		f.emit(span, OpConstant, f.constant(eval.Unit{}))
+	}
+	f.patchJump(end)
+	return nil
+}
+
+// compileMatch compiles a match expression. The subject is kept in a
+// hidden local slot while the arms are tried; each arm is a new scope
+// holding its pattern's bindings.
+func (f *funcCompiler) compileMatch(expr *ast.MatchExpression) error {
+	span := spanOf(expr)
+	if err := f.compileExpression(expr.Subject); err != nil {
+		return err
+	}
+	subject := f.newSlot("")
+	f.emit(span, OpStoreLocal, subject)
+	depth := f.depth
+
+	var ends []int
+	for _, arm := range expr.Arms {
+		f.depth = depth
+		cells := f.beginScope(arm.Span)
+		pattern, err := f.compilePattern(arm.Pattern, false)
+		if err != nil {
+			return err
+		}
+		next := f.emit(span, OpMatch, subject, f.constant(pattern), 0)
+		if err := f.compileBlockValue(arm.Body); err != nil {
+			return err
+		}
+		f.endScope(cells)
+		ends = append(ends, f.emit(span, OpJump, 0))
+
+		// OpMatch's target is its third operand
+		target := len(f.fn.Code)
+		f.fn.Code[next+5] = byte(target >> 8)
+		f.fn.Code[next+6] = byte(target)
+	}
+
+	f.depth = depth
+	f.emit(span, OpNoMatch, subject)
+	f.depth = depth + 1
+	for _, end := range ends {
+		f.patchJump(end)
+	}
+	return nil
+}
+
+// compileRecordLiteral compiles a record literal. Its type is the most
+// recently declared record type with exactly its fields; the field values
+// are evaluated in the type's declaration order.
+func (f *funcCompiler) compileRecordLiteral(expr *ast.RecordLiteral) error {
+	span := spanOf(expr)
+	decl := f.findRecordType(expr.Fields)
+	if decl == nil {
+		f.fail(span, "no record type has exactly the fields {%s}", fieldList(expr.Fields))
+		f.emit(span, OpConstant, f.constant(eval.Unit{}))
+		return nil
+	}
+
+	shape := &RecordShape{TypeName: decl.Name, Fields: make([]string, len(decl.Fields))}
+	for i, fieldDecl := range decl.Fields {
+		shape.Fields[i] = fieldDecl.Name
+		for _, field := range expr.Fields {
+			if field.Name == fieldDecl.Name {
+				if err := f.compileExpression(field.Value); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	f.emit(span, OpRecord, f.constant(shape))
+	return nil
+}
+
+// findRecordType finds the most recently declared record type whose fields
+// are exactly the given fields, or nil if there is none.
+func (c *compiler) findRecordType(fields []*ast.FieldValue) *ast.TypeDeclaration {
+	for i := len(c.records) - 1; i >= 0; i-- {
+		decl := c.records[i]
+		if len(decl.Fields) != len(fields) {
+			continue
+		}
+
+		matches := true
+		for _, fieldDecl := range decl.Fields {
+			found := false
+			for _, field := range fields {
+				if field.Name == fieldDecl.Name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return decl
+		}
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+)
+
+// Opcode identifies a bytecode instruction. Each instruction is one opcode
+// byte followed by its operands, each a big-endian uint16.
+//
+// Instructions work on a stack of values. A function's frame starts with
+// its local variable slots, parameters first; the operands of the
+// instructions being evaluated are above them.
+type Opcode byte
+
+const (
+	// Values and variables
+	OpConstant     Opcode = iota // constant: push Constants[constant]
+	OpPop                        // pop a value
+	OpPopN                       // n: pop n values
+	OpLoadLocal                  // slot: push a local variable
+	OpStoreLocal                 // slot: pop a value into a local variable
+	OpLoadCell                   // slot: push the value in a local variable's cell
+	OpStoreCell                  // slot: pop a value into a local variable's cell
+	OpMakeCells                  // constant: put new cells in the local slots listed by Constants[constant] ([]int)
+	OpLoadUpvalue                // index: push the value in a captured cell
+	OpStoreUpvalue               // index: pop a value into a captured cell
+	OpLoadGlobal                 // global: push a global variable
+	OpDefineGlobal               // global, mutable: pop a value into a global, declaring it mutable if mutable is 1
+	OpSetGlobal                  // global: pop a value into a global, keeping its mutability
+	OpAssignGlobal               // global: pop a value into a global declared with 'let mut'
+
+	// Operators
+	OpAdd
+	OpSub
+	OpMul
+	OpDiv
+	OpMod
+	OpEqual
+	OpNotEqual
+	OpLess
+	OpLessEqual
+	OpGreater
+	OpGreaterEqual
+	OpNeg
+	OpNot
+
+	// Control flow
+	OpJump            // target: continue at target
+	OpJumpIfFalse     // target, condition: pop a boolean and jump if it is false
+	OpJumpIfFalseKeep // target, condition: jump if the boolean on top is false, else pop it
+	OpJumpIfTrueKeep  // target, condition: jump if the boolean on top is true, else pop it
+	OpCheckBool       // condition: fail unless the value on top is a boolean
+	OpFail            // constant: fail with the message Constants[constant]
+	OpEscape          // constant: fail with the message Constants[constant], at the call of the current function
+
+	// Functions
+	OpCallee  // kind, index, argc, name: push the function called name, checking it takes argc arguments
+	OpCall    // argc: call the function below the argc arguments on top
+	OpReturn  // return the value on top from the current function
+	OpClosure // constant: push a closure of the function Constants[constant]
+	OpPrint   // pop a value and print it
+
+	// Data
+	OpArray        // n: pop n values and push an array of them
+	OpTuple        // n: pop n values and push a tuple of them
+	OpRecord       // constant: pop the field values of the record type Constants[constant] (*RecordShape)
+	OpUpdateRecord // constant: pop the values of the fields listed by Constants[constant] ([]string) and a record, and push the updated copy
+	OpIndex        // pop an index and an array and push the element
+	OpField        // name: pop a record and push its field Constants[name]
+	OpArrayMethod  // name: pop an array and push the result of its method Constants[name] (len or sorted)
+
+	// Places: a variable, with the elements and fields inside it described
+	// by a *Path constant. The path's index values are on the stack, in
+	// order, under the variable's value.
+	OpCheckMethod // path: check the place holds an array, for the array method Path.Method
+	OpSetPath     // path: pop a value and the place, and push the variable's new value
+	OpPushPath    // path: pop a value and the place, and push the variable's new value with the value appended to the array at the place
+	OpPopPath     // path: pop the place, and push the last element of the array at the place and the variable's new value without it
+
+	// Patterns
+	OpMatch       // slot, pattern, target: match local slot against the *Pattern constant, binding its names, or jump to target
+	OpNoMatch     // slot: fail because no match arm matched local slot
+	OpDestructure // pattern: pop a value and bind the names of the *Pattern constant, failing if it does not match
+)
+
+// Callee kinds for OpCallee: where the function is stored.
+const (
+	CalleeLocal   = iota // A local slot
+	CalleeCell           // The cell in a local slot
+	CalleeUpvalue        // A captured cell
+	CalleeGlobal         // A global
+)
+
+// Conditions for the jumps that test a boolean, naming what the boolean is
+// in the error when it is not one.
+const (
+	ConditionIf   = iota // An if condition
+	ConditionLoop        // A loop condition
+	ConditionAnd         // An operand of &&
+	ConditionOr          // An operand of ||
+)
+
+// opcodeInfo describes an opcode for the disassembler.
+type opcodeInfo struct {
+	name     string
+	operands int
+}
+
+var opcodes = map[Opcode]opcodeInfo{
+	OpConstant:        {"CONSTANT", 1},
+	OpPop:             {"POP", 0},
+	OpPopN:            {"POPN", 1},
+	OpLoadLocal:       {"LOAD_LOCAL", 1},
+	OpStoreLocal:      {"STORE_LOCAL", 1},
+	OpLoadCell:        {"LOAD_CELL", 1},
+	OpStoreCell:       {"STORE_CELL", 1},
+	OpMakeCells:       {"MAKE_CELLS", 1},
+	OpLoadUpvalue:     {"LOAD_UPVALUE", 1},
+	OpStoreUpvalue:    {"STORE_UPVALUE", 1},
+	OpLoadGlobal:      {"LOAD_GLOBAL", 1},
+	OpDefineGlobal:    {"DEFINE_GLOBAL", 2},
+	OpSetGlobal:       {"SET_GLOBAL", 1},
+	OpAssignGlobal:    {"ASSIGN_GLOBAL", 1},
+	OpAdd:             {"ADD", 0},
+	OpSub:             {"SUB", 0},
+	OpMul:             {"MUL", 0},
+	OpDiv:             {"DIV", 0},
+	OpMod:             {"MOD", 0},
+	OpEqual:           {"EQUAL", 0},
+	OpNotEqual:        {"NOT_EQUAL", 0},
+	OpLess:            {"LESS", 0},
+	OpLessEqual:       {"LESS_EQUAL", 0},
+	OpGreater:         {"GREATER", 0},
+	OpGreaterEqual:    {"GREATER_EQUAL", 0},
+	OpNeg:             {"NEG", 0},
+	OpNot:             {"NOT", 0},
+	OpJump:            {"JUMP", 1},
+	OpJumpIfFalse:     {"JUMP_IF_FALSE", 2},
+	OpJumpIfFalseKeep: {"JUMP_IF_FALSE_KEEP", 2},
+	OpJumpIfTrueKeep:  {"JUMP_IF_TRUE_KEEP", 2},
+	OpCheckBool:       {"CHECK_BOOL", 1},
+	OpFail:            {"FAIL", 1},
+	OpEscape:          {"ESCAPE", 1},
+	OpCallee:          {"CALLEE", 4},
+	OpCall:            {"CALL", 1},
+	OpReturn:          {"RETURN", 0},
+	OpClosure:         {"CLOSURE", 1},
+	OpPrint:           {"PRINT", 0},
+	OpArray:           {"ARRAY", 1},
+	OpTuple:           {"TUPLE", 1},
+	OpRecord:          {"RECORD", 1},
+	OpUpdateRecord:    {"UPDATE_RECORD", 1},
+	OpIndex:           {"INDEX", 0},
+	OpField:           {"FIELD", 1},
+	OpArrayMethod:     {"ARRAY_METHOD", 1},
+	OpCheckMethod:     {"CHECK_METHOD", 1},
+	OpSetPath:         {"SET_PATH", 1},
+	OpPushPath:        {"PUSH_PATH", 1},
+	OpPopPath:         {"POP_PATH", 1},
+	OpMatch:           {"MATCH", 3},
+	OpNoMatch:         {"NO_MATCH", 1},
+	OpDestructure:     {"DESTRUCTURE", 1},
+}
+
+// String returns the name of the opcode.
+func (op Opcode) String() string {
+	if info, ok := opcodes[op]; ok {
+		return info.name
+	}
+	return fmt.Sprintf("OP_%d", byte(op))
+}
+
+// Operands returns the number of operands the opcode takes.
+func (op Opcode) Operands() int {
+	return opcodes[op].operands
+}
+
+// Program is a compiled Cow program.
+type Program struct {
+	Main    *Function // The top-level statements, run as a function of no arguments
+	Globals []string  // Names of the global variables, by index
+}
+
+// Function is a compiled function: the code run by calling it, and what
+// the code refers to.
+type Function struct {
+	Name       string        // The function name, or "" for a function literal
+	Arity      int           // Number of parameters
+	NumLocals  int           // Number of local slots, including the parameters
+	MaxStack   int           // Most operands the function's instructions have on the stack at once
+	Code       []byte        // The instructions
+	Constants  []interface{} // The constants the instructions refer to
+	Spans      []SpanEntry   // Source spans of the instructions, by code offset
+	Upvalues   []Upvalue     // The cells a closure of the function captures when it is created
+	Boxed      []int         // Parameter slots put in cells on entry, because closures capture them
+	LocalNames []string      // Names of the local slots, for error messages
+}
+
+// SpanEntry gives the source span of the instructions from Offset up to
+// the next entry.
+type SpanEntry struct {
+	Offset int
+	Span   ast.Span
+}
+
+// SpanAt returns the source span of the instruction at a code offset.
+func (f *Function) SpanAt(offset int) ast.Span {
+	span := ast.Span{}
+	for _, entry := range f.Spans {
+		if entry.Offset > offset {
+			break
+		}
+		span = entry.Span
+	}
+	return span
+}
+
+// Upvalue says where a closure gets a captured cell from when it is created.
+type Upvalue struct {
+	FromLocal bool   // True for a local slot of the enclosing function, false for one of its upvalues
+	Index     int    // The slot or upvalue index
+	Name      string // The captured variable
+}
+
+// RecordShape describes the record built by OpRecord.
+type RecordShape struct {
+	TypeName string   // The record type
+	Fields   []string // The field names, in declaration order; the values are pushed in this order
+}
+
+// Path describes the elements and fields inside a variable that an
+// assignment or array method updates.
+type Path struct {
+	Method string     // The array method, for OpCheckMethod
+	Steps  []PathStep // The accesses, from the variable inward
+}
+
+// PathStep is an array index or record field access in a Path.
+type PathStep struct {
+	Index bool     // True for an array index, which is taken from the stack
+	Field string   // The record field, when Index is false
+	Span  ast.Span // The access, for error locations
+}
+
+// Indices returns the number of array index steps, whose values are on the stack.
+func (p *Path) Indices() int {
+	n := 0
+	for _, step := range p.Steps {
+		if step.Index {
+			n++
+		}
+	}
+	return n
+}
+
+// PatternKind identifies the kind of a compiled pattern.
+type PatternKind int
+
+const (
+	PatternWildcard    PatternKind = iota // _
+	PatternBinding                        // A name
+	PatternLiteral                        // A literal value
+	PatternConstructor                    // A constructor with patterns for its fields
+	PatternArray                          // An array with patterns for its elements
+	PatternTuple                          // A tuple with patterns for its elements; () matches unit
+)
+
+// Pattern is a compiled pattern, matched by OpMatch and OpDestructure.
+type Pattern struct {
+	Kind     PatternKind
+	Name     string      // The constructor name, for PatternConstructor
+	Value    interface{} // The literal value, for PatternLiteral
+	Elements []*Pattern  // The field or element patterns
+	Target   Target      // Where the value is bound, for PatternBinding
+}
+
+// Target kinds: where a pattern binds a value.
+const (
+	TargetLocal  = iota // A local slot
+	TargetCell          // The cell in a local slot
+	TargetGlobal        // A global
+)
+
+// Target is a variable a pattern binds.
+type Target struct {
+	Kind    int  // TargetLocal, TargetCell or TargetGlobal
+	Index   int  // The slot or global index
+	Mutable bool // For globals, whether the binding is declared with 'let mut'
+}
@@ -0,0 +1,191 @@
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+)
+
+// compilePattern compiles a pattern, declaring the names it binds in the
+// innermost scope.
+func (f *funcCompiler) compilePattern(pattern ast.Pattern, mutable bool) (*Pattern, error) {
+	switch p := pattern.(type) {
+	case *ast.WildcardPattern:
+		return &Pattern{Kind: PatternWildcard}, nil
+
+	case *ast.BindingPattern:
+		compiled := &Pattern{Kind: PatternBinding}
+		switch {
+		case f.scope.global:
+			compiled.Target = Target{Kind: TargetGlobal, Index: f.global(p.Name), Mutable: mutable}
+		default:
+			l := f.declare(p.Name, mutable)
+			compiled.Target = Target{Kind: TargetLocal, Index: l.slot}
+			if l.cell {
+				compiled.Target.Kind = TargetCell
+			}
+		}
+		return compiled, nil
+
+	case *ast.LiteralPattern:
+		var value interface{}
+		switch lit := p.Value.(type) {
+		case *ast.IntLiteral:
+			value = lit.Value
+		case *ast.FloatLiteral:
+			value = lit.Value
+		case *ast.StringLiteral:
+			value = lit.Value
+		case *ast.BoolLiteral:
+			value = lit.Value
+		default:
+			return nil, fmt.Errorf("%s: unsupported literal pattern: %T", p.Pos(), p.Value)
+		}
+		return &Pattern{Kind: PatternLiteral, Value: value}, nil
+
+	case *ast.ConstructorPattern:
+		elements, err := f.compilePatterns(p.Args, mutable)
+		return &Pattern{Kind: PatternConstructor, Name: p.Name, Elements: elements}, err
+
+	case *ast.ArrayPattern:
+		elements, err := f.compilePatterns(p.Elements, mutable)
+		return &Pattern{Kind: PatternArray, Elements: elements}, err
+
+	case *ast.TuplePattern:
+		elements, err := f.compilePatterns(p.Elements, mutable)
+		return &Pattern{Kind: PatternTuple, Elements: elements}, err
+
+	default:
+		return nil, fmt.Errorf("%s: unknown pattern type: %T", pattern.Pos(), pattern)
+	}
+}
+
+// compilePatterns compiles a list of patterns.
+func (f *funcCompiler) compilePatterns(patterns []ast.Pattern, mutable bool) ([]*Pattern, error) {
+	compiled := make([]*Pattern, len(patterns))
+	for i, pattern := range patterns {
+		var err error
+		if compiled[i], err = f.compilePattern(pattern, mutable); err != nil {
+			return nil, err
+		}
+	}
+	return compiled, nil
+}
+
+// capturedNames returns the names used inside the functions nested in a
+// list of statements. A local variable with one of these names may be
+// captured by a closure, so it is kept in a cell. Shadowing is ignored, so
+// the result may include names that only refer to the nested functions' own
+// variables.
+func capturedNames(statements []ast.Statement) map[string]bool {
+	v := &nameVisitor{names: make(map[string]bool)}
+	for _, stmt := range statements {
+		v.statement(stmt)
+	}
+	return v.names
+}
+
+// nameVisitor collects the names used inside nested functions.
+type nameVisitor struct {
+	names  map[string]bool
+	nested int // Number of enclosing nested functions
+}
+
+func (v *nameVisitor) use(name string) {
+	if v.nested > 0 {
+		v.names[name] = true
+	}
+}
+
+func (v *nameVisitor) function(body *ast.Block) {
+	v.nested++
+	v.block(body)
+	v.nested--
+}
+
+func (v *nameVisitor) block(block *ast.Block) {
+	for _, stmt := range block.Statements {
+		v.statement(stmt)
+	}
+}
+
+func (v *nameVisitor) statement(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.LetStatement:
+		v.expression(s.Value)
+	case *ast.ExpressionStatement:
+		v.expression(s.Expression)
+	case *ast.FunctionDef:
+		v.use(s.Name)
+		v.function(s.Body)
+	case *ast.ReturnStatement:
+		v.expression(s.Value)
+	case *ast.Block:
+		v.block(s)
+	case *ast.Assignment:
+		v.use(s.Name)
+		v.expression(s.Value)
+	case *ast.IndexAssignment:
+		v.expression(s.Target)
+		v.expression(s.Value)
+	case *ast.ForStatement:
+		if s.Condition != nil {
+			v.expression(s.Condition)
+		}
+		v.block(s.Body)
+	}
+}
+
+func (v *nameVisitor) expression(expr ast.Expression) {
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		v.use(e.Name)
+	case *ast.FunctionCall:
+		v.use(e.Name)
+		for _, arg := range e.Arguments {
+			v.expression(arg)
+		}
+	case *ast.UnaryExpression:
+		v.expression(e.Operand)
+	case *ast.BinaryExpression:
+		v.expression(e.Left)
+		v.expression(e.Right)
+	case *ast.FunctionLiteral:
+		v.function(e.Body)
+	case *ast.IfExpression:
+		v.expression(e.Condition)
+		v.block(e.Consequence)
+		if e.Alternative != nil {
+			v.block(e.Alternative)
+		}
+	case *ast.MatchExpression:
+		v.expression(e.Subject)
+		for _, arm := range e.Arms {
+			v.block(arm.Body)
+		}
+	case *ast.ArrayLiteral:
+		for _, elem := range e.Elements {
+			v.expression(elem)
+		}
+	case *ast.TupleLiteral:
+		for _, elem := range e.Elements {
+			v.expression(elem)
+		}
+	case *ast.RecordLiteral:
+		for _, field := range e.Fields {
+			v.expression(field.Value)
+		}
+	case *ast.RecordUpdate:
+		v.expression(e.Base)
+		for _, field := range e.Fields {
+			v.expression(field.Value)
+		}
+	case *ast.IndexAccess:
+		v.expression(e.Object)
+		v.expression(e.Index)
+	case *ast.MemberAccess:
+		v.expression(e.Object)
+	case *ast.BlockExpression:
+		v.block(e.Block)
+	}
+}
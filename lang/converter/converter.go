@@ -28,29 +28,148 @@ func ParseTreeToAST(tree *parsetree.ProgramNode) (*ast.Program, error) {
 	}
 
 	// Process the root based on the Cow grammar
-	// Current grammar: Program -> TopLevelItem TopLevelItemRest
-	if rootNonTerminal.Symbol == "Program" {
-		// Program should have two children: TopLevelItem and TopLevelItemRest
-		if len(rootNonTerminal.Children) != 2 {
-			return nil, fmt.Errorf("Program node expected 2 children, got %d", len(rootNonTerminal.Children))
+	// Current grammar: Program -> NEWLINE Program | TopLevelItem TopLevelItemRest | ε
+	statements, err := extractProgram(rootNonTerminal)
+	if err != nil {
+		return nil, err
+	}
+	program.Statements = append(program.Statements, statements...)
+
+	return program, nil
+}
+
+// tokenPosition returns the source position of a terminal node.
+func tokenPosition(node *parsetree.TerminalNode) ast.Position {
+	return ast.Position{Line: node.Token.Line, Column: node.Token.Column}
+}
+
+// tokenEnd returns the position just past the text of a terminal node.
+func tokenEnd(node *parsetree.TerminalNode) ast.Position {
+	end := tokenPosition(node)
+	for _, r := range node.Token.Value {
+		if r == '\n' {
+			end.Line++
+			end.Column = 1
+		} else {
+			end.Column++
 		}
+	}
+	return end
+}
 
-		// Convert the first top-level item
-		stmt, err := convertTopLevelItem(rootNonTerminal.Children[0])
-		if err != nil {
-			return nil, err
+// firstTerminal returns the first token under a parse tree node, or nil if
+// the node derives no tokens.
+func firstTerminal(node parsetree.ParseTree) *parsetree.TerminalNode {
+	switch n := node.(type) {
+	case *parsetree.TerminalNode:
+		return n
+	case *parsetree.NonTerminalNode:
+		for _, child := range n.Children {
+			if terminal := firstTerminal(child); terminal != nil {
+				return terminal
+			}
 		}
-		program.Statements = append(program.Statements, stmt)
+	}
+	return nil
+}
 
-		// Extract remaining items from TopLevelItemRest
-		restStmts, err := extractTopLevelItemRest(rootNonTerminal.Children[1])
-		if err != nil {
-			return nil, err
+// lastTerminal returns the last token under a parse tree node, or nil if
+// the node derives no tokens.
+func lastTerminal(node parsetree.ParseTree) *parsetree.TerminalNode {
+	switch n := node.(type) {
+	case *parsetree.TerminalNode:
+		return n
+	case *parsetree.NonTerminalNode:
+		for i := len(n.Children) - 1; i >= 0; i-- {
+			if terminal := lastTerminal(n.Children[i]); terminal != nil {
+				return terminal
+			}
 		}
-		program.Statements = append(program.Statements, restStmts...)
 	}
+	return nil
+}
 
-	return program, nil
+// spanOf returns the span from the first to the last token under a sequence
+// of sibling parse tree nodes.
+// Returns the zero Span if the nodes derive no tokens.
+func spanOf(nodes ...parsetree.ParseTree) ast.Span {
+	var first, last *parsetree.TerminalNode
+	for _, node := range nodes {
+		if first == nil {
+			first = firstTerminal(node)
+		}
+		if terminal := lastTerminal(node); terminal != nil {
+			last = terminal
+		}
+	}
+	if first == nil {
+		return ast.Span{}
+	}
+	return ast.Span{Start: tokenPosition(first), Stop: tokenEnd(last)}
+}
+
+// extendSpan returns the span from the start of an AST node to the last token
+// under a parse tree node that follows it.
+func extendSpan(start ast.Node, end parsetree.ParseTree) ast.Span {
+	span := ast.Span{Start: start.Pos(), Stop: start.End()}
+	if last := lastTerminal(end); last != nil {
+		span.Stop = tokenEnd(last)
+	}
+	return span
+}
+
+// nodeSpan returns the span of an AST node.
+func nodeSpan(node ast.Node) ast.Span {
+	return ast.Span{Start: node.Pos(), Stop: node.End()}
+}
+
+// spanBetween returns the span from the start of one AST node to the end of another.
+func spanBetween(first, last ast.Node) ast.Span {
+	return ast.Span{Start: first.Pos(), Stop: last.End()}
+}
+
+// extractProgram extracts the statements of a Program node.
+// Program: NEWLINE Program | TopLevelItem TopLevelItemRest | ε
+func extractProgram(node *parsetree.NonTerminalNode) ([]ast.Statement, error) {
+	if node.Symbol != "Program" {
+		return nil, fmt.Errorf("expected Program, got %s", node.Symbol)
+	}
+
+	// Empty program (epsilon)
+	if len(node.Children) == 0 {
+		return []ast.Statement{}, nil
+	}
+
+	// Program should have two children: NEWLINE Program or TopLevelItem TopLevelItemRest
+	if len(node.Children) != 2 {
+		return nil, fmt.Errorf("Program node expected 0 or 2 children, got %d", len(node.Children))
+	}
+
+	// Skip leading newline
+	if _, ok := node.Children[0].(*parsetree.TerminalNode); ok {
+		switch rest := node.Children[1].(type) {
+		case *parsetree.EmptyNode:
+			return []ast.Statement{}, nil
+		case *parsetree.NonTerminalNode:
+			return extractProgram(rest)
+		default:
+			return nil, fmt.Errorf("unexpected node type for Program: %T", rest)
+		}
+	}
+
+	// Convert the first top-level item
+	stmt, err := convertTopLevelItem(node.Children[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract remaining items from TopLevelItemRest
+	restStmts, err := extractTopLevelItemRest(node.Children[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]ast.Statement{stmt}, restStmts...), nil
 }
 
 // convertTopLevelItem converts a TopLevelItem to a statement.
@@ -84,8 +203,7 @@ func convertTopLevelItem(node parsetree.ParseTree) (ast.Statement, error) {
 					assignmentRest := assignNonTerm.Children[1]
 					if restNode, ok := assignmentRest.(*parsetree.NonTerminalNode); ok && len(restNode.Children) > 0 {
 						// Has assignment: EQUALS Assignment
-						// This should be an index assignment: arr[0] = value
-						return convertIndexAssignmentFromExpression(assignNonTerm)
+						return convertAssignmentFromExpression(assignNonTerm)
 					}
 				}
 			}
@@ -95,6 +213,7 @@ func convertTopLevelItem(node parsetree.ParseTree) (ast.Statement, error) {
 				return nil, err
 			}
 			return &ast.ExpressionStatement{
+				Span:       nodeSpan(expr),
 				Token:      "", // Will be set by evaluator
 				Expression: expr,
 			}, nil
@@ -130,7 +249,7 @@ func extractTopLevelItemRest(node parsetree.ParseTree) ([]ast.Statement, error)
 }
 
 // extractTopLevelItemRest2 extracts items from TopLevelItemRest2.
-// TopLevelItemRest2: TopLevelItem TopLevelItemRest | ε
+// TopLevelItemRest2: NEWLINE TopLevelItemRest2 | TopLevelItem TopLevelItemRest | ε
 func extractTopLevelItemRest2(node parsetree.ParseTree) ([]ast.Statement, error) {
 	switch n := node.(type) {
 	case *parsetree.EmptyNode:
@@ -144,6 +263,11 @@ func extractTopLevelItemRest2(node parsetree.ParseTree) ([]ast.Statement, error)
 		if len(n.Children) == 0 {
 			return []ast.Statement{}, nil
 		} else if len(n.Children) == 2 {
+			// NEWLINE TopLevelItemRest2 - skip the extra newline
+			if _, ok := n.Children[0].(*parsetree.TerminalNode); ok {
+				return extractTopLevelItemRest2(n.Children[1])
+			}
+
 			// TopLevelItem TopLevelItemRest
 			stmt, err := convertTopLevelItem(n.Children[0])
 			if err != nil {
@@ -247,20 +371,28 @@ func convertToStatement(node parsetree.ParseTree) (ast.Statement, error) {
 			return convertToStatement(n.Children[0])
 
 		case "LetStatement":
-			// LetStatement: LET IDENTIFIER EQUALS Expression
-			if len(n.Children) != 4 {
-				return nil, fmt.Errorf("LetStatement node expected 4 children, got %d", len(n.Children))
+			// LetStatement: LET MutModifier LetTarget TypeAnnotation EQUALS Expression
+			if len(n.Children) != 6 {
+				return nil, fmt.Errorf("LetStatement node expected 6 children, got %d", len(n.Children))
 			}
 
-			// Extract identifier (child 1)
-			identifierNode, ok := n.Children[1].(*parsetree.TerminalNode)
-			if !ok {
-				return nil, fmt.Errorf("expected terminal for identifier, got %T", n.Children[1])
+			// Extract mut modifier (child 1)
+			mutable := isMutModifier(n.Children[1])
+
+			// Extract name or destructuring pattern (child 2)
+			name, pattern, err := convertLetTarget(n.Children[2])
+			if err != nil {
+				return nil, err
 			}
-			name := identifierNode.Token.Value
 
-			// Extract value expression (child 3)
-			valueExpr, err := convertToExpression(n.Children[3])
+			// Extract optional type annotation (child 3)
+			annotation, err := convertTypeAnnotation(n.Children[3])
+			if err != nil {
+				return nil, err
+			}
+
+			// Extract value expression (child 5)
+			valueExpr, err := convertToExpression(n.Children[5])
 			if err != nil {
 				return nil, err
 			}
@@ -272,9 +404,13 @@ func convertToStatement(node parsetree.ParseTree) (ast.Statement, error) {
 			}
 
 			return &ast.LetStatement{
-				Token: letNode.Token.Value,
-				Name:  name,
-				Value: valueExpr,
+				Span:    spanOf(n),
+				Token:   letNode.Token.Value,
+				Name:    name,
+				Pattern: pattern,
+				Mutable: mutable,
+				Type:    annotation,
+				Value:   valueExpr,
 			}, nil
 
 		case "ExpressionStatement":
@@ -283,28 +419,20 @@ func convertToStatement(node parsetree.ParseTree) (ast.Statement, error) {
 				return nil, fmt.Errorf("ExpressionStatement node expected 1 child, got %d", len(n.Children))
 			}
 
-			// Check if the expression is actually an assignment (arr[0] = value)
+			// Check if the expression is actually an assignment (x = value, arr[0] = value)
 			// Expression -> Assignment -> LogicalOr AssignmentRest
 			exprNode := n.Children[0]
 			if exprNonTerm, ok := exprNode.(*parsetree.NonTerminalNode); ok {
 				if exprNonTerm.Symbol == "Expression" && len(exprNonTerm.Children) == 1 {
 					// Unwrap to get Assignment or FunctionLiteral
 					innerNode := exprNonTerm.Children[0]
-					if innerNonTerm, ok := innerNode.(*parsetree.NonTerminalNode); ok {
-						// Check if it's an Assignment sequence
-						if len(innerNonTerm.Children) == 1 {
-							// It's a SynSequence wrapping Assignment
-							assignmentNode := innerNonTerm.Children[0]
-							if assignNonTerm, ok := assignmentNode.(*parsetree.NonTerminalNode); ok && assignNonTerm.Symbol == "Assignment" {
-								if len(assignNonTerm.Children) == 2 {
-									// Check AssignmentRest
-									assignmentRest := assignNonTerm.Children[1]
-									if restNode, ok := assignmentRest.(*parsetree.NonTerminalNode); ok && len(restNode.Children) > 0 {
-										// Has assignment: EQUALS Assignment
-										// This is an index assignment: arr[0] = value
-										return convertIndexAssignmentFromExpression(assignNonTerm)
-									}
-								}
+					if assignNonTerm, ok := innerNode.(*parsetree.NonTerminalNode); ok && assignNonTerm.Symbol == "Assignment" {
+						if len(assignNonTerm.Children) == 2 {
+							// Check AssignmentRest
+							assignmentRest := assignNonTerm.Children[1]
+							if restNode, ok := assignmentRest.(*parsetree.NonTerminalNode); ok && len(restNode.Children) > 0 {
+								// Has assignment: EQUALS Assignment
+								return convertAssignmentFromExpression(assignNonTerm)
 							}
 						}
 					}
@@ -318,6 +446,7 @@ func convertToStatement(node parsetree.ParseTree) (ast.Statement, error) {
 			}
 
 			return &ast.ExpressionStatement{
+				Span:       nodeSpan(expr),
 				Token:      "", // Could extract from expression if needed
 				Expression: expr,
 			}, nil
@@ -346,17 +475,33 @@ func convertToStatement(node parsetree.ParseTree) (ast.Statement, error) {
 				return nil, err
 			}
 
+			// Build the target arr[i][j] from the innermost index out
+			var target ast.Expression = &ast.Identifier{
+				Span:  spanOf(nameNode),
+				Token: nameNode.Token.Value,
+				Name:  nameNode.Token.Value,
+			}
+			for _, index := range indices {
+				target = &ast.IndexAccess{
+					Span:   spanOf(nameNode, n.Children[1]),
+					Token:  "[",
+					Object: target,
+					Index:  index,
+				}
+			}
+
 			return &ast.IndexAssignment{
-				Token:   nameNode.Token.Value,
-				Name:    nameNode.Token.Value,
-				Indices: indices,
-				Value:   valueExpr,
+				Span:   spanOf(n),
+				Token:  nameNode.Token.Value,
+				Name:   nameNode.Token.Value,
+				Target: target,
+				Value:  valueExpr,
 			}, nil
 
 		case "FunctionDef":
-			// FunctionDef: FN IDENTIFIER LPAREN ParameterList RPAREN Block
-			if len(n.Children) != 6 {
-				return nil, fmt.Errorf("FunctionDef node expected 6 children, got %d", len(n.Children))
+			// FunctionDef: FN IDENTIFIER TypeParams LPAREN ParameterList RPAREN ReturnType Block
+			if len(n.Children) != 8 {
+				return nil, fmt.Errorf("FunctionDef node expected 8 children, got %d", len(n.Children))
 			}
 
 			// Extract fn token (child 0)
@@ -371,25 +516,45 @@ func convertToStatement(node parsetree.ParseTree) (ast.Statement, error) {
 				return nil, fmt.Errorf("expected terminal for function name, got %T", n.Children[1])
 			}
 
-			// Extract parameter list (child 3)
-			params, err := extractParameterList(n.Children[3])
+			// Extract type parameters (child 2)
+			typeParams, err := extractTypeParams(n.Children[2])
 			if err != nil {
 				return nil, err
 			}
 
-			// Extract block (child 5)
-			block, err := convertBlock(n.Children[5])
+			// Extract parameter list (child 4)
+			params, paramTypes, err := extractParameterList(n.Children[4])
+			if err != nil {
+				return nil, err
+			}
+
+			// Extract optional return type (child 6)
+			returnType, err := convertReturnType(n.Children[6])
+			if err != nil {
+				return nil, err
+			}
+
+			// Extract block (child 7)
+			block, err := convertBlock(n.Children[7])
 			if err != nil {
 				return nil, err
 			}
 
 			return &ast.FunctionDef{
+				Span:       spanOf(n),
 				Token:      fnNode.Token.Value,
 				Name:       nameNode.Token.Value,
+				TypeParams: typeParams,
 				Parameters: params,
+				ParamTypes: paramTypes,
+				ReturnType: returnType,
 				Body:       block,
 			}, nil
 
+		case "TypeDecl":
+			// TypeDecl: TYPE IDENTIFIER TypeParams EQUALS TypeBody
+			return convertTypeDeclaration(n)
+
 		case "ReturnStatement":
 			// ReturnStatement: RETURN Expression
 			if len(n.Children) != 2 {
@@ -409,6 +574,7 @@ func convertToStatement(node parsetree.ParseTree) (ast.Statement, error) {
 			}
 
 			return &ast.ReturnStatement{
+				Span:  spanOf(n),
 				Token: returnNode.Token.Value,
 				Value: valueExpr,
 			}, nil
@@ -447,6 +613,7 @@ func convertToStatement(node parsetree.ParseTree) (ast.Statement, error) {
 			}
 
 			return &ast.ForStatement{
+				Span:      spanOf(n),
 				Token:     forNode.Token.Value,
 				Condition: condition,
 				Body:      body,
@@ -465,6 +632,7 @@ func convertToStatement(node parsetree.ParseTree) (ast.Statement, error) {
 			}
 
 			return &ast.BreakStatement{
+				Span:  spanOf(n),
 				Token: breakNode.Token.Value,
 			}, nil
 
@@ -481,6 +649,7 @@ func convertToStatement(node parsetree.ParseTree) (ast.Statement, error) {
 			}
 
 			return &ast.ContinueStatement{
+				Span:  spanOf(n),
 				Token: continueNode.Token.Value,
 			}, nil
 
@@ -524,24 +693,30 @@ func convertToExpression(node parsetree.ParseTree) (ast.Expression, error) {
 					// Epsilon - no assignment, just return left side
 					return convertToExpression(n.Children[0])
 				}
-				// Has assignment: EQUALS Assignment
+				// Has assignment: EQUALS Assignment, or a record field (COLON/WITH)
 				// This is only valid in statement context, not general expressions
 				// For now, return an error - ExpressionStatement will handle it specially
+				if err := checkAssignmentOperator(restNode); err != nil {
+					return nil, err
+				}
 				return nil, fmt.Errorf("assignment is only allowed at statement level, not in expressions")
 			}
 			return convertToExpression(n.Children[0])
 
 		case "Expression":
-			// Expression: LogicalOr | FunctionLiteral
+			// Expression: Assignment | FunctionLiteral | BraceExpression
 			if len(n.Children) != 1 {
 				return nil, fmt.Errorf("Expression node expected 1 child, got %d", len(n.Children))
 			}
-			// The child is either a sequence containing LogicalOr, or FunctionLiteral
+			// The child is a sequence containing Assignment, a FunctionLiteral, or a BraceExpression
 			child := n.Children[0]
 			if childNonTerm, ok := child.(*parsetree.NonTerminalNode); ok {
 				if childNonTerm.Symbol == "FunctionLiteral" {
 					return convertFunctionLiteral(childNonTerm)
 				}
+				if childNonTerm.Symbol == "BraceExpression" {
+					return convertBraceExpression(childNonTerm)
+				}
 				// Otherwise it's a sequence containing LogicalOr - unwrap it
 				if len(childNonTerm.Children) == 1 {
 					return convertToExpression(childNonTerm.Children[0])
@@ -662,15 +837,13 @@ func convertPrimary(node *parsetree.NonTerminalNode) (ast.Expression, error) {
 			if len(node.Children) != 2 {
 				return nil, fmt.Errorf("Primary IDENTIFIER variant expected 2 children, got %d", len(node.Children))
 			}
-			name := firstChild.Token.Value
-			token := firstChild.Token.Value
-			return convertIdentifierPrimary(name, token, node.Children[1])
+			return convertIdentifierPrimary(firstChild, node.Children[1])
 		} else if firstChild.Token.Type == "LPAREN" {
-			// LPAREN Expression RPAREN
+			// LPAREN ParenContent RPAREN
 			if len(node.Children) != 3 {
 				return nil, fmt.Errorf("Primary LPAREN variant expected 3 children, got %d", len(node.Children))
 			}
-			return convertToExpression(node.Children[1])
+			return convertParenContent(spanOf(node), node.Children[1])
 		}
 		return nil, fmt.Errorf("unexpected terminal in Primary: %s", firstChild.Token.Type)
 
@@ -692,6 +865,16 @@ func convertPrimary(node *parsetree.NonTerminalNode) (ast.Expression, error) {
 				return nil, fmt.Errorf("Primary FunctionLiteral variant expected 1 child, got %d", len(node.Children))
 			}
 			return convertFunctionLiteral(firstChild)
+		} else if firstChild.Symbol == "IfExpression" {
+			if len(node.Children) != 1 {
+				return nil, fmt.Errorf("Primary IfExpression variant expected 1 child, got %d", len(node.Children))
+			}
+			return convertIfExpression(firstChild)
+		} else if firstChild.Symbol == "MatchExpression" {
+			if len(node.Children) != 1 {
+				return nil, fmt.Errorf("Primary MatchExpression variant expected 1 child, got %d", len(node.Children))
+			}
+			return convertMatchExpression(firstChild)
 		}
 		return nil, fmt.Errorf("unexpected non-terminal in Primary: %s", firstChild.Symbol)
 
@@ -737,6 +920,7 @@ func convertUnary(node *parsetree.NonTerminalNode) (ast.Expression, error) {
 			}
 
 			return &ast.UnaryExpression{
+				Span:     spanOf(node),
 				Token:    opToken.Token.Value,
 				Operator: operator,
 				Operand:  operand,
@@ -757,11 +941,15 @@ func convertUnary(node *parsetree.NonTerminalNode) (ast.Expression, error) {
 // convertIdentifierPrimary converts an identifier with PrimaryRest.
 // If PrimaryRest is empty, it's an Identifier.
 // If PrimaryRest has LPAREN, it's a FunctionCall.
-func convertIdentifierPrimary(name, token string, primaryRest parsetree.ParseTree) (ast.Expression, error) {
+func convertIdentifierPrimary(nameNode *parsetree.TerminalNode, primaryRest parsetree.ParseTree) (ast.Expression, error) {
+	name := nameNode.Token.Value
+	token := nameNode.Token.Value
+
 	switch rest := primaryRest.(type) {
 	case *parsetree.EmptyNode:
 		// PrimaryRest is ε, so this is just an identifier
 		return &ast.Identifier{
+			Span:  spanOf(nameNode),
 			Token: token,
 			Name:  name,
 		}, nil
@@ -775,6 +963,7 @@ func convertIdentifierPrimary(name, token string, primaryRest parsetree.ParseTre
 		if len(rest.Children) == 0 {
 			// Empty - just an identifier
 			return &ast.Identifier{
+				Span:  spanOf(nameNode),
 				Token: token,
 				Name:  name,
 			}, nil
@@ -791,7 +980,7 @@ func convertIdentifierPrimary(name, token string, primaryRest parsetree.ParseTre
 			return nil, fmt.Errorf("expected terminal as first child of PrimaryRest, got %T", rest.Children[0])
 		}
 
-		baseExpr := &ast.Identifier{Token: token, Name: name}
+		baseExpr := &ast.Identifier{Span: spanOf(nameNode), Token: token, Name: name}
 
 		switch firstToken.Token.Type {
 		case "LPAREN":
@@ -801,6 +990,7 @@ func convertIdentifierPrimary(name, token string, primaryRest parsetree.ParseTre
 				return nil, err
 			}
 			return &ast.FunctionCall{
+				Span:      spanOf(nameNode, rest),
 				Token:     token,
 				Name:      name,
 				Arguments: arguments,
@@ -816,6 +1006,7 @@ func convertIdentifierPrimary(name, token string, primaryRest parsetree.ParseTre
 				return nil, err
 			}
 			indexAccess := &ast.IndexAccess{
+				Span:   extendSpan(baseExpr, rest.Children[2]),
 				Token:  "[",
 				Object: baseExpr,
 				Index:  indexExpr,
@@ -833,6 +1024,7 @@ func convertIdentifierPrimary(name, token string, primaryRest parsetree.ParseTre
 				return nil, fmt.Errorf("expected IDENTIFIER after DOT, got %T", rest.Children[1])
 			}
 			memberAccess := &ast.MemberAccess{
+				Span:   extendSpan(baseExpr, memberToken),
 				Token:  ".",
 				Object: baseExpr,
 				Member: memberToken.Token.Value,
@@ -913,6 +1105,7 @@ func processBinaryOpRest(left ast.Expression, rest parsetree.ParseTree, expected
 
 		// Build binary expression
 		binaryExpr := &ast.BinaryExpression{
+			Span:     spanBetween(left, right),
 			Token:    operator,
 			Left:     left,
 			Operator: operator,
@@ -968,6 +1161,7 @@ func processAddRest(left ast.Expression, addRest parsetree.ParseTree) (ast.Expre
 
 		// Build binary expression: left op rightTerm
 		binaryExpr := &ast.BinaryExpression{
+			Span:     spanBetween(left, rightTerm),
 			Token:    operator,
 			Left:     left,
 			Operator: operator,
@@ -1023,6 +1217,7 @@ func processMulRest(left ast.Expression, mulRest parsetree.ParseTree) (ast.Expre
 
 		// Build binary expression: left op rightFactor
 		binaryExpr := &ast.BinaryExpression{
+			Span:     spanBetween(left, rightFactor),
 			Token:    operator,
 			Left:     left,
 			Operator: operator,
@@ -1173,6 +1368,7 @@ func convertTerminalToExpression(node *parsetree.TerminalNode) (ast.Expression,
 				token.Line, token.Column, err)
 		}
 		return &ast.IntLiteral{
+			Span:  spanOf(node),
 			Token: token.Value,
 			Value: value,
 		}, nil
@@ -1184,18 +1380,21 @@ func convertTerminalToExpression(node *parsetree.TerminalNode) (ast.Expression,
 				token.Line, token.Column, err)
 		}
 		return &ast.FloatLiteral{
+			Span:  spanOf(node),
 			Token: token.Value,
 			Value: value,
 		}, nil
 
 	case "TRUE":
 		return &ast.BoolLiteral{
+			Span:  spanOf(node),
 			Token: token.Value,
 			Value: true,
 		}, nil
 
 	case "FALSE":
 		return &ast.BoolLiteral{
+			Span:  spanOf(node),
 			Token: token.Value,
 			Value: false,
 		}, nil
@@ -1207,6 +1406,7 @@ func convertTerminalToExpression(node *parsetree.TerminalNode) (ast.Expression,
 				token.Line, token.Column, err)
 		}
 		return &ast.StringLiteral{
+			Span:  spanOf(node),
 			Token: token.Value,
 			Value: value,
 		}, nil
@@ -1218,6 +1418,7 @@ func convertTerminalToExpression(node *parsetree.TerminalNode) (ast.Expression,
 				token.Line, token.Column, err)
 		}
 		return &ast.StringLiteral{
+			Span:  spanOf(node),
 			Token: token.Value,
 			Value: value,
 		}, nil
@@ -1320,92 +1521,108 @@ func parseRawStringLiteral(value string) (string, error) {
 	return value[1 : len(value)-1], nil
 }
 
-// extractParameterList extracts parameter names from a ParameterList parse tree node.
-// ParameterList: ε | IDENTIFIER ParameterRest
-func extractParameterList(node parsetree.ParseTree) ([]string, error) {
+// extractParameterList extracts parameter names and their optional type annotations
+// from a ParameterList parse tree node. The types slice has a nil entry for each
+// unannotated parameter.
+// ParameterList: ε | IDENTIFIER TypeAnnotation ParameterRest
+func extractParameterList(node parsetree.ParseTree) ([]string, []ast.TypeExpression, error) {
 	// Handle epsilon production
 	if _, ok := node.(*parsetree.EmptyNode); ok {
-		return []string{}, nil
+		return []string{}, []ast.TypeExpression{}, nil
 	}
 
 	nonTerminal, ok := node.(*parsetree.NonTerminalNode)
 	if !ok {
-		return nil, fmt.Errorf("expected non-terminal for parameter list, got %T", node)
+		return nil, nil, fmt.Errorf("expected non-terminal for parameter list, got %T", node)
 	}
 
 	if nonTerminal.Symbol != "ParameterList" {
-		return nil, fmt.Errorf("expected ParameterList node, got %s", nonTerminal.Symbol)
+		return nil, nil, fmt.Errorf("expected ParameterList node, got %s", nonTerminal.Symbol)
 	}
 
 	// Check if empty (epsilon)
 	if len(nonTerminal.Children) == 0 {
-		return []string{}, nil
+		return []string{}, []ast.TypeExpression{}, nil
 	}
 
-	// ParameterList: IDENTIFIER ParameterRest
-	if len(nonTerminal.Children) != 2 {
-		return nil, fmt.Errorf("ParameterList node expected 0 or 2 children, got %d", len(nonTerminal.Children))
+	// ParameterList: IDENTIFIER TypeAnnotation ParameterRest
+	if len(nonTerminal.Children) != 3 {
+		return nil, nil, fmt.Errorf("ParameterList node expected 0 or 3 children, got %d", len(nonTerminal.Children))
 	}
 
 	// Extract first parameter (child 0)
 	firstParam, ok := nonTerminal.Children[0].(*parsetree.TerminalNode)
 	if !ok {
-		return nil, fmt.Errorf("expected terminal for first parameter, got %T", nonTerminal.Children[0])
+		return nil, nil, fmt.Errorf("expected terminal for first parameter, got %T", nonTerminal.Children[0])
+	}
+
+	// Extract its optional type annotation (child 1)
+	firstType, err := convertTypeAnnotation(nonTerminal.Children[1])
+	if err != nil {
+		return nil, nil, err
 	}
 
 	params := []string{firstParam.Token.Value}
+	types := []ast.TypeExpression{firstType}
 
 	// Extract remaining parameters from ParameterRest
-	restParams, err := extractParameterRest(nonTerminal.Children[1])
+	restParams, restTypes, err := extractParameterRest(nonTerminal.Children[2])
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return append(params, restParams...), nil
+	return append(params, restParams...), append(types, restTypes...), nil
 }
 
 // extractParameterRest extracts remaining parameters from ParameterRest node.
-// ParameterRest: COMMA IDENTIFIER ParameterRest | ε
-func extractParameterRest(node parsetree.ParseTree) ([]string, error) {
+// ParameterRest: COMMA IDENTIFIER TypeAnnotation ParameterRest | ε
+func extractParameterRest(node parsetree.ParseTree) ([]string, []ast.TypeExpression, error) {
 	// Handle epsilon production
 	if _, ok := node.(*parsetree.EmptyNode); ok {
-		return []string{}, nil
+		return []string{}, []ast.TypeExpression{}, nil
 	}
 
 	nonTerminal, ok := node.(*parsetree.NonTerminalNode)
 	if !ok {
-		return nil, fmt.Errorf("expected non-terminal for parameter rest, got %T", node)
+		return nil, nil, fmt.Errorf("expected non-terminal for parameter rest, got %T", node)
 	}
 
 	if nonTerminal.Symbol != "ParameterRest" {
-		return nil, fmt.Errorf("expected ParameterRest node, got %s", nonTerminal.Symbol)
+		return nil, nil, fmt.Errorf("expected ParameterRest node, got %s", nonTerminal.Symbol)
 	}
 
 	// Check if empty (epsilon)
 	if len(nonTerminal.Children) == 0 {
-		return []string{}, nil
+		return []string{}, []ast.TypeExpression{}, nil
 	}
 
-	// ParameterRest: COMMA IDENTIFIER ParameterRest
-	if len(nonTerminal.Children) != 3 {
-		return nil, fmt.Errorf("ParameterRest node expected 0 or 3 children, got %d", len(nonTerminal.Children))
+	// ParameterRest: COMMA IDENTIFIER TypeAnnotation ParameterRest
+	if len(nonTerminal.Children) != 4 {
+		return nil, nil, fmt.Errorf("ParameterRest node expected 0 or 4 children, got %d", len(nonTerminal.Children))
 	}
 
 	// Extract parameter (child 1)
 	param, ok := nonTerminal.Children[1].(*parsetree.TerminalNode)
 	if !ok {
-		return nil, fmt.Errorf("expected terminal for parameter, got %T", nonTerminal.Children[1])
+		return nil, nil, fmt.Errorf("expected terminal for parameter, got %T", nonTerminal.Children[1])
+	}
+
+	// Extract its optional type annotation (child 2)
+	paramType, err := convertTypeAnnotation(nonTerminal.Children[2])
+	if err != nil {
+		return nil, nil, err
 	}
 
 	params := []string{param.Token.Value}
+	types := []ast.TypeExpression{paramType}
 
 	// Recursively extract remaining parameters
-	restParams, err := extractParameterRest(nonTerminal.Children[2])
+	restParams, restTypes, err := extractParameterRest(nonTerminal.Children[3])
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return append(params, restParams...), nil
+	return append(params, restParams...), append(types, restTypes...), nil
 }
 
 // convertBlock converts a Block parse tree node to an AST Block.
@@ -1438,6 +1655,7 @@ func convertBlock(node parsetree.ParseTree) (*ast.Block, error) {
 	}
 
 	return &ast.Block{
+		Span:       spanOf(nonTerminal),
 		Token:      lbrace.Token.Value,
 		Statements: statements,
 	}, nil
@@ -1533,15 +1751,15 @@ func extractBlockStmtRest(node parsetree.ParseTree) ([]ast.Statement, error) {
 }
 
 // convertFunctionLiteral converts a FunctionLiteral parse tree node to an AST FunctionLiteral.
-// FunctionLiteral: FN LPAREN ParameterList RPAREN Block
+// FunctionLiteral: FN LPAREN ParameterList RPAREN ReturnType Block
 func convertFunctionLiteral(node *parsetree.NonTerminalNode) (ast.Expression, error) {
 	if node.Symbol != "FunctionLiteral" {
 		return nil, fmt.Errorf("expected FunctionLiteral node, got %s", node.Symbol)
 	}
 
-	// FunctionLiteral: FN LPAREN ParameterList RPAREN Block
-	if len(node.Children) != 5 {
-		return nil, fmt.Errorf("FunctionLiteral node expected 5 children, got %d", len(node.Children))
+	// FunctionLiteral: FN LPAREN ParameterList RPAREN ReturnType Block
+	if len(node.Children) != 6 {
+		return nil, fmt.Errorf("FunctionLiteral node expected 6 children, got %d", len(node.Children))
 	}
 
 	// Extract fn token (child 0)
@@ -1551,24 +1769,147 @@ func convertFunctionLiteral(node *parsetree.NonTerminalNode) (ast.Expression, er
 	}
 
 	// Extract parameter list (child 2)
-	params, err := extractParameterList(node.Children[2])
+	params, paramTypes, err := extractParameterList(node.Children[2])
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract optional return type (child 4)
+	returnType, err := convertReturnType(node.Children[4])
 	if err != nil {
 		return nil, err
 	}
 
-	// Extract block (child 4)
-	block, err := convertBlock(node.Children[4])
+	// Extract block (child 5)
+	block, err := convertBlock(node.Children[5])
 	if err != nil {
 		return nil, err
 	}
 
 	return &ast.FunctionLiteral{
+		Span:       spanOf(node),
 		Token:      fnNode.Token.Value,
 		Parameters: params,
+		ParamTypes: paramTypes,
+		ReturnType: returnType,
 		Body:       block,
 	}, nil
 }
 
+// convertIfExpression converts an IfExpression parse tree node to an AST IfExpression.
+// IfExpression: IF Expression Block ElseClause
+func convertIfExpression(node *parsetree.NonTerminalNode) (*ast.IfExpression, error) {
+	if node.Symbol != "IfExpression" {
+		return nil, fmt.Errorf("expected IfExpression node, got %s", node.Symbol)
+	}
+
+	if len(node.Children) != 4 {
+		return nil, fmt.Errorf("IfExpression node expected 4 children, got %d", len(node.Children))
+	}
+
+	// Extract if token (child 0)
+	ifNode, ok := node.Children[0].(*parsetree.TerminalNode)
+	if !ok {
+		return nil, fmt.Errorf("expected terminal for if keyword, got %T", node.Children[0])
+	}
+
+	// Extract condition (child 1)
+	condition, err := convertToExpression(node.Children[1])
+	if err != nil {
+		return nil, fmt.Errorf("error converting if condition: %v", err)
+	}
+
+	// Extract consequence block (child 2)
+	consequence, err := convertBlock(node.Children[2])
+	if err != nil {
+		return nil, fmt.Errorf("error converting if body: %v", err)
+	}
+
+	// Extract optional else clause (child 3)
+	alternative, err := convertElseClause(node.Children[3])
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.IfExpression{
+		Span:        spanOf(node),
+		Token:       ifNode.Token.Value,
+		Condition:   condition,
+		Consequence: consequence,
+		Alternative: alternative,
+	}, nil
+}
+
+// convertElseClause converts an ElseClause parse tree node to the else block of an IfExpression.
+// ElseClause: ELSE ElseBody | ε
+// ElseBody: IfExpression | Block
+// Returns nil if there is no else branch. An "else if" is wrapped in a block
+// containing the nested IfExpression as its only statement.
+func convertElseClause(node parsetree.ParseTree) (*ast.Block, error) {
+	// Handle epsilon production
+	if _, ok := node.(*parsetree.EmptyNode); ok {
+		return nil, nil
+	}
+
+	nonTerminal, ok := node.(*parsetree.NonTerminalNode)
+	if !ok {
+		return nil, fmt.Errorf("expected non-terminal for else clause, got %T", node)
+	}
+
+	if nonTerminal.Symbol != "ElseClause" {
+		return nil, fmt.Errorf("expected ElseClause node, got %s", nonTerminal.Symbol)
+	}
+
+	// Check if empty (epsilon)
+	if len(nonTerminal.Children) == 0 {
+		return nil, nil
+	}
+
+	// ElseClause: ELSE ElseBody
+	if len(nonTerminal.Children) != 2 {
+		return nil, fmt.Errorf("ElseClause node expected 0 or 2 children, got %d", len(nonTerminal.Children))
+	}
+
+	elseBody, ok := nonTerminal.Children[1].(*parsetree.NonTerminalNode)
+	if !ok || elseBody.Symbol != "ElseBody" || len(elseBody.Children) != 1 {
+		return nil, fmt.Errorf("expected ElseBody with 1 child, got %v", nonTerminal.Children[1])
+	}
+
+	body, ok := elseBody.Children[0].(*parsetree.NonTerminalNode)
+	if !ok {
+		return nil, fmt.Errorf("expected non-terminal in ElseBody, got %T", elseBody.Children[0])
+	}
+
+	switch body.Symbol {
+	case "Block":
+		block, err := convertBlock(body)
+		if err != nil {
+			return nil, fmt.Errorf("error converting else body: %v", err)
+		}
+		return block, nil
+
+	case "IfExpression":
+		nested, err := convertIfExpression(body)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.Block{
+			Span:  nodeSpan(nested),
+			Token: nested.Token,
+			Statements: []ast.Statement{
+				&ast.ExpressionStatement{
+					Span:       nodeSpan(nested),
+					Token:      nested.Token,
+					Expression: nested,
+				},
+			},
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected non-terminal in ElseBody: %s", body.Symbol)
+	}
+}
+
 // processPrimaryRest processes a PrimaryRest node and builds index/member access expressions.
 // This handles chaining like arr[0].len() or obj.field[0]
 func processPrimaryRest(base ast.Expression, primaryRest parsetree.ParseTree) (ast.Expression, error) {
@@ -1615,6 +1956,7 @@ func processPrimaryRest(base ast.Expression, primaryRest parsetree.ParseTree) (a
 				// Pass the MemberAccess itself as the first argument
 				// It will be evaluated to an ArrayMethod if it's an array method call
 				nextBase = &ast.FunctionCall{
+					Span:      extendSpan(base, rest.Children[2]),
 					Token:     funcName,
 					Name:      funcName,
 					Arguments: append([]ast.Expression{memberAccess}, arguments...),
@@ -1634,6 +1976,7 @@ func processPrimaryRest(base ast.Expression, primaryRest parsetree.ParseTree) (a
 				return nil, err
 			}
 			nextBase = &ast.IndexAccess{
+				Span:   extendSpan(base, rest.Children[2]),
 				Token:  "[",
 				Object: base,
 				Index:  indexExpr,
@@ -1650,6 +1993,7 @@ func processPrimaryRest(base ast.Expression, primaryRest parsetree.ParseTree) (a
 				return nil, fmt.Errorf("expected IDENTIFIER after DOT, got %T", rest.Children[1])
 			}
 			nextBase = &ast.MemberAccess{
+				Span:   extendSpan(base, memberToken),
 				Token:  ".",
 				Object: base,
 				Member: memberToken.Token.Value,
@@ -1689,6 +2033,7 @@ func convertArrayLiteral(node *parsetree.NonTerminalNode) (ast.Expression, error
 	if _, isEmpty := arrayContent.(*parsetree.EmptyNode); isEmpty {
 		// Empty array
 		return &ast.ArrayLiteral{
+			Span:     spanOf(node),
 			Token:    "[",
 			Elements: []ast.Expression{},
 		}, nil
@@ -1712,6 +2057,7 @@ func convertArrayLiteral(node *parsetree.NonTerminalNode) (ast.Expression, error
 	}
 
 	return &ast.ArrayLiteral{
+		Span:     spanOf(node),
 		Token:    "[",
 		Elements: elements,
 	}, nil
@@ -1832,11 +2178,13 @@ func extractIndexChain(node parsetree.ParseTree) ([]ast.Expression, error) {
 	return append([]ast.Expression{firstIndex}, restIndices...), nil
 }
 
-// convertIndexAssignmentFromExpression converts an Assignment parse tree node to an IndexAssignment AST node.
+// convertAssignmentFromExpression converts an Assignment parse tree node to an
+// Assignment or IndexAssignment AST node.
 // Assignment: LogicalOr AssignmentRest
 // AssignmentRest: EQUALS Assignment
-// The left side (LogicalOr) must evaluate to an IndexAccess expression.
-func convertIndexAssignmentFromExpression(assignmentNode *parsetree.NonTerminalNode) (ast.Statement, error) {
+// The left side (LogicalOr) must be an Identifier, or a chain of IndexAccess and
+// MemberAccess expressions starting at one.
+func convertAssignmentFromExpression(assignmentNode *parsetree.NonTerminalNode) (ast.Statement, error) {
 	if len(assignmentNode.Children) != 2 {
 		return nil, fmt.Errorf("Assignment expected 2 children, got %d", len(assignmentNode.Children))
 	}
@@ -1847,19 +2195,15 @@ func convertIndexAssignmentFromExpression(assignmentNode *parsetree.NonTerminalN
 		return nil, fmt.Errorf("error converting left side of assignment: %v", err)
 	}
 
-	// Extract array name and indices from the left expression
-	// It should be an IndexAccess or nested IndexAccess
-	arrName, indices, err := extractIndexAssignmentParts(leftExpr)
-	if err != nil {
-		return nil, fmt.Errorf("left side of assignment must be an array index access: %v", err)
-	}
-
 	// Parse AssignmentRest: EQUALS Assignment
 	assignmentRest := assignmentNode.Children[1]
 	restNode, ok := assignmentRest.(*parsetree.NonTerminalNode)
 	if !ok || len(restNode.Children) != 2 {
 		return nil, fmt.Errorf("invalid assignment rest")
 	}
+	if err := checkAssignmentOperator(restNode); err != nil {
+		return nil, err
+	}
 
 	// Parse right side (the value to assign)
 	valueExpr, err := convertToExpression(restNode.Children[1]) // Children[1] is the Assignment
@@ -1867,36 +2211,72 @@ func convertIndexAssignmentFromExpression(assignmentNode *parsetree.NonTerminalN
 		return nil, fmt.Errorf("error converting right side of assignment: %v", err)
 	}
 
+	// Plain variable reassignment: x = value
+	if ident, ok := leftExpr.(*ast.Identifier); ok {
+		return &ast.Assignment{
+			Span:  spanBetween(ident, valueExpr),
+			Token: ident.Name,
+			Name:  ident.Name,
+			Value: valueExpr,
+		}, nil
+	}
+
+	// Otherwise the left side must be an array element or record field
+	// inside a variable, such as arr[i][j] or p.items[0]
+	root, err := assignmentRoot(leftExpr)
+	if err != nil {
+		return nil, fmt.Errorf("left side of assignment must be a variable, array element or record field: %v", err)
+	}
+
 	return &ast.IndexAssignment{
-		Token:   arrName,
-		Name:    arrName,
-		Indices: indices,
-		Value:   valueExpr,
+		Span:   spanBetween(leftExpr, valueExpr),
+		Token:  root.Name,
+		Name:   root.Name,
+		Target: leftExpr,
+		Value:  valueExpr,
 	}, nil
 }
 
-// extractIndexAssignmentParts extracts the array name and index expressions from an expression.
-// For example, from arr[0] it returns ("arr", [0])
-// From matrix[i][j] it returns ("matrix", [i, j])
-func extractIndexAssignmentParts(expr ast.Expression) (string, []ast.Expression, error) {
+// checkAssignmentOperator rejects record field syntax (name: value, base with name: value)
+// in an AssignmentRest node outside of braces.
+func checkAssignmentOperator(restNode *parsetree.NonTerminalNode) error {
+	operator, ok := restNode.Children[0].(*parsetree.TerminalNode)
+	if !ok {
+		return fmt.Errorf("invalid assignment rest")
+	}
+	switch operator.Token.Type {
+	case "COLON":
+		return fmt.Errorf("record field syntax (name: value) is only allowed inside braces")
+	case "WITH":
+		return fmt.Errorf("record update syntax (base with name: value) is only allowed inside braces")
+	}
+	return nil
+}
+
+// isMutModifier reports whether a MutModifier node holds the MUT keyword.
+// MutModifier: MUT | ε
+func isMutModifier(node parsetree.ParseTree) bool {
+	switch n := node.(type) {
+	case *parsetree.TerminalNode:
+		return n.Token.Type == "MUT"
+	case *parsetree.NonTerminalNode:
+		return len(n.Children) == 1 && isMutModifier(n.Children[0])
+	default:
+		return false
+	}
+}
+
+// assignmentRoot returns the variable an assignment target is inside.
+// For example, for arr[0] it returns arr, and for p.items[i][j] it returns p.
+func assignmentRoot(expr ast.Expression) (*ast.Identifier, error) {
 	switch e := expr.(type) {
+	case *ast.Identifier:
+		return e, nil
 	case *ast.IndexAccess:
-		// Base case or nested index access
-		// Check if the object is an Identifier or another IndexAccess
-		if ident, ok := e.Object.(*ast.Identifier); ok {
-			// Base case: arr[i]
-			return ident.Name, []ast.Expression{e.Index}, nil
-		} else if _, ok := e.Object.(*ast.IndexAccess); ok {
-			// Nested: arr[i][j]
-			name, indices, err := extractIndexAssignmentParts(e.Object)
-			if err != nil {
-				return "", nil, err
-			}
-			return name, append(indices, e.Index), nil
-		}
-		return "", nil, fmt.Errorf("index access object must be identifier or index access, got %T", e.Object)
-
+		return assignmentRoot(e.Object)
+	case *ast.MemberAccess:
+		return assignmentRoot(e.Object)
 	default:
-		return "", nil, fmt.Errorf("assignment target must be an array index access, got %T", expr)
+		return nil, fmt.Errorf("assignment target must be a variable, index access or field access, got %T", expr)
 	}
 }
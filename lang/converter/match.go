@@ -0,0 +1,327 @@
+package converter
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+	"github.com/shadowCow/cow-lang-go/tooling/parsetree"
+)
+
+// This file converts match expressions and their patterns.
+
+// convertMatchExpression converts a MatchExpression node.
+// MatchExpression: MATCH Expression LBRACE MatchArms RBRACE
+func convertMatchExpression(node *parsetree.NonTerminalNode) (*ast.MatchExpression, error) {
+	if len(node.Children) != 5 {
+		return nil, fmt.Errorf("MatchExpression node expected 5 children, got %d", len(node.Children))
+	}
+
+	// Extract match token (child 0)
+	matchNode, ok := node.Children[0].(*parsetree.TerminalNode)
+	if !ok {
+		return nil, fmt.Errorf("expected terminal for match keyword, got %T", node.Children[0])
+	}
+
+	// Extract subject (child 1)
+	subject, err := convertToExpression(node.Children[1])
+	if err != nil {
+		return nil, fmt.Errorf("error converting match subject: %v", err)
+	}
+
+	// Extract arms (child 3)
+	arms, err := extractMatchArms(node.Children[3])
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.MatchExpression{
+		Span:    spanOf(node),
+		Token:   matchNode.Token.Value,
+		Subject: subject,
+		Arms:    arms,
+	}, nil
+}
+
+// extractMatchArms extracts the arms of a match expression.
+// MatchArms: NEWLINE MatchArms | MatchArm MatchArmRest | ε
+// MatchArmRest: COMMA MatchArms | NEWLINE MatchArms | ε
+func extractMatchArms(node parsetree.ParseTree) ([]*ast.MatchArm, error) {
+	arms := []*ast.MatchArm{}
+	for {
+		children, err := optionalChildren(node, "MatchArms")
+		if err != nil {
+			return nil, err
+		}
+
+		switch len(children) {
+		case 0:
+			return arms, nil
+
+		case 2:
+			// Skip blank lines between arms
+			if _, ok := children[0].(*parsetree.TerminalNode); ok {
+				node = children[1]
+				continue
+			}
+
+			arm, err := convertMatchArm(children[0])
+			if err != nil {
+				return nil, err
+			}
+			arms = append(arms, arm)
+
+			// MatchArmRest: separator followed by more arms, or nothing
+			rest, err := optionalChildren(children[1], "MatchArmRest")
+			if err != nil {
+				return nil, err
+			}
+			if len(rest) == 0 {
+				return arms, nil
+			}
+			if len(rest) != 2 {
+				return nil, fmt.Errorf("MatchArmRest node expected 0 or 2 children, got %d", len(rest))
+			}
+			node = rest[1]
+
+		default:
+			return nil, fmt.Errorf("MatchArms node expected 0 or 2 children, got %d", len(children))
+		}
+	}
+}
+
+// convertMatchArm converts a MatchArm node.
+// MatchArm: Pattern FAT_ARROW Expression
+// A block body ({ ... }) is used as the arm body directly; any other expression
+// is wrapped in a block containing it as the only statement.
+func convertMatchArm(node parsetree.ParseTree) (*ast.MatchArm, error) {
+	nonTerminal, ok := node.(*parsetree.NonTerminalNode)
+	if !ok || nonTerminal.Symbol != "MatchArm" {
+		return nil, fmt.Errorf("expected MatchArm node, got %T", node)
+	}
+	if len(nonTerminal.Children) != 3 {
+		return nil, fmt.Errorf("MatchArm node expected 3 children, got %d", len(nonTerminal.Children))
+	}
+
+	pattern, err := convertPattern(nonTerminal.Children[0])
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err := convertToExpression(nonTerminal.Children[2])
+	if err != nil {
+		return nil, fmt.Errorf("error converting match arm body: %v", err)
+	}
+
+	var body *ast.Block
+	if blockExpr, ok := expr.(*ast.BlockExpression); ok {
+		body = blockExpr.Block
+	} else {
+		body = &ast.Block{
+			Span:  nodeSpan(expr),
+			Token: pattern.TokenLiteral(),
+			Statements: []ast.Statement{
+				&ast.ExpressionStatement{
+					Span:       nodeSpan(expr),
+					Token:      pattern.TokenLiteral(),
+					Expression: expr,
+				},
+			},
+		}
+	}
+
+	return &ast.MatchArm{
+		Span:    spanBetween(pattern, body),
+		Token:   pattern.TokenLiteral(),
+		Pattern: pattern,
+		Body:    body,
+	}, nil
+}
+
+// convertPattern converts a Pattern node.
+// Pattern: IDENTIFIER PatternArgs | Literal | MINUS Literal | LBRACKET PatternList RBRACKET | LPAREN PatternList RPAREN
+func convertPattern(node parsetree.ParseTree) (ast.Pattern, error) {
+	nonTerminal, ok := node.(*parsetree.NonTerminalNode)
+	if !ok || nonTerminal.Symbol != "Pattern" {
+		return nil, fmt.Errorf("expected Pattern node, got %T", node)
+	}
+	if len(nonTerminal.Children) == 0 {
+		return nil, fmt.Errorf("Pattern node has no children")
+	}
+
+	switch first := nonTerminal.Children[0].(type) {
+	case *parsetree.NonTerminalNode:
+		// Literal
+		return convertLiteralPattern(first, nil)
+
+	case *parsetree.TerminalNode:
+		switch first.Token.Type {
+		case "IDENTIFIER":
+			if len(nonTerminal.Children) != 2 {
+				return nil, fmt.Errorf("Pattern IDENTIFIER variant expected 2 children, got %d", len(nonTerminal.Children))
+			}
+			return convertIdentifierPattern(first, nonTerminal.Children[1])
+
+		case "MINUS":
+			// MINUS Literal (negative number)
+			if len(nonTerminal.Children) != 2 {
+				return nil, fmt.Errorf("Pattern MINUS variant expected 2 children, got %d", len(nonTerminal.Children))
+			}
+			literal, ok := nonTerminal.Children[1].(*parsetree.NonTerminalNode)
+			if !ok {
+				return nil, fmt.Errorf("expected Literal after '-' in pattern, got %T", nonTerminal.Children[1])
+			}
+			return convertLiteralPattern(literal, first)
+
+		case "LBRACKET":
+			// LBRACKET PatternList RBRACKET
+			if len(nonTerminal.Children) != 3 {
+				return nil, fmt.Errorf("Pattern LBRACKET variant expected 3 children, got %d", len(nonTerminal.Children))
+			}
+			elements, err := extractPatternList(nonTerminal.Children[1])
+			if err != nil {
+				return nil, err
+			}
+			return &ast.ArrayPattern{
+				Span:     spanOf(nonTerminal),
+				Token:    first.Token.Value,
+				Elements: elements,
+			}, nil
+
+		case "LPAREN":
+			// LPAREN PatternList RPAREN
+			if len(nonTerminal.Children) != 3 {
+				return nil, fmt.Errorf("Pattern LPAREN variant expected 3 children, got %d", len(nonTerminal.Children))
+			}
+			return convertParenPattern(spanOf(nonTerminal), nonTerminal.Children[1])
+		}
+		return nil, fmt.Errorf("unexpected terminal in Pattern: %s", first.Token.Type)
+
+	default:
+		return nil, fmt.Errorf("unexpected first child type in Pattern: %T", first)
+	}
+}
+
+// convertIdentifierPattern converts an identifier pattern with optional arguments.
+// _ is a wildcard, an uppercase name is a constructor, and any other name is a binding.
+// PatternArgs: LPAREN Pattern PatternRest RPAREN | ε
+func convertIdentifierPattern(nameNode *parsetree.TerminalNode, patternArgs parsetree.ParseTree) (ast.Pattern, error) {
+	name := nameNode.Token.Value
+	span := spanOf(nameNode, patternArgs)
+
+	argChildren, err := optionalChildren(patternArgs, "PatternArgs")
+	if err != nil {
+		return nil, err
+	}
+
+	var args []ast.Pattern
+	if len(argChildren) != 0 {
+		if len(argChildren) != 4 {
+			return nil, fmt.Errorf("PatternArgs node expected 0 or 4 children, got %d", len(argChildren))
+		}
+		args, err = extractPatternSequence(argChildren[1], argChildren[2])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !isConstructorName(name) {
+		if args != nil {
+			return nil, fmt.Errorf("pattern %s(...) must name a constructor (constructors start with an uppercase letter)", name)
+		}
+		if name == "_" {
+			return &ast.WildcardPattern{Span: span, Token: name}, nil
+		}
+		return &ast.BindingPattern{Span: span, Token: name, Name: name}, nil
+	}
+
+	if args == nil {
+		args = []ast.Pattern{}
+	}
+	return &ast.ConstructorPattern{
+		Span:  span,
+		Token: name,
+		Name:  name,
+		Args:  args,
+	}, nil
+}
+
+// convertLiteralPattern converts a Literal node in a pattern.
+// minus is a preceding '-', which negates a number; it is nil when there is no '-'.
+func convertLiteralPattern(node *parsetree.NonTerminalNode, minus *parsetree.TerminalNode) (ast.Pattern, error) {
+	expr, err := convertToExpression(node)
+	if err != nil {
+		return nil, err
+	}
+
+	if minus != nil {
+		span := ast.Span{Start: tokenPosition(minus), Stop: expr.End()}
+		switch lit := expr.(type) {
+		case *ast.IntLiteral:
+			expr = &ast.IntLiteral{Span: span, Token: "-" + lit.Token, Value: -lit.Value}
+		case *ast.FloatLiteral:
+			expr = &ast.FloatLiteral{Span: span, Token: "-" + lit.Token, Value: -lit.Value}
+		default:
+			return nil, fmt.Errorf("only numbers can be negated in patterns, got %s", expr.TokenLiteral())
+		}
+	}
+
+	return &ast.LiteralPattern{
+		Span:  nodeSpan(expr),
+		Token: expr.TokenLiteral(),
+		Value: expr,
+	}, nil
+}
+
+// extractPatternList extracts the element patterns of an array or tuple pattern.
+// PatternList: Pattern PatternRest | ε
+func extractPatternList(node parsetree.ParseTree) ([]ast.Pattern, error) {
+	children, err := optionalChildren(node, "PatternList")
+	if err != nil {
+		return nil, err
+	}
+	if len(children) == 0 {
+		return []ast.Pattern{}, nil
+	}
+	if len(children) != 2 {
+		return nil, fmt.Errorf("PatternList node expected 0 or 2 children, got %d", len(children))
+	}
+	return extractPatternSequence(children[0], children[1])
+}
+
+// extractPatternSequence extracts a comma-separated list of patterns.
+// The list is a Pattern followed by PatternRest: COMMA Pattern PatternRest | ε
+func extractPatternSequence(first parsetree.ParseTree, rest parsetree.ParseTree) ([]ast.Pattern, error) {
+	firstPattern, err := convertPattern(first)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := []ast.Pattern{firstPattern}
+	for {
+		restChildren, err := optionalChildren(rest, "PatternRest")
+		if err != nil {
+			return nil, err
+		}
+		if len(restChildren) == 0 {
+			return patterns, nil
+		}
+		if len(restChildren) != 3 {
+			return nil, fmt.Errorf("PatternRest node expected 0 or 3 children, got %d", len(restChildren))
+		}
+		next, err := convertPattern(restChildren[1])
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, next)
+		rest = restChildren[2]
+	}
+}
+
+// isConstructorName reports whether a name refers to a constructor.
+// Types and constructors start with an uppercase letter.
+func isConstructorName(name string) bool {
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
+}
@@ -0,0 +1,316 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+	"github.com/shadowCow/cow-lang-go/tooling/parsetree"
+)
+
+// This file converts brace expressions (record literals, record updates and
+// block expressions) and record type fields.
+
+// braceItem is one statement inside a brace expression, with the separator that follows it.
+type braceItem struct {
+	statement parsetree.ParseTree
+	comma     bool
+}
+
+// convertBraceExpression converts a BraceExpression node.
+// BraceExpression: LBRACE BraceStatements RBRACE
+// The contents are parsed as statements, and their shape decides the result:
+//   - every statement is a field (name: value): a RecordLiteral
+//   - the first statement is base with name: value, the rest are fields: a RecordUpdate
+//   - otherwise: a BlockExpression
+func convertBraceExpression(node *parsetree.NonTerminalNode) (ast.Expression, error) {
+	if len(node.Children) != 3 {
+		return nil, fmt.Errorf("BraceExpression node expected 3 children, got %d", len(node.Children))
+	}
+
+	lbrace, ok := node.Children[0].(*parsetree.TerminalNode)
+	if !ok {
+		return nil, fmt.Errorf("expected terminal for {, got %T", node.Children[0])
+	}
+	token := lbrace.Token.Value
+	span := spanOf(node)
+
+	items, err := extractBraceStatements(node.Children[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return &ast.BlockExpression{
+			Span:  span,
+			Token: token,
+			Block: &ast.Block{Span: span, Token: token, Statements: []ast.Statement{}},
+		}, nil
+	}
+
+	// Record update: { base with name: value, ... }
+	if assign, op := fieldAssignment(items[0].statement); op == "WITH" {
+		rest := assign.Children[1].(*parsetree.NonTerminalNode)
+		base, err := convertToExpression(assign.Children[0])
+		if err != nil {
+			return nil, fmt.Errorf("error converting record update base: %v", err)
+		}
+
+		first, err := convertFieldValue(rest.Children[1])
+		if err != nil {
+			return nil, err
+		}
+		fields, err := convertFieldValues(items[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		return &ast.RecordUpdate{
+			Span:   span,
+			Token:  token,
+			Base:   base,
+			Fields: append([]*ast.FieldValue{first}, fields...),
+		}, nil
+	}
+
+	// Record literal: { name: value, ... }
+	if _, op := fieldAssignment(items[0].statement); op == "COLON" {
+		fields, err := convertFieldValues(items)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.RecordLiteral{
+			Span:   span,
+			Token:  token,
+			Fields: fields,
+		}, nil
+	}
+
+	// Block expression: statements separated by newlines
+	statements := []ast.Statement{}
+	for _, item := range items {
+		if item.comma {
+			return nil, fmt.Errorf("statements in a block are separated by newlines, not commas")
+		}
+		stmt, err := convertToStatement(item.statement)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, stmt)
+	}
+
+	return &ast.BlockExpression{
+		Span:  span,
+		Token: token,
+		Block: &ast.Block{Span: span, Token: token, Statements: statements},
+	}, nil
+}
+
+// extractBraceStatements extracts the statements of a brace expression.
+// BraceStatements: NEWLINE BraceStatements | Statement BraceStmtRest | ε
+// BraceStmtRest: COMMA BraceStatements | NEWLINE BraceStatements | ε
+func extractBraceStatements(node parsetree.ParseTree) ([]braceItem, error) {
+	items := []braceItem{}
+	for {
+		children, err := optionalChildren(node, "BraceStatements")
+		if err != nil {
+			return nil, err
+		}
+
+		switch len(children) {
+		case 0:
+			return items, nil
+
+		case 2:
+			// Skip blank lines between statements
+			if _, ok := children[0].(*parsetree.TerminalNode); ok {
+				node = children[1]
+				continue
+			}
+
+			rest, err := optionalChildren(children[1], "BraceStmtRest")
+			if err != nil {
+				return nil, err
+			}
+			if len(rest) == 0 {
+				return append(items, braceItem{statement: children[0]}), nil
+			}
+			if len(rest) != 2 {
+				return nil, fmt.Errorf("BraceStmtRest node expected 0 or 2 children, got %d", len(rest))
+			}
+
+			separator, ok := rest[0].(*parsetree.TerminalNode)
+			if !ok {
+				return nil, fmt.Errorf("expected terminal separator in BraceStmtRest, got %T", rest[0])
+			}
+			items = append(items, braceItem{
+				statement: children[0],
+				comma:     separator.Token.Type == "COMMA",
+			})
+			node = rest[1]
+
+		default:
+			return nil, fmt.Errorf("BraceStatements node expected 0 or 2 children, got %d", len(children))
+		}
+	}
+}
+
+// fieldAssignment finds the Assignment node of a statement written as name: value
+// or base with name: value, returning it with the operator token type (COLON or WITH).
+// Returns nil and "" for any other statement.
+// Statement -> ExpressionStatement -> Expression -> Assignment -> LogicalOr AssignmentRest
+func fieldAssignment(node parsetree.ParseTree) (*parsetree.NonTerminalNode, string) {
+	for {
+		nonTerminal, ok := node.(*parsetree.NonTerminalNode)
+		if !ok {
+			return nil, ""
+		}
+
+		switch nonTerminal.Symbol {
+		case "Statement", "ExpressionStatement", "Expression":
+			if len(nonTerminal.Children) != 1 {
+				return nil, ""
+			}
+			node = nonTerminal.Children[0]
+
+		case "Assignment":
+			if len(nonTerminal.Children) != 2 {
+				return nil, ""
+			}
+			rest, ok := nonTerminal.Children[1].(*parsetree.NonTerminalNode)
+			if !ok || len(rest.Children) != 2 {
+				return nil, ""
+			}
+			operator, ok := rest.Children[0].(*parsetree.TerminalNode)
+			if !ok {
+				return nil, ""
+			}
+			if operator.Token.Type != "COLON" && operator.Token.Type != "WITH" {
+				return nil, ""
+			}
+			return nonTerminal, string(operator.Token.Type)
+
+		default:
+			return nil, ""
+		}
+	}
+}
+
+// convertFieldValues converts brace statements that must all be fields (name: value).
+func convertFieldValues(items []braceItem) ([]*ast.FieldValue, error) {
+	fields := []*ast.FieldValue{}
+	seen := map[string]bool{}
+	for _, item := range items {
+		assign, op := fieldAssignment(item.statement)
+		if op != "COLON" {
+			return nil, fmt.Errorf("expected a record field (name: value)")
+		}
+		field, err := convertFieldValue(assign)
+		if err != nil {
+			return nil, err
+		}
+		if seen[field.Name] {
+			return nil, fmt.Errorf("duplicate record field '%s'", field.Name)
+		}
+		seen[field.Name] = true
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// convertFieldValue converts an Assignment node written as name: value.
+// Assignment: LogicalOr AssignmentRest
+// AssignmentRest: COLON Expression
+func convertFieldValue(node parsetree.ParseTree) (*ast.FieldValue, error) {
+	assign, ok := node.(*parsetree.NonTerminalNode)
+	if !ok || assign.Symbol != "Assignment" || len(assign.Children) != 2 {
+		return nil, fmt.Errorf("expected a record field (name: value)")
+	}
+	rest, ok := assign.Children[1].(*parsetree.NonTerminalNode)
+	if !ok || len(rest.Children) != 2 {
+		return nil, fmt.Errorf("expected a record field (name: value)")
+	}
+	if operator, ok := rest.Children[0].(*parsetree.TerminalNode); !ok || operator.Token.Type != "COLON" {
+		return nil, fmt.Errorf("expected a record field (name: value)")
+	}
+
+	name, err := convertToExpression(assign.Children[0])
+	if err != nil {
+		return nil, err
+	}
+	ident, ok := name.(*ast.Identifier)
+	if !ok {
+		return nil, fmt.Errorf("record field name must be an identifier, got %s", name.TokenLiteral())
+	}
+
+	value, err := convertToExpression(rest.Children[1])
+	if err != nil {
+		return nil, fmt.Errorf("error converting value of field '%s': %v", ident.Name, err)
+	}
+
+	return &ast.FieldValue{
+		Span:  spanBetween(ident, value),
+		Token: ident.Token,
+		Name:  ident.Name,
+		Value: value,
+	}, nil
+}
+
+// extractRecordFields extracts the fields of a record type declaration.
+// RecordFields: NEWLINE RecordFields | IDENTIFIER COLON TypeExpr RecordFieldRest | ε
+// RecordFieldRest: COMMA RecordFields | NEWLINE RecordFields | ε
+func extractRecordFields(node parsetree.ParseTree) ([]*ast.FieldDecl, error) {
+	fields := []*ast.FieldDecl{}
+	seen := map[string]bool{}
+	for {
+		children, err := optionalChildren(node, "RecordFields")
+		if err != nil {
+			return nil, err
+		}
+
+		switch len(children) {
+		case 0:
+			return fields, nil
+
+		case 2:
+			// Skip blank lines between fields
+			node = children[1]
+
+		case 4:
+			nameNode, ok := children[0].(*parsetree.TerminalNode)
+			if !ok {
+				return nil, fmt.Errorf("expected terminal for field name, got %T", children[0])
+			}
+			name := nameNode.Token.Value
+			if seen[name] {
+				return nil, fmt.Errorf("duplicate record field '%s'", name)
+			}
+			seen[name] = true
+
+			fieldType, err := convertTypeExpression(children[2])
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, &ast.FieldDecl{
+				Span:  spanOf(children[0], children[1], children[2]),
+				Token: name,
+				Name:  name,
+				Type:  fieldType,
+			})
+
+			rest, err := optionalChildren(children[3], "RecordFieldRest")
+			if err != nil {
+				return nil, err
+			}
+			if len(rest) == 0 {
+				return fields, nil
+			}
+			if len(rest) != 2 {
+				return nil, fmt.Errorf("RecordFieldRest node expected 0 or 2 children, got %d", len(rest))
+			}
+			node = rest[1]
+
+		default:
+			return nil, fmt.Errorf("RecordFields node expected 0, 2 or 4 children, got %d", len(children))
+		}
+	}
+}
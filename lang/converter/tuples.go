@@ -0,0 +1,127 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+	"github.com/shadowCow/cow-lang-go/tooling/parsetree"
+)
+
+// This file converts tuple expressions, the unit value and tuple destructuring.
+
+// convertParenContent converts the contents of parentheses in an expression.
+// ParenContent: Expression TupleRest | ε
+// () is the unit value (an empty tuple), (e) is just e, and (a, b) or (a,) is a tuple.
+func convertParenContent(span ast.Span, node parsetree.ParseTree) (ast.Expression, error) {
+	children, err := optionalChildren(node, "ParenContent")
+	if err != nil {
+		return nil, err
+	}
+	if len(children) == 0 {
+		return &ast.TupleLiteral{
+			Span:     span,
+			Token:    "(",
+			Elements: []ast.Expression{},
+		}, nil
+	}
+	if len(children) != 2 {
+		return nil, fmt.Errorf("ParenContent node expected 0 or 2 children, got %d", len(children))
+	}
+
+	first, err := convertToExpression(children[0])
+	if err != nil {
+		return nil, err
+	}
+
+	rest, err := optionalChildren(children[1], "TupleRest")
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) == 0 {
+		// Grouping: (e)
+		return first, nil
+	}
+
+	elements := []ast.Expression{first}
+	for len(rest) != 0 {
+		// TupleRest: COMMA TupleElements
+		if len(rest) != 2 {
+			return nil, fmt.Errorf("TupleRest node expected 0 or 2 children, got %d", len(rest))
+		}
+
+		// TupleElements: Expression TupleRest | ε (trailing comma)
+		tupleElements, err := optionalChildren(rest[1], "TupleElements")
+		if err != nil {
+			return nil, err
+		}
+		if len(tupleElements) == 0 {
+			break
+		}
+		if len(tupleElements) != 2 {
+			return nil, fmt.Errorf("TupleElements node expected 0 or 2 children, got %d", len(tupleElements))
+		}
+
+		element, err := convertToExpression(tupleElements[0])
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+
+		rest, err = optionalChildren(tupleElements[1], "TupleRest")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &ast.TupleLiteral{
+		Span:     span,
+		Token:    "(",
+		Elements: elements,
+	}, nil
+}
+
+// convertLetTarget converts the target of a let statement to either a name or
+// a destructuring pattern.
+// LetTarget: IDENTIFIER | LPAREN PatternList RPAREN
+func convertLetTarget(node parsetree.ParseTree) (string, ast.Pattern, error) {
+	nonTerminal, ok := node.(*parsetree.NonTerminalNode)
+	if !ok || nonTerminal.Symbol != "LetTarget" {
+		return "", nil, fmt.Errorf("expected LetTarget node, got %T", node)
+	}
+
+	switch len(nonTerminal.Children) {
+	case 1:
+		identifierNode, ok := nonTerminal.Children[0].(*parsetree.TerminalNode)
+		if !ok {
+			return "", nil, fmt.Errorf("expected terminal for identifier, got %T", nonTerminal.Children[0])
+		}
+		return identifierNode.Token.Value, nil, nil
+
+	case 3:
+		pattern, err := convertParenPattern(spanOf(nonTerminal), nonTerminal.Children[1])
+		if err != nil {
+			return "", nil, err
+		}
+		return "", pattern, nil
+
+	default:
+		return "", nil, fmt.Errorf("LetTarget node expected 1 or 3 children, got %d", len(nonTerminal.Children))
+	}
+}
+
+// convertParenPattern converts the contents of parentheses in a pattern.
+// A single parenthesized pattern is just that pattern; anything else is a tuple pattern.
+func convertParenPattern(span ast.Span, patternList parsetree.ParseTree) (ast.Pattern, error) {
+	elements, err := extractPatternList(patternList)
+	if err != nil {
+		return nil, err
+	}
+	if len(elements) == 1 {
+		return elements[0], nil
+	}
+	return &ast.TuplePattern{
+		Span:     span,
+		Token:    "(",
+		Elements: elements,
+	}, nil
+}
@@ -0,0 +1,411 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+	"github.com/shadowCow/cow-lang-go/tooling/parsetree"
+)
+
+// This file converts type declarations and type expressions.
+
+// optionalChildren returns the children of a node that may be an epsilon production.
+// Returns nil for an EmptyNode or a non-terminal with no children.
+func optionalChildren(node parsetree.ParseTree, symbol string) ([]parsetree.ParseTree, error) {
+	switch n := node.(type) {
+	case *parsetree.EmptyNode:
+		return nil, nil
+	case *parsetree.NonTerminalNode:
+		if string(n.Symbol) != symbol {
+			return nil, fmt.Errorf("expected %s node, got %s", symbol, n.Symbol)
+		}
+		return n.Children, nil
+	default:
+		return nil, fmt.Errorf("expected non-terminal for %s, got %T", symbol, node)
+	}
+}
+
+// convertTypeDeclaration converts a TypeDecl node to a TypeDeclaration.
+// TypeDecl: TYPE IDENTIFIER TypeParams EQUALS TypeBody
+func convertTypeDeclaration(node *parsetree.NonTerminalNode) (*ast.TypeDeclaration, error) {
+	if len(node.Children) != 5 {
+		return nil, fmt.Errorf("TypeDecl node expected 5 children, got %d", len(node.Children))
+	}
+
+	// Extract type token (child 0)
+	typeNode, ok := node.Children[0].(*parsetree.TerminalNode)
+	if !ok {
+		return nil, fmt.Errorf("expected terminal for type keyword, got %T", node.Children[0])
+	}
+
+	// Extract type name (child 1)
+	nameNode, ok := node.Children[1].(*parsetree.TerminalNode)
+	if !ok {
+		return nil, fmt.Errorf("expected terminal for type name, got %T", node.Children[1])
+	}
+
+	// Extract type parameters (child 2)
+	typeParams, err := extractTypeParams(node.Children[2])
+	if err != nil {
+		return nil, err
+	}
+
+	decl := &ast.TypeDeclaration{
+		Span:       spanOf(node),
+		Token:      typeNode.Token.Value,
+		Name:       nameNode.Token.Value,
+		TypeParams: typeParams,
+	}
+
+	// Extract record fields or variants (child 4)
+	// TypeBody: LBRACE RecordFields RBRACE declares a record type
+	if body, ok := node.Children[4].(*parsetree.NonTerminalNode); ok && len(body.Children) == 3 {
+		decl.Fields, err = extractRecordFields(body.Children[1])
+		if err != nil {
+			return nil, err
+		}
+		return decl, nil
+	}
+
+	decl.Variants, err = extractTypeBody(node.Children[4])
+	if err != nil {
+		return nil, err
+	}
+
+	return decl, nil
+}
+
+// extractTypeParams extracts type parameter names.
+// TypeParams: LESS_THAN IDENTIFIER TypeParamRest GREATER_THAN | ε
+func extractTypeParams(node parsetree.ParseTree) ([]string, error) {
+	children, err := optionalChildren(node, "TypeParams")
+	if err != nil {
+		return nil, err
+	}
+	if len(children) == 0 {
+		return []string{}, nil
+	}
+	if len(children) != 4 {
+		return nil, fmt.Errorf("TypeParams node expected 0 or 4 children, got %d", len(children))
+	}
+
+	first, ok := children[1].(*parsetree.TerminalNode)
+	if !ok {
+		return nil, fmt.Errorf("expected terminal for type parameter, got %T", children[1])
+	}
+
+	params := []string{first.Token.Value}
+	rest := children[2]
+	for {
+		restChildren, err := optionalChildren(rest, "TypeParamRest")
+		if err != nil {
+			return nil, err
+		}
+		if len(restChildren) == 0 {
+			return params, nil
+		}
+		// TypeParamRest: COMMA IDENTIFIER TypeParamRest
+		if len(restChildren) != 3 {
+			return nil, fmt.Errorf("TypeParamRest node expected 0 or 3 children, got %d", len(restChildren))
+		}
+		param, ok := restChildren[1].(*parsetree.TerminalNode)
+		if !ok {
+			return nil, fmt.Errorf("expected terminal for type parameter, got %T", restChildren[1])
+		}
+		params = append(params, param.Token.Value)
+		rest = restChildren[2]
+	}
+}
+
+// extractTypeBody extracts the variants of a type declaration.
+// TypeBody: PIPE VariantList | VariantList
+// The record alternative (LBRACE RecordFields RBRACE) is handled by convertTypeDeclaration.
+func extractTypeBody(node parsetree.ParseTree) ([]*ast.VariantDecl, error) {
+	nonTerminal, ok := node.(*parsetree.NonTerminalNode)
+	if !ok || nonTerminal.Symbol != "TypeBody" {
+		return nil, fmt.Errorf("expected TypeBody node, got %T", node)
+	}
+
+	switch len(nonTerminal.Children) {
+	case 1:
+		return extractVariantList(nonTerminal.Children[0])
+	case 2:
+		// Skip the optional leading pipe
+		return extractVariantList(nonTerminal.Children[1])
+	default:
+		return nil, fmt.Errorf("TypeBody node expected 1 or 2 children, got %d", len(nonTerminal.Children))
+	}
+}
+
+// extractVariantList extracts variants from a VariantList node.
+// VariantList: Variant VariantRest
+// VariantRest: PIPE Variant VariantRest | ε
+func extractVariantList(node parsetree.ParseTree) ([]*ast.VariantDecl, error) {
+	nonTerminal, ok := node.(*parsetree.NonTerminalNode)
+	if !ok || nonTerminal.Symbol != "VariantList" {
+		return nil, fmt.Errorf("expected VariantList node, got %T", node)
+	}
+	if len(nonTerminal.Children) != 2 {
+		return nil, fmt.Errorf("VariantList node expected 2 children, got %d", len(nonTerminal.Children))
+	}
+
+	first, err := convertVariant(nonTerminal.Children[0])
+	if err != nil {
+		return nil, err
+	}
+
+	variants := []*ast.VariantDecl{first}
+	rest := nonTerminal.Children[1]
+	for {
+		restChildren, err := optionalChildren(rest, "VariantRest")
+		if err != nil {
+			return nil, err
+		}
+		if len(restChildren) == 0 {
+			return variants, nil
+		}
+		if len(restChildren) != 3 {
+			return nil, fmt.Errorf("VariantRest node expected 0 or 3 children, got %d", len(restChildren))
+		}
+		variant, err := convertVariant(restChildren[1])
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+		rest = restChildren[2]
+	}
+}
+
+// convertVariant converts a Variant node to a VariantDecl.
+// Variant: IDENTIFIER VariantPayload
+// VariantPayload: OF TypeExpr | ε
+// A tuple payload, as in Node of (Tree<T>, T, Tree<T>), declares one field per element.
+func convertVariant(node parsetree.ParseTree) (*ast.VariantDecl, error) {
+	nonTerminal, ok := node.(*parsetree.NonTerminalNode)
+	if !ok || nonTerminal.Symbol != "Variant" {
+		return nil, fmt.Errorf("expected Variant node, got %T", node)
+	}
+	if len(nonTerminal.Children) != 2 {
+		return nil, fmt.Errorf("Variant node expected 2 children, got %d", len(nonTerminal.Children))
+	}
+
+	nameNode, ok := nonTerminal.Children[0].(*parsetree.TerminalNode)
+	if !ok {
+		return nil, fmt.Errorf("expected terminal for variant name, got %T", nonTerminal.Children[0])
+	}
+
+	variant := &ast.VariantDecl{
+		Span:   spanOf(nonTerminal),
+		Token:  nameNode.Token.Value,
+		Name:   nameNode.Token.Value,
+		Fields: []ast.TypeExpression{},
+	}
+
+	payload, err := optionalChildren(nonTerminal.Children[1], "VariantPayload")
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) == 0 {
+		return variant, nil
+	}
+	if len(payload) != 2 {
+		return nil, fmt.Errorf("VariantPayload node expected 0 or 2 children, got %d", len(payload))
+	}
+
+	fieldType, err := convertTypeExpression(payload[1])
+	if err != nil {
+		return nil, err
+	}
+	if tuple, ok := fieldType.(*ast.TupleType); ok {
+		variant.Fields = tuple.Elements
+	} else {
+		variant.Fields = []ast.TypeExpression{fieldType}
+	}
+
+	return variant, nil
+}
+
+// convertTypeAnnotation converts an optional type annotation.
+// TypeAnnotation: COLON TypeExpr | ε
+// Returns nil if there is no annotation.
+func convertTypeAnnotation(node parsetree.ParseTree) (ast.TypeExpression, error) {
+	children, err := optionalChildren(node, "TypeAnnotation")
+	if err != nil {
+		return nil, err
+	}
+	if len(children) == 0 {
+		return nil, nil
+	}
+	if len(children) != 2 {
+		return nil, fmt.Errorf("TypeAnnotation node expected 0 or 2 children, got %d", len(children))
+	}
+	return convertTypeExpression(children[1])
+}
+
+// convertReturnType converts an optional function return type.
+// ReturnType: ARROW TypeExpr | ε
+// Returns nil if there is no return type.
+func convertReturnType(node parsetree.ParseTree) (ast.TypeExpression, error) {
+	children, err := optionalChildren(node, "ReturnType")
+	if err != nil {
+		return nil, err
+	}
+	if len(children) == 0 {
+		return nil, nil
+	}
+	if len(children) != 2 {
+		return nil, fmt.Errorf("ReturnType node expected 0 or 2 children, got %d", len(children))
+	}
+	return convertTypeExpression(children[1])
+}
+
+// convertTypeExpression converts a TypeExpr node to a TypeExpression.
+// TypeExpr: IDENTIFIER TypeArgs | LPAREN TypeExprList RPAREN | LBRACKET TypeExpr RBRACKET |
+// FN LPAREN TypeExprList RPAREN ARROW TypeExpr
+// A parenthesized single type is just that type, and () is the unit type.
+func convertTypeExpression(node parsetree.ParseTree) (ast.TypeExpression, error) {
+	nonTerminal, ok := node.(*parsetree.NonTerminalNode)
+	if !ok || nonTerminal.Symbol != "TypeExpr" {
+		return nil, fmt.Errorf("expected TypeExpr node, got %T", node)
+	}
+	if len(nonTerminal.Children) == 0 {
+		return nil, fmt.Errorf("TypeExpr node has no children")
+	}
+
+	first, ok := nonTerminal.Children[0].(*parsetree.TerminalNode)
+	if !ok {
+		return nil, fmt.Errorf("expected terminal as first child of TypeExpr, got %T", nonTerminal.Children[0])
+	}
+
+	switch first.Token.Type {
+	case "IDENTIFIER":
+		// IDENTIFIER TypeArgs
+		if len(nonTerminal.Children) != 2 {
+			return nil, fmt.Errorf("TypeExpr IDENTIFIER variant expected 2 children, got %d", len(nonTerminal.Children))
+		}
+
+		args := []ast.TypeExpression{}
+		argChildren, err := optionalChildren(nonTerminal.Children[1], "TypeArgs")
+		if err != nil {
+			return nil, err
+		}
+		if len(argChildren) != 0 {
+			// TypeArgs: LESS_THAN TypeExpr TypeExprRest GREATER_THAN
+			if len(argChildren) != 4 {
+				return nil, fmt.Errorf("TypeArgs node expected 0 or 4 children, got %d", len(argChildren))
+			}
+			args, err = extractTypeExprSequence(argChildren[1], argChildren[2])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return &ast.NamedType{
+			Span:  spanOf(nonTerminal),
+			Token: first.Token.Value,
+			Name:  first.Token.Value,
+			Args:  args,
+		}, nil
+
+	case "LPAREN":
+		// LPAREN TypeExprList RPAREN
+		if len(nonTerminal.Children) != 3 {
+			return nil, fmt.Errorf("TypeExpr LPAREN variant expected 3 children, got %d", len(nonTerminal.Children))
+		}
+		elements, err := extractTypeExprList(nonTerminal.Children[1])
+		if err != nil {
+			return nil, err
+		}
+		if len(elements) == 1 {
+			return elements[0], nil
+		}
+		return &ast.TupleType{
+			Span:     spanOf(nonTerminal),
+			Token:    first.Token.Value,
+			Elements: elements,
+		}, nil
+
+	case "LBRACKET":
+		// LBRACKET TypeExpr RBRACKET
+		if len(nonTerminal.Children) != 3 {
+			return nil, fmt.Errorf("TypeExpr LBRACKET variant expected 3 children, got %d", len(nonTerminal.Children))
+		}
+		element, err := convertTypeExpression(nonTerminal.Children[1])
+		if err != nil {
+			return nil, err
+		}
+		return &ast.ArrayType{
+			Span:    spanOf(nonTerminal),
+			Token:   first.Token.Value,
+			Element: element,
+		}, nil
+
+	case "FN":
+		// FN LPAREN TypeExprList RPAREN ARROW TypeExpr
+		if len(nonTerminal.Children) != 6 {
+			return nil, fmt.Errorf("TypeExpr FN variant expected 6 children, got %d", len(nonTerminal.Children))
+		}
+		params, err := extractTypeExprList(nonTerminal.Children[2])
+		if err != nil {
+			return nil, err
+		}
+		result, err := convertTypeExpression(nonTerminal.Children[5])
+		if err != nil {
+			return nil, err
+		}
+		return &ast.FunctionType{
+			Span:   spanOf(nonTerminal),
+			Token:  first.Token.Value,
+			Params: params,
+			Return: result,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected terminal in TypeExpr: %s", first.Token.Type)
+	}
+}
+
+// extractTypeExprList extracts the types of a TypeExprList node.
+// TypeExprList: TypeExpr TypeExprRest | ε
+func extractTypeExprList(node parsetree.ParseTree) ([]ast.TypeExpression, error) {
+	children, err := optionalChildren(node, "TypeExprList")
+	if err != nil {
+		return nil, err
+	}
+	if len(children) == 0 {
+		return []ast.TypeExpression{}, nil
+	}
+	if len(children) != 2 {
+		return nil, fmt.Errorf("TypeExprList node expected 0 or 2 children, got %d", len(children))
+	}
+	return extractTypeExprSequence(children[0], children[1])
+}
+
+// extractTypeExprSequence extracts a comma-separated list of type expressions.
+// The list is a TypeExpr followed by TypeExprRest: COMMA TypeExpr TypeExprRest | ε
+func extractTypeExprSequence(first parsetree.ParseTree, rest parsetree.ParseTree) ([]ast.TypeExpression, error) {
+	firstType, err := convertTypeExpression(first)
+	if err != nil {
+		return nil, err
+	}
+
+	types := []ast.TypeExpression{firstType}
+	for {
+		restChildren, err := optionalChildren(rest, "TypeExprRest")
+		if err != nil {
+			return nil, err
+		}
+		if len(restChildren) == 0 {
+			return types, nil
+		}
+		if len(restChildren) != 3 {
+			return nil, fmt.Errorf("TypeExprRest node expected 0 or 3 children, got %d", len(restChildren))
+		}
+		next, err := convertTypeExpression(restChildren[1])
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, next)
+		rest = restChildren[2]
+	}
+}
@@ -0,0 +1,204 @@
+package eval
+
+// Array is a runtime array value.
+//
+// Arrays are values: an Array is never changed once built, and Set, Push and
+// Pop return a new Array. Copying an array is just copying the pointer, and
+// the new array shares all but O(log n) of its storage with the old one.
+//
+// The elements are stored in a persistent vector: a tree of nodes with
+// arrayWidth children each, whose leaves hold the elements, plus a tail
+// holding the last (up to arrayWidth) elements so pushes and pops at the end
+// rarely touch the tree.
+type Array struct {
+	count int           // Number of elements
+	shift uint          // Bit offset of the root's child index: arrayBits times the tree height
+	root  *arrayNode    // Tree holding the elements before the tail
+	tail  []interface{} // The last elements, never shared with a writer
+}
+
+const (
+	arrayBits  = 5
+	arrayWidth = 1 << arrayBits
+	arrayMask  = arrayWidth - 1
+)
+
+// arrayNode is a node of an Array's tree. Children of leaves are elements;
+// children of inner nodes are *arrayNode or nil.
+type arrayNode struct {
+	children [arrayWidth]interface{}
+}
+
+// emptyArray is the array with no elements.
+var emptyArray = &Array{shift: arrayBits, root: &arrayNode{}}
+
+// NewArray creates an array holding the given elements.
+func NewArray(elements []interface{}) *Array {
+	arr := emptyArray
+	for _, element := range elements {
+		arr = arr.Push(element)
+	}
+	return arr
+}
+
+// Len returns the number of elements.
+func (a *Array) Len() int {
+	return a.count
+}
+
+// Get returns the element at index i, which must be in range.
+func (a *Array) Get(i int) interface{} {
+	if i >= a.tailOffset() {
+		return a.tail[i-a.tailOffset()]
+	}
+	return a.leafFor(i).children[i&arrayMask]
+}
+
+// Values returns the elements as a new slice.
+func (a *Array) Values() []interface{} {
+	values := make([]interface{}, 0, a.count)
+	for i := 0; i < a.tailOffset(); i += arrayWidth {
+		values = append(values, a.leafFor(i).children[:]...)
+	}
+	return append(values, a.tail...)
+}
+
+// Set returns a copy of the array with the element at index i, which must
+// be in range, replaced by value.
+func (a *Array) Set(i int, value interface{}) *Array {
+	if i >= a.tailOffset() {
+		tail := append([]interface{}{}, a.tail...)
+		tail[i-a.tailOffset()] = value
+		return &Array{count: a.count, shift: a.shift, root: a.root, tail: tail}
+	}
+	return &Array{count: a.count, shift: a.shift, root: setInNode(a.shift, a.root, i, value), tail: a.tail}
+}
+
+// Push returns a copy of the array with value appended.
+func (a *Array) Push(value interface{}) *Array {
+	if len(a.tail) < arrayWidth {
+		tail := make([]interface{}, len(a.tail), len(a.tail)+1)
+		copy(tail, a.tail)
+		return &Array{count: a.count + 1, shift: a.shift, root: a.root, tail: append(tail, value)}
+	}
+
+	// The tail is full: move it into the tree and start a new one
+	leaf := &arrayNode{}
+	copy(leaf.children[:], a.tail)
+	shift := a.shift
+	var root *arrayNode
+	if a.count>>arrayBits > 1<<a.shift {
+		// The tree is full: add a level
+		root = &arrayNode{}
+		root.children[0] = a.root
+		root.children[1] = newPath(a.shift, leaf)
+		shift += arrayBits
+	} else {
+		root = a.pushLeaf(a.shift, a.root, leaf)
+	}
+	return &Array{count: a.count + 1, shift: shift, root: root, tail: []interface{}{value}}
+}
+
+// Pop returns a copy of the array without its last element, and that
+// element. The array must not be empty.
+func (a *Array) Pop() (*Array, interface{}) {
+	last := a.Get(a.count - 1)
+	if a.count == 1 {
+		return emptyArray, last
+	}
+	if len(a.tail) > 1 {
+		return &Array{count: a.count - 1, shift: a.shift, root: a.root, tail: a.tail[:len(a.tail)-1]}, last
+	}
+
+	// The tail becomes empty: make the last leaf of the tree the new tail
+	tail := a.leafFor(a.count - 2).children[:]
+	root := a.popLeaf(a.shift, a.root)
+	shift := a.shift
+	if root == nil {
+		root = &arrayNode{}
+	}
+	if shift > arrayBits && root.children[1] == nil {
+		// Only one subtree is left: remove a level
+		root = root.children[0].(*arrayNode)
+		shift -= arrayBits
+	}
+	return &Array{count: a.count - 1, shift: shift, root: root, tail: tail}, last
+}
+
+// tailOffset returns the index of the first element in the tail.
+func (a *Array) tailOffset() int {
+	if a.count < arrayWidth {
+		return 0
+	}
+	return ((a.count - 1) >> arrayBits) << arrayBits
+}
+
+// leafFor returns the leaf of the tree holding the element at index i.
+func (a *Array) leafFor(i int) *arrayNode {
+	node := a.root
+	for level := a.shift; level > 0; level -= arrayBits {
+		node = node.children[(i>>level)&arrayMask].(*arrayNode)
+	}
+	return node
+}
+
+// pushLeaf returns a copy of node with leaf added after its last leaf.
+func (a *Array) pushLeaf(level uint, node *arrayNode, leaf *arrayNode) *arrayNode {
+	copied := *node
+	index := ((a.count - 1) >> level) & arrayMask
+	if level == arrayBits {
+		copied.children[index] = leaf
+	} else if child, ok := node.children[index].(*arrayNode); ok {
+		copied.children[index] = a.pushLeaf(level-arrayBits, child, leaf)
+	} else {
+		copied.children[index] = newPath(level-arrayBits, leaf)
+	}
+	return &copied
+}
+
+// popLeaf returns a copy of node without its last leaf, or nil if that
+// leaves it empty.
+func (a *Array) popLeaf(level uint, node *arrayNode) *arrayNode {
+	index := ((a.count - 2) >> level) & arrayMask
+	if level > arrayBits {
+		child := a.popLeaf(level-arrayBits, node.children[index].(*arrayNode))
+		if child == nil && index == 0 {
+			return nil
+		}
+		copied := *node
+		if child == nil {
+			copied.children[index] = nil
+		} else {
+			copied.children[index] = child
+		}
+		return &copied
+	}
+	if index == 0 {
+		return nil
+	}
+	copied := *node
+	copied.children[index] = nil
+	return &copied
+}
+
+// newPath wraps leaf in inner nodes down from the given level.
+func newPath(level uint, leaf *arrayNode) *arrayNode {
+	if level == 0 {
+		return leaf
+	}
+	node := &arrayNode{}
+	node.children[0] = newPath(level-arrayBits, leaf)
+	return node
+}
+
+// setInNode returns a copy of node with the element at index i replaced.
+func setInNode(level uint, node *arrayNode, i int, value interface{}) *arrayNode {
+	copied := *node
+	if level == 0 {
+		copied.children[i&arrayMask] = value
+	} else {
+		index := (i >> level) & arrayMask
+		copied.children[index] = setInNode(level-arrayBits, node.children[index].(*arrayNode), i, value)
+	}
+	return &copied
+}
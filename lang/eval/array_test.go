@@ -0,0 +1,86 @@
+package eval
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// array creates an array of the given elements.
+func array(elements ...interface{}) *Array {
+	return NewArray(elements)
+}
+
+// TestArrayOperations checks arrays against slices through random pushes,
+// pops and sets, keeping every version to check that none of them change.
+func TestArrayOperations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	type version struct {
+		arr      *Array
+		expected []interface{}
+	}
+	versions := []version{{arr: emptyArray}}
+
+	for step := 0; step < 5000; step++ {
+		// Usually build on the latest version, sometimes on an older one
+		base := versions[len(versions)-1]
+		if rng.Intn(10) == 0 {
+			base = versions[rng.Intn(len(versions))]
+		}
+
+		next := version{expected: append([]interface{}{}, base.expected...)}
+		switch op := rng.Intn(10); {
+		case op < 6 || len(base.expected) == 0:
+			next.arr = base.arr.Push(int64(step))
+			next.expected = append(next.expected, int64(step))
+		case op < 8:
+			var last interface{}
+			next.arr, last = base.arr.Pop()
+			if last != base.expected[len(base.expected)-1] {
+				t.Fatalf("step %d: popped %v, expected %v", step, last, base.expected[len(base.expected)-1])
+			}
+			next.expected = next.expected[:len(next.expected)-1]
+		default:
+			i := rng.Intn(len(base.expected))
+			next.arr = base.arr.Set(i, -int64(step))
+			next.expected[i] = -int64(step)
+		}
+		versions = append(versions, next)
+	}
+
+	for n, v := range versions {
+		if v.arr.Len() != len(v.expected) {
+			t.Fatalf("version %d: length %d, expected %d", n, v.arr.Len(), len(v.expected))
+		}
+		values := v.arr.Values()
+		for i, expected := range v.expected {
+			if v.arr.Get(i) != expected || values[i] != expected {
+				t.Fatalf("version %d: element %d is %v, expected %v", n, i, v.arr.Get(i), expected)
+			}
+		}
+	}
+}
+
+// TestArrayGrowAndShrink pushes and pops enough elements to add and remove tree levels.
+func TestArrayGrowAndShrink(t *testing.T) {
+	const n = arrayWidth*arrayWidth*arrayWidth + arrayWidth + 1
+	arr := emptyArray
+	for i := 0; i < n; i++ {
+		arr = arr.Push(i)
+	}
+	if arr.shift != 3*arrayBits {
+		t.Errorf("expected a tree of height 3, got shift %d", arr.shift)
+	}
+	for i := n - 1; i >= 0; i-- {
+		var last interface{}
+		arr, last = arr.Pop()
+		if last != i {
+			t.Fatalf("popped %v, expected %d", last, i)
+		}
+		if arr.Len() > 0 && arr.Get(arr.Len()-1) != i-1 {
+			t.Fatalf("after popping %d, last element is %v", i, arr.Get(arr.Len()-1))
+		}
+	}
+	if arr.Len() != 0 || arr.shift != arrayBits {
+		t.Errorf("expected an empty array, got length %d and shift %d", arr.Len(), arr.shift)
+	}
+}
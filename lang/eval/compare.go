@@ -0,0 +1,195 @@
+package eval
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// This file defines equality and ordering of runtime values.
+//
+// Values of the same type are totally ordered:
+//   - Numbers compare by their mathematical value, so an i64 and an f64 are
+//     compared exactly, without rounding the integer to a float: 1 == 1.0,
+//     but 9007199254740993 > 9007199254740992.0. NaN equals itself and sorts
+//     before every other number; -0.0 equals 0.0.
+//   - Strings compare lexicographically by bytes; false sorts before true.
+//   - Arrays and tuples compare lexicographically, element by element; a
+//     prefix sorts before the longer array.
+//   - Variants of a type compare by the declaration order of their
+//     constructors, then by their fields.
+//   - Records of a type compare field by field, in declaration order.
+//
+// Functions cannot be ordered. Equality of functions is identity.
+
+// Equal reports whether two values are structurally equal.
+// Values that cannot be ordered are equal only if they are the same value.
+func Equal(left, right interface{}) bool {
+	order, err := Compare(left, right)
+	if err != nil {
+		return isSameFunction(left, right)
+	}
+	return order == 0
+}
+
+// isSameFunction reports whether two values are the same function.
+func isSameFunction(left, right interface{}) bool {
+	if l, ok := left.(*Constructor); ok {
+		r, ok := right.(*Constructor)
+		return ok && l.TypeName == r.TypeName && l.Name == r.Name
+	}
+	// Other functions, including closures of the bytecode VM, are pointers
+	return left == right
+}
+
+// Compare orders two values, returning -1, 0 or +1 as left is less
+// than, equal to or greater than right.
+// Returns an error if the values have different types or cannot be ordered.
+func Compare(left, right interface{}) (int, error) {
+	switch l := left.(type) {
+	case int64:
+		switch r := right.(type) {
+		case int64:
+			return cmp.Compare(l, r), nil
+		case float64:
+			return compareIntFloat(l, r), nil
+		}
+
+	case float64:
+		switch r := right.(type) {
+		case float64:
+			return cmp.Compare(l, r), nil
+		case int64:
+			return -compareIntFloat(r, l), nil
+		}
+
+	case string:
+		if r, ok := right.(string); ok {
+			return cmp.Compare(l, r), nil
+		}
+
+	case bool:
+		if r, ok := right.(bool); ok {
+			return compareBools(l, r), nil
+		}
+
+	case Unit:
+		if _, ok := right.(Unit); ok {
+			return 0, nil
+		}
+
+	case *Array:
+		if r, ok := right.(*Array); ok {
+			return compareSequences(l.Values(), r.Values())
+		}
+
+	case *Tuple:
+		if r, ok := right.(*Tuple); ok {
+			return compareSequences(l.Elements, r.Elements)
+		}
+
+	case *Variant:
+		if r, ok := right.(*Variant); ok && l.TypeName == r.TypeName {
+			if l.Tag != r.Tag {
+				return cmp.Compare(l.Tag, r.Tag), nil
+			}
+			return compareSequences(l.Fields, r.Fields)
+		}
+
+	case *Record:
+		if r, ok := right.(*Record); ok && l.TypeName == r.TypeName {
+			return compareSequences(l.Values, r.Values)
+		}
+	}
+
+	return 0, fmt.Errorf("cannot compare %s with %s", describeValue(left), describeValue(right))
+}
+
+// compareSequences orders two sequences of values lexicographically.
+func compareSequences(left, right []interface{}) (int, error) {
+	for i := 0; i < len(left) && i < len(right); i++ {
+		order, err := Compare(left[i], right[i])
+		if err != nil || order != 0 {
+			return order, err
+		}
+	}
+	return cmp.Compare(len(left), len(right)), nil
+}
+
+// compareIntFloat orders an integer and a float by their exact values.
+func compareIntFloat(i int64, f float64) int {
+	switch {
+	case math.IsNaN(f):
+		return 1
+	case f >= math.MaxInt64: // 2^63, the first float above every int64
+		return -1
+	case f < math.MinInt64:
+		return 1
+	}
+
+	// f is within int64 range, so its integer part converts exactly
+	whole := math.Trunc(f)
+	if order := cmp.Compare(i, int64(whole)); order != 0 {
+		return order
+	}
+	return cmp.Compare(0, f-whole)
+}
+
+// compareBools orders false before true.
+func compareBools(left, right bool) int {
+	switch {
+	case left == right:
+		return 0
+	case right:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// describeValue names the type of a runtime value, for error messages.
+func describeValue(value interface{}) string {
+	switch v := value.(type) {
+	case int64:
+		return "i64"
+	case float64:
+		return "f64"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case Unit:
+		return "()"
+	case *Array:
+		return "array"
+	case *Tuple:
+		return "tuple"
+	case *Variant:
+		return v.TypeName
+	case *Record:
+		return v.TypeName
+	case *Function, *Constructor, *Native, *ArrayMethod:
+		return "function"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// Sorted returns a copy of the array in ascending order.
+// The sort is stable, so equal elements keep their relative order.
+func (a *Array) Sorted() (*Array, error) {
+	sorted := a.Values()
+	var sortErr error
+	sort.SliceStable(sorted, func(i, j int) bool {
+		order, err := Compare(sorted[i], sorted[j])
+		if err != nil && sortErr == nil {
+			sortErr = err
+		}
+		return order < 0
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+	return NewArray(sorted), nil
+}
@@ -0,0 +1,128 @@
+package eval
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestCompareValues tests the ordering of runtime values.
+func TestCompareValues(t *testing.T) {
+	some := func(value interface{}) *Variant {
+		return &Variant{TypeName: "Option", Constructor: "Some", Tag: 0, Fields: []interface{}{value}}
+	}
+	none := &Variant{TypeName: "Option", Constructor: "None", Tag: 1, Fields: []interface{}{}}
+	point := func(x, y float64) *Record {
+		return &Record{TypeName: "Point", Fields: []string{"x", "y"}, Values: []interface{}{x, y}}
+	}
+
+	tests := []struct {
+		name        string
+		left, right interface{}
+		expected    int
+	}{
+		{"integers", int64(1), int64(2), -1},
+		{"integer and equal float", int64(1), 1.0, 0},
+		{"float and integer", 2.5, int64(2), 1},
+		{"negative float and integer", -2.5, int64(-2), -1},
+		{"integer beyond float precision", int64(1<<53 + 1), float64(1 << 53), 1},
+		{"integer and huge float", int64(math.MaxInt64), math.Pow(2, 63), -1},
+		{"NaN equals NaN", math.NaN(), math.NaN(), 0},
+		{"NaN sorts first", math.NaN(), math.Inf(-1), -1},
+		{"NaN before integer", math.NaN(), int64(0), -1},
+		{"negative zero", math.Copysign(0, -1), 0.0, 0},
+		{"strings", "apple", "banana", -1},
+		{"booleans", true, false, 1},
+		{"unit", Unit{}, Unit{}, 0},
+		{"equal arrays", array(int64(1), int64(2)), array(int64(1), int64(2)), 0},
+		{"arrays by element", array(int64(1), int64(3)), array(int64(2)), -1},
+		{"prefix array first", array(int64(1)), array(int64(1), int64(0)), -1},
+		{"nested arrays", array(array("b")), array(array("a", "z")), 1},
+		{"tuples", &Tuple{Elements: []interface{}{int64(1), "b"}}, &Tuple{Elements: []interface{}{int64(1), "a"}}, 1},
+		{"constructor order", some(int64(100)), none, -1},
+		{"variant fields", some(int64(1)), some(int64(2)), -1},
+		{"records by field", point(1, 5), point(2, 0), -1},
+		{"equal records", point(1, 2), point(1, 2), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := Compare(tt.left, tt.right)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, actual)
+			}
+			if reverse, _ := Compare(tt.right, tt.left); reverse != -tt.expected {
+				t.Errorf("expected %d with operands swapped, got %d", -tt.expected, reverse)
+			}
+		})
+	}
+}
+
+// TestCompareValuesErrors tests values that cannot be ordered.
+func TestCompareValuesErrors(t *testing.T) {
+	fn := &Function{}
+	tests := []struct {
+		name        string
+		left, right interface{}
+		expected    string
+	}{
+		{"different types", int64(1), "one", "cannot compare i64 with string"},
+		{"functions", fn, fn, "cannot compare function with function"},
+		{"functions in arrays", array(fn), array(fn), "cannot compare function with function"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compare(tt.left, tt.right)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+// TestValuesEqual tests structural equality, including values that cannot be ordered.
+func TestValuesEqual(t *testing.T) {
+	fn, other := &Function{}, &Function{}
+	tests := []struct {
+		name        string
+		left, right interface{}
+		expected    bool
+	}{
+		{"equal arrays", array(int64(1), array("a")), array(int64(1), array("a")), true},
+		{"different arrays", array(int64(1)), array(int64(2)), false},
+		{"integer and float", int64(3), 3.0, true},
+		{"different types", int64(1), "1", false},
+		{"same function", fn, fn, true},
+		{"different functions", fn, other, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := Equal(tt.left, tt.right); actual != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// TestSorted tests that sorting is stable and leaves its input unchanged.
+func TestSorted(t *testing.T) {
+	first := &Tuple{Elements: []interface{}{int64(1)}}
+	second := &Tuple{Elements: []interface{}{int64(1)}}
+	arr := NewArray([]interface{}{first, second, &Tuple{Elements: []interface{}{int64(0)}}})
+
+	sorted, err := arr.Sorted()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sorted.Get(1) != first || sorted.Get(2) != second {
+		t.Errorf("expected equal elements to keep their order, got %v", sorted.Values())
+	}
+	if arr.Get(0) != first {
+		t.Errorf("expected the input to be unchanged, got %v", arr.Values())
+	}
+}
@@ -0,0 +1,168 @@
+package eval
+
+import (
+	"fmt"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+	"github.com/shadowCow/cow-lang-go/lang/types"
+)
+
+// This file is the API for Go programs that embed the interpreter: they
+// register native functions for Cow programs to call, and look up and call
+// the functions a program defines.
+
+// NativeFunc is the Go implementation of a native function.
+// It is called with as many arguments as its signature has parameters.
+type NativeFunc func(args []Value) (Value, error)
+
+// Native is a function implemented in Go, registered with Evaluator.Register.
+type Native struct {
+	Name  string     // The name Cow programs call it by
+	Type  types.Type // The function type, for the type checker
+	Arity int        // Number of parameters
+	Fn    NativeFunc // The implementation
+}
+
+// Register binds a Go function to a global name, so Cow programs can call it
+// like a function they define.
+// The signature is the function's type, built with types.Func; the runner
+// declares it to the type checker, which checks every call against it.
+// Returns an error if the signature is not a function type.
+func (e *Evaluator) Register(name string, signature types.Type, fn NativeFunc) error {
+	con, ok := signature.(*types.TCon)
+	if !ok || con.Name != "fn" {
+		return fmt.Errorf("signature of native function %s must be a function type, got %v", name, signature)
+	}
+
+	native := &Native{Name: name, Type: signature, Arity: len(con.Args) - 1, Fn: fn}
+	e.natives = append(e.natives, native)
+	e.env.Define(name, native, false)
+	return nil
+}
+
+// Natives returns the registered native functions, in registration order.
+func (e *Evaluator) Natives() []*Native {
+	return append([]*Native(nil), e.natives...)
+}
+
+// Lookup returns the value of a global variable, such as a function defined
+// by an evaluated program. The second result is false if it is undefined.
+func (e *Evaluator) Lookup(name string) (Value, bool) {
+	value, ok := e.env.Get(name)
+	if !ok {
+		return Value{}, false
+	}
+	return wrap(value), true
+}
+
+// Call calls a Cow function, constructor or native function with the given
+// arguments. Like Eval, it returns an *Error for a runtime error in a
+// function with source positions.
+func (e *Evaluator) Call(fn Value, args ...Value) (Value, error) {
+	values := unwrapAll(args)
+
+	var result interface{}
+	var err error
+	switch f := fn.v.(type) {
+	case *Function:
+		if len(values) != len(f.Parameters) {
+			return Value{}, fmt.Errorf("function expects %d arguments, got %d", len(f.Parameters), len(values))
+		}
+		result, err = e.applyFunction(f, values)
+	case *Constructor:
+		if len(values) != f.Arity {
+			return Value{}, fmt.Errorf("constructor %s expects %d arguments, got %d", f.Name, f.Arity, len(values))
+		}
+		result = f.build(values)
+	case *Native:
+		if len(values) != f.Arity {
+			return Value{}, fmt.Errorf("%s expects %d arguments, got %d", f.Name, f.Arity, len(values))
+		}
+		result, err = f.apply(values)
+	default:
+		return Value{}, fmt.Errorf("cannot call %s: it is not a function", fn.Kind())
+	}
+	if err != nil {
+		return Value{}, sourceError(err)
+	}
+	return wrap(result), nil
+}
+
+// callNative calls a native function with the given argument expressions.
+func (e *Evaluator) callNative(native *Native, args []ast.Expression) (interface{}, error) {
+	if len(args) != native.Arity {
+		return nil, fmt.Errorf("%s expects %d arguments, got %d", native.Name, native.Arity, len(args))
+	}
+
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		val, err := e.evalExpression(arg)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating argument %d to %s: %w", i, native.Name, err)
+		}
+		values[i] = val
+	}
+
+	return native.apply(values)
+}
+
+// apply calls the Go function with evaluated arguments.
+func (native *Native) apply(values []interface{}) (interface{}, error) {
+	result, err := native.Fn(wrapAll(values))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", native.Name, err)
+	}
+	if err := native.checkResult(result); err != nil {
+		return nil, fmt.Errorf("%s: %w", native.Name, err)
+	}
+	return result.unwrap(), nil
+}
+
+// builtinKinds maps the built-in types to the kind of their values.
+var builtinKinds = map[string]Kind{
+	"i64":    IntKind,
+	"f64":    FloatKind,
+	"string": StringKind,
+	"bool":   BoolKind,
+	"array":  ArrayKind,
+	"fn":     FunctionKind,
+}
+
+// checkResult checks that a value returned by the Go function is of the
+// return type of the signature. Only the outermost type is checked; a type
+// parameter accepts any value.
+func (native *Native) checkResult(result Value) error {
+	signature := native.Type.(*types.TCon)
+	returnType, ok := signature.Args[len(signature.Args)-1].(*types.TCon)
+	if !ok {
+		return nil
+	}
+
+	got := "a value of kind " + result.Kind().String()
+	switch v := result.v.(type) {
+	case *Tuple:
+		if returnType.Name == "tuple" && len(v.Elements) == len(returnType.Args) {
+			return nil
+		}
+		got = fmt.Sprintf("a tuple of %d elements", len(v.Elements))
+	case *Record:
+		if v.TypeName == returnType.Name {
+			return nil
+		}
+		got = "a value of type " + v.TypeName
+	case *Variant:
+		if v.TypeName == returnType.Name {
+			return nil
+		}
+		got = "a value of type " + v.TypeName
+	case nil:
+		if returnType.Name == "tuple" && len(returnType.Args) == 0 {
+			return nil
+		}
+	default:
+		if kind, ok := builtinKinds[returnType.Name]; ok && kind == result.Kind() {
+			return nil
+		}
+	}
+	return fmt.Errorf("returned %s, but its signature returns %s", got, returnType)
+}
@@ -0,0 +1,241 @@
+package eval
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+	"github.com/shadowCow/cow-lang-go/lang/types"
+)
+
+// TestValueConversions tests converting Go values to Cow values and back.
+func TestValueConversions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		kind     Kind
+		exported interface{}
+		str      string
+	}{
+		{"int", 42, IntKind, int64(42), "42"},
+		{"uint8", uint8(7), IntKind, int64(7), "7"},
+		{"float32", float32(1.5), FloatKind, 1.5, "1.5"},
+		{"string", "hi", StringKind, "hi", `"hi"`},
+		{"bool", true, BoolKind, true, "true"},
+		{"nil is unit", nil, UnitKind, nil, "()"},
+		{"slice", []int{1, 2}, ArrayKind, []interface{}{int64(1), int64(2)}, "[1, 2]"},
+		{"nested slice", [][]string{{"a"}, {}}, ArrayKind, []interface{}{[]interface{}{"a"}, []interface{}{}}, `[["a"], []]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := ValueOf(tt.input)
+			if err != nil {
+				t.Fatalf("ValueOf failed: %v", err)
+			}
+			if value.Kind() != tt.kind {
+				t.Errorf("Expected kind %v, got %v", tt.kind, value.Kind())
+			}
+			if exported := value.Export(); !reflect.DeepEqual(exported, tt.exported) {
+				t.Errorf("Expected export %#v, got %#v", tt.exported, exported)
+			}
+			if value.String() != tt.str {
+				t.Errorf("Expected %s, got %s", tt.str, value.String())
+			}
+		})
+	}
+
+	if _, err := ValueOf(map[string]int{}); err == nil {
+		t.Error("Expected an error converting a map")
+	}
+	if _, err := ValueOf(uint64(1 << 63)); err == nil {
+		t.Error("Expected an error converting an integer that does not fit in i64")
+	}
+	if !TupleValue(IntValue(1), StringValue("a")).Equal(TupleValue(FloatValue(1), StringValue("a"))) {
+		t.Error("Expected (1, \"a\") to equal (1.0, \"a\")")
+	}
+}
+
+// TestRegisterNative tests calling a registered Go function from Cow code.
+func TestRegisterNative(t *testing.T) {
+	var output bytes.Buffer
+	evaluator := NewEvaluator(&output)
+
+	err := evaluator.Register("double", types.Func([]types.Type{types.Int}, types.Int), func(args []Value) (Value, error) {
+		n, _ := args[0].AsInt()
+		return IntValue(2 * n), nil
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	err = evaluator.Register("fail", types.Func(nil, types.Unit), func(args []Value) (Value, error) {
+		return Value{}, errors.New("out of cheese")
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := evaluator.Register("answer", types.Int, nil); err == nil {
+		t.Error("Expected an error registering a non-function signature")
+	}
+
+	// println(double(21))
+	program := &ast.Program{
+		Statements: []ast.Statement{
+			&ast.ExpressionStatement{
+				Token: "println",
+				Expression: &ast.FunctionCall{
+					Token: "println",
+					Name:  "println",
+					Arguments: []ast.Expression{
+						&ast.FunctionCall{
+							Token:     "double",
+							Name:      "double",
+							Arguments: []ast.Expression{&ast.IntLiteral{Token: "21", Value: 21}},
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := evaluator.Eval(program); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if output.String() != "42\n" {
+		t.Errorf("Expected output %q, got %q", "42\n", output.String())
+	}
+
+	// fail()
+	program = &ast.Program{
+		Statements: []ast.Statement{
+			&ast.ExpressionStatement{
+				Token:      "fail",
+				Expression: &ast.FunctionCall{Token: "fail", Name: "fail"},
+			},
+		},
+	}
+	err = evaluator.Eval(program)
+	if err == nil || err.Error() != "fail: out of cheese" {
+		t.Errorf("Expected the native function's error, got %v", err)
+	}
+
+	if natives := evaluator.Natives(); len(natives) != 2 || natives[0].Name != "double" || natives[0].Arity != 1 {
+		t.Errorf("Expected the natives double and fail, got %v", natives)
+	}
+}
+
+// TestCallFromGo tests looking up and calling Cow functions from Go.
+func TestCallFromGo(t *testing.T) {
+	// fn greet(name) { "hello " + name }
+	program := &ast.Program{
+		Statements: []ast.Statement{
+			&ast.FunctionDef{
+				Token:      "fn",
+				Name:       "greet",
+				Parameters: []string{"name"},
+				Body: &ast.Block{
+					Token: "{",
+					Statements: []ast.Statement{
+						&ast.ExpressionStatement{
+							Token: "\"hello \"",
+							Expression: &ast.BinaryExpression{
+								Token:    "+",
+								Left:     &ast.StringLiteral{Token: "\"hello \"", Value: "hello "},
+								Operator: "+",
+								Right:    &ast.Identifier{Token: "name", Name: "name"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	evaluator := NewEvaluator(&bytes.Buffer{})
+	if err := evaluator.Eval(program); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	greet, ok := evaluator.Lookup("greet")
+	if !ok || greet.Kind() != FunctionKind {
+		t.Fatalf("Expected to find the function greet, got %v", greet)
+	}
+	result, err := evaluator.Call(greet, StringValue("cow"))
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if s, _ := result.AsString(); s != "hello cow" {
+		t.Errorf("Expected %q, got %v", "hello cow", result)
+	}
+
+	if _, err := evaluator.Call(greet); err == nil || !strings.Contains(err.Error(), "expects 1 arguments, got 0") {
+		t.Errorf("Expected an arity error, got %v", err)
+	}
+	if _, err := evaluator.Call(IntValue(1)); err == nil {
+		t.Error("Expected an error calling an integer")
+	}
+	if _, ok := evaluator.Lookup("missing"); ok {
+		t.Error("Expected missing to be undefined")
+	}
+}
+
+// TestNativeReturnType tests that a value returned by a native function must
+// be of the return type of its signature.
+func TestNativeReturnType(t *testing.T) {
+	tests := []struct {
+		name       string
+		returnType types.Type
+		result     Value
+		expected   string // Expected error; empty means the call succeeds
+	}{
+		{name: "int", returnType: types.Int, result: IntValue(1)},
+		{name: "array", returnType: types.Array(types.String), result: ArrayValue(StringValue("a"))},
+		{name: "unit", returnType: types.Unit, result: Value{}},
+		{name: "tuple", returnType: types.Tuple(types.Int, types.Bool), result: TupleValue(IntValue(1), BoolValue(true))},
+		{name: "type parameter", returnType: &types.TParam{Name: "T"}, result: StringValue("any")},
+		{
+			name:       "string for int",
+			returnType: types.Int,
+			result:     StringValue("one"),
+			expected:   "bad: returned a value of kind string, but its signature returns i64",
+		},
+		{
+			name:       "int for unit",
+			returnType: types.Unit,
+			result:     IntValue(1),
+			expected:   "bad: returned a value of kind int, but its signature returns ()",
+		},
+		{
+			name:       "tuple of another length",
+			returnType: types.Tuple(types.Int, types.Int),
+			result:     TupleValue(IntValue(1)),
+			expected:   "bad: returned a tuple of 1 elements, but its signature returns (i64, i64)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluator := NewEvaluator(&bytes.Buffer{})
+			err := evaluator.Register("bad", types.Func(nil, tt.returnType), func(args []Value) (Value, error) {
+				return tt.result, nil
+			})
+			if err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+
+			native, _ := evaluator.Lookup("bad")
+			_, err = evaluator.Call(native)
+			if tt.expected == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected error %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@
 package eval
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -12,16 +13,18 @@ import (
 
 // Environment stores variable bindings with support for scope chaining.
 type Environment struct {
-	store  map[string]interface{}
-	parent *Environment // Parent environment for scope chain (nil for global scope)
+	store   map[string]interface{}
+	mutable map[string]bool // Names declared with 'let mut' in this scope
+	parent  *Environment    // Parent environment for scope chain (nil for global scope)
 }
 
 // NewEnvironment creates a new environment with an optional parent.
 // Pass nil for parent to create a global scope environment.
 func NewEnvironment(parent *Environment) *Environment {
 	return &Environment{
-		store:  make(map[string]interface{}),
-		parent: parent,
+		store:   make(map[string]interface{}),
+		mutable: make(map[string]bool),
+		parent:  parent,
 	}
 }
 
@@ -44,18 +47,92 @@ func (env *Environment) Set(name string, value interface{}) {
 	env.store[name] = value
 }
 
+// Define declares a new binding in the current scope.
+// A redeclaration shadows the previous binding, including its mutability.
+func (env *Environment) Define(name string, value interface{}, mutable bool) {
+	env.store[name] = value
+	if mutable {
+		env.mutable[name] = true
+	} else {
+		delete(env.mutable, name)
+	}
+}
+
+// Assign updates an existing binding, searching up the scope chain.
+// Returns an error if the variable is undefined or was not declared with 'let mut'.
+func (env *Environment) Assign(name string, value interface{}) error {
+	if _, exists := env.store[name]; exists {
+		if !env.mutable[name] {
+			return fmt.Errorf("cannot assign to immutable variable '%s' (declare it with 'let mut')", name)
+		}
+		env.store[name] = value
+		return nil
+	}
+	if env.parent != nil {
+		return env.parent.Assign(name, value)
+	}
+	return fmt.Errorf("cannot assign to undefined variable: %s", name)
+}
+
 // Function represents a user-defined function at runtime.
 // Functions are first-class values that can be stored in variables.
 type Function struct {
-	Parameters []string  // Parameter names
-	Body       *ast.Block // Function body
-	// Note: No Env field - we don't capture closures, only access globals
+	Parameters []string     // Parameter names
+	Body       *ast.Block   // Function body
+	Env        *Environment // Environment the function was defined in (captured for closures)
+}
+
+// Variant is a runtime value of an algebraic data type.
+// It is tagged with the constructor that built it and holds the constructor's fields.
+type Variant struct {
+	TypeName    string        // The declared type (e.g., "Option")
+	Constructor string        // The constructor tag (e.g., "Some")
+	Tag         int           // Position of the constructor in the type declaration, for ordering
+	Fields      []interface{} // Field values (empty for constructors without a payload)
+}
+
+// Constructor is a callable constructor of an algebraic data type.
+// Constructors without a payload are bound directly to their Variant value instead.
+type Constructor struct {
+	TypeName string // The declared type
+	Name     string // The constructor name
+	Tag      int    // Position of the constructor in the type declaration
+	Arity    int    // Number of fields
+}
+
+// Tuple is a runtime tuple value with at least one element.
+// The empty tuple () is the Unit value instead.
+type Tuple struct {
+	Elements []interface{} // The element values
+}
+
+// Unit is the runtime unit value, written (). It is the value of blocks and
+// functions that do not end with an expression, and of println.
+type Unit struct{}
+
+// Record is a runtime value of a record (product) type.
+// Field names and values are kept in declaration order.
+type Record struct {
+	TypeName string        // The declared record type (e.g., "Point")
+	Fields   []string      // Field names, in declaration order
+	Values   []interface{} // Field values, parallel to Fields
+}
+
+// Get returns the value of the named field.
+func (r *Record) Get(name string) (interface{}, bool) {
+	for i, field := range r.Fields {
+		if field == name {
+			return r.Values[i], true
+		}
+	}
+	return nil, false
 }
 
 // ControlFlow represents control flow signals (break, continue, return).
 // These are used as special error values to manage control flow in loops and functions.
 type ControlFlow struct {
-	Type string // "break", "continue", or "return"
+	Type  string      // "break", "continue", or "return"
+	Value interface{} // The returned value (only set for "return")
 }
 
 // Error implements the error interface for ControlFlow.
@@ -72,32 +149,83 @@ func continueSignal() error {
 	return &ControlFlow{Type: "continue"}
 }
 
+func returnSignal(value interface{}) error {
+	return &ControlFlow{Type: "return", Value: value}
+}
+
 // isBreak checks if an error is a break signal
 func isBreak(err error) bool {
-	if cf, ok := err.(*ControlFlow); ok {
-		return cf.Type == "break"
-	}
-	return false
+	var cf *ControlFlow
+	return errors.As(err, &cf) && cf.Type == "break"
 }
 
 // isContinue checks if an error is a continue signal
 func isContinue(err error) bool {
-	if cf, ok := err.(*ControlFlow); ok {
-		return cf.Type == "continue"
+	var cf *ControlFlow
+	return errors.As(err, &cf) && cf.Type == "continue"
+}
+
+// returnedValue extracts the value carried by a return signal.
+// The second result is false if the error is not a return signal.
+func returnedValue(err error) (interface{}, bool) {
+	var cf *ControlFlow
+	if errors.As(err, &cf) && cf.Type == "return" {
+		return cf.Value, true
 	}
-	return false
+	return nil, false
 }
 
 // isControlFlow checks if an error is any control flow signal
 func isControlFlow(err error) bool {
-	_, ok := err.(*ControlFlow)
-	return ok
+	var cf *ControlFlow
+	return errors.As(err, &cf)
+}
+
+// Error is a runtime error at a position in the source code.
+// The span is that of the innermost expression or statement that failed; the
+// message includes the context added as the error propagated.
+type Error struct {
+	Span ast.Span
+	Err  error
+}
+
+// Error formats the error as line:column: message.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %v", e.Span.Start, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// located marks an error with the span of the node where it happened while
+// it propagates, without changing its message.
+type located struct {
+	span ast.Span
+	err  error
+}
+
+func (l *located) Error() string { return l.err.Error() }
+
+func (l *located) Unwrap() error { return l.err }
+
+// locate marks an error with the span of a node, unless it is nil, already
+// has a span, is a control flow signal, or the node's position is unknown.
+func locate(node ast.Node, err error) error {
+	var loc *located
+	if err == nil || isControlFlow(err) || errors.As(err, &loc) || !node.Pos().IsValid() {
+		return err
+	}
+	return &located{span: ast.Span{Start: node.Pos(), Stop: node.End()}, err: err}
 }
 
 // Evaluator holds the state during evaluation.
 type Evaluator struct {
-	output io.Writer     // Where to write println output
-	env    *Environment  // Variable storage
+	output  io.Writer              // Where to write println output
+	env     *Environment           // Variable storage
+	records []*ast.TypeDeclaration // Declared record types, in declaration order
+	natives []*Native              // Registered native functions, in registration order
 }
 
 // NewEvaluator creates a new evaluator.
@@ -110,17 +238,72 @@ func NewEvaluator(output io.Writer) *Evaluator {
 }
 
 // Eval evaluates a program AST.
+// A runtime error in a program with source positions is returned as an
+// *Error locating the expression or statement that failed.
 func (e *Evaluator) Eval(program *ast.Program) error {
+	_, err := e.EvalValue(program)
+	return err
+}
+
+// EvalValue evaluates a program like Eval and returns the value of its last
+// statement if that is an expression statement, or unit otherwise.
+// Bindings persist between calls, so a program can be evaluated in pieces.
+func (e *Evaluator) EvalValue(program *ast.Program) (Value, error) {
+	var result interface{} = Unit{}
 	for _, stmt := range program.Statements {
-		if err := e.evalStatement(stmt); err != nil {
-			return err
+		result = Unit{}
+
+		var err error
+		if exprStmt, ok := stmt.(*ast.ExpressionStatement); ok {
+			result, err = e.evalExpression(exprStmt.Expression)
+		} else {
+			err = e.evalStatement(stmt)
+		}
+		if err != nil {
+			return Value{}, sourceError(locate(stmt, escapedControlFlow(err)))
 		}
 	}
-	return nil
+	return wrap(result), nil
+}
+
+// sourceError returns an error that was located while it propagated as an
+// *Error, and other errors unchanged.
+func sourceError(err error) error {
+	var loc *located
+	if errors.As(err, &loc) {
+		return &Error{Span: loc.span, Err: err}
+	}
+	return err
+}
+
+// escapedControlFlow converts a control flow signal that escaped its enclosing
+// construct (e.g., break outside a loop) into a regular error.
+// Other errors are returned unchanged.
+func escapedControlFlow(err error) error {
+	if _, ok := returnedValue(err); ok {
+		return fmt.Errorf("return statement outside function")
+	}
+	if isBreak(err) {
+		return fmt.Errorf("break statement outside loop")
+	}
+	if isContinue(err) {
+		return fmt.Errorf("continue statement outside loop")
+	}
+	return err
 }
 
 // evalStatement evaluates a single statement.
+// Errors are marked with the span of the statement if no expression in it
+// failed first.
 func (e *Evaluator) evalStatement(stmt ast.Statement) error {
+	if err := e.evalStatementKind(stmt); err != nil {
+		return locate(stmt, err)
+	}
+	return nil
+}
+
+// evalStatementKind evaluates a statement according to its kind.
+func (e *Evaluator) evalStatementKind(stmt ast.Statement) error {
 	switch s := stmt.(type) {
 	case *ast.LetStatement:
 		return e.evalLetStatement(s)
@@ -132,15 +315,24 @@ func (e *Evaluator) evalStatement(stmt ast.Statement) error {
 	case *ast.FunctionDef:
 		return e.evalFunctionDef(s)
 
+	case *ast.TypeDeclaration:
+		return e.evalTypeDeclaration(s)
+
 	case *ast.ReturnStatement:
-		// Return statements should only be evaluated inside blocks
-		// This case shouldn't be hit at the top level
-		return fmt.Errorf("return statement outside function")
+		// Return signals function exit via control flow, carrying the value
+		value, err := e.evalExpression(s.Value)
+		if err != nil {
+			return err
+		}
+		return returnSignal(value)
 
 	case *ast.Block:
 		_, err := e.evalBlock(s)
 		return err
 
+	case *ast.Assignment:
+		return e.evalAssignment(s)
+
 	case *ast.IndexAssignment:
 		return e.evalIndexAssignment(s)
 
@@ -161,21 +353,70 @@ func (e *Evaluator) evalStatement(stmt ast.Statement) error {
 }
 
 // evalLetStatement evaluates a let statement (variable declaration).
+// A destructuring let binds every name in its pattern.
 func (e *Evaluator) evalLetStatement(stmt *ast.LetStatement) error {
+	if stmt.Pattern != nil {
+		return e.evalLetPattern(stmt)
+	}
+
 	// Evaluate the value expression
 	value, err := e.evalExpression(stmt.Value)
 	if err != nil {
-		return fmt.Errorf("error evaluating let statement for '%s': %v", stmt.Name, err)
+		return fmt.Errorf("error evaluating let statement for '%s': %w", stmt.Name, err)
 	}
 
 	// Store the variable in the environment
-	e.env.Set(stmt.Name, value)
+	e.env.Define(stmt.Name, value, stmt.Mutable)
+	return nil
+}
+
+// evalLetPattern evaluates a destructuring let statement: let (a, b) = pair
+func (e *Evaluator) evalLetPattern(stmt *ast.LetStatement) error {
+	value, err := e.evalExpression(stmt.Value)
+	if err != nil {
+		return fmt.Errorf("error evaluating let statement: %w", err)
+	}
+
+	bindings := make(map[string]interface{})
+	matched, err := e.matchPattern(stmt.Pattern, value, bindings)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return fmt.Errorf("let pattern does not match value %s", FormatElement(value))
+	}
+
+	for name, bound := range bindings {
+		e.env.Define(name, bound, stmt.Mutable)
+	}
 	return nil
 }
 
+// evalAssignment evaluates a variable reassignment.
+// The binding is looked up through the scope chain, so closures and loop
+// bodies update the variable where it was declared.
+func (e *Evaluator) evalAssignment(stmt *ast.Assignment) error {
+	value, err := e.evalExpression(stmt.Value)
+	if err != nil {
+		return fmt.Errorf("error evaluating assignment to '%s': %w", stmt.Name, err)
+	}
+
+	return e.env.Assign(stmt.Name, value)
+}
+
 // evalExpression evaluates an expression and returns its value.
 // For now, values are represented as interface{} and can be int64 or float64.
+// Errors are marked with the span of the innermost expression that failed.
 func (e *Evaluator) evalExpression(expr ast.Expression) (interface{}, error) {
+	value, err := e.evalExpressionKind(expr)
+	if err != nil {
+		return nil, locate(expr, err)
+	}
+	return value, nil
+}
+
+// evalExpressionKind evaluates an expression according to its kind.
+func (e *Evaluator) evalExpressionKind(expr ast.Expression) (interface{}, error) {
 	switch ex := expr.(type) {
 	case *ast.IntLiteral:
 		return ex.Value, nil
@@ -213,6 +454,24 @@ func (e *Evaluator) evalExpression(expr ast.Expression) (interface{}, error) {
 	case *ast.MemberAccess:
 		return e.evalMemberAccess(ex)
 
+	case *ast.IfExpression:
+		return e.evalIfExpression(ex)
+
+	case *ast.MatchExpression:
+		return e.evalMatchExpression(ex)
+
+	case *ast.TupleLiteral:
+		return e.evalTupleLiteral(ex)
+
+	case *ast.RecordLiteral:
+		return e.evalRecordLiteral(ex)
+
+	case *ast.RecordUpdate:
+		return e.evalRecordUpdate(ex)
+
+	case *ast.BlockExpression:
+		return e.evalScopedBlock(ex.Block)
+
 	default:
 		return nil, fmt.Errorf("unknown expression type: %T", expr)
 	}
@@ -235,7 +494,7 @@ func (e *Evaluator) evalFunctionCall(call *ast.FunctionCall) (interface{}, error
 		for i, arg := range call.Arguments {
 			value, err := e.evalExpression(arg)
 			if err != nil {
-				return nil, fmt.Errorf("error evaluating argument %d to println: %v", i, err)
+				return nil, fmt.Errorf("error evaluating argument %d to println: %w", i, err)
 			}
 
 			// Print the value
@@ -243,13 +502,14 @@ func (e *Evaluator) evalFunctionCall(call *ast.FunctionCall) (interface{}, error
 				return nil, err
 			}
 		}
-		// println returns void/nil
-		return nil, nil
+		// println returns unit
+		return Unit{}, nil
 	}
 
 	// Check for array method calls (like len, push, pop)
-	// These are represented as function calls where the first argument is the array object
-	if len(call.Arguments) > 0 {
+	// These are represented as function calls where the first argument is the
+	// member access naming the method, e.g. xs.push(1) is push(xs.push, 1)
+	if isMethodCall(call) {
 		// Evaluate first argument to see if it's an ArrayMethod
 		firstArg, err := e.evalExpression(call.Arguments[0])
 		if err == nil {
@@ -266,17 +526,60 @@ func (e *Evaluator) evalFunctionCall(call *ast.FunctionCall) (interface{}, error
 		return nil, fmt.Errorf("undefined function: %s", call.Name)
 	}
 
-	// Check if it's a Function
-	fn, ok := fnValue.(*Function)
-	if !ok {
+	switch fn := fnValue.(type) {
+	case *Function:
+		// Call the user-defined function
+		return e.callUserFunction(fn, call.Arguments)
+	case *Constructor:
+		// Build a variant value
+		return e.callConstructor(fn, call.Arguments)
+	case *Native:
+		// Call the Go function
+		return e.callNative(fn, call.Arguments)
+	default:
 		return nil, fmt.Errorf("%s is not a function (it's a %T)", call.Name, fnValue)
 	}
+}
+
+// isMethodCall reports whether a call has the form obj.name(args).
+func isMethodCall(call *ast.FunctionCall) bool {
+	if len(call.Arguments) == 0 {
+		return false
+	}
+	member, ok := call.Arguments[0].(*ast.MemberAccess)
+	return ok && member.Member == call.Name
+}
+
+// callConstructor builds a Variant from an ADT constructor call.
+func (e *Evaluator) callConstructor(ctor *Constructor, args []ast.Expression) (interface{}, error) {
+	if len(args) != ctor.Arity {
+		return nil, fmt.Errorf("constructor %s expects %d arguments, got %d",
+			ctor.Name, ctor.Arity, len(args))
+	}
+
+	fields := make([]interface{}, len(args))
+	for i, arg := range args {
+		val, err := e.evalExpression(arg)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating argument %d to %s: %w", i, ctor.Name, err)
+		}
+		fields[i] = val
+	}
 
-	// Call the user-defined function
-	return e.callUserFunction(fn, call.Arguments)
+	return ctor.build(fields), nil
 }
 
-// callArrayMethod calls an array method (len, push, pop)
+// build returns the variant of the constructor with the given fields.
+func (ctor *Constructor) build(fields []interface{}) *Variant {
+	return &Variant{
+		TypeName:    ctor.TypeName,
+		Constructor: ctor.Name,
+		Tag:         ctor.Tag,
+		Fields:      fields,
+	}
+}
+
+// callArrayMethod calls an array method (len, push, pop, sorted)
 func (e *Evaluator) callArrayMethod(method *ArrayMethod, args []ast.Expression) (interface{}, error) {
 	switch method.Method {
 	case "len":
@@ -284,59 +587,69 @@ func (e *Evaluator) callArrayMethod(method *ArrayMethod, args []ast.Expression)
 		if len(args) != 0 {
 			return nil, fmt.Errorf("len() takes no arguments, got %d", len(args))
 		}
-		return int64(len(method.Array)), nil
+		return int64(method.Array.Len()), nil
 
 	case "push":
-		// push(item) appends an item and returns nil
+		// push(item) appends an item to the array where it is stored and returns unit
 		if len(args) != 1 {
 			return nil, fmt.Errorf("push() takes exactly 1 argument, got %d", len(args))
 		}
+		if method.place == nil {
+			return nil, fmt.Errorf("push() needs an array stored in a variable, element or field")
+		}
 
 		// Evaluate the item to push
 		item, err := e.evalExpression(args[0])
 		if err != nil {
-			return nil, fmt.Errorf("error evaluating push argument: %v", err)
+			return nil, fmt.Errorf("error evaluating push argument: %w", err)
 		}
 
-		// Append to the array
-		newArray := append(method.Array, item)
-
-		// Update the array in the environment
-		// We need to get the identifier name from the object expression
-		if ident, ok := method.Object.(*ast.Identifier); ok {
-			e.env.Set(ident.Name, newArray)
-		} else {
-			return nil, fmt.Errorf("push() only supported on simple identifiers, not complex expressions")
+		err = e.updatePlace(method.place, func(value interface{}) (interface{}, error) {
+			arr, ok := value.(*Array)
+			if !ok {
+				return nil, fmt.Errorf("cannot push to non-array type: %T", value)
+			}
+			return arr.Push(item), nil
+		})
+		if err != nil {
+			return nil, err
 		}
-
-		return nil, nil
+		return Unit{}, nil
 
 	case "pop":
-		// pop() removes and returns the last element
+		// pop() removes and returns the last element of the array where it is stored
 		if len(args) != 0 {
 			return nil, fmt.Errorf("pop() takes no arguments, got %d", len(args))
 		}
-
-		if len(method.Array) == 0 {
-			return nil, fmt.Errorf("cannot pop from empty array")
+		if method.place == nil {
+			return nil, fmt.Errorf("pop() needs an array stored in a variable, element or field")
 		}
 
-		// Get the last element
-		lastIndex := len(method.Array) - 1
-		lastElement := method.Array[lastIndex]
-
-		// Remove the last element
-		newArray := method.Array[:lastIndex]
-
-		// Update the array in the environment
-		if ident, ok := method.Object.(*ast.Identifier); ok {
-			e.env.Set(ident.Name, newArray)
-		} else {
-			return nil, fmt.Errorf("pop() only supported on simple identifiers, not complex expressions")
+		var lastElement interface{}
+		err := e.updatePlace(method.place, func(value interface{}) (interface{}, error) {
+			arr, ok := value.(*Array)
+			if !ok {
+				return nil, fmt.Errorf("cannot pop from non-array type: %T", value)
+			}
+			if arr.Len() == 0 {
+				return nil, fmt.Errorf("cannot pop from empty array")
+			}
+			var rest *Array
+			rest, lastElement = arr.Pop()
+			return rest, nil
+		})
+		if err != nil {
+			return nil, err
 		}
-
 		return lastElement, nil
 
+	case "sorted":
+		// sorted() returns a sorted copy of the array, keeping equal elements in order
+		if len(args) != 0 {
+			return nil, fmt.Errorf("sorted() takes no arguments, got %d", len(args))
+		}
+		return method.Array.Sorted()
+
 	default:
 		return nil, fmt.Errorf("unknown array method: %s", method.Method)
 	}
@@ -344,60 +657,132 @@ func (e *Evaluator) callArrayMethod(method *ArrayMethod, args []ast.Expression)
 
 // println prints a value to the output writer.
 func (e *Evaluator) println(value interface{}) error {
-	var str string
+	str, err := Format(value)
+	if err != nil {
+		return err
+	}
 
+	_, err = e.output.Write([]byte(str + "\n"))
+	return err
+}
+
+// Format formats a value the way println prints it.
+// Strings are printed as they are; strings nested in other values are quoted.
+func Format(value interface{}) (string, error) {
 	switch v := value.(type) {
 	case int64:
-		str = fmt.Sprintf("%d\n", v)
+		return fmt.Sprintf("%d", v), nil
 	case float64:
-		str = fmt.Sprintf("%g\n", v)
+		return fmt.Sprintf("%g", v), nil
 	case bool:
-		str = fmt.Sprintf("%t\n", v)
+		return fmt.Sprintf("%t", v), nil
 	case string:
-		str = fmt.Sprintf("%s\n", v)
-	case []interface{}:
-		str = e.formatArray(v) + "\n"
+		return v, nil
+	case *Array:
+		return formatArray(v), nil
+	case *Variant:
+		return formatVariant(v), nil
+	case *Record:
+		return formatRecord(v), nil
+	case *Tuple:
+		return formatTuple(v), nil
+	case Unit:
+		return "()", nil
 	default:
-		return fmt.Errorf("cannot print value of type %T", value)
+		return "", fmt.Errorf("cannot print value of type %T", value)
 	}
-
-	_, err := e.output.Write([]byte(str))
-	return err
 }
 
 // formatArray formats an array for printing.
-func (e *Evaluator) formatArray(arr []interface{}) string {
-	if len(arr) == 0 {
+func formatArray(arr *Array) string {
+	if arr.Len() == 0 {
 		return "[]"
 	}
 
-	parts := make([]string, len(arr))
-	for i, elem := range arr {
-		switch v := elem.(type) {
-		case int64:
-			parts[i] = fmt.Sprintf("%d", v)
-		case float64:
-			parts[i] = fmt.Sprintf("%g", v)
-		case bool:
-			parts[i] = fmt.Sprintf("%t", v)
-		case string:
-			parts[i] = fmt.Sprintf("%q", v)
-		case []interface{}:
-			parts[i] = e.formatArray(v)
-		default:
-			parts[i] = fmt.Sprintf("%v", v)
-		}
+	parts := make([]string, arr.Len())
+	for i, elem := range arr.Values() {
+		parts[i] = FormatElement(elem)
 	}
 
 	return "[" + strings.Join(parts, ", ") + "]"
 }
 
+// formatVariant formats an ADT value for printing, e.g. Some(3) or None.
+func formatVariant(v *Variant) string {
+	if len(v.Fields) == 0 {
+		return v.Constructor
+	}
+
+	parts := make([]string, len(v.Fields))
+	for i, field := range v.Fields {
+		parts[i] = FormatElement(field)
+	}
+
+	return v.Constructor + "(" + strings.Join(parts, ", ") + ")"
+}
+
+// formatRecord formats a record value for printing, e.g. { x: 1, y: 2 }.
+func formatRecord(r *Record) string {
+	if len(r.Fields) == 0 {
+		return "{}"
+	}
+
+	parts := make([]string, len(r.Fields))
+	for i, field := range r.Fields {
+		parts[i] = field + ": " + FormatElement(r.Values[i])
+	}
+
+	return "{ " + strings.Join(parts, ", ") + " }"
+}
+
+// formatTuple formats a tuple for printing, e.g. (1, "a") or (1,).
+func formatTuple(t *Tuple) string {
+	parts := make([]string, len(t.Elements))
+	for i, elem := range t.Elements {
+		parts[i] = FormatElement(elem)
+	}
+
+	if len(parts) == 1 {
+		return "(" + parts[0] + ",)"
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// FormatElement formats a value the way it is printed nested inside an
+// array, tuple, variant or record, and in error messages. Strings are
+// quoted so that nested values read unambiguously. Functions, closures and
+// constructors, whichever backend made them, are formatted as <function>.
+func FormatElement(elem interface{}) string {
+	switch v := elem.(type) {
+	case int64:
+		return fmt.Sprintf("%d", v)
+	case float64:
+		return fmt.Sprintf("%g", v)
+	case bool:
+		return fmt.Sprintf("%t", v)
+	case string:
+		return fmt.Sprintf("%q", v)
+	case *Array:
+		return formatArray(v)
+	case *Variant:
+		return formatVariant(v)
+	case *Record:
+		return formatRecord(v)
+	case *Tuple:
+		return formatTuple(v)
+	case Unit:
+		return "()"
+	default:
+		return "<function>"
+	}
+}
+
 // evalUnaryExpression evaluates a unary expression (e.g., !true, -5).
 func (e *Evaluator) evalUnaryExpression(expr *ast.UnaryExpression) (interface{}, error) {
 	// Evaluate the operand
 	operand, err := e.evalExpression(expr.Operand)
 	if err != nil {
-		return nil, fmt.Errorf("error evaluating operand of %s: %v", expr.Operator, err)
+		return nil, fmt.Errorf("error evaluating operand of %s: %w", expr.Operator, err)
 	}
 
 	switch expr.Operator {
@@ -439,20 +824,30 @@ func (e *Evaluator) evalBinaryExpression(expr *ast.BinaryExpression) (interface{
 	// For other operators, evaluate both operands
 	leftVal, err := e.evalExpression(expr.Left)
 	if err != nil {
-		return nil, fmt.Errorf("error evaluating left operand of %s: %v", expr.Operator, err)
+		return nil, fmt.Errorf("error evaluating left operand of %s: %w", expr.Operator, err)
 	}
 
 	rightVal, err := e.evalExpression(expr.Right)
 	if err != nil {
-		return nil, fmt.Errorf("error evaluating right operand of %s: %v", expr.Operator, err)
+		return nil, fmt.Errorf("error evaluating right operand of %s: %w", expr.Operator, err)
 	}
 
 	// Handle equality operators (work on any type)
 	if expr.Operator == "EQUAL_EQUAL" || expr.Operator == "==" {
-		return e.evalEquality(leftVal, rightVal), nil
+		return Equal(leftVal, rightVal), nil
 	}
 	if expr.Operator == "NOT_EQUAL" || expr.Operator == "!=" {
-		return !e.evalEquality(leftVal, rightVal), nil
+		return !Equal(leftVal, rightVal), nil
+	}
+
+	// Handle comparison operators (work on any values of the same type)
+	switch expr.Operator {
+	case "LESS_THAN", "<", "LESS_EQUAL", "<=", "GREATER_THAN", ">", "GREATER_EQUAL", ">=":
+		order, err := Compare(leftVal, rightVal)
+		if err != nil {
+			return nil, fmt.Errorf("operator %s: %w", expr.Operator, err)
+		}
+		return compareResult(order, expr.Operator), nil
 	}
 
 	// Handle string operations
@@ -467,7 +862,7 @@ func (e *Evaluator) evalBinaryExpression(expr *ast.BinaryExpression) (interface{
 		return e.evalStringBinaryOp(leftStr, rightStr, expr.Operator)
 	}
 
-	// Handle comparison and arithmetic operators (require numeric types)
+	// Handle arithmetic operators (require numeric types)
 	leftInt, leftIsInt := leftVal.(int64)
 	leftFloat, leftIsFloat := leftVal.(float64)
 	rightInt, rightIsInt := rightVal.(int64)
@@ -500,7 +895,21 @@ func (e *Evaluator) evalBinaryExpression(expr *ast.BinaryExpression) (interface{
 	return e.evalIntBinaryOp(leftInt, rightInt, expr.Operator)
 }
 
-// evalIntBinaryOp performs integer binary operations (arithmetic and comparison).
+// compareResult converts the result of Compare to the result of a comparison operator.
+func compareResult(order int, operator string) bool {
+	switch operator {
+	case "LESS_THAN", "<":
+		return order < 0
+	case "LESS_EQUAL", "<=":
+		return order <= 0
+	case "GREATER_THAN", ">":
+		return order > 0
+	default:
+		return order >= 0
+	}
+}
+
+// evalIntBinaryOp performs integer arithmetic.
 func (e *Evaluator) evalIntBinaryOp(left, right int64, operator string) (interface{}, error) {
 	switch operator {
 	// Arithmetic operators
@@ -521,22 +930,12 @@ func (e *Evaluator) evalIntBinaryOp(left, right int64, operator string) (interfa
 		}
 		return left % right, nil
 
-	// Comparison operators
-	case "LESS_THAN", "<":
-		return left < right, nil
-	case "LESS_EQUAL", "<=":
-		return left <= right, nil
-	case "GREATER_THAN", ">":
-		return left > right, nil
-	case "GREATER_EQUAL", ">=":
-		return left >= right, nil
-
 	default:
 		return nil, fmt.Errorf("unknown binary operator: %s", operator)
 	}
 }
 
-// evalFloatBinaryOp performs floating-point binary operations (arithmetic and comparison).
+// evalFloatBinaryOp performs floating-point arithmetic.
 func (e *Evaluator) evalFloatBinaryOp(left, right float64, operator string) (interface{}, error) {
 	switch operator {
 	// Arithmetic operators
@@ -554,16 +953,6 @@ func (e *Evaluator) evalFloatBinaryOp(left, right float64, operator string) (int
 	case "MODULO", "%":
 		return nil, fmt.Errorf("modulo operator not supported for floating-point numbers")
 
-	// Comparison operators
-	case "LESS_THAN", "<":
-		return left < right, nil
-	case "LESS_EQUAL", "<=":
-		return left <= right, nil
-	case "GREATER_THAN", ">":
-		return left > right, nil
-	case "GREATER_EQUAL", ">=":
-		return left >= right, nil
-
 	default:
 		return nil, fmt.Errorf("unknown binary operator: %s", operator)
 	}
@@ -574,7 +963,7 @@ func (e *Evaluator) evalLogicalAnd(expr *ast.BinaryExpression) (interface{}, err
 	// Evaluate left operand
 	leftVal, err := e.evalExpression(expr.Left)
 	if err != nil {
-		return nil, fmt.Errorf("error evaluating left operand of &&: %v", err)
+		return nil, fmt.Errorf("error evaluating left operand of &&: %w", err)
 	}
 
 	leftBool, ok := leftVal.(bool)
@@ -590,7 +979,7 @@ func (e *Evaluator) evalLogicalAnd(expr *ast.BinaryExpression) (interface{}, err
 	// Left is true, evaluate right
 	rightVal, err := e.evalExpression(expr.Right)
 	if err != nil {
-		return nil, fmt.Errorf("error evaluating right operand of &&: %v", err)
+		return nil, fmt.Errorf("error evaluating right operand of &&: %w", err)
 	}
 
 	rightBool, ok := rightVal.(bool)
@@ -606,7 +995,7 @@ func (e *Evaluator) evalLogicalOr(expr *ast.BinaryExpression) (interface{}, erro
 	// Evaluate left operand
 	leftVal, err := e.evalExpression(expr.Left)
 	if err != nil {
-		return nil, fmt.Errorf("error evaluating left operand of ||: %v", err)
+		return nil, fmt.Errorf("error evaluating left operand of ||: %w", err)
 	}
 
 	leftBool, ok := leftVal.(bool)
@@ -622,7 +1011,7 @@ func (e *Evaluator) evalLogicalOr(expr *ast.BinaryExpression) (interface{}, erro
 	// Left is false, evaluate right
 	rightVal, err := e.evalExpression(expr.Right)
 	if err != nil {
-		return nil, fmt.Errorf("error evaluating right operand of ||: %v", err)
+		return nil, fmt.Errorf("error evaluating right operand of ||: %w", err)
 	}
 
 	rightBool, ok := rightVal.(bool)
@@ -633,34 +1022,18 @@ func (e *Evaluator) evalLogicalOr(expr *ast.BinaryExpression) (interface{}, erro
 	return rightBool, nil
 }
 
-// evalStringBinaryOp performs string binary operations (concatenation and comparison).
+// evalStringBinaryOp performs string concatenation.
 func (e *Evaluator) evalStringBinaryOp(left, right string, operator string) (interface{}, error) {
 	switch operator {
 	// Concatenation
 	case "PLUS", "+":
 		return left + right, nil
 
-	// Comparison operators (lexicographic)
-	case "LESS_THAN", "<":
-		return left < right, nil
-	case "LESS_EQUAL", "<=":
-		return left <= right, nil
-	case "GREATER_THAN", ">":
-		return left > right, nil
-	case "GREATER_EQUAL", ">=":
-		return left >= right, nil
-
 	default:
 		return nil, fmt.Errorf("operator %s not supported for strings", operator)
 	}
 }
 
-// evalEquality checks if two values are equal.
-// Works on any type.
-func (e *Evaluator) evalEquality(left, right interface{}) bool {
-	return left == right
-}
-
 // evalFunctionDef evaluates a function definition statement.
 // Creates a Function value and stores it in the environment.
 func (e *Evaluator) evalFunctionDef(stmt *ast.FunctionDef) error {
@@ -668,6 +1041,7 @@ func (e *Evaluator) evalFunctionDef(stmt *ast.FunctionDef) error {
 	fn := &Function{
 		Parameters: stmt.Parameters,
 		Body:       stmt.Body,
+		Env:        e.env,
 	}
 
 	// Store in environment (global scope)
@@ -675,43 +1049,212 @@ func (e *Evaluator) evalFunctionDef(stmt *ast.FunctionDef) error {
 	return nil
 }
 
+// evalTypeDeclaration evaluates an algebraic data type declaration.
+// Each variant with fields is bound to a callable Constructor; each variant
+// without fields is bound directly to its Variant value (e.g., None).
+// Record types are remembered so that record literals can find their type.
+func (e *Evaluator) evalTypeDeclaration(stmt *ast.TypeDeclaration) error {
+	if stmt.Fields != nil {
+		e.records = append(e.records, stmt)
+		return nil
+	}
+
+	seen := make(map[string]bool, len(stmt.Variants))
+	for tag, variant := range stmt.Variants {
+		if seen[variant.Name] {
+			return fmt.Errorf("duplicate constructor %s in type %s", variant.Name, stmt.Name)
+		}
+		seen[variant.Name] = true
+
+		if len(variant.Fields) == 0 {
+			e.env.Set(variant.Name, &Variant{
+				TypeName:    stmt.Name,
+				Constructor: variant.Name,
+				Tag:         tag,
+				Fields:      []interface{}{},
+			})
+			continue
+		}
+
+		e.env.Set(variant.Name, &Constructor{
+			TypeName: stmt.Name,
+			Name:     variant.Name,
+			Tag:      tag,
+			Arity:    len(variant.Fields),
+		})
+	}
+	return nil
+}
+
 // evalFunctionLiteral evaluates a function literal expression.
 // Returns a Function value that can be assigned to variables or passed as arguments.
+// The function captures the current environment, so it can still see the
+// enclosing function's locals after that function returns.
 func (e *Evaluator) evalFunctionLiteral(expr *ast.FunctionLiteral) (interface{}, error) {
 	return &Function{
 		Parameters: expr.Parameters,
 		Body:       expr.Body,
+		Env:        e.env,
 	}, nil
 }
 
-// returnValue is a special type used to propagate return statements up through block evaluation.
-type returnValue struct {
-	value interface{}
-}
-
-// evalBlock evaluates a block of statements.
-// Returns the value from a return statement, or nil if no return.
+// evalBlock evaluates a block of statements in the current environment.
+// Returns the value of the trailing expression statement, or unit if the block
+// does not end with an expression. Return statements propagate as a
+// ControlFlow signal carrying the returned value.
 func (e *Evaluator) evalBlock(block *ast.Block) (interface{}, error) {
+	var result interface{} = Unit{}
 	for _, stmt := range block.Statements {
-		// Check if it's a return statement
-		if retStmt, ok := stmt.(*ast.ReturnStatement); ok {
-			// Evaluate return expression
-			val, err := e.evalExpression(retStmt.Value)
+		result = Unit{}
+
+		// Expression statements produce the block's value when they come last
+		if exprStmt, ok := stmt.(*ast.ExpressionStatement); ok {
+			val, err := e.evalExpression(exprStmt.Expression)
 			if err != nil {
 				return nil, err
 			}
-			// Return the value wrapped to signal a return
-			return &returnValue{value: val}, nil
+			result = val
+			continue
 		}
 
-		// Evaluate other statements
 		if err := e.evalStatement(stmt); err != nil {
 			return nil, err
 		}
 	}
 
-	// No return statement found
-	return nil, fmt.Errorf("function must end with return statement")
+	return result, nil
+}
+
+// evalScopedBlock evaluates a block in a new child scope, so that bindings
+// made inside the block are not visible after it finishes.
+func (e *Evaluator) evalScopedBlock(block *ast.Block) (interface{}, error) {
+	savedEnv := e.env
+	e.env = NewEnvironment(savedEnv)
+	defer func() { e.env = savedEnv }()
+
+	return e.evalBlock(block)
+}
+
+// evalIfExpression evaluates a conditional expression.
+// The value is the value of the chosen block, or unit if there is no else.
+func (e *Evaluator) evalIfExpression(expr *ast.IfExpression) (interface{}, error) {
+	condValue, err := e.evalExpression(expr.Condition)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating if condition: %w", err)
+	}
+
+	condBool, ok := condValue.(bool)
+	if !ok {
+		return nil, fmt.Errorf("if condition must be boolean, got %T", condValue)
+	}
+
+	if expr.Alternative == nil {
+		if condBool {
+			if _, err := e.evalScopedBlock(expr.Consequence); err != nil {
+				return nil, err
+			}
+		}
+		return Unit{}, nil
+	}
+	if condBool {
+		return e.evalScopedBlock(expr.Consequence)
+	}
+	return e.evalScopedBlock(expr.Alternative)
+}
+
+// evalMatchExpression evaluates a match expression.
+// Arms are tried in order; the first arm whose pattern matches is evaluated
+// in a new scope holding the pattern's bindings.
+func (e *Evaluator) evalMatchExpression(expr *ast.MatchExpression) (interface{}, error) {
+	subject, err := e.evalExpression(expr.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating match subject: %w", err)
+	}
+
+	for _, arm := range expr.Arms {
+		bindings := make(map[string]interface{})
+		matched, err := e.matchPattern(arm.Pattern, subject, bindings)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
+		}
+
+		savedEnv := e.env
+		e.env = NewEnvironment(savedEnv)
+		for name, value := range bindings {
+			e.env.Set(name, value)
+		}
+		result, err := e.evalBlock(arm.Body)
+		e.env = savedEnv
+		return result, err
+	}
+
+	return nil, fmt.Errorf("no match arm matched value %s", FormatElement(subject))
+}
+
+// matchPattern reports whether a value matches a pattern, collecting the
+// values of binding patterns into bindings.
+func (e *Evaluator) matchPattern(pattern ast.Pattern, value interface{}, bindings map[string]interface{}) (bool, error) {
+	switch p := pattern.(type) {
+	case *ast.WildcardPattern:
+		return true, nil
+
+	case *ast.BindingPattern:
+		bindings[p.Name] = value
+		return true, nil
+
+	case *ast.LiteralPattern:
+		literal, err := e.evalExpression(p.Value)
+		if err != nil {
+			return false, err
+		}
+		return Equal(literal, value), nil
+
+	case *ast.ConstructorPattern:
+		variant, ok := value.(*Variant)
+		if !ok || variant.Constructor != p.Name {
+			return false, nil
+		}
+		if len(p.Args) != len(variant.Fields) {
+			return false, fmt.Errorf("pattern %s expects %d fields, value has %d",
+				p.Name, len(p.Args), len(variant.Fields))
+		}
+		return e.matchAll(p.Args, variant.Fields, bindings)
+
+	case *ast.ArrayPattern:
+		arr, ok := value.(*Array)
+		if !ok || arr.Len() != len(p.Elements) {
+			return false, nil
+		}
+		return e.matchAll(p.Elements, arr.Values(), bindings)
+
+	case *ast.TuplePattern:
+		if len(p.Elements) == 0 {
+			_, ok := value.(Unit)
+			return ok, nil
+		}
+		tuple, ok := value.(*Tuple)
+		if !ok || len(tuple.Elements) != len(p.Elements) {
+			return false, nil
+		}
+		return e.matchAll(p.Elements, tuple.Elements, bindings)
+
+	default:
+		return false, fmt.Errorf("unknown pattern type: %T", pattern)
+	}
+}
+
+// matchAll matches each pattern against the value at the same position.
+func (e *Evaluator) matchAll(patterns []ast.Pattern, values []interface{}, bindings map[string]interface{}) (bool, error) {
+	for i, pattern := range patterns {
+		matched, err := e.matchPattern(pattern, values[i], bindings)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
 }
 
 // callUserFunction calls a user-defined function with the given arguments.
@@ -727,14 +1270,24 @@ func (e *Evaluator) callUserFunction(fn *Function, args []ast.Expression) (inter
 	for i, arg := range args {
 		val, err := e.evalExpression(arg)
 		if err != nil {
-			return nil, fmt.Errorf("error evaluating argument %d: %v", i, err)
+			return nil, fmt.Errorf("error evaluating argument %d: %w", i, err)
 		}
 		argValues[i] = val
 	}
 
+	return e.applyFunction(fn, argValues)
+}
+
+// applyFunction calls a user-defined function with evaluated arguments,
+// whose number has been checked.
+func (e *Evaluator) applyFunction(fn *Function, argValues []interface{}) (interface{}, error) {
 	// Create new environment for function scope
-	// Parent is current environment (typically global)
-	fnEnv := NewEnvironment(e.env)
+	// Parent is the environment the function was defined in (lexical scoping)
+	parent := fn.Env
+	if parent == nil {
+		parent = e.env
+	}
+	fnEnv := NewEnvironment(parent)
 
 	// Bind parameters to argument values
 	for i, param := range fn.Parameters {
@@ -749,32 +1302,31 @@ func (e *Evaluator) callUserFunction(fn *Function, args []ast.Expression) (inter
 	// Execute function body
 	result, err := e.evalBlock(fn.Body)
 	if err != nil {
-		return nil, err
-	}
-
-	// Unwrap return value
-	if retVal, ok := result.(*returnValue); ok {
-		return retVal.value, nil
+		// Unwrap return value
+		if value, ok := returnedValue(err); ok {
+			return value, nil
+		}
+		return nil, escapedControlFlow(err)
 	}
 
-	// This shouldn't happen if evalBlock works correctly
-	return nil, fmt.Errorf("function did not return properly")
+	// No return statement executed: the trailing expression is the result,
+	// or unit if the body does not end with an expression
+	return result, nil
 }
 
-// evalArrayLiteral evaluates an array literal expression.
-// Returns a Go slice ([]interface{}) containing the evaluated elements.
+// evalArrayLiteral evaluates an array literal expression to an *Array.
 func (e *Evaluator) evalArrayLiteral(expr *ast.ArrayLiteral) (interface{}, error) {
 	elements := make([]interface{}, len(expr.Elements))
 
 	for i, elemExpr := range expr.Elements {
 		val, err := e.evalExpression(elemExpr)
 		if err != nil {
-			return nil, fmt.Errorf("error evaluating array element %d: %v", i, err)
+			return nil, fmt.Errorf("error evaluating array element %d: %w", i, err)
 		}
 		elements[i] = val
 	}
 
-	return elements, nil
+	return NewArray(elements), nil
 }
 
 // evalIndexAccess evaluates array indexing: arr[index]
@@ -782,11 +1334,11 @@ func (e *Evaluator) evalIndexAccess(expr *ast.IndexAccess) (interface{}, error)
 	// Evaluate the object being indexed
 	obj, err := e.evalExpression(expr.Object)
 	if err != nil {
-		return nil, fmt.Errorf("error evaluating indexed object: %v", err)
+		return nil, fmt.Errorf("error evaluating indexed object: %w", err)
 	}
 
 	// Check if it's an array
-	arr, ok := obj.([]interface{})
+	arr, ok := obj.(*Array)
 	if !ok {
 		return nil, fmt.Errorf("cannot index non-array type: %T", obj)
 	}
@@ -794,7 +1346,7 @@ func (e *Evaluator) evalIndexAccess(expr *ast.IndexAccess) (interface{}, error)
 	// Evaluate the index expression
 	indexVal, err := e.evalExpression(expr.Index)
 	if err != nil {
-		return nil, fmt.Errorf("error evaluating index: %v", err)
+		return nil, fmt.Errorf("error evaluating index: %w", err)
 	}
 
 	// Convert index to int64
@@ -804,24 +1356,42 @@ func (e *Evaluator) evalIndexAccess(expr *ast.IndexAccess) (interface{}, error)
 	}
 
 	// Bounds check
-	if index < 0 || index >= int64(len(arr)) {
-		return nil, fmt.Errorf("array index out of bounds: index %d, length %d", index, len(arr))
+	if index < 0 || index >= int64(arr.Len()) {
+		return nil, fmt.Errorf("array index out of bounds: index %d, length %d", index, arr.Len())
 	}
 
-	return arr[index], nil
+	return arr.Get(int(index)), nil
 }
 
 // evalMemberAccess evaluates member access: obj.member
-// For arrays, this is used to access methods like len, push, pop
+// For records, this reads a field; for arrays, it accesses methods like len, push, pop
 func (e *Evaluator) evalMemberAccess(expr *ast.MemberAccess) (interface{}, error) {
-	// Evaluate the object
-	obj, err := e.evalExpression(expr.Object)
+	// Evaluate the object, keeping its place so that array methods can update it
+	var target *place
+	var obj interface{}
+	var err error
+	if isPlace(expr.Object) {
+		target, err = e.resolvePlace(expr.Object)
+		if err == nil {
+			obj, err = e.readPlace(target)
+		}
+	} else {
+		obj, err = e.evalExpression(expr.Object)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("error evaluating object for member access: %v", err)
+		return nil, fmt.Errorf("error evaluating object for member access: %w", err)
+	}
+
+	if record, ok := obj.(*Record); ok {
+		value, ok := record.Get(expr.Member)
+		if !ok {
+			return nil, fmt.Errorf("record %s has no field '%s'", record.TypeName, expr.Member)
+		}
+		return value, nil
 	}
 
 	// Check if it's an array
-	arr, ok := obj.([]interface{})
+	arr, ok := obj.(*Array)
 	if !ok {
 		return nil, fmt.Errorf("member access only supported on arrays, got %T", obj)
 	}
@@ -831,90 +1401,158 @@ func (e *Evaluator) evalMemberAccess(expr *ast.MemberAccess) (interface{}, error
 	return &ArrayMethod{
 		Array:  arr,
 		Method: expr.Member,
-		Object: expr.Object, // Keep object expression for updates
+		place:  target,
 	}, nil
 }
 
-// ArrayMethod represents a method bound to an array instance
-type ArrayMethod struct {
-	Array  []interface{}
-	Method string
-	Object ast.Expression // The original object expression (for mutations)
-}
-
-// evalIndexAssignment evaluates assignment to an array index: arr[index] = value
-func (e *Evaluator) evalIndexAssignment(stmt *ast.IndexAssignment) error {
-	// Get the array from the environment
-	arrValue, exists := e.env.Get(stmt.Name)
-	if !exists {
-		return fmt.Errorf("undefined variable: %s", stmt.Name)
+// evalTupleLiteral evaluates a tuple expression.
+// The empty tuple () evaluates to the Unit value.
+func (e *Evaluator) evalTupleLiteral(expr *ast.TupleLiteral) (interface{}, error) {
+	if len(expr.Elements) == 0 {
+		return Unit{}, nil
 	}
 
-	// Navigate through the index chain to find the target array and final index
-	currentArray := arrValue
-	for i := 0; i < len(stmt.Indices)-1; i++ {
-		// Evaluate this index
-		indexVal, err := e.evalExpression(stmt.Indices[i])
+	elements := make([]interface{}, len(expr.Elements))
+	for i, elemExpr := range expr.Elements {
+		val, err := e.evalExpression(elemExpr)
 		if err != nil {
-			return fmt.Errorf("error evaluating index %d: %v", i, err)
+			return nil, fmt.Errorf("error evaluating tuple element %d: %w", i, err)
 		}
+		elements[i] = val
+	}
 
-		index, ok := indexVal.(int64)
-		if !ok {
-			return fmt.Errorf("array index must be an integer, got %T", indexVal)
+	return &Tuple{Elements: elements}, nil
+}
+
+// evalRecordLiteral evaluates a record literal: { x: 1.0, y: 2.0 }
+// The record's type is the most recently declared record type with exactly
+// the literal's fields. Values are stored in the declared field order.
+func (e *Evaluator) evalRecordLiteral(expr *ast.RecordLiteral) (interface{}, error) {
+	decl := e.findRecordType(expr.Fields)
+	if decl == nil {
+		names := make([]string, len(expr.Fields))
+		for i, field := range expr.Fields {
+			names[i] = field.Name
 		}
+		return nil, fmt.Errorf("no record type has exactly the fields {%s}", strings.Join(names, ", "))
+	}
 
-		// Get the nested array
-		arr, ok := currentArray.([]interface{})
-		if !ok {
-			return fmt.Errorf("cannot index non-array type: %T", currentArray)
+	record := &Record{
+		TypeName: decl.Name,
+		Fields:   make([]string, len(decl.Fields)),
+		Values:   make([]interface{}, len(decl.Fields)),
+	}
+	for i, fieldDecl := range decl.Fields {
+		record.Fields[i] = fieldDecl.Name
+		for _, field := range expr.Fields {
+			if field.Name != fieldDecl.Name {
+				continue
+			}
+			value, err := e.evalExpression(field.Value)
+			if err != nil {
+				return nil, err
+			}
+			record.Values[i] = value
 		}
+	}
 
-		if index < 0 || index >= int64(len(arr)) {
-			return fmt.Errorf("array index out of bounds: index %d, length %d", index, len(arr))
+	return record, nil
+}
+
+// findRecordType finds the most recently declared record type whose fields
+// are exactly the given fields, or nil if there is none.
+func (e *Evaluator) findRecordType(fields []*ast.FieldValue) *ast.TypeDeclaration {
+	for i := len(e.records) - 1; i >= 0; i-- {
+		decl := e.records[i]
+		if len(decl.Fields) != len(fields) {
+			continue
+		}
+
+		matches := true
+		for _, fieldDecl := range decl.Fields {
+			found := false
+			for _, field := range fields {
+				if field.Name == fieldDecl.Name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return decl
 		}
+	}
+	return nil
+}
 
-		currentArray = arr[index]
+// evalRecordUpdate evaluates a functional record update: { p with x: 1.0 }
+// Returns a copy of the base record with the given fields replaced.
+func (e *Evaluator) evalRecordUpdate(expr *ast.RecordUpdate) (interface{}, error) {
+	base, err := e.evalExpression(expr.Base)
+	if err != nil {
+		return nil, err
 	}
 
-	// Now currentArray is the final array to modify
-	arr, ok := currentArray.([]interface{})
+	record, ok := base.(*Record)
 	if !ok {
-		return fmt.Errorf("cannot index non-array type: %T", currentArray)
+		return nil, fmt.Errorf("record update requires a record, got %T", base)
 	}
 
-	// Evaluate the final index
-	finalIndexVal, err := e.evalExpression(stmt.Indices[len(stmt.Indices)-1])
-	if err != nil {
-		return fmt.Errorf("error evaluating final index: %v", err)
+	updated := &Record{
+		TypeName: record.TypeName,
+		Fields:   record.Fields,
+		Values:   append([]interface{}{}, record.Values...),
 	}
+	for _, field := range expr.Fields {
+		index := -1
+		for i, name := range updated.Fields {
+			if name == field.Name {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("record %s has no field '%s'", record.TypeName, field.Name)
+		}
 
-	finalIndex, ok := finalIndexVal.(int64)
-	if !ok {
-		return fmt.Errorf("array index must be an integer, got %T", finalIndexVal)
+		value, err := e.evalExpression(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		updated.Values[index] = value
 	}
 
-	// Bounds check
-	if finalIndex < 0 || finalIndex >= int64(len(arr)) {
-		return fmt.Errorf("array index out of bounds: index %d, length %d", finalIndex, len(arr))
+	return updated, nil
+}
+
+// ArrayMethod represents a method bound to an array instance
+type ArrayMethod struct {
+	Array  *Array
+	Method string
+	place  *place // Where the array is stored, for methods that update it; nil if it is not stored
+}
+
+// evalIndexAssignment evaluates assignment to an array element or record
+// field: arr[index] = value or p.items[0] = value
+func (e *Evaluator) evalIndexAssignment(stmt *ast.IndexAssignment) error {
+	target, err := e.resolvePlace(stmt.Target)
+	if err != nil {
+		return err
 	}
 
 	// Evaluate the value to assign
 	value, err := e.evalExpression(stmt.Value)
 	if err != nil {
-		return fmt.Errorf("error evaluating assignment value: %v", err)
+		return fmt.Errorf("error evaluating assignment value: %w", err)
 	}
 
-	// Perform the assignment
-	arr[finalIndex] = value
-
-	// Note: The slice is modified in place, so we don't need to update the environment
-	// unless this was a single-level array (no nested indices)
-	if len(stmt.Indices) == 1 {
-		e.env.Set(stmt.Name, arr)
-	}
-
-	return nil
+	return e.updatePlace(target, func(interface{}) (interface{}, error) {
+		return value, nil
+	})
 }
 
 // evalForStatement evaluates a for loop.
@@ -926,7 +1564,7 @@ func (e *Evaluator) evalForStatement(stmt *ast.ForStatement) error {
 		if stmt.Condition != nil {
 			condValue, err := e.evalExpression(stmt.Condition)
 			if err != nil {
-				return fmt.Errorf("error evaluating loop condition: %v", err)
+				return fmt.Errorf("error evaluating loop condition: %w", err)
 			}
 
 			// Check if condition is a boolean
@@ -941,8 +1579,9 @@ func (e *Evaluator) evalForStatement(stmt *ast.ForStatement) error {
 			}
 		}
 
-		// Execute loop body
-		_, err := e.evalBlock(stmt.Body)
+		// Execute loop body, in a new scope per iteration so that closures
+		// made in different iterations do not share bindings
+		_, err := e.evalScopedBlock(stmt.Body)
 		if err != nil {
 			// Check for control flow signals
 			if isBreak(err) {
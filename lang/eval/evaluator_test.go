@@ -2,6 +2,7 @@ package eval
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/shadowCow/cow-lang-go/lang/ast"
@@ -275,3 +276,249 @@ func TestPrintlnFormatting(t *testing.T) {
 		})
 	}
 }
+
+// TestEvalIfExpression tests that if/else produces the value of the chosen branch.
+func TestEvalIfExpression(t *testing.T) {
+	branch := func(value int64) *ast.Block {
+		return &ast.Block{
+			Token: "{",
+			Statements: []ast.Statement{
+				&ast.ExpressionStatement{
+					Token:      "n",
+					Expression: &ast.IntLiteral{Token: "n", Value: value},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		condition   bool
+		alternative *ast.Block
+		expected    string
+	}{
+		{"condition true", true, branch(2), "1\n"},
+		{"condition false", false, branch(2), "2\n"},
+		{"no else, condition true", true, nil, "()\n"},
+		{"no else, condition false", false, nil, "()\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := &ast.Program{
+				Statements: []ast.Statement{
+					&ast.ExpressionStatement{
+						Token: "println",
+						Expression: &ast.FunctionCall{
+							Token: "println",
+							Name:  "println",
+							Arguments: []ast.Expression{
+								&ast.IfExpression{
+									Token:       "if",
+									Condition:   &ast.BoolLiteral{Token: "b", Value: tt.condition},
+									Consequence: branch(1),
+									Alternative: tt.alternative,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			var output bytes.Buffer
+			evaluator := NewEvaluator(&output)
+			err := evaluator.Eval(program)
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if output.String() != tt.expected {
+				t.Errorf("Expected output %q, got %q", tt.expected, output.String())
+			}
+		})
+	}
+}
+
+// TestEvalIfNonBoolCondition tests that a non-boolean condition is a runtime error.
+func TestEvalIfNonBoolCondition(t *testing.T) {
+	program := &ast.Program{
+		Statements: []ast.Statement{
+			&ast.ExpressionStatement{
+				Token: "if",
+				Expression: &ast.IfExpression{
+					Token:       "if",
+					Condition:   &ast.IntLiteral{Token: "1", Value: 1},
+					Consequence: &ast.Block{Token: "{"},
+				},
+			},
+		},
+	}
+
+	var output bytes.Buffer
+	evaluator := NewEvaluator(&output)
+	err := evaluator.Eval(program)
+
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "if condition must be boolean") {
+		t.Errorf("Expected non-boolean condition error, got %v", err)
+	}
+}
+
+// TestEvalAssignment tests reassignment of mutable and immutable bindings.
+func TestEvalAssignment(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutable  bool
+		expected string
+		errText  string
+	}{
+		{name: "let mut", mutable: true, expected: "2\n"},
+		{name: "immutable let", mutable: false, errText: "cannot assign to immutable variable 'x'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := &ast.Program{
+				Statements: []ast.Statement{
+					&ast.LetStatement{
+						Token:   "let",
+						Name:    "x",
+						Mutable: tt.mutable,
+						Value:   &ast.IntLiteral{Token: "1", Value: 1},
+					},
+					&ast.Assignment{
+						Token: "x",
+						Name:  "x",
+						Value: &ast.IntLiteral{Token: "2", Value: 2},
+					},
+					&ast.ExpressionStatement{
+						Token: "println",
+						Expression: &ast.FunctionCall{
+							Token:     "println",
+							Name:      "println",
+							Arguments: []ast.Expression{&ast.Identifier{Token: "x", Name: "x"}},
+						},
+					},
+				},
+			}
+
+			var output bytes.Buffer
+			evaluator := NewEvaluator(&output)
+			err := evaluator.Eval(program)
+
+			if tt.errText != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errText) {
+					t.Fatalf("Expected error containing %q, got %v", tt.errText, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if output.String() != tt.expected {
+				t.Errorf("Expected output %q, got %q", tt.expected, output.String())
+			}
+		})
+	}
+}
+
+// TestEvalConstructorArity tests that constructors check their argument count.
+func TestEvalConstructorArity(t *testing.T) {
+	program := &ast.Program{
+		Statements: []ast.Statement{
+			&ast.TypeDeclaration{
+				Token: "type",
+				Name:  "Option",
+				Variants: []*ast.VariantDecl{
+					{Token: "Some", Name: "Some", Fields: []ast.TypeExpression{&ast.NamedType{Token: "T", Name: "T"}}},
+					{Token: "None", Name: "None"},
+				},
+			},
+			&ast.ExpressionStatement{
+				Token: "Some",
+				Expression: &ast.FunctionCall{
+					Token:     "Some",
+					Name:      "Some",
+					Arguments: []ast.Expression{},
+				},
+			},
+		},
+	}
+
+	var output bytes.Buffer
+	evaluator := NewEvaluator(&output)
+	err := evaluator.Eval(program)
+
+	if err == nil || !strings.Contains(err.Error(), "constructor Some expects 1 arguments, got 0") {
+		t.Fatalf("Expected constructor arity error, got %v", err)
+	}
+}
+
+func TestEvalRecordLiteralRequiresDeclaredType(t *testing.T) {
+	program := &ast.Program{
+		Statements: []ast.Statement{
+			&ast.TypeDeclaration{
+				Token: "type",
+				Name:  "Point",
+				Fields: []*ast.FieldDecl{
+					{Token: "x", Name: "x", Type: &ast.NamedType{Token: "f64", Name: "f64"}},
+					{Token: "y", Name: "y", Type: &ast.NamedType{Token: "f64", Name: "f64"}},
+				},
+			},
+			&ast.ExpressionStatement{
+				Token: "{",
+				Expression: &ast.RecordLiteral{
+					Token: "{",
+					Fields: []*ast.FieldValue{
+						{Token: "x", Name: "x", Value: &ast.FloatLiteral{Token: "1.0", Value: 1.0}},
+						{Token: "z", Name: "z", Value: &ast.FloatLiteral{Token: "2.0", Value: 2.0}},
+					},
+				},
+			},
+		},
+	}
+
+	var output bytes.Buffer
+	evaluator := NewEvaluator(&output)
+	err := evaluator.Eval(program)
+
+	if err == nil || !strings.Contains(err.Error(), "no record type has exactly the fields {x, z}") {
+		t.Fatalf("Expected unknown record type error, got %v", err)
+	}
+}
+
+func TestEvalLetPatternMismatch(t *testing.T) {
+	program := &ast.Program{
+		Statements: []ast.Statement{
+			&ast.LetStatement{
+				Token: "let",
+				Pattern: &ast.TuplePattern{
+					Token: "(",
+					Elements: []ast.Pattern{
+						&ast.BindingPattern{Token: "a", Name: "a"},
+						&ast.BindingPattern{Token: "b", Name: "b"},
+					},
+				},
+				Value: &ast.TupleLiteral{
+					Token: "(",
+					Elements: []ast.Expression{
+						&ast.IntLiteral{Token: "1", Value: 1},
+						&ast.IntLiteral{Token: "2", Value: 2},
+						&ast.IntLiteral{Token: "3", Value: 3},
+					},
+				},
+			},
+		},
+	}
+
+	var output bytes.Buffer
+	evaluator := NewEvaluator(&output)
+	err := evaluator.Eval(program)
+
+	if err == nil || !strings.Contains(err.Error(), "let pattern does not match value (1, 2, 3)") {
+		t.Fatalf("Expected let pattern mismatch error, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package eval
+
+import (
+	"fmt"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+)
+
+// This file handles place expressions: a variable, or an array element or
+// record field inside one, such as xs, m[0] or p.items[i]. Assignments and
+// the array methods push and pop update places.
+//
+// Arrays and records are values, so updating a place never changes a value
+// another variable can see: it builds new copies of the arrays and records
+// along the path and assigns the result to the variable, which must be
+// declared with 'let mut'.
+
+// place is a resolved place expression. Its index expressions are evaluated
+// once, when the place is resolved.
+type place struct {
+	variable *ast.Identifier // The variable holding the value
+	path     []placeStep     // Array elements and record fields from the variable to the place
+}
+
+// placeStep is one array index or record field access in a place.
+type placeStep struct {
+	node  ast.Expression // The IndexAccess or MemberAccess, for error locations
+	index int64          // The array index, for an IndexAccess
+	field string         // The record field, for a MemberAccess
+}
+
+// isPlace reports whether an expression is a place expression: a variable
+// followed by any number of index and field accesses.
+func isPlace(expr ast.Expression) bool {
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		return true
+	case *ast.IndexAccess:
+		return isPlace(e.Object)
+	case *ast.MemberAccess:
+		return isPlace(e.Object)
+	default:
+		return false
+	}
+}
+
+// resolvePlace evaluates the index expressions of a place expression.
+func (e *Evaluator) resolvePlace(expr ast.Expression) (*place, error) {
+	switch p := expr.(type) {
+	case *ast.Identifier:
+		return &place{variable: p}, nil
+
+	case *ast.IndexAccess:
+		target, err := e.resolvePlace(p.Object)
+		if err != nil {
+			return nil, err
+		}
+		indexVal, err := e.evalExpression(p.Index)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating index: %w", err)
+		}
+		index, ok := indexVal.(int64)
+		if !ok {
+			return nil, locate(p.Index, fmt.Errorf("array index must be an integer, got %T", indexVal))
+		}
+		target.path = append(target.path, placeStep{node: p, index: index})
+		return target, nil
+
+	case *ast.MemberAccess:
+		target, err := e.resolvePlace(p.Object)
+		if err != nil {
+			return nil, err
+		}
+		target.path = append(target.path, placeStep{node: p, field: p.Member})
+		return target, nil
+
+	default:
+		return nil, locate(expr, fmt.Errorf("cannot assign to %T: expected a variable, array element or record field", expr))
+	}
+}
+
+// readPlace returns the value at a place.
+func (e *Evaluator) readPlace(target *place) (interface{}, error) {
+	value, err := e.evalIdentifier(target.variable)
+	if err != nil {
+		return nil, locate(target.variable, err)
+	}
+	for _, step := range target.path {
+		if value, err = step.get(value); err != nil {
+			return nil, err
+		}
+	}
+	return value, nil
+}
+
+// updatePlace replaces the value at a place with update(value).
+func (e *Evaluator) updatePlace(target *place, update func(interface{}) (interface{}, error)) error {
+	value, err := e.evalIdentifier(target.variable)
+	if err != nil {
+		return locate(target.variable, err)
+	}
+	updated, err := updatePath(value, target.path, update)
+	if err != nil {
+		return err
+	}
+	return locate(target.variable, e.env.Assign(target.variable.Name, updated))
+}
+
+// updatePath returns a copy of value with the value at the end of path
+// replaced by update(value at path).
+func updatePath(value interface{}, path []placeStep, update func(interface{}) (interface{}, error)) (interface{}, error) {
+	if len(path) == 0 {
+		return update(value)
+	}
+	step := path[0]
+	inner, err := step.get(value)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := updatePath(inner, path[1:], update)
+	if err != nil {
+		return nil, err
+	}
+	return step.set(value, updated), nil
+}
+
+// get returns the array element or record field the step accesses in value.
+func (step placeStep) get(value interface{}) (interface{}, error) {
+	if _, ok := step.node.(*ast.MemberAccess); ok {
+		record, ok := value.(*Record)
+		if !ok {
+			return nil, locate(step.node, fmt.Errorf("cannot access field '%s' of %s", step.field, describeValue(value)))
+		}
+		field, ok := record.Get(step.field)
+		if !ok {
+			return nil, locate(step.node, fmt.Errorf("record %s has no field '%s'", record.TypeName, step.field))
+		}
+		return field, nil
+	}
+
+	arr, ok := value.(*Array)
+	if !ok {
+		return nil, locate(step.node, fmt.Errorf("cannot index non-array type: %T", value))
+	}
+	if step.index < 0 || step.index >= int64(arr.Len()) {
+		return nil, locate(step.node, fmt.Errorf("array index out of bounds: index %d, length %d", step.index, arr.Len()))
+	}
+	return arr.Get(int(step.index)), nil
+}
+
+// set returns a copy of value with the accessed array element or record
+// field replaced. The step must have been checked with get.
+func (step placeStep) set(value interface{}, inner interface{}) interface{} {
+	if arr, ok := value.(*Array); ok {
+		return arr.Set(int(step.index), inner)
+	}
+
+	record := value.(*Record)
+	updated := &Record{
+		TypeName: record.TypeName,
+		Fields:   record.Fields,
+		Values:   append([]interface{}{}, record.Values...),
+	}
+	for i, field := range record.Fields {
+		if field == step.field {
+			updated.Values[i] = inner
+		}
+	}
+	return updated
+}
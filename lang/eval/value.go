@@ -0,0 +1,310 @@
+package eval
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Kind is the kind of a Value.
+type Kind int
+
+const (
+	UnitKind Kind = iota
+	IntKind
+	FloatKind
+	StringKind
+	BoolKind
+	ArrayKind
+	TupleKind
+	RecordKind
+	VariantKind
+	FunctionKind
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case UnitKind:
+		return "unit"
+	case IntKind:
+		return "int"
+	case FloatKind:
+		return "float"
+	case StringKind:
+		return "string"
+	case BoolKind:
+		return "bool"
+	case ArrayKind:
+		return "array"
+	case TupleKind:
+		return "tuple"
+	case RecordKind:
+		return "record"
+	case VariantKind:
+		return "variant"
+	default:
+		return "function"
+	}
+}
+
+// Value is a Cow value, as exchanged with Go programs that embed the
+// interpreter. Values are immutable. The zero Value is unit.
+type Value struct {
+	v interface{} // The runtime value, as the evaluator represents it
+}
+
+// wrap returns the Value for a runtime value.
+func wrap(v interface{}) Value {
+	if _, ok := v.(Unit); ok {
+		return Value{}
+	}
+	return Value{v: v}
+}
+
+// unwrap returns the runtime value of a Value.
+func (v Value) unwrap() interface{} {
+	if v.v == nil {
+		return Unit{}
+	}
+	return v.v
+}
+
+// IntValue returns the Cow integer i.
+func IntValue(i int64) Value { return Value{v: i} }
+
+// FloatValue returns the Cow float f.
+func FloatValue(f float64) Value { return Value{v: f} }
+
+// StringValue returns the Cow string s.
+func StringValue(s string) Value { return Value{v: s} }
+
+// BoolValue returns the Cow boolean b.
+func BoolValue(b bool) Value { return Value{v: b} }
+
+// ArrayValue returns a Cow array of the given elements.
+func ArrayValue(elements ...Value) Value {
+	return Value{v: NewArray(unwrapAll(elements))}
+}
+
+// TupleValue returns a Cow tuple of the given elements.
+// The tuple of no elements is unit.
+func TupleValue(elements ...Value) Value {
+	if len(elements) == 0 {
+		return Value{}
+	}
+	return Value{v: &Tuple{Elements: unwrapAll(elements)}}
+}
+
+// ValueOf converts a Go value to a Cow value.
+// Booleans, strings, integers, floats and slices or arrays of convertible
+// values are converted; a Value is returned as it is, and nil is unit.
+func ValueOf(x interface{}) (Value, error) {
+	switch x := x.(type) {
+	case nil:
+		return Value{}, nil
+	case Value:
+		return x, nil
+	case bool:
+		return BoolValue(x), nil
+	case string:
+		return StringValue(x), nil
+	case int64:
+		return IntValue(x), nil
+	case float64:
+		return FloatValue(x), nil
+	}
+
+	rv := reflect.ValueOf(x)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return IntValue(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return Value{}, fmt.Errorf("integer %d does not fit in i64", rv.Uint())
+		}
+		return IntValue(int64(rv.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return FloatValue(rv.Float()), nil
+	case reflect.Slice, reflect.Array:
+		elements := make([]Value, rv.Len())
+		for i := range elements {
+			element, err := ValueOf(rv.Index(i).Interface())
+			if err != nil {
+				return Value{}, fmt.Errorf("element %d: %w", i, err)
+			}
+			elements[i] = element
+		}
+		return ArrayValue(elements...), nil
+	default:
+		return Value{}, fmt.Errorf("cannot convert %T to a Cow value", x)
+	}
+}
+
+// Kind returns the kind of the value.
+func (v Value) Kind() Kind {
+	switch v.v.(type) {
+	case nil:
+		return UnitKind
+	case int64:
+		return IntKind
+	case float64:
+		return FloatKind
+	case string:
+		return StringKind
+	case bool:
+		return BoolKind
+	case *Array:
+		return ArrayKind
+	case *Tuple:
+		return TupleKind
+	case *Record:
+		return RecordKind
+	case *Variant:
+		return VariantKind
+	default:
+		return FunctionKind
+	}
+}
+
+// AsInt returns the value of an integer. The second result is false if the
+// value is not an integer.
+func (v Value) AsInt() (int64, bool) {
+	i, ok := v.v.(int64)
+	return i, ok
+}
+
+// AsFloat returns the value of a float. The second result is false if the
+// value is not a float.
+func (v Value) AsFloat() (float64, bool) {
+	f, ok := v.v.(float64)
+	return f, ok
+}
+
+// AsString returns the value of a string. The second result is false if the
+// value is not a string.
+func (v Value) AsString() (string, bool) {
+	s, ok := v.v.(string)
+	return s, ok
+}
+
+// AsBool returns the value of a boolean. The second result is false if the
+// value is not a boolean.
+func (v Value) AsBool() (bool, bool) {
+	b, ok := v.v.(bool)
+	return b, ok
+}
+
+// Elements returns the elements of an array or tuple, or the fields of a
+// variant, in order. It returns nil for other values.
+func (v Value) Elements() []Value {
+	switch x := v.v.(type) {
+	case *Array:
+		return wrapAll(x.Values())
+	case *Tuple:
+		return wrapAll(x.Elements)
+	case *Variant:
+		return wrapAll(x.Fields)
+	default:
+		return nil
+	}
+}
+
+// Field returns the value of a record's field. The second result is false if
+// the value is not a record or has no such field.
+func (v Value) Field(name string) (Value, bool) {
+	r, ok := v.v.(*Record)
+	if !ok {
+		return Value{}, false
+	}
+	field, ok := r.Get(name)
+	return wrap(field), ok
+}
+
+// FieldNames returns the field names of a record, in declaration order.
+// It returns nil for other values.
+func (v Value) FieldNames() []string {
+	if r, ok := v.v.(*Record); ok {
+		return append([]string(nil), r.Fields...)
+	}
+	return nil
+}
+
+// TypeName returns the declared type of a record or variant, or "" for
+// other values.
+func (v Value) TypeName() string {
+	switch x := v.v.(type) {
+	case *Record:
+		return x.TypeName
+	case *Variant:
+		return x.TypeName
+	default:
+		return ""
+	}
+}
+
+// Constructor returns the constructor that built a variant, or "" for other
+// values.
+func (v Value) Constructor() string {
+	if variant, ok := v.v.(*Variant); ok {
+		return variant.Constructor
+	}
+	return ""
+}
+
+// Equal reports whether two values are equal, as == does in Cow.
+func (v Value) Equal(other Value) bool {
+	return Equal(v.unwrap(), other.unwrap())
+}
+
+// Export converts the value to Go: an int64, float64, string or bool; nil
+// for unit; a []interface{} for an array or tuple; and a
+// map[string]interface{} for a record. Variants and functions are returned
+// as the Value itself.
+func (v Value) Export() interface{} {
+	switch x := v.v.(type) {
+	case nil:
+		return nil
+	case int64, float64, string, bool:
+		return x
+	case *Array, *Tuple:
+		elements := v.Elements()
+		exported := make([]interface{}, len(elements))
+		for i, element := range elements {
+			exported[i] = element.Export()
+		}
+		return exported
+	case *Record:
+		exported := make(map[string]interface{}, len(x.Fields))
+		for i, field := range x.Fields {
+			exported[field] = wrap(x.Values[i]).Export()
+		}
+		return exported
+	default:
+		return v
+	}
+}
+
+// String formats the value the way it is printed nested in other values,
+// with strings quoted. Functions are formatted as <function>.
+func (v Value) String() string {
+	return FormatElement(v.unwrap())
+}
+
+// wrapAll returns the Values for runtime values.
+func wrapAll(values []interface{}) []Value {
+	wrapped := make([]Value, len(values))
+	for i, value := range values {
+		wrapped[i] = wrap(value)
+	}
+	return wrapped
+}
+
+// unwrapAll returns the runtime values of Values.
+func unwrapAll(values []Value) []interface{} {
+	unwrapped := make([]interface{}, len(values))
+	for i, value := range values {
+		unwrapped[i] = value.unwrap()
+	}
+	return unwrapped
+}
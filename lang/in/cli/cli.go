@@ -1,25 +1,37 @@
 // Package cli provides the command-line interface adapter for the Cow language.
-// This package handles argument parsing and delegates to the runner for execution.
+// This package handles argument parsing and delegates to the runner for
+// execution, or to the REPL when no file is given.
 package cli
 
 import (
 	"fmt"
 	"io"
+	"os"
+	"strconv"
+	"strings"
 
+	"github.com/shadowCow/cow-lang-go/lang/in/repl"
 	"github.com/shadowCow/cow-lang-go/lang/runner"
 )
 
 // Config holds the configuration for the CLI.
 type Config struct {
 	Args   []string  // Command-line arguments (including program name)
+	Input  io.Reader // Input stream for the REPL (nil for no input)
 	Output io.Writer // Output stream for program output
 }
 
 // Run executes the CLI with the given configuration.
 // It parses the arguments, validates them, and delegates to the runner.
+// With no arguments, or the single argument repl, it starts the REPL instead.
 func Run(config Config) error {
+	if len(config.Args) == 1 || (len(config.Args) == 2 && config.Args[1] == "repl") {
+		return runREPL(config)
+	}
+
 	// Parse arguments
 	debug := false
+	backend := runner.TreeWalker
 	var filePath string
 
 	// Skip program name (first argument)
@@ -31,6 +43,12 @@ func Run(config Config) error {
 		if arg == "--debug" {
 			debug = true
 			args = args[1:]
+		} else if arg == "--engine" && len(args) > 1 {
+			backend = runner.Backend(args[1])
+			if backend != runner.TreeWalker && backend != runner.BytecodeVM {
+				return fmt.Errorf("unknown engine %q: expected tree or vm", args[1])
+			}
+			args = args[2:]
 		} else {
 			filePath = arg
 			args = args[1:]
@@ -40,9 +58,109 @@ func Run(config Config) error {
 
 	// Validate that a file path was provided
 	if filePath == "" {
-		return fmt.Errorf("usage: cow-lang [--debug] <file.cow>")
+		return fmt.Errorf("usage: cow-lang [--debug] [--engine tree|vm] <file.cow> | cow-lang repl")
 	}
 
 	// Execute the file using the runner
-	return runner.Run(filePath, config.Output, debug)
+	if err := runner.RunWithBackend(filePath, config.Output, debug, backend); err != nil {
+		return withExcerpts(err)
+	}
+	return nil
+}
+
+// runREPL runs the REPL over the configured input and output.
+func runREPL(config Config) error {
+	input := config.Input
+	if input == nil {
+		input = strings.NewReader("")
+	}
+	return repl.New(config.Output).Run(input)
+}
+
+// excerptError adds source excerpts to the message of an error.
+type excerptError struct {
+	err      error
+	excerpts string
+}
+
+func (e *excerptError) Error() string { return e.err.Error() + e.excerpts }
+
+func (e *excerptError) Unwrap() error { return e.err }
+
+// withExcerpts adds the offending source line, with a caret under the error
+// location, for each located error in err.
+// Returns err unchanged if it has no locations or the source cannot be read.
+func withExcerpts(err error) error {
+	var excerpts strings.Builder
+	sources := make(map[string][]string)
+	for _, sourceErr := range sourceErrors(err) {
+		lines, ok := sources[sourceErr.File]
+		if !ok {
+			content, readErr := os.ReadFile(sourceErr.File)
+			if readErr == nil {
+				lines = strings.Split(string(content), "\n")
+			}
+			sources[sourceErr.File] = lines
+		}
+		excerpts.WriteString(excerpt(sourceErr, lines))
+	}
+	if excerpts.Len() == 0 {
+		return err
+	}
+	return &excerptError{err: err, excerpts: excerpts.String()}
+}
+
+// sourceErrors finds the located errors in an error tree, in order.
+func sourceErrors(err error) []*runner.SourceError {
+	switch e := err.(type) {
+	case *runner.SourceError:
+		return []*runner.SourceError{e}
+	case interface{ Unwrap() []error }:
+		var found []*runner.SourceError
+		for _, inner := range e.Unwrap() {
+			found = append(found, sourceErrors(inner)...)
+		}
+		return found
+	case interface{ Unwrap() error }:
+		return sourceErrors(e.Unwrap())
+	default:
+		return nil
+	}
+}
+
+// excerpt formats the source line of a located error with carets under the
+// error's span (or one caret if the span's end is unknown or on another line):
+//
+//	 --> main.cow:3:5
+//	  |
+//	3 |     xs[i]
+//	  |     ^^^^^
+func excerpt(sourceErr *runner.SourceError, lines []string) string {
+	start, stop := sourceErr.Span.Start, sourceErr.Span.Stop
+	if start.Line < 1 || start.Line > len(lines) {
+		return ""
+	}
+	line := []rune(strings.TrimRight(lines[start.Line-1], "\r"))
+
+	// Indent the carets like the source line, keeping tabs so they line up
+	var indent strings.Builder
+	for i := 0; i < start.Column-1 && i < len(line); i++ {
+		if line[i] == '\t' {
+			indent.WriteRune('\t')
+		} else {
+			indent.WriteRune(' ')
+		}
+	}
+	width := 1
+	if stop.Line == start.Line && stop.Column > start.Column {
+		width = stop.Column - start.Column
+	}
+
+	number := strconv.Itoa(start.Line)
+	gutter := strings.Repeat(" ", len(number))
+	return fmt.Sprintf("\n%s--> %s:%d:%d\n%s |\n%s | %s\n%s | %s%s",
+		gutter, sourceErr.File, start.Line, start.Column,
+		gutter,
+		number, string(line),
+		gutter, indent.String(), strings.Repeat("^", width))
 }
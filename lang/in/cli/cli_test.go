@@ -2,6 +2,8 @@ package cli
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -57,6 +59,66 @@ func TestCLIWithExamples(t *testing.T) {
 			args:     []string{"cow-lang", "../../examples/loops_simple.cow"},
 			expected: "1\n",
 		},
+		{
+			name:     "conditionals",
+			args:     []string{"cow-lang", "../../examples/conditionals.cow"},
+			expected: "positive\nnegative\nzero\n120\n7\n-5\n0\nbranch taken\n",
+		},
+		{
+			name:     "comments",
+			args:     []string{"cow-lang", "../../examples/comments.cow"},
+			expected: "3\n42\n",
+		},
+		{
+			name:     "closures",
+			args:     []string{"cow-lang", "../../examples/closures.cow"},
+			expected: "6\n11\n15\n21\nHello, Cow\n10\n0\n20\n",
+		},
+		{
+			name:     "loops",
+			args:     []string{"cow-lang", "../../examples/loops.cow"},
+			expected: "Infinite loop with break:\n1\nCondition loop:\nLoop body executed\nCounter loop:\n12\nIterating array elements:\n10\n20\n30\n40\n50\n",
+		},
+		{
+			name:     "mutability",
+			args:     []string{"cow-lang", "../../examples/mutability.cow"},
+			expected: "1\n3\n10\n",
+		},
+		{
+			name:     "adts",
+			args:     []string{"cow-lang", "../../examples/adts.cow"},
+			expected: "Some(3)\nNone\nGreen\nRect(2, 3.5)\nSome(\"hi\")\nNode(Leaf(1), 2, Leaf(3))\nSome(Some(7))\nCircle(1.5)\ntrue\nfalse\ntrue\n[Some(1), None]\n",
+		},
+		{
+			name:     "match",
+			args:     []string{"cow-lang", "../../examples/match.cow"},
+			expected: "3\n0\nzero inside\nnested value\nempty inside\nempty\n15\none\nminus one\nmany\nempty\none: x\nx and y\nmore\nyes\n",
+		},
+		{
+			name:     "records",
+			args:     []string{"cow-lang", "../../examples/records.cow"},
+			expected: "{ x: 1.5, y: 2 }\n1.5\n2\n{ x: 3, y: 4 }\n{ x: 10, y: 2 }\n{ x: 1.5, y: 2 }\nAda\n{ name: \"Ada\", age: 37 }\ntrue\nfalse\n{ x: 2.5, y: 3 }\n[{ x: 1.5, y: 2 }, { x: 3, y: 4 }]\nSome({ x: 1.5, y: 2 })\n7\n",
+		},
+		{
+			name:     "tuples",
+			args:     []string{"cow-lang", "../../examples/tuples.cow"},
+			expected: "(1, \"one\")\n1\none\n12\n(7,)\n7\n3\n2\ntrue\nfalse\norigin\non y axis\non x axis\nelsewhere\n()\nlog: started\n()\ntrue\n",
+		},
+		{
+			name:     "types",
+			args:     []string{"cow-lang", "../../examples/types.cow"},
+			expected: "3.5\nSome(10)\nSome(\"a\")\n7\nseven\nfalse\ntrue\n",
+		},
+		{
+			name:     "value_semantics",
+			args:     []string{"cow-lang", "../../examples/value_semantics.cow"},
+			expected: "[100, 2, 3, 4]\n[1, 2, 3]\n[[1, 5], [20, 3]]\n[1]\nrope\n{ owner: \"Grace\", items: [\"lamp\"] }\n{ owner: \"Ada\", items: [] }\n[100, 2, 3, 4, 0]\n[100, 2, 3, 4]\n",
+		},
+		{
+			name:     "comparison",
+			args:     []string{"cow-lang", "../../examples/comparison.cow"},
+			expected: strings.Repeat("true\n", 11) + "[1, 2, 3]\n[\"apple\", \"fig\", \"pear\"]\n[Some(1), Some(3), None]\n[{ x: 1, y: 2 }, { x: 2, y: 1 }]\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -114,7 +176,7 @@ func TestCLIWithDebugFlag(t *testing.T) {
 func TestCLIMissingFile(t *testing.T) {
 	var output bytes.Buffer
 	config := Config{
-		Args:   []string{"cow-lang"},
+		Args:   []string{"cow-lang", "--debug"},
 		Output: &output,
 	}
 
@@ -123,7 +185,7 @@ func TestCLIMissingFile(t *testing.T) {
 		t.Fatal("expected error for missing file argument")
 	}
 
-	expectedError := "usage: cow-lang [--debug] <file.cow>"
+	expectedError := "usage: cow-lang [--debug] [--engine tree|vm] <file.cow> | cow-lang repl"
 	if err.Error() != expectedError {
 		t.Errorf("expected error %q, got %q", expectedError, err.Error())
 	}
@@ -146,3 +208,67 @@ func TestCLIFileNotFound(t *testing.T) {
 		t.Errorf("expected error to mention file name, got: %v", err)
 	}
 }
+
+func TestCLIShowsSourceOfRuntimeError(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "test.cow")
+	source := "let xs = [1, 2, 3]\nlet i = 5\nprintln(xs[i])\n"
+	if err := os.WriteFile(testFile, []byte(source), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	var output bytes.Buffer
+	config := Config{
+		Args:   []string{"cow-lang", testFile},
+		Output: &output,
+	}
+
+	err := Run(config)
+	if err == nil {
+		t.Fatal("expected runtime error")
+	}
+
+	expected := "\n --> " + testFile + ":3:9\n  |\n3 | println(xs[i])\n  |         ^^^^^"
+	if !strings.HasSuffix(err.Error(), expected) {
+		t.Errorf("expected error to end with %q, got %q", expected, err.Error())
+	}
+}
+
+func TestCLIWithEngineFlag(t *testing.T) {
+	var output bytes.Buffer
+	config := Config{
+		Args:   []string{"cow-lang", "--engine", "vm", "../../examples/hello_println.cow"},
+		Output: &output,
+	}
+
+	err := Run(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.String() != "42\n" {
+		t.Errorf("expected output %q, got %q", "42\n", output.String())
+	}
+
+	config.Args = []string{"cow-lang", "--engine", "jit", "../../examples/hello_println.cow"}
+	err = Run(config)
+	if err == nil || !strings.Contains(err.Error(), `unknown engine "jit"`) {
+		t.Errorf("expected unknown engine error, got %v", err)
+	}
+}
+
+func TestCLIStartsREPL(t *testing.T) {
+	for _, args := range [][]string{{"cow-lang"}, {"cow-lang", "repl"}} {
+		var output bytes.Buffer
+		config := Config{
+			Args:   args,
+			Input:  strings.NewReader("let x = 20\nx + 1\n"),
+			Output: &output,
+		}
+
+		if err := Run(config); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		if !strings.Contains(output.String(), "cow> 21\n") {
+			t.Errorf("%v: expected the REPL to print 21, got %q", args, output.String())
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Package repl provides an interactive read-eval-print loop for the Cow language.
+// Every entry is evaluated by the same evaluator, so let bindings, functions
+// and types persist from one entry to the next.
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shadowCow/cow-lang-go/lang/eval"
+	"github.com/shadowCow/cow-lang-go/lang/langdef"
+	"github.com/shadowCow/cow-lang-go/lang/patterns"
+	"github.com/shadowCow/cow-lang-go/lang/runner"
+	"github.com/shadowCow/cow-lang-go/lang/types"
+)
+
+const (
+	prompt             = "cow> "
+	continuationPrompt = "...> "
+)
+
+const help = `Enter statements or expressions to evaluate them.
+An entry with an unclosed {, ( or [ continues on the next line.
+Commands:
+  :tokens <code>  show the tokens of the code
+  :tree <code>    show the parse tree of the code
+  :ast <code>     show the abstract syntax tree of the code
+  :help           show this help
+  :quit           leave the REPL
+`
+
+// REPL is an interactive session: an engine for the language front end, and
+// the checkers and evaluator that remember the entries made so far.
+type REPL struct {
+	output    io.Writer
+	engine    *runner.Engine
+	checker   *types.Checker
+	patterns  *patterns.Checker
+	evaluator *eval.Evaluator
+}
+
+// New creates a REPL writing prompts, results, errors and program output to output.
+func New(output io.Writer) *REPL {
+	return &REPL{
+		output:    output,
+		engine:    runner.New(),
+		checker:   types.NewChecker(),
+		patterns:  patterns.NewChecker(),
+		evaluator: eval.NewEvaluator(output),
+	}
+}
+
+// Run reads entries from input until it ends or :quit is entered.
+// Errors in entries are printed, and the session continues; Run only
+// returns an error if reading input or writing output fails.
+func (r *REPL) Run(input io.Reader) error {
+	scanner := bufio.NewScanner(input)
+	var entry strings.Builder
+
+	for {
+		if entry.Len() == 0 {
+			fmt.Fprint(r.output, prompt)
+		} else {
+			fmt.Fprint(r.output, continuationPrompt)
+		}
+		if !scanner.Scan() {
+			break
+		}
+		line := scanner.Text()
+
+		// Commands are only recognized at the start of an entry
+		if entry.Len() == 0 && strings.HasPrefix(strings.TrimSpace(line), ":") {
+			if quit := r.command(strings.TrimSpace(line)); quit {
+				return nil
+			}
+			continue
+		}
+
+		entry.WriteString(line)
+		entry.WriteString("\n")
+		if r.incomplete(entry.String()) {
+			continue
+		}
+		r.evaluate(entry.String())
+		entry.Reset()
+	}
+
+	// Evaluate an unfinished entry, which reports what is missing
+	fmt.Fprintln(r.output)
+	if strings.TrimSpace(entry.String()) != "" {
+		r.evaluate(entry.String())
+	}
+	return scanner.Err()
+}
+
+// incomplete reports whether source has more opening brackets than closing
+// ones, so the entry continues on the next line. Brackets in strings and
+// comments do not count, and source that cannot be tokenized is complete so
+// that the error is reported.
+func (r *REPL) incomplete(source string) bool {
+	tokens, err := r.engine.Tokenize(source)
+	if err != nil {
+		return false
+	}
+
+	depth := 0
+	for _, token := range tokens {
+		switch token.Type {
+		case string(langdef.TOKEN_LBRACE), string(langdef.TOKEN_LPAREN), string(langdef.TOKEN_LBRACKET):
+			depth++
+		case string(langdef.TOKEN_RBRACE), string(langdef.TOKEN_RPAREN), string(langdef.TOKEN_RBRACKET):
+			depth--
+		}
+	}
+	return depth > 0
+}
+
+// command runs a meta-command. It returns true for :quit.
+func (r *REPL) command(line string) bool {
+	name, source, _ := strings.Cut(line, " ")
+	var err error
+	switch name {
+	case ":quit", ":q":
+		return true
+	case ":help":
+		fmt.Fprint(r.output, help)
+	case ":tokens":
+		err = r.showTokens(source)
+	case ":tree":
+		err = r.showTree(source)
+	case ":ast":
+		err = r.showAST(source)
+	default:
+		err = fmt.Errorf("unknown command %s (enter :help for the list of commands)", name)
+	}
+	if err != nil {
+		fmt.Fprintf(r.output, "error: %v\n", err)
+	}
+	return false
+}
+
+// evaluate checks and evaluates an entry, printing the value of a final
+// expression unless it is unit.
+func (r *REPL) evaluate(source string) {
+	if strings.TrimSpace(source) == "" {
+		return
+	}
+
+	value, err := r.eval(source)
+	if err != nil {
+		fmt.Fprintf(r.output, "error: %v\n", err)
+		return
+	}
+	if value.Kind() != eval.UnitKind {
+		fmt.Fprintln(r.output, value)
+	}
+}
+
+// eval runs an entry through every stage of the pipeline.
+// If any stage fails, the checkers forget the entry, so that its bindings
+// and declarations cannot be used by later entries.
+func (r *REPL) eval(source string) (eval.Value, error) {
+	checked, patterns := r.checker.Snapshot(), r.patterns.Snapshot()
+	value, err := r.run(source)
+	if err != nil {
+		r.checker.Restore(checked)
+		r.patterns.Restore(patterns)
+	}
+	return value, err
+}
+
+// run parses, checks and evaluates an entry.
+func (r *REPL) run(source string) (eval.Value, error) {
+	program, err := r.engine.Parse(source)
+	if err != nil {
+		return eval.Value{}, err
+	}
+	if err := r.checker.Check(program); err != nil {
+		return eval.Value{}, fmt.Errorf("type error: %w", err)
+	}
+	if err := r.patterns.Check(program); err != nil {
+		return eval.Value{}, fmt.Errorf("pattern check error: %w", err)
+	}
+	return r.evaluator.EvalValue(program)
+}
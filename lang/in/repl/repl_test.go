@@ -0,0 +1,131 @@
+package repl
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// run feeds input to a new REPL and returns what it wrote.
+func run(t *testing.T, input string) string {
+	t.Helper()
+	var output bytes.Buffer
+	if err := New(&output).Run(strings.NewReader(input)); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	return output.String()
+}
+
+// TestREPL tests sessions of entries, checking everything the REPL writes.
+func TestREPL(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "expression results",
+			input:    "1 + 2\n\"cow\"\n[1, 2]\n",
+			expected: "cow> 3\ncow> \"cow\"\ncow> [1, 2]\ncow> \n",
+		},
+		{
+			name:     "bindings persist between entries",
+			input:    "let x = 5\nlet mut y = x * 2\ny = y + 1\ny\n",
+			expected: "cow> cow> cow> cow> 11\ncow> \n",
+		},
+		{
+			name:     "functions and types persist between entries",
+			input:    "type Shape = Circle of f64 | Square of f64\nfn area(s) {\n  match s {\n    Circle(r) => 3.0 * r * r,\n    Square(w) => w * w\n  }\n}\narea(Square(2.0))\n",
+			expected: "cow> cow> ...> ...> ...> ...> ...> cow> 4\ncow> \n",
+		},
+		{
+			name:     "println output and unit results",
+			input:    "println(\"hi\")\n()\n",
+			expected: "cow> hi\ncow> cow> \n",
+		},
+		{
+			name:     "braces in strings and comments do not continue the entry",
+			input:    "\"{\" // {\n",
+			expected: "cow> \"{\"\ncow> \n",
+		},
+		{
+			name:     "errors do not end the session",
+			input:    "let s: string = 1\n1 / 0\nmissing\n2\n",
+			expected: "cow> error: type error: 1:17: expected string, found i64\ncow> error: 1:1: division by zero\ncow> error: type error: 1:1: undefined variable: missing\ncow> 2\ncow> \n",
+		},
+		{
+			name:     "failed entries are forgotten",
+			input:    "let x = 1 + \"s\"\nx\nlet a = [1]\nlet y = a[5]\ny\nlet p = match 1 { 1 => 2 }\np\nnever\n",
+			expected: "cow> error: type error: 1:9: operator + operands have different types: i64 and string\ncow> error: type error: 1:1: undefined variable: x\ncow> cow> error: 1:9: error evaluating let statement for 'y': array index out of bounds: index 5, length 1\ncow> error: type error: 1:1: undefined variable: y\ncow> error: pattern check error: 1:9: match is not exhaustive, missing cases: _\ncow> error: type error: 1:1: undefined variable: p\ncow> error: type error: 1:1: undefined variable: never\ncow> \n",
+		},
+		{
+			name:     "unfinished entry at end of input",
+			input:    "fn f() {\n",
+			expected: "cow> ...> \nerror: parser error: expected `!`, `(`, `-`, `[`, `break`, `continue`, `false`, `fn`, `for`, `if`, `let`, `match`, `return`, `true`, `{`, `}`, a newline, a number, a string or an identifier, found end of input\n",
+		},
+		{
+			name:     "quit",
+			input:    ":quit\n1\n",
+			expected: "cow> ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := run(t, tt.input); actual != tt.expected {
+				t.Errorf("Expected output:\n%q\ngot:\n%q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// TestREPLCommands tests the meta-commands that show the front end's stages.
+func TestREPLCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		contains []string
+	}{
+		{
+			name:     "tokens",
+			input:    ":tokens let x = 1 // one\n",
+			contains: []string{"1:1 LET \"let\"\n1:5 IDENTIFIER \"x\"\n1:7 EQUALS \"=\"\n1:9 INT_DECIMAL \"1\"\ncow> "},
+		},
+		{
+			name:     "parse tree",
+			input:    ":tree 1\n",
+			contains: []string{"Program\n", "      Literal\n", "INT_DECIMAL \"1\"\n", "MulRest ε\n"},
+		},
+		{
+			name:     "AST",
+			input:    ":ast let y = f(2)\n",
+			contains: []string{"LetStatement\n  Name: \"y\"\n  Value:\n    FunctionCall\n      Name: \"f\"\n      Arguments:\n        IntLiteral\n          Value: 2\n"},
+		},
+		{
+			name:     "commands do not evaluate code",
+			input:    ":ast let z = 1\nz\n",
+			contains: []string{"error: type error: 1:1: undefined variable: z"},
+		},
+		{
+			name:     "errors",
+			input:    ":tree let\n:nope\n",
+			contains: []string{"error: parser error:", "error: unknown command :nope"},
+		},
+		{
+			name:     "help",
+			input:    ":help\n",
+			contains: []string{":tokens <code>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := run(t, tt.input)
+			for _, want := range tt.contains {
+				if !strings.Contains(actual, want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, actual)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package repl
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+	"github.com/shadowCow/cow-lang-go/lang/langdef"
+	"github.com/shadowCow/cow-lang-go/tooling/parsetree"
+)
+
+// showTokens prints the significant tokens of source code, one per line.
+func (r *REPL) showTokens(source string) error {
+	tokens, err := r.engine.Tokenize(source)
+	if err != nil {
+		return err
+	}
+
+	trivia := make(map[string]bool)
+	for _, name := range langdef.TriviaTokens() {
+		trivia[name] = true
+	}
+	for _, token := range tokens {
+		if !trivia[token.Type] {
+			fmt.Fprintf(r.output, "%d:%d %s %q\n", token.Line, token.Column, token.Type, token.Value)
+		}
+	}
+	return nil
+}
+
+// showTree prints the parse tree of source code, one node per line,
+// indented by depth.
+func (r *REPL) showTree(source string) error {
+	tree, err := r.engine.ParseTree(source)
+	if err != nil {
+		return err
+	}
+	r.printTree(tree.Root, 0)
+	return nil
+}
+
+func (r *REPL) printTree(node parsetree.ParseTree, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch n := node.(type) {
+	case *parsetree.NonTerminalNode:
+		fmt.Fprintf(r.output, "%s%s\n", indent, n.Symbol)
+		for _, child := range n.Children {
+			r.printTree(child, depth+1)
+		}
+	case *parsetree.TerminalNode:
+		fmt.Fprintf(r.output, "%s%s %q\n", indent, n.Token.Type, n.Token.Value)
+	case *parsetree.EmptyNode:
+		fmt.Fprintf(r.output, "%s%s ε\n", indent, n.Symbol)
+	}
+}
+
+// showAST prints the AST of source code: each node's type, then its fields,
+// indented by depth. Spans, tokens and empty fields are left out.
+func (r *REPL) showAST(source string) error {
+	program, err := r.engine.Parse(source)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range program.Statements {
+		r.printNode(reflect.ValueOf(stmt), 0)
+	}
+	return nil
+}
+
+// printNode prints an AST node, or a list of nodes, at the given depth.
+func (r *REPL) printNode(v reflect.Value, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Slice {
+		for i := 0; i < v.Len(); i++ {
+			r.printNode(v.Index(i), depth)
+		}
+		return
+	}
+	if v.Kind() != reflect.Struct {
+		fmt.Fprintf(r.output, "%s%v\n", indent, v.Interface())
+		return
+	}
+
+	fmt.Fprintf(r.output, "%s%s\n", indent, v.Type().Name())
+	for i := 0; i < v.NumField(); i++ {
+		field, value := v.Type().Field(i), v.Field(i)
+		if field.Name == "Token" || field.Type == reflect.TypeOf(ast.Span{}) || value.IsZero() {
+			continue
+		}
+		if value.Kind() == reflect.Slice && value.Len() == 0 {
+			continue
+		}
+
+		switch value.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Struct:
+			fmt.Fprintf(r.output, "%s  %s:\n", indent, field.Name)
+			r.printNode(value, depth+2)
+		case reflect.Slice:
+			if value.Type().Elem().Kind() == reflect.String {
+				fmt.Fprintf(r.output, "%s  %s: %s\n", indent, field.Name, strings.Join(value.Interface().([]string), ", "))
+				continue
+			}
+			fmt.Fprintf(r.output, "%s  %s:\n", indent, field.Name)
+			r.printNode(value, depth+2)
+		case reflect.String:
+			fmt.Fprintf(r.output, "%s  %s: %q\n", indent, field.Name, value.String())
+		default:
+			fmt.Fprintf(r.output, "%s  %s: %v\n", indent, field.Name, value.Interface())
+		}
+	}
+}
@@ -25,15 +25,15 @@ func TestSimplePattern(t *testing.T) {
 
 	dfa := automata.CompileLexicalGrammar(lexGrammar)
 
-	fmt.Printf("DFA Initial State: %s\n", dfa.InitialState)
+	fmt.Printf("DFA Initial State: %d\n", dfa.InitialState)
 	fmt.Printf("DFA States: %d\n", len(dfa.States))
 	fmt.Printf("DFA Accepting States: %d\n", len(dfa.AcceptingStates))
 
 	// Print all states and their transitions
 	for stateName, state := range dfa.States {
-		fmt.Printf("\nState %s:\n", stateName)
+		fmt.Printf("\nState %d:\n", stateName)
 		for char, next := range state.Transitions {
-			fmt.Printf("  '%c' -> %s\n", char, next)
+			fmt.Printf("  '%c' -> %d\n", char, next)
 		}
 		if len(state.Transitions) == 0 {
 			fmt.Printf("  (no transitions)\n")
@@ -43,7 +43,7 @@ func TestSimplePattern(t *testing.T) {
 	// Print accepting states
 	fmt.Printf("\nAccepting States:\n")
 	for stateName, acceptInfo := range dfa.AcceptingStates {
-		fmt.Printf("  %s: token=%s, priority=%d\n", stateName, acceptInfo.TokenType, acceptInfo.Priority)
+		fmt.Printf("  %d: token=%s, priority=%d\n", stateName, acceptInfo.TokenType, acceptInfo.Priority)
 	}
 
 	if len(dfa.States) == 0 {
@@ -0,0 +1,24 @@
+// Command gentables writes the Cow language's lexer DFA and LL(1) parse
+// table as Go source code. It is run by go generate in package langdef.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/shadowCow/cow-lang-go/lang/langdef"
+)
+
+func main() {
+	output := flag.String("o", "tables_gen.go", "file to write")
+	flag.Parse()
+
+	source, err := langdef.GenerateTables()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
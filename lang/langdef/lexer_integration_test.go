@@ -142,3 +142,36 @@ func TestPriorityHandling(t *testing.T) {
 		})
 	}
 }
+
+// TestLexUnicodeText tests that strings and comments may contain any
+// Unicode character.
+func TestLexUnicodeText(t *testing.T) {
+	dfa := automata.CompileLexicalGrammar(GetLexical())
+
+	tests := []struct {
+		name         string
+		input        string
+		expectedType string
+	}{
+		{"string with accent", `"café"`, "STRING"},
+		{"string with emoji", `"moo 🐄"`, "STRING"},
+		{"raw string", "`日本\n語`", "RAW_STRING"},
+		{"line comment", "// Привет", "LINE_COMMENT"},
+		{"block comment", "/* ünïcödé */", "BLOCK_COMMENT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.NewLexer(dfa, tt.input).Tokenize()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(tokens) != 1 {
+				t.Fatalf("Expected 1 token, got %d", len(tokens))
+			}
+			if tokens[0].Type != tt.expectedType || tokens[0].Value != tt.input {
+				t.Errorf("Expected %s %q, got %s %q", tt.expectedType, tt.input, tokens[0].Type, tokens[0].Value)
+			}
+		})
+	}
+}
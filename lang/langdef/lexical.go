@@ -18,11 +18,18 @@ const (
 
 	// Keywords
 	TOKEN_LET      grammar.TokenType = "LET"      // let keyword for variable declaration
+	TOKEN_MUT      grammar.TokenType = "MUT"      // mut keyword for mutable bindings
 	TOKEN_FN       grammar.TokenType = "FN"       // fn keyword for function declaration
 	TOKEN_RETURN   grammar.TokenType = "RETURN"   // return keyword for function return
 	TOKEN_FOR      grammar.TokenType = "FOR"      // for keyword for loops
 	TOKEN_BREAK    grammar.TokenType = "BREAK"    // break keyword to exit loops
 	TOKEN_CONTINUE grammar.TokenType = "CONTINUE" // continue keyword to skip to next iteration
+	TOKEN_IF       grammar.TokenType = "IF"       // if keyword for conditional expressions
+	TOKEN_ELSE     grammar.TokenType = "ELSE"     // else keyword for conditional alternatives
+	TOKEN_TYPE     grammar.TokenType = "TYPE"     // type keyword for type declarations
+	TOKEN_OF       grammar.TokenType = "OF"       // of keyword for variant payloads
+	TOKEN_MATCH    grammar.TokenType = "MATCH"    // match keyword for pattern matching
+	TOKEN_WITH     grammar.TokenType = "WITH"     // with keyword for record updates
 	TOKEN_TRUE     grammar.TokenType = "TRUE"     // true boolean literal
 	TOKEN_FALSE    grammar.TokenType = "FALSE"    // false boolean literal
 
@@ -52,6 +59,12 @@ const (
 	// Assignment
 	TOKEN_EQUALS grammar.TokenType = "EQUALS" // =
 
+	// Pattern matching
+	TOKEN_FAT_ARROW grammar.TokenType = "FAT_ARROW" // => (separates a match pattern from its body)
+
+	// Type annotations
+	TOKEN_ARROW grammar.TokenType = "ARROW" // -> (precedes a return type)
+
 	// Punctuation
 	TOKEN_LPAREN   grammar.TokenType = "LPAREN"   // (
 	TOKEN_RPAREN   grammar.TokenType = "RPAREN"   // )
@@ -61,11 +74,17 @@ const (
 	TOKEN_RBRACKET grammar.TokenType = "RBRACKET" // ]
 	TOKEN_COMMA    grammar.TokenType = "COMMA"    // ,
 	TOKEN_DOT      grammar.TokenType = "DOT"      // .
+	TOKEN_PIPE     grammar.TokenType = "PIPE"     // | (separates variants)
+	TOKEN_COLON    grammar.TokenType = "COLON"    // : (record fields)
 
 	// Whitespace and separators
 	TOKEN_NEWLINE    grammar.TokenType = "NEWLINE"    // \n (statement separator)
 	TOKEN_WHITESPACE grammar.TokenType = "WHITESPACE" // spaces, tabs (to be skipped)
 
+	// Comments (skipped by the parser, kept as trivia)
+	TOKEN_LINE_COMMENT  grammar.TokenType = "LINE_COMMENT"  // // to end of line
+	TOKEN_BLOCK_COMMENT grammar.TokenType = "BLOCK_COMMENT" // /* ... */, may nest
+
 	// TODO: Add remaining tokens for Phase 1
 	// - Keywords: TOKEN_KEYWORD_FN, TOKEN_KEYWORD_LET, TOKEN_KEYWORD_MATCH, etc.
 	// - Operators: TOKEN_PLUS, TOKEN_MINUS, TOKEN_STAR, etc.
@@ -74,6 +93,17 @@ const (
 	// - Boolean literals: TOKEN_TRUE, TOKEN_FALSE
 )
 
+// TriviaTokens returns the token types that carry no syntactic meaning.
+// The parser skips them, but keeps them attached to the following token so
+// tools such as formatters can recover comments.
+func TriviaTokens() []string {
+	return []string{
+		string(TOKEN_WHITESPACE),
+		string(TOKEN_LINE_COMMENT),
+		string(TOKEN_BLOCK_COMMENT),
+	}
+}
+
 // GetLexicalGrammar returns the lexical grammar for the Cow language.
 // This defines how the source text is tokenized.
 func GetLexicalGrammar() grammar.LexicalGrammar {
@@ -154,6 +184,11 @@ func GetLexicalGrammar() grammar.LexicalGrammar {
 				Pattern:  grammar.Literal("let"),
 				Priority: 5,
 			},
+			{
+				Name:     TOKEN_MUT,
+				Pattern:  grammar.Literal("mut"),
+				Priority: 5,
+			},
 			{
 				Name:     TOKEN_TRUE,
 				Pattern:  grammar.Literal("true"),
@@ -189,6 +224,36 @@ func GetLexicalGrammar() grammar.LexicalGrammar {
 				Pattern:  grammar.Literal("continue"),
 				Priority: 5,
 			},
+			{
+				Name:     TOKEN_IF,
+				Pattern:  grammar.Literal("if"),
+				Priority: 5,
+			},
+			{
+				Name:     TOKEN_ELSE,
+				Pattern:  grammar.Literal("else"),
+				Priority: 5,
+			},
+			{
+				Name:     TOKEN_TYPE,
+				Pattern:  grammar.Literal("type"),
+				Priority: 5,
+			},
+			{
+				Name:     TOKEN_OF,
+				Pattern:  grammar.Literal("of"),
+				Priority: 5,
+			},
+			{
+				Name:     TOKEN_MATCH,
+				Pattern:  grammar.Literal("match"),
+				Priority: 5,
+			},
+			{
+				Name:     TOKEN_WITH,
+				Pattern:  grammar.Literal("with"),
+				Priority: 5,
+			},
 
 			// String literals
 			// Regular strings with escape sequences: "..."
@@ -215,7 +280,8 @@ func GetLexicalGrammar() grammar.LexicalGrammar {
 					},
 					grammar.Literal("\""),
 				},
-				Priority: 3,
+				Priority:    3,
+				DisplayName: "a string",
 			},
 
 			// Raw strings (can span multiple lines): `...`
@@ -228,6 +294,25 @@ func GetLexicalGrammar() grammar.LexicalGrammar {
 					},
 					grammar.Literal("`"),
 				},
+				Priority:    3,
+				DisplayName: "a string",
+			},
+
+			// Comments
+			// Line comments run to the end of the line (the newline is not included)
+			{
+				Name: TOKEN_LINE_COMMENT,
+				Pattern: grammar.LexSequence{
+					grammar.Literal("//"),
+					grammar.LexZeroOrMore{Inner: grammar.AnyCharExcept{'\n'}},
+				},
+				Priority: 3,
+			},
+
+			// Block comments can span lines and nest: /* outer /* inner */ outer */
+			{
+				Name:     TOKEN_BLOCK_COMMENT,
+				Pattern:  grammar.NestedDelimited{Open: "/*", Close: "*/"},
 				Priority: 3,
 			},
 
@@ -242,7 +327,8 @@ func GetLexicalGrammar() grammar.LexicalGrammar {
 					},
 					grammar.LexZeroOrMore{Inner: letterOrDigit},
 				},
-				Priority: 4,
+				Priority:    4,
+				DisplayName: "an identifier",
 			},
 
 			// Number literals
@@ -256,7 +342,8 @@ func GetLexicalGrammar() grammar.LexicalGrammar {
 					grammar.Literal("0x"),
 					grammar.LexOneOrMore{Inner: hexDigitOrUnderscore},
 				},
-				Priority: 3,
+				Priority:    3,
+				DisplayName: "a number",
 			},
 
 			// Binary integers: 0b1010, 0b1111_0000
@@ -266,7 +353,8 @@ func GetLexicalGrammar() grammar.LexicalGrammar {
 					grammar.Literal("0b"),
 					grammar.LexOneOrMore{Inner: binaryDigitOrUnderscore},
 				},
-				Priority: 3,
+				Priority:    3,
+				DisplayName: "a number",
 			},
 
 			// Float literals: 3.14, 1.5e10, 2e-5, 3.14e-8
@@ -286,51 +374,71 @@ func GetLexicalGrammar() grammar.LexicalGrammar {
 						exponent,
 					},
 				},
-				Priority: 2,
+				Priority:    2,
+				DisplayName: "a number",
 			},
 
 			// Decimal integers: 42, 1_000_000
 			{
-				Name:     TOKEN_INT_DECIMAL,
-				Pattern:  integerPart,
-				Priority: 1,
+				Name:        TOKEN_INT_DECIMAL,
+				Pattern:     integerPart,
+				Priority:    1,
+				DisplayName: "a number",
 			},
 
 			// Operators
 			// Multi-character operators must have higher priority than single-character ones
+			// Only tokens that are nothing but binary operators are shown as "an operator"
+			// in syntax errors; < and > also bracket type arguments, and - negates
 
 			// Comparison operators (2-character, priority 2)
 			{
-				Name:     TOKEN_EQUAL_EQUAL,
-				Pattern:  grammar.Literal("=="),
-				Priority: 2,
+				Name:        TOKEN_EQUAL_EQUAL,
+				Pattern:     grammar.Literal("=="),
+				Priority:    2,
+				DisplayName: "an operator",
 			},
 			{
-				Name:     TOKEN_NOT_EQUAL,
-				Pattern:  grammar.Literal("!="),
+				Name:     TOKEN_FAT_ARROW,
+				Pattern:  grammar.Literal("=>"),
 				Priority: 2,
 			},
 			{
-				Name:     TOKEN_LESS_EQUAL,
-				Pattern:  grammar.Literal("<="),
+				Name:     TOKEN_ARROW,
+				Pattern:  grammar.Literal("->"),
 				Priority: 2,
 			},
 			{
-				Name:     TOKEN_GREATER_EQUAL,
-				Pattern:  grammar.Literal(">="),
-				Priority: 2,
+				Name:        TOKEN_NOT_EQUAL,
+				Pattern:     grammar.Literal("!="),
+				Priority:    2,
+				DisplayName: "an operator",
+			},
+			{
+				Name:        TOKEN_LESS_EQUAL,
+				Pattern:     grammar.Literal("<="),
+				Priority:    2,
+				DisplayName: "an operator",
+			},
+			{
+				Name:        TOKEN_GREATER_EQUAL,
+				Pattern:     grammar.Literal(">="),
+				Priority:    2,
+				DisplayName: "an operator",
 			},
 
 			// Logical operators (2-character, priority 2)
 			{
-				Name:     TOKEN_AND,
-				Pattern:  grammar.Literal("&&"),
-				Priority: 2,
+				Name:        TOKEN_AND,
+				Pattern:     grammar.Literal("&&"),
+				Priority:    2,
+				DisplayName: "an operator",
 			},
 			{
-				Name:     TOKEN_OR,
-				Pattern:  grammar.Literal("||"),
-				Priority: 2,
+				Name:        TOKEN_OR,
+				Pattern:     grammar.Literal("||"),
+				Priority:    2,
+				DisplayName: "an operator",
 			},
 
 			// Single-character operators (priority 1)
@@ -355,9 +463,10 @@ func GetLexicalGrammar() grammar.LexicalGrammar {
 				Priority: 1,
 			},
 			{
-				Name:     TOKEN_PLUS,
-				Pattern:  grammar.Literal("+"),
-				Priority: 1,
+				Name:        TOKEN_PLUS,
+				Pattern:     grammar.Literal("+"),
+				Priority:    1,
+				DisplayName: "an operator",
 			},
 			{
 				Name:     TOKEN_MINUS,
@@ -365,19 +474,22 @@ func GetLexicalGrammar() grammar.LexicalGrammar {
 				Priority: 1,
 			},
 			{
-				Name:     TOKEN_MULTIPLY,
-				Pattern:  grammar.Literal("*"),
-				Priority: 1,
+				Name:        TOKEN_MULTIPLY,
+				Pattern:     grammar.Literal("*"),
+				Priority:    1,
+				DisplayName: "an operator",
 			},
 			{
-				Name:     TOKEN_DIVIDE,
-				Pattern:  grammar.Literal("/"),
-				Priority: 1,
+				Name:        TOKEN_DIVIDE,
+				Pattern:     grammar.Literal("/"),
+				Priority:    1,
+				DisplayName: "an operator",
 			},
 			{
-				Name:     TOKEN_MODULO,
-				Pattern:  grammar.Literal("%"),
-				Priority: 1,
+				Name:        TOKEN_MODULO,
+				Pattern:     grammar.Literal("%"),
+				Priority:    1,
+				DisplayName: "an operator",
 			},
 
 			// Punctuation - single character tokens
@@ -421,12 +533,23 @@ func GetLexicalGrammar() grammar.LexicalGrammar {
 				Pattern:  grammar.Literal("."),
 				Priority: 1,
 			},
+			{
+				Name:     TOKEN_PIPE,
+				Pattern:  grammar.Literal("|"),
+				Priority: 1,
+			},
+			{
+				Name:     TOKEN_COLON,
+				Pattern:  grammar.Literal(":"),
+				Priority: 1,
+			},
 
 			// Newline - statement separator (higher priority than whitespace)
 			{
-				Name:     TOKEN_NEWLINE,
-				Pattern:  grammar.LexOneOrMore{Inner: grammar.Literal("\n")},
-				Priority: 2,
+				Name:        TOKEN_NEWLINE,
+				Pattern:     grammar.LexOneOrMore{Inner: grammar.Literal("\n")},
+				Priority:    2,
+				DisplayName: "a newline",
 			},
 
 			// Whitespace - one or more non-newline whitespace characters
@@ -18,6 +18,8 @@ const (
 	// Statements
 	SYM_STATEMENT            grammar.Symbol = "Statement"
 	SYM_LET_STATEMENT        grammar.Symbol = "LetStatement"
+	SYM_MUT_MODIFIER         grammar.Symbol = "MutModifier"
+	SYM_LET_TARGET           grammar.Symbol = "LetTarget"
 	SYM_EXPRESSION_STATEMENT grammar.Symbol = "ExpressionStatement"
 	SYM_FUNCTION_DEF         grammar.Symbol = "FunctionDef"
 	SYM_RETURN_STATEMENT     grammar.Symbol = "ReturnStatement"
@@ -25,6 +27,16 @@ const (
 	SYM_FOR_CONDITION        grammar.Symbol = "ForCondition"
 	SYM_BREAK_STATEMENT      grammar.Symbol = "BreakStatement"
 	SYM_CONTINUE_STATEMENT   grammar.Symbol = "ContinueStatement"
+	SYM_IF_EXPRESSION        grammar.Symbol = "IfExpression"
+	SYM_ELSE_CLAUSE          grammar.Symbol = "ElseClause"
+	SYM_ELSE_BODY            grammar.Symbol = "ElseBody"
+	SYM_MATCH_EXPRESSION     grammar.Symbol = "MatchExpression"
+	SYM_MATCH_ARMS           grammar.Symbol = "MatchArms"
+	SYM_MATCH_ARM            grammar.Symbol = "MatchArm"
+	SYM_MATCH_ARM_REST       grammar.Symbol = "MatchArmRest"
+	SYM_BRACE_EXPRESSION     grammar.Symbol = "BraceExpression"
+	SYM_BRACE_STATEMENTS     grammar.Symbol = "BraceStatements"
+	SYM_BRACE_STMT_REST      grammar.Symbol = "BraceStmtRest"
 	SYM_BLOCK                grammar.Symbol = "Block"
 	SYM_BLOCK_STATEMENTS     grammar.Symbol = "BlockStatements"
 	SYM_BLOCK_STMT_REST      grammar.Symbol = "BlockStmtRest"
@@ -65,8 +77,11 @@ const (
 	SYM_UNARY_OP grammar.Symbol = "UnaryOp"
 
 	// Primary (highest precedence)
-	SYM_PRIMARY      grammar.Symbol = "Primary"
-	SYM_PRIMARY_REST grammar.Symbol = "PrimaryRest"
+	SYM_PRIMARY        grammar.Symbol = "Primary"
+	SYM_PRIMARY_REST   grammar.Symbol = "PrimaryRest"
+	SYM_PAREN_CONTENT  grammar.Symbol = "ParenContent"
+	SYM_TUPLE_REST     grammar.Symbol = "TupleRest"
+	SYM_TUPLE_ELEMENTS grammar.Symbol = "TupleElements"
 
 	// Function calls and parameters
 	SYM_FUNCTION_CALL   grammar.Symbol = "FunctionCall"
@@ -77,6 +92,10 @@ const (
 	SYM_PARAMETER_REST  grammar.Symbol = "ParameterRest"
 	SYM_FUNCTION_LITERAL grammar.Symbol = "FunctionLiteral"
 
+	// Type annotations
+	SYM_TYPE_ANNOTATION grammar.Symbol = "TypeAnnotation"
+	SYM_RETURN_TYPE     grammar.Symbol = "ReturnType"
+
 	// Literal values
 	SYM_LITERAL grammar.Symbol = "Literal"
 
@@ -92,20 +111,75 @@ const (
 	SYM_INDEX_CHAIN_REST  grammar.Symbol = "IndexChainRest"
 	SYM_ASSIGNMENT        grammar.Symbol = "Assignment"
 	SYM_ASSIGNMENT_REST   grammar.Symbol = "AssignmentRest"
+
+	// Patterns (match arms)
+	SYM_PATTERN         grammar.Symbol = "Pattern"
+	SYM_PATTERN_ARGS    grammar.Symbol = "PatternArgs"
+	SYM_PATTERN_LIST    grammar.Symbol = "PatternList"
+	SYM_PATTERN_REST    grammar.Symbol = "PatternRest"
+
+	// Type declarations (algebraic data types and records)
+	SYM_TYPE_DECL         grammar.Symbol = "TypeDecl"
+	SYM_TYPE_PARAMS       grammar.Symbol = "TypeParams"
+	SYM_TYPE_PARAM_REST   grammar.Symbol = "TypeParamRest"
+	SYM_TYPE_BODY         grammar.Symbol = "TypeBody"
+	SYM_VARIANT_LIST      grammar.Symbol = "VariantList"
+	SYM_VARIANT           grammar.Symbol = "Variant"
+	SYM_VARIANT_PAYLOAD   grammar.Symbol = "VariantPayload"
+	SYM_VARIANT_REST      grammar.Symbol = "VariantRest"
+	SYM_RECORD_FIELDS     grammar.Symbol = "RecordFields"
+	SYM_RECORD_FIELD_REST grammar.Symbol = "RecordFieldRest"
+	SYM_TYPE_EXPR         grammar.Symbol = "TypeExpr"
+	SYM_TYPE_ARGS         grammar.Symbol = "TypeArgs"
+	SYM_TYPE_EXPR_REST    grammar.Symbol = "TypeExprRest"
+	SYM_TYPE_EXPR_LIST    grammar.Symbol = "TypeExprList"
 )
 
 // GetSyntacticGrammar returns the syntactic grammar for the Cow language.
 // This defines how tokens are organized into language constructs.
 //
 // Grammar (LL(1) - left-factored with operator precedence):
-//   Program -> Statement ProgramRest
-//   ProgramRest -> NEWLINE ProgramRest2 | ε
-//   ProgramRest2 -> Statement ProgramRest | ε
+//   Program -> NEWLINE Program | TopLevelItem TopLevelItemRest | ε
+//   TopLevelItemRest -> NEWLINE TopLevelItemRest2 | ε
+//   TopLevelItemRest2 -> NEWLINE TopLevelItemRest2 | TopLevelItem TopLevelItemRest | ε
+//   TopLevelItem -> FunctionDef | TypeDecl | LetStatement | TopLevelExpression
 //   Statement -> LetStatement | ExpressionStatement
-//   LetStatement -> LET IDENTIFIER EQUALS Expression
+//   LetStatement -> LET MutModifier LetTarget TypeAnnotation EQUALS Expression
+//   MutModifier -> MUT | ε
+//   LetTarget -> IDENTIFIER | LPAREN PatternList RPAREN
+//   TypeAnnotation -> COLON TypeExpr | ε
+//
+//   FunctionDef -> FN IDENTIFIER TypeParams LPAREN ParameterList RPAREN ReturnType Block
+//   FunctionLiteral -> FN LPAREN ParameterList RPAREN ReturnType Block
+//   ParameterList -> IDENTIFIER TypeAnnotation ParameterRest | ε
+//   ParameterRest -> COMMA IDENTIFIER TypeAnnotation ParameterRest | ε
+//   ReturnType -> ARROW TypeExpr | ε
 //   ExpressionStatement -> Expression
 //
-//   Expression -> LogicalOr
+//   TypeDecl -> TYPE IDENTIFIER TypeParams EQUALS TypeBody
+//   TypeParams -> LESS_THAN IDENTIFIER TypeParamRest GREATER_THAN | ε
+//   TypeParamRest -> COMMA IDENTIFIER TypeParamRest | ε
+//   TypeBody -> PIPE VariantList | VariantList | LBRACE RecordFields RBRACE
+//   RecordFields -> NEWLINE RecordFields | IDENTIFIER COLON TypeExpr RecordFieldRest | ε
+//   RecordFieldRest -> COMMA RecordFields | NEWLINE RecordFields | ε
+//   VariantList -> Variant VariantRest
+//   Variant -> IDENTIFIER VariantPayload
+//   VariantPayload -> OF TypeExpr | ε
+//   VariantRest -> PIPE Variant VariantRest | ε
+//   TypeExpr -> IDENTIFIER TypeArgs | LPAREN TypeExprList RPAREN | LBRACKET TypeExpr RBRACKET
+//             | FN LPAREN TypeExprList RPAREN ARROW TypeExpr
+//   TypeExprList -> TypeExpr TypeExprRest | ε
+//   TypeArgs -> LESS_THAN TypeExpr TypeExprRest GREATER_THAN | ε
+//   TypeExprRest -> COMMA TypeExpr TypeExprRest | ε
+//
+//   Assignment -> LogicalOr AssignmentRest
+//   AssignmentRest -> EQUALS Assignment | COLON Expression | WITH Assignment | ε
+//
+//   BraceExpression -> LBRACE BraceStatements RBRACE
+//   BraceStatements -> NEWLINE BraceStatements | Statement BraceStmtRest | ε
+//   BraceStmtRest -> COMMA BraceStatements | NEWLINE BraceStatements | ε
+//
+//   Expression -> Assignment | FunctionLiteral | BraceExpression
 //   LogicalOr -> LogicalAnd LogicalOrRest
 //   LogicalOrRest -> OR LogicalAnd LogicalOrRest | ε
 //   LogicalAnd -> Equality LogicalAndRest
@@ -124,7 +198,21 @@ const (
 //   MulOp -> MULTIPLY | DIVIDE | MODULO
 //   Unary -> UnaryOp Unary | Primary
 //   UnaryOp -> NOT | MINUS
-//   Primary -> IDENTIFIER PrimaryRest | Literal | LPAREN Expression RPAREN
+//   Primary -> IDENTIFIER PrimaryRest | Literal | IfExpression | MatchExpression | LPAREN ParenContent RPAREN
+//   ParenContent -> Expression TupleRest | ε
+//   TupleRest -> COMMA TupleElements | ε
+//   TupleElements -> Expression TupleRest | ε
+//   MatchExpression -> MATCH Expression LBRACE MatchArms RBRACE
+//   MatchArms -> NEWLINE MatchArms | MatchArm MatchArmRest | ε
+//   MatchArmRest -> COMMA MatchArms | NEWLINE MatchArms | ε
+//   MatchArm -> Pattern FAT_ARROW Expression
+//   Pattern -> IDENTIFIER PatternArgs | Literal | MINUS Literal | LBRACKET PatternList RBRACKET | LPAREN PatternList RPAREN
+//   PatternArgs -> LPAREN Pattern PatternRest RPAREN | ε
+//   PatternList -> Pattern PatternRest | ε
+//   PatternRest -> COMMA Pattern PatternRest | ε
+//   IfExpression -> IF Expression Block ElseClause
+//   ElseClause -> ELSE ElseBody | ε
+//   ElseBody -> IfExpression | Block
 //   PrimaryRest -> LPAREN Arguments RPAREN | ε
 //   Arguments -> ε | ArgumentList
 //   ArgumentList -> Expression ArgumentRest
@@ -145,9 +233,18 @@ func GetSyntacticGrammar() grammar.SyntacticGrammar {
 		StartSymbol: SYM_PROGRAM,
 		Productions: map[grammar.Symbol]grammar.ProductionRule{
 			// Program is a sequence of top-level items (functions or statements)
-			SYM_PROGRAM: grammar.SynSequence{
-				grammar.NonTerminal{Symbol: SYM_TOP_LEVEL_ITEM},
-				grammar.NonTerminal{Symbol: SYM_TOP_LEVEL_ITEM_REST},
+			// Program: NEWLINE Program | TopLevelItem TopLevelItemRest | ε
+			// Leading newlines (e.g., after a comment line) are skipped
+			SYM_PROGRAM: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_NEWLINE},
+					grammar.NonTerminal{Symbol: SYM_PROGRAM},
+				},
+				grammar.SynSequence{
+					grammar.NonTerminal{Symbol: SYM_TOP_LEVEL_ITEM},
+					grammar.NonTerminal{Symbol: SYM_TOP_LEVEL_ITEM_REST},
+				},
+				grammar.SynSequence{}, // epsilon - empty program
 			},
 
 			// TopLevelItemRest: NEWLINE TopLevelItemRest2 | ε
@@ -160,8 +257,13 @@ func GetSyntacticGrammar() grammar.SyntacticGrammar {
 				grammar.SynSequence{}, // empty sequence = epsilon
 			},
 
-			// TopLevelItemRest2: TopLevelItem TopLevelItemRest | ε
+			// TopLevelItemRest2: NEWLINE TopLevelItemRest2 | TopLevelItem TopLevelItemRest | ε
+			// Repeated newlines occur when a line holds only a comment
 			SYM_TOP_LEVEL_ITEM_REST2: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_NEWLINE},
+					grammar.NonTerminal{Symbol: SYM_TOP_LEVEL_ITEM_REST2},
+				},
 				grammar.SynSequence{
 					grammar.NonTerminal{Symbol: SYM_TOP_LEVEL_ITEM},
 					grammar.NonTerminal{Symbol: SYM_TOP_LEVEL_ITEM_REST},
@@ -175,6 +277,7 @@ func GetSyntacticGrammar() grammar.SyntacticGrammar {
 			// FunctionLiterals can still be used in let statements and function arguments
 			SYM_TOP_LEVEL_ITEM: grammar.SynAlternative{
 				grammar.NonTerminal{Symbol: SYM_FUNCTION_DEF},
+				grammar.NonTerminal{Symbol: SYM_TYPE_DECL},
 				grammar.NonTerminal{Symbol: SYM_LET_STATEMENT},
 				grammar.NonTerminal{Symbol: SYM_TOP_LEVEL_EXPRESSION},
 			},
@@ -193,12 +296,22 @@ func GetSyntacticGrammar() grammar.SyntacticGrammar {
 				grammar.NonTerminal{Symbol: SYM_ASSIGNMENT_REST},
 			},
 
-			// AssignmentRest: EQUALS Assignment | ε
+			// AssignmentRest: EQUALS Assignment | COLON Expression | WITH Assignment | ε
+			// COLON and WITH only appear inside braces, in record literals ({ x: 1.0 })
+			// and record updates ({ p with x: 1.0 }); the converter rejects them elsewhere
 			SYM_ASSIGNMENT_REST: grammar.SynAlternative{
 				grammar.SynSequence{
 					grammar.Terminal{TokenType: TOKEN_EQUALS},
 					grammar.NonTerminal{Symbol: SYM_ASSIGNMENT},
 				},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_COLON},
+					grammar.NonTerminal{Symbol: SYM_EXPRESSION},
+				},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_WITH},
+					grammar.NonTerminal{Symbol: SYM_ASSIGNMENT},
+				},
 				grammar.SynSequence{}, // epsilon
 			},
 
@@ -214,49 +327,259 @@ func GetSyntacticGrammar() grammar.SyntacticGrammar {
 				grammar.NonTerminal{Symbol: SYM_EXPRESSION_STATEMENT},
 			},
 
-			// LetStatement: LET IDENTIFIER EQUALS Expression
+			// LetStatement: LET MutModifier LetTarget TypeAnnotation EQUALS Expression
 			SYM_LET_STATEMENT: grammar.SynSequence{
 				grammar.Terminal{TokenType: TOKEN_LET},
-				grammar.Terminal{TokenType: TOKEN_IDENTIFIER},
+				grammar.NonTerminal{Symbol: SYM_MUT_MODIFIER},
+				grammar.NonTerminal{Symbol: SYM_LET_TARGET},
+				grammar.NonTerminal{Symbol: SYM_TYPE_ANNOTATION},
 				grammar.Terminal{TokenType: TOKEN_EQUALS},
 				grammar.NonTerminal{Symbol: SYM_EXPRESSION},
 			},
 
+			// LetTarget: IDENTIFIER | LPAREN PatternList RPAREN
+			// A parenthesized pattern destructures a tuple: let (a, b) = pair
+			SYM_LET_TARGET: grammar.SynAlternative{
+				grammar.Terminal{TokenType: TOKEN_IDENTIFIER},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_LPAREN},
+					grammar.NonTerminal{Symbol: SYM_PATTERN_LIST},
+					grammar.Terminal{TokenType: TOKEN_RPAREN},
+				},
+			},
+
+			// MutModifier: MUT | ε
+			// Bindings are immutable unless declared with let mut
+			SYM_MUT_MODIFIER: grammar.SynAlternative{
+				grammar.Terminal{TokenType: TOKEN_MUT},
+				grammar.SynSequence{}, // epsilon - immutable binding
+			},
+
 			// ExpressionStatement: Expression
 			// Note: arr[0] = 5 is parsed as an expression, then converted to IndexAssignment in converter
 			SYM_EXPRESSION_STATEMENT: grammar.SynSequence{
 				grammar.NonTerminal{Symbol: SYM_EXPRESSION},
 			},
 
-			// FunctionDef: FN IDENTIFIER LPAREN ParameterList RPAREN Block
+			// TypeDecl: TYPE IDENTIFIER TypeParams EQUALS TypeBody
+			// Declares an algebraic data type: type Option<T> = Some of T | None
+			SYM_TYPE_DECL: grammar.SynSequence{
+				grammar.Terminal{TokenType: TOKEN_TYPE},
+				grammar.Terminal{TokenType: TOKEN_IDENTIFIER},
+				grammar.NonTerminal{Symbol: SYM_TYPE_PARAMS},
+				grammar.Terminal{TokenType: TOKEN_EQUALS},
+				grammar.NonTerminal{Symbol: SYM_TYPE_BODY},
+			},
+
+			// TypeParams: LESS_THAN IDENTIFIER TypeParamRest GREATER_THAN | ε
+			SYM_TYPE_PARAMS: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_LESS_THAN},
+					grammar.Terminal{TokenType: TOKEN_IDENTIFIER},
+					grammar.NonTerminal{Symbol: SYM_TYPE_PARAM_REST},
+					grammar.Terminal{TokenType: TOKEN_GREATER_THAN},
+				},
+				grammar.SynSequence{}, // epsilon - no type parameters
+			},
+
+			// TypeParamRest: COMMA IDENTIFIER TypeParamRest | ε
+			SYM_TYPE_PARAM_REST: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_COMMA},
+					grammar.Terminal{TokenType: TOKEN_IDENTIFIER},
+					grammar.NonTerminal{Symbol: SYM_TYPE_PARAM_REST},
+				},
+				grammar.SynSequence{}, // epsilon
+			},
+
+			// TypeBody: PIPE VariantList | VariantList | LBRACE RecordFields RBRACE
+			// A leading pipe before the first variant is optional
+			// Braces declare a record type: type Point = { x: f64, y: f64 }
+			SYM_TYPE_BODY: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_PIPE},
+					grammar.NonTerminal{Symbol: SYM_VARIANT_LIST},
+				},
+				grammar.NonTerminal{Symbol: SYM_VARIANT_LIST},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_LBRACE},
+					grammar.NonTerminal{Symbol: SYM_RECORD_FIELDS},
+					grammar.Terminal{TokenType: TOKEN_RBRACE},
+				},
+			},
+
+			// RecordFields: NEWLINE RecordFields | IDENTIFIER COLON TypeExpr RecordFieldRest | ε
+			SYM_RECORD_FIELDS: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_NEWLINE},
+					grammar.NonTerminal{Symbol: SYM_RECORD_FIELDS},
+				},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_IDENTIFIER},
+					grammar.Terminal{TokenType: TOKEN_COLON},
+					grammar.NonTerminal{Symbol: SYM_TYPE_EXPR},
+					grammar.NonTerminal{Symbol: SYM_RECORD_FIELD_REST},
+				},
+				grammar.SynSequence{}, // epsilon - no more fields
+			},
+
+			// RecordFieldRest: COMMA RecordFields | NEWLINE RecordFields | ε
+			SYM_RECORD_FIELD_REST: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_COMMA},
+					grammar.NonTerminal{Symbol: SYM_RECORD_FIELDS},
+				},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_NEWLINE},
+					grammar.NonTerminal{Symbol: SYM_RECORD_FIELDS},
+				},
+				grammar.SynSequence{}, // epsilon - last field
+			},
+
+			// VariantList: Variant VariantRest
+			SYM_VARIANT_LIST: grammar.SynSequence{
+				grammar.NonTerminal{Symbol: SYM_VARIANT},
+				grammar.NonTerminal{Symbol: SYM_VARIANT_REST},
+			},
+
+			// Variant: IDENTIFIER VariantPayload
+			SYM_VARIANT: grammar.SynSequence{
+				grammar.Terminal{TokenType: TOKEN_IDENTIFIER},
+				grammar.NonTerminal{Symbol: SYM_VARIANT_PAYLOAD},
+			},
+
+			// VariantPayload: OF TypeExpr | ε
+			// A parenthesized list of types declares a constructor with several fields
+			SYM_VARIANT_PAYLOAD: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_OF},
+					grammar.NonTerminal{Symbol: SYM_TYPE_EXPR},
+				},
+				grammar.SynSequence{}, // epsilon - constructor without fields
+			},
+
+			// VariantRest: PIPE Variant VariantRest | ε
+			SYM_VARIANT_REST: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_PIPE},
+					grammar.NonTerminal{Symbol: SYM_VARIANT},
+					grammar.NonTerminal{Symbol: SYM_VARIANT_REST},
+				},
+				grammar.SynSequence{}, // epsilon
+			},
+
+			// TypeExpr: IDENTIFIER TypeArgs | LPAREN TypeExprList RPAREN | LBRACKET TypeExpr RBRACKET
+			//         | FN LPAREN TypeExprList RPAREN ARROW TypeExpr
+			// Handles: i32, Option<T>, Result<T, E>, (Tree<T>, T, Tree<T>), (), [i32], fn(i32) -> bool
+			SYM_TYPE_EXPR: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_IDENTIFIER},
+					grammar.NonTerminal{Symbol: SYM_TYPE_ARGS},
+				},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_LPAREN},
+					grammar.NonTerminal{Symbol: SYM_TYPE_EXPR_LIST},
+					grammar.Terminal{TokenType: TOKEN_RPAREN},
+				},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_LBRACKET},
+					grammar.NonTerminal{Symbol: SYM_TYPE_EXPR},
+					grammar.Terminal{TokenType: TOKEN_RBRACKET},
+				},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_FN},
+					grammar.Terminal{TokenType: TOKEN_LPAREN},
+					grammar.NonTerminal{Symbol: SYM_TYPE_EXPR_LIST},
+					grammar.Terminal{TokenType: TOKEN_RPAREN},
+					grammar.Terminal{TokenType: TOKEN_ARROW},
+					grammar.NonTerminal{Symbol: SYM_TYPE_EXPR},
+				},
+			},
+
+			// TypeExprList: TypeExpr TypeExprRest | ε
+			// Empty for the unit type () and functions without parameters
+			SYM_TYPE_EXPR_LIST: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.NonTerminal{Symbol: SYM_TYPE_EXPR},
+					grammar.NonTerminal{Symbol: SYM_TYPE_EXPR_REST},
+				},
+				grammar.SynSequence{}, // epsilon
+			},
+
+			// TypeArgs: LESS_THAN TypeExpr TypeExprRest GREATER_THAN | ε
+			SYM_TYPE_ARGS: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_LESS_THAN},
+					grammar.NonTerminal{Symbol: SYM_TYPE_EXPR},
+					grammar.NonTerminal{Symbol: SYM_TYPE_EXPR_REST},
+					grammar.Terminal{TokenType: TOKEN_GREATER_THAN},
+				},
+				grammar.SynSequence{}, // epsilon - no type arguments
+			},
+
+			// TypeExprRest: COMMA TypeExpr TypeExprRest | ε
+			SYM_TYPE_EXPR_REST: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_COMMA},
+					grammar.NonTerminal{Symbol: SYM_TYPE_EXPR},
+					grammar.NonTerminal{Symbol: SYM_TYPE_EXPR_REST},
+				},
+				grammar.SynSequence{}, // epsilon
+			},
+
+			// FunctionDef: FN IDENTIFIER TypeParams LPAREN ParameterList RPAREN ReturnType Block
+			// Type parameters make annotated functions generic: fn first<T>(xs: [T]) -> T
 			SYM_FUNCTION_DEF: grammar.SynSequence{
 				grammar.Terminal{TokenType: TOKEN_FN},
 				grammar.Terminal{TokenType: TOKEN_IDENTIFIER},
+				grammar.NonTerminal{Symbol: SYM_TYPE_PARAMS},
 				grammar.Terminal{TokenType: TOKEN_LPAREN},
 				grammar.NonTerminal{Symbol: SYM_PARAMETER_LIST},
 				grammar.Terminal{TokenType: TOKEN_RPAREN},
+				grammar.NonTerminal{Symbol: SYM_RETURN_TYPE},
 				grammar.NonTerminal{Symbol: SYM_BLOCK},
 			},
 
-			// ParameterList: ε | IDENTIFIER ParameterRest
+			// ParameterList: ε | IDENTIFIER TypeAnnotation ParameterRest
 			SYM_PARAMETER_LIST: grammar.SynAlternative{
 				grammar.SynSequence{}, // epsilon - no parameters
 				grammar.SynSequence{
 					grammar.Terminal{TokenType: TOKEN_IDENTIFIER},
+					grammar.NonTerminal{Symbol: SYM_TYPE_ANNOTATION},
 					grammar.NonTerminal{Symbol: SYM_PARAMETER_REST},
 				},
 			},
 
-			// ParameterRest: COMMA IDENTIFIER ParameterRest | ε
+			// ParameterRest: COMMA IDENTIFIER TypeAnnotation ParameterRest | ε
 			SYM_PARAMETER_REST: grammar.SynAlternative{
 				grammar.SynSequence{
 					grammar.Terminal{TokenType: TOKEN_COMMA},
 					grammar.Terminal{TokenType: TOKEN_IDENTIFIER},
+					grammar.NonTerminal{Symbol: SYM_TYPE_ANNOTATION},
 					grammar.NonTerminal{Symbol: SYM_PARAMETER_REST},
 				},
 				grammar.SynSequence{}, // epsilon
 			},
 
+			// TypeAnnotation: COLON TypeExpr | ε
+			// Annotations are optional; unannotated bindings have inferred types
+			SYM_TYPE_ANNOTATION: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_COLON},
+					grammar.NonTerminal{Symbol: SYM_TYPE_EXPR},
+				},
+				grammar.SynSequence{}, // epsilon - inferred type
+			},
+
+			// ReturnType: ARROW TypeExpr | ε
+			SYM_RETURN_TYPE: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_ARROW},
+					grammar.NonTerminal{Symbol: SYM_TYPE_EXPR},
+				},
+				grammar.SynSequence{}, // epsilon - inferred return type
+			},
+
 			// ReturnStatement: RETURN Expression
 			SYM_RETURN_STATEMENT: grammar.SynSequence{
 				grammar.Terminal{TokenType: TOKEN_RETURN},
@@ -271,13 +594,140 @@ func GetSyntacticGrammar() grammar.SyntacticGrammar {
 				grammar.NonTerminal{Symbol: SYM_BLOCK},
 			},
 
-			// ForCondition: Expression | ε
-			// Empty (epsilon) for infinite loops, Expression for condition loops
+			// ForCondition: Assignment | ε
+			// Empty (epsilon) for infinite loops, an expression for condition loops
+			// Uses Assignment (not Expression) so that "for {" is not read as a brace expression
 			SYM_FOR_CONDITION: grammar.SynAlternative{
-				grammar.NonTerminal{Symbol: SYM_EXPRESSION},
+				grammar.NonTerminal{Symbol: SYM_ASSIGNMENT},
 				grammar.SynSequence{}, // epsilon for infinite loop
 			},
 
+			// IfExpression: IF Expression Block ElseClause
+			// Conditionals are expressions; the value is the value of the chosen block
+			SYM_IF_EXPRESSION: grammar.SynSequence{
+				grammar.Terminal{TokenType: TOKEN_IF},
+				grammar.NonTerminal{Symbol: SYM_EXPRESSION},
+				grammar.NonTerminal{Symbol: SYM_BLOCK},
+				grammar.NonTerminal{Symbol: SYM_ELSE_CLAUSE},
+			},
+
+			// ElseClause: ELSE ElseBody | ε
+			// The else keyword must follow the closing brace on the same line
+			SYM_ELSE_CLAUSE: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_ELSE},
+					grammar.NonTerminal{Symbol: SYM_ELSE_BODY},
+				},
+				grammar.SynSequence{}, // epsilon - no else branch
+			},
+
+			// ElseBody: IfExpression | Block
+			// Chains "else if" without requiring a nested block
+			SYM_ELSE_BODY: grammar.SynAlternative{
+				grammar.NonTerminal{Symbol: SYM_IF_EXPRESSION},
+				grammar.NonTerminal{Symbol: SYM_BLOCK},
+			},
+
+			// MatchExpression: MATCH Expression LBRACE MatchArms RBRACE
+			// The value is the value of the first arm whose pattern matches
+			SYM_MATCH_EXPRESSION: grammar.SynSequence{
+				grammar.Terminal{TokenType: TOKEN_MATCH},
+				grammar.NonTerminal{Symbol: SYM_EXPRESSION},
+				grammar.Terminal{TokenType: TOKEN_LBRACE},
+				grammar.NonTerminal{Symbol: SYM_MATCH_ARMS},
+				grammar.Terminal{TokenType: TOKEN_RBRACE},
+			},
+
+			// MatchArms: NEWLINE MatchArms | MatchArm MatchArmRest | ε
+			// Allows arms on separate lines
+			SYM_MATCH_ARMS: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_NEWLINE},
+					grammar.NonTerminal{Symbol: SYM_MATCH_ARMS},
+				},
+				grammar.SynSequence{
+					grammar.NonTerminal{Symbol: SYM_MATCH_ARM},
+					grammar.NonTerminal{Symbol: SYM_MATCH_ARM_REST},
+				},
+				grammar.SynSequence{}, // epsilon - no more arms
+			},
+
+			// MatchArmRest: COMMA MatchArms | NEWLINE MatchArms | ε
+			// Arms are separated by commas, newlines, or both
+			SYM_MATCH_ARM_REST: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_COMMA},
+					grammar.NonTerminal{Symbol: SYM_MATCH_ARMS},
+				},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_NEWLINE},
+					grammar.NonTerminal{Symbol: SYM_MATCH_ARMS},
+				},
+				grammar.SynSequence{}, // epsilon - last arm
+			},
+
+			// MatchArm: Pattern FAT_ARROW Expression
+			// A block body ({ ... }) is a BraceExpression
+			SYM_MATCH_ARM: grammar.SynSequence{
+				grammar.NonTerminal{Symbol: SYM_PATTERN},
+				grammar.Terminal{TokenType: TOKEN_FAT_ARROW},
+				grammar.NonTerminal{Symbol: SYM_EXPRESSION},
+			},
+
+			// Pattern: IDENTIFIER PatternArgs | Literal | MINUS Literal | LBRACKET PatternList RBRACKET | LPAREN PatternList RPAREN
+			// An uppercase identifier is a constructor, _ is a wildcard, anything else binds a name
+			SYM_PATTERN: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_IDENTIFIER},
+					grammar.NonTerminal{Symbol: SYM_PATTERN_ARGS},
+				},
+				grammar.NonTerminal{Symbol: SYM_LITERAL},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_MINUS},
+					grammar.NonTerminal{Symbol: SYM_LITERAL},
+				},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_LBRACKET},
+					grammar.NonTerminal{Symbol: SYM_PATTERN_LIST},
+					grammar.Terminal{TokenType: TOKEN_RBRACKET},
+				},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_LPAREN},
+					grammar.NonTerminal{Symbol: SYM_PATTERN_LIST},
+					grammar.Terminal{TokenType: TOKEN_RPAREN},
+				},
+			},
+
+			// PatternArgs: LPAREN Pattern PatternRest RPAREN | ε
+			SYM_PATTERN_ARGS: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_LPAREN},
+					grammar.NonTerminal{Symbol: SYM_PATTERN},
+					grammar.NonTerminal{Symbol: SYM_PATTERN_REST},
+					grammar.Terminal{TokenType: TOKEN_RPAREN},
+				},
+				grammar.SynSequence{}, // epsilon - no constructor arguments
+			},
+
+			// PatternList: Pattern PatternRest | ε
+			SYM_PATTERN_LIST: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.NonTerminal{Symbol: SYM_PATTERN},
+					grammar.NonTerminal{Symbol: SYM_PATTERN_REST},
+				},
+				grammar.SynSequence{}, // epsilon - empty array pattern or unit pattern
+			},
+
+			// PatternRest: COMMA Pattern PatternRest | ε
+			SYM_PATTERN_REST: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_COMMA},
+					grammar.NonTerminal{Symbol: SYM_PATTERN},
+					grammar.NonTerminal{Symbol: SYM_PATTERN_REST},
+				},
+				grammar.SynSequence{}, // epsilon
+			},
+
 			// BreakStatement: BREAK
 			SYM_BREAK_STATEMENT: grammar.SynSequence{
 				grammar.Terminal{TokenType: TOKEN_BREAK},
@@ -342,13 +792,52 @@ func GetSyntacticGrammar() grammar.SyntacticGrammar {
 				grammar.SynSequence{}, // epsilon - allows last statement without trailing newline
 			},
 
-			// Expression: Assignment | FunctionLiteral
+			// Expression: Assignment | FunctionLiteral | BraceExpression
 			// FunctionLiteral is at this level (not in Primary) to avoid LL(1) conflict with FunctionDef at top level
+			// BraceExpression is at this level so that blocks after "for" and "if" conditions stay unambiguous
 			SYM_EXPRESSION: grammar.SynAlternative{
 				grammar.SynSequence{
 					grammar.NonTerminal{Symbol: SYM_ASSIGNMENT},
 				},
 				grammar.NonTerminal{Symbol: SYM_FUNCTION_LITERAL},
+				grammar.NonTerminal{Symbol: SYM_BRACE_EXPRESSION},
+			},
+
+			// BraceExpression: LBRACE BraceStatements RBRACE
+			// A record literal ({ x: 1.0, y: 2.0 }), a record update ({ p with x: 1.0 }),
+			// or a block whose value is its trailing expression. The contents are parsed
+			// as statements and the converter decides which form it is.
+			SYM_BRACE_EXPRESSION: grammar.SynSequence{
+				grammar.Terminal{TokenType: TOKEN_LBRACE},
+				grammar.NonTerminal{Symbol: SYM_BRACE_STATEMENTS},
+				grammar.Terminal{TokenType: TOKEN_RBRACE},
+			},
+
+			// BraceStatements: NEWLINE BraceStatements | Statement BraceStmtRest | ε
+			SYM_BRACE_STATEMENTS: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_NEWLINE},
+					grammar.NonTerminal{Symbol: SYM_BRACE_STATEMENTS},
+				},
+				grammar.SynSequence{
+					grammar.NonTerminal{Symbol: SYM_STATEMENT},
+					grammar.NonTerminal{Symbol: SYM_BRACE_STMT_REST},
+				},
+				grammar.SynSequence{}, // epsilon - empty braces
+			},
+
+			// BraceStmtRest: COMMA BraceStatements | NEWLINE BraceStatements | ε
+			// Record fields are separated by commas, statements by newlines
+			SYM_BRACE_STMT_REST: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_COMMA},
+					grammar.NonTerminal{Symbol: SYM_BRACE_STATEMENTS},
+				},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_NEWLINE},
+					grammar.NonTerminal{Symbol: SYM_BRACE_STATEMENTS},
+				},
+				grammar.SynSequence{}, // epsilon
 			},
 
 			// LogicalOr: LogicalAnd LogicalOrRest
@@ -489,7 +978,7 @@ func GetSyntacticGrammar() grammar.SyntacticGrammar {
 				grammar.Terminal{TokenType: TOKEN_MINUS},
 			},
 
-			// Primary: IDENTIFIER PrimaryRest | Literal | ArrayLiteral | LPAREN Expression RPAREN
+			// Primary: IDENTIFIER PrimaryRest | Literal | ArrayLiteral | IfExpression | LPAREN ParenContent RPAREN
 			// NOTE: FunctionLiteral removed to avoid LL(1) conflict with FunctionDef at top level
 			SYM_PRIMARY: grammar.SynAlternative{
 				grammar.SynSequence{
@@ -498,13 +987,44 @@ func GetSyntacticGrammar() grammar.SyntacticGrammar {
 				},
 				grammar.NonTerminal{Symbol: SYM_LITERAL},
 				grammar.NonTerminal{Symbol: SYM_ARRAY_LITERAL},
+				grammar.NonTerminal{Symbol: SYM_IF_EXPRESSION},
+				grammar.NonTerminal{Symbol: SYM_MATCH_EXPRESSION},
 				grammar.SynSequence{
 					grammar.Terminal{TokenType: TOKEN_LPAREN},
-					grammar.NonTerminal{Symbol: SYM_EXPRESSION},
+					grammar.NonTerminal{Symbol: SYM_PAREN_CONTENT},
 					grammar.Terminal{TokenType: TOKEN_RPAREN},
 				},
 			},
 
+			// ParenContent: Expression TupleRest | ε
+			// () is the unit value, (e) is grouping, and (a, b) or (a,) is a tuple
+			SYM_PAREN_CONTENT: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.NonTerminal{Symbol: SYM_EXPRESSION},
+					grammar.NonTerminal{Symbol: SYM_TUPLE_REST},
+				},
+				grammar.SynSequence{}, // epsilon - unit
+			},
+
+			// TupleRest: COMMA TupleElements | ε
+			SYM_TUPLE_REST: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: TOKEN_COMMA},
+					grammar.NonTerminal{Symbol: SYM_TUPLE_ELEMENTS},
+				},
+				grammar.SynSequence{}, // epsilon
+			},
+
+			// TupleElements: Expression TupleRest | ε
+			// Epsilon allows a trailing comma, which is how a one-element tuple is written: (a,)
+			SYM_TUPLE_ELEMENTS: grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.NonTerminal{Symbol: SYM_EXPRESSION},
+					grammar.NonTerminal{Symbol: SYM_TUPLE_REST},
+				},
+				grammar.SynSequence{}, // epsilon - trailing comma
+			},
+
 			// PrimaryRest: LPAREN Arguments RPAREN | LBRACKET Expression RBRACKET | DOT IDENTIFIER PrimaryRest | ε
 			SYM_PRIMARY_REST: grammar.SynAlternative{
 				// Function call: LPAREN Arguments RPAREN
@@ -555,12 +1075,13 @@ func GetSyntacticGrammar() grammar.SyntacticGrammar {
 				grammar.SynSequence{}, // empty sequence = epsilon
 			},
 
-			// FunctionLiteral: FN LPAREN ParameterList RPAREN Block
+			// FunctionLiteral: FN LPAREN ParameterList RPAREN ReturnType Block
 			SYM_FUNCTION_LITERAL: grammar.SynSequence{
 				grammar.Terminal{TokenType: TOKEN_FN},
 				grammar.Terminal{TokenType: TOKEN_LPAREN},
 				grammar.NonTerminal{Symbol: SYM_PARAMETER_LIST},
 				grammar.Terminal{TokenType: TOKEN_RPAREN},
+				grammar.NonTerminal{Symbol: SYM_RETURN_TYPE},
 				grammar.NonTerminal{Symbol: SYM_BLOCK},
 			},
 
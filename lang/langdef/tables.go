@@ -0,0 +1,49 @@
+package langdef
+
+import (
+	"fmt"
+
+	"github.com/shadowCow/cow-lang-go/tooling/automata"
+	"github.com/shadowCow/cow-lang-go/tooling/codegen"
+	"github.com/shadowCow/cow-lang-go/tooling/ll1"
+)
+
+// The lexer DFA and the LL(1) parse table are generated ahead of time into
+// tables_gen.go, so programs start without compiling the grammars. Run
+// go generate after changing the grammars.
+//go:generate go run ./internal/gentables -o tables_gen.go
+
+// LexerDFA returns the DFA of the lexical grammar, for lexer.NewLexer.
+// It is equal to automata.CompileLexicalGrammar(GetLexical()), and shared by
+// every caller, so it must not be changed.
+func LexerDFA() automata.DfaWithTokens {
+	return lexerDFA
+}
+
+// ParseTable returns the LL(1) parse table of the syntactic grammar, for
+// ll1.NewParser. It is equal to the table ll1.BuildParseTable builds, and
+// shared by every caller, so it must not be changed.
+func ParseTable() *ll1.ParseTable {
+	return parseTable
+}
+
+// GenerateTables compiles the grammars and returns the Go source code of
+// tables_gen.go.
+func GenerateTables() ([]byte, error) {
+	g := GetSyntacticGrammar()
+	firstSets := ll1.ComputeFirstSets(g)
+	followSets := ll1.ComputeFollowSets(g, firstSets)
+	table, err := ll1.BuildParseTable(g, firstSets, followSets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build LL(1) parse table: %w", err)
+	}
+
+	return codegen.Generate(codegen.Tables{
+		Package:   "langdef",
+		Command:   "gentables",
+		DFAName:   "lexerDFA",
+		DFA:       automata.CompileLexicalGrammar(GetLexical()),
+		TableName: "parseTable",
+		Table:     table,
+	})
+}
@@ -0,0 +1,1794 @@
+// Code generated by gentables; DO NOT EDIT.
+
+package langdef
+
+import (
+	"github.com/shadowCow/cow-lang-go/tooling/automata"
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+	"github.com/shadowCow/cow-lang-go/tooling/ll1"
+)
+
+// lexerDFA is the lexer DFA.
+var lexerDFA = automata.DfaWithTokens{
+	InitialState: 0,
+	States: []automata.DfaStateWithToken{
+		{ // 0
+			Transitions: []automata.RangeTransition{
+				{Lo: '\t', Hi: '\t', To: 1}, {Lo: '\n', Hi: '\n', To: 2}, {Lo: '\r', Hi: '\r', To: 1}, {Lo: ' ', Hi: ' ', To: 1},
+				{Lo: '!', Hi: '!', To: 3}, {Lo: '"', Hi: '"', To: 4}, {Lo: '%', Hi: '%', To: 5}, {Lo: '&', Hi: '&', To: 6},
+				{Lo: '(', Hi: '(', To: 7}, {Lo: ')', Hi: ')', To: 8}, {Lo: '*', Hi: '*', To: 9}, {Lo: '+', Hi: '+', To: 10},
+				{Lo: ',', Hi: ',', To: 11}, {Lo: '-', Hi: '-', To: 12}, {Lo: '.', Hi: '.', To: 13}, {Lo: '/', Hi: '/', To: 14},
+				{Lo: '0', Hi: '0', To: 15}, {Lo: '1', Hi: '9', To: 16}, {Lo: ':', Hi: ':', To: 17}, {Lo: '<', Hi: '<', To: 18},
+				{Lo: '=', Hi: '=', To: 19}, {Lo: '>', Hi: '>', To: 20}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '[', Hi: '[', To: 22},
+				{Lo: ']', Hi: ']', To: 23}, {Lo: '_', Hi: '_', To: 21}, {Lo: '`', Hi: '`', To: 24}, {Lo: 'a', Hi: 'a', To: 21},
+				{Lo: 'b', Hi: 'b', To: 25}, {Lo: 'c', Hi: 'c', To: 26}, {Lo: 'd', Hi: 'd', To: 21}, {Lo: 'e', Hi: 'e', To: 27},
+				{Lo: 'f', Hi: 'f', To: 28}, {Lo: 'g', Hi: 'h', To: 21}, {Lo: 'i', Hi: 'i', To: 29}, {Lo: 'j', Hi: 'k', To: 21},
+				{Lo: 'l', Hi: 'l', To: 30}, {Lo: 'm', Hi: 'm', To: 31}, {Lo: 'n', Hi: 'n', To: 21}, {Lo: 'o', Hi: 'o', To: 32},
+				{Lo: 'p', Hi: 'q', To: 21}, {Lo: 'r', Hi: 'r', To: 33}, {Lo: 's', Hi: 's', To: 21}, {Lo: 't', Hi: 't', To: 34},
+				{Lo: 'u', Hi: 'v', To: 21}, {Lo: 'w', Hi: 'w', To: 35}, {Lo: 'x', Hi: 'z', To: 21}, {Lo: '{', Hi: '{', To: 36},
+				{Lo: '|', Hi: '|', To: 37}, {Lo: '}', Hi: '}', To: 38},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 1
+			Transitions: []automata.RangeTransition{
+				{Lo: '\t', Hi: '\t', To: 1}, {Lo: '\r', Hi: '\r', To: 1}, {Lo: ' ', Hi: ' ', To: 1},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 2
+			Transitions: []automata.RangeTransition{
+				{Lo: '\n', Hi: '\n', To: 2},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 3
+			Transitions: []automata.RangeTransition{
+				{Lo: '=', Hi: '=', To: 39},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 4
+			Transitions: []automata.RangeTransition{
+				{Lo: '\x00', Hi: '\t', To: 4}, {Lo: '\v', Hi: '!', To: 4}, {Lo: '"', Hi: '"', To: 40}, {Lo: '#', Hi: '[', To: 4},
+				{Lo: '\\', Hi: '\\', To: 41}, {Lo: ']', Hi: '\U0010ffff', To: 4},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 5
+			DefaultTransition: automata.NoState,
+		},
+		{ // 6
+			Transitions: []automata.RangeTransition{
+				{Lo: '&', Hi: '&', To: 42},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 7
+			DefaultTransition: automata.NoState,
+		},
+		{ // 8
+			DefaultTransition: automata.NoState,
+		},
+		{ // 9
+			DefaultTransition: automata.NoState,
+		},
+		{ // 10
+			DefaultTransition: automata.NoState,
+		},
+		{ // 11
+			DefaultTransition: automata.NoState,
+		},
+		{ // 12
+			Transitions: []automata.RangeTransition{
+				{Lo: '>', Hi: '>', To: 43},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 13
+			DefaultTransition: automata.NoState,
+		},
+		{ // 14
+			Transitions: []automata.RangeTransition{
+				{Lo: '*', Hi: '*', To: 44}, {Lo: '/', Hi: '/', To: 45},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 15
+			Transitions: []automata.RangeTransition{
+				{Lo: '.', Hi: '.', To: 46}, {Lo: '0', Hi: '9', To: 16}, {Lo: 'E', Hi: 'E', To: 47}, {Lo: '_', Hi: '_', To: 16},
+				{Lo: 'b', Hi: 'b', To: 48}, {Lo: 'e', Hi: 'e', To: 47}, {Lo: 'x', Hi: 'x', To: 49},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 16
+			Transitions: []automata.RangeTransition{
+				{Lo: '.', Hi: '.', To: 46}, {Lo: '0', Hi: '9', To: 16}, {Lo: 'E', Hi: 'E', To: 47}, {Lo: '_', Hi: '_', To: 16},
+				{Lo: 'e', Hi: 'e', To: 47},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 17
+			DefaultTransition: automata.NoState,
+		},
+		{ // 18
+			Transitions: []automata.RangeTransition{
+				{Lo: '=', Hi: '=', To: 50},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 19
+			Transitions: []automata.RangeTransition{
+				{Lo: '=', Hi: '=', To: 51}, {Lo: '>', Hi: '>', To: 52},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 20
+			Transitions: []automata.RangeTransition{
+				{Lo: '=', Hi: '=', To: 53},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 21
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 22
+			DefaultTransition: automata.NoState,
+		},
+		{ // 23
+			DefaultTransition: automata.NoState,
+		},
+		{ // 24
+			Transitions: []automata.RangeTransition{
+				{Lo: '\x00', Hi: '_', To: 24}, {Lo: '`', Hi: '`', To: 54}, {Lo: 'a', Hi: '\U0010ffff', To: 24},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 25
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'q', To: 21},
+				{Lo: 'r', Hi: 'r', To: 55}, {Lo: 's', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 26
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'n', To: 21},
+				{Lo: 'o', Hi: 'o', To: 56}, {Lo: 'p', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 27
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'k', To: 21},
+				{Lo: 'l', Hi: 'l', To: 57}, {Lo: 'm', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 28
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'a', To: 58},
+				{Lo: 'b', Hi: 'm', To: 21}, {Lo: 'n', Hi: 'n', To: 59}, {Lo: 'o', Hi: 'o', To: 60}, {Lo: 'p', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 29
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'e', To: 21},
+				{Lo: 'f', Hi: 'f', To: 61}, {Lo: 'g', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 30
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'd', To: 21},
+				{Lo: 'e', Hi: 'e', To: 62}, {Lo: 'f', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 31
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'a', To: 63},
+				{Lo: 'b', Hi: 't', To: 21}, {Lo: 'u', Hi: 'u', To: 64}, {Lo: 'v', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 32
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'e', To: 21},
+				{Lo: 'f', Hi: 'f', To: 65}, {Lo: 'g', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 33
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'd', To: 21},
+				{Lo: 'e', Hi: 'e', To: 66}, {Lo: 'f', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 34
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'q', To: 21},
+				{Lo: 'r', Hi: 'r', To: 67}, {Lo: 's', Hi: 'x', To: 21}, {Lo: 'y', Hi: 'y', To: 68}, {Lo: 'z', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 35
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'h', To: 21},
+				{Lo: 'i', Hi: 'i', To: 69}, {Lo: 'j', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 36
+			DefaultTransition: automata.NoState,
+		},
+		{ // 37
+			Transitions: []automata.RangeTransition{
+				{Lo: '|', Hi: '|', To: 70},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 38
+			DefaultTransition: automata.NoState,
+		},
+		{ // 39
+			DefaultTransition: automata.NoState,
+		},
+		{ // 40
+			DefaultTransition: automata.NoState,
+		},
+		{ // 41
+			Transitions: []automata.RangeTransition{
+				{Lo: '"', Hi: '"', To: 4}, {Lo: '\\', Hi: '\\', To: 4}, {Lo: 'n', Hi: 'n', To: 4}, {Lo: 'r', Hi: 'r', To: 4},
+				{Lo: 't', Hi: 't', To: 4},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 42
+			DefaultTransition: automata.NoState,
+		},
+		{ // 43
+			DefaultTransition: automata.NoState,
+		},
+		{ // 44
+			DefaultTransition: automata.NoState,
+		},
+		{ // 45
+			Transitions: []automata.RangeTransition{
+				{Lo: '\x00', Hi: '\t', To: 45}, {Lo: '\v', Hi: '\U0010ffff', To: 45},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 46
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 71},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 47
+			Transitions: []automata.RangeTransition{
+				{Lo: '+', Hi: '+', To: 72}, {Lo: '-', Hi: '-', To: 72}, {Lo: '0', Hi: '9', To: 73},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 48
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '1', To: 74}, {Lo: '_', Hi: '_', To: 74},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 49
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 75}, {Lo: 'A', Hi: 'F', To: 75}, {Lo: '_', Hi: '_', To: 75}, {Lo: 'a', Hi: 'f', To: 75},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 50
+			DefaultTransition: automata.NoState,
+		},
+		{ // 51
+			DefaultTransition: automata.NoState,
+		},
+		{ // 52
+			DefaultTransition: automata.NoState,
+		},
+		{ // 53
+			DefaultTransition: automata.NoState,
+		},
+		{ // 54
+			DefaultTransition: automata.NoState,
+		},
+		{ // 55
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'd', To: 21},
+				{Lo: 'e', Hi: 'e', To: 76}, {Lo: 'f', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 56
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'm', To: 21},
+				{Lo: 'n', Hi: 'n', To: 77}, {Lo: 'o', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 57
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'r', To: 21},
+				{Lo: 's', Hi: 's', To: 78}, {Lo: 't', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 58
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'k', To: 21},
+				{Lo: 'l', Hi: 'l', To: 79}, {Lo: 'm', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 59
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 60
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'q', To: 21},
+				{Lo: 'r', Hi: 'r', To: 80}, {Lo: 's', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 61
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 62
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 's', To: 21},
+				{Lo: 't', Hi: 't', To: 81}, {Lo: 'u', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 63
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 's', To: 21},
+				{Lo: 't', Hi: 't', To: 82}, {Lo: 'u', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 64
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 's', To: 21},
+				{Lo: 't', Hi: 't', To: 83}, {Lo: 'u', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 65
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 66
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 's', To: 21},
+				{Lo: 't', Hi: 't', To: 84}, {Lo: 'u', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 67
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 't', To: 21},
+				{Lo: 'u', Hi: 'u', To: 85}, {Lo: 'v', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 68
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'o', To: 21},
+				{Lo: 'p', Hi: 'p', To: 86}, {Lo: 'q', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 69
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 's', To: 21},
+				{Lo: 't', Hi: 't', To: 87}, {Lo: 'u', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 70
+			DefaultTransition: automata.NoState,
+		},
+		{ // 71
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 71}, {Lo: 'E', Hi: 'E', To: 47}, {Lo: '_', Hi: '_', To: 71}, {Lo: 'e', Hi: 'e', To: 47},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 72
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 73},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 73
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 73}, {Lo: '_', Hi: '_', To: 73},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 74
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '1', To: 74}, {Lo: '_', Hi: '_', To: 74},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 75
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 75}, {Lo: 'A', Hi: 'F', To: 75}, {Lo: '_', Hi: '_', To: 75}, {Lo: 'a', Hi: 'f', To: 75},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 76
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'a', To: 88},
+				{Lo: 'b', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 77
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 's', To: 21},
+				{Lo: 't', Hi: 't', To: 89}, {Lo: 'u', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 78
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'd', To: 21},
+				{Lo: 'e', Hi: 'e', To: 90}, {Lo: 'f', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 79
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'r', To: 21},
+				{Lo: 's', Hi: 's', To: 91}, {Lo: 't', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 80
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 81
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 82
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'b', To: 21},
+				{Lo: 'c', Hi: 'c', To: 92}, {Lo: 'd', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 83
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 84
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 't', To: 21},
+				{Lo: 'u', Hi: 'u', To: 93}, {Lo: 'v', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 85
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'd', To: 21},
+				{Lo: 'e', Hi: 'e', To: 94}, {Lo: 'f', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 86
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'd', To: 21},
+				{Lo: 'e', Hi: 'e', To: 95}, {Lo: 'f', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 87
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'g', To: 21},
+				{Lo: 'h', Hi: 'h', To: 96}, {Lo: 'i', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 88
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'j', To: 21},
+				{Lo: 'k', Hi: 'k', To: 97}, {Lo: 'l', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 89
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'h', To: 21},
+				{Lo: 'i', Hi: 'i', To: 98}, {Lo: 'j', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 90
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 91
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'd', To: 21},
+				{Lo: 'e', Hi: 'e', To: 99}, {Lo: 'f', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 92
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'g', To: 21},
+				{Lo: 'h', Hi: 'h', To: 100}, {Lo: 'i', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 93
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'q', To: 21},
+				{Lo: 'r', Hi: 'r', To: 101}, {Lo: 's', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 94
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 95
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 96
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 97
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 98
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'm', To: 21},
+				{Lo: 'n', Hi: 'n', To: 102}, {Lo: 'o', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 99
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 100
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 101
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'm', To: 21},
+				{Lo: 'n', Hi: 'n', To: 103}, {Lo: 'o', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 102
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 't', To: 21},
+				{Lo: 'u', Hi: 'u', To: 104}, {Lo: 'v', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 103
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 104
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'd', To: 21},
+				{Lo: 'e', Hi: 'e', To: 105}, {Lo: 'f', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+		{ // 105
+			Transitions: []automata.RangeTransition{
+				{Lo: '0', Hi: '9', To: 21}, {Lo: 'A', Hi: 'Z', To: 21}, {Lo: '_', Hi: '_', To: 21}, {Lo: 'a', Hi: 'z', To: 21},
+			},
+			DefaultTransition: automata.NoState,
+		},
+	},
+	AcceptingStates: map[int]automata.AcceptingState{
+		1:   {TokenType: "WHITESPACE", Priority: 1},
+		2:   {TokenType: "NEWLINE", Priority: 2},
+		3:   {TokenType: "NOT", Priority: 1},
+		5:   {TokenType: "MODULO", Priority: 1},
+		7:   {TokenType: "LPAREN", Priority: 1},
+		8:   {TokenType: "RPAREN", Priority: 1},
+		9:   {TokenType: "MULTIPLY", Priority: 1},
+		10:  {TokenType: "PLUS", Priority: 1},
+		11:  {TokenType: "COMMA", Priority: 1},
+		12:  {TokenType: "MINUS", Priority: 1},
+		13:  {TokenType: "DOT", Priority: 1},
+		14:  {TokenType: "DIVIDE", Priority: 1},
+		15:  {TokenType: "INT_DECIMAL", Priority: 1},
+		16:  {TokenType: "INT_DECIMAL", Priority: 1},
+		17:  {TokenType: "COLON", Priority: 1},
+		18:  {TokenType: "LESS_THAN", Priority: 1},
+		19:  {TokenType: "EQUALS", Priority: 1},
+		20:  {TokenType: "GREATER_THAN", Priority: 1},
+		21:  {TokenType: "IDENTIFIER", Priority: 4},
+		22:  {TokenType: "LBRACKET", Priority: 1},
+		23:  {TokenType: "RBRACKET", Priority: 1},
+		25:  {TokenType: "IDENTIFIER", Priority: 4},
+		26:  {TokenType: "IDENTIFIER", Priority: 4},
+		27:  {TokenType: "IDENTIFIER", Priority: 4},
+		28:  {TokenType: "IDENTIFIER", Priority: 4},
+		29:  {TokenType: "IDENTIFIER", Priority: 4},
+		30:  {TokenType: "IDENTIFIER", Priority: 4},
+		31:  {TokenType: "IDENTIFIER", Priority: 4},
+		32:  {TokenType: "IDENTIFIER", Priority: 4},
+		33:  {TokenType: "IDENTIFIER", Priority: 4},
+		34:  {TokenType: "IDENTIFIER", Priority: 4},
+		35:  {TokenType: "IDENTIFIER", Priority: 4},
+		36:  {TokenType: "LBRACE", Priority: 1},
+		37:  {TokenType: "PIPE", Priority: 1},
+		38:  {TokenType: "RBRACE", Priority: 1},
+		39:  {TokenType: "NOT_EQUAL", Priority: 2},
+		40:  {TokenType: "STRING", Priority: 3},
+		42:  {TokenType: "AND", Priority: 2},
+		43:  {TokenType: "ARROW", Priority: 2},
+		44:  {TokenType: "BLOCK_COMMENT", Priority: 3},
+		45:  {TokenType: "LINE_COMMENT", Priority: 3},
+		50:  {TokenType: "LESS_EQUAL", Priority: 2},
+		51:  {TokenType: "EQUAL_EQUAL", Priority: 2},
+		52:  {TokenType: "FAT_ARROW", Priority: 2},
+		53:  {TokenType: "GREATER_EQUAL", Priority: 2},
+		54:  {TokenType: "RAW_STRING", Priority: 3},
+		55:  {TokenType: "IDENTIFIER", Priority: 4},
+		56:  {TokenType: "IDENTIFIER", Priority: 4},
+		57:  {TokenType: "IDENTIFIER", Priority: 4},
+		58:  {TokenType: "IDENTIFIER", Priority: 4},
+		59:  {TokenType: "FN", Priority: 5},
+		60:  {TokenType: "IDENTIFIER", Priority: 4},
+		61:  {TokenType: "IF", Priority: 5},
+		62:  {TokenType: "IDENTIFIER", Priority: 4},
+		63:  {TokenType: "IDENTIFIER", Priority: 4},
+		64:  {TokenType: "IDENTIFIER", Priority: 4},
+		65:  {TokenType: "OF", Priority: 5},
+		66:  {TokenType: "IDENTIFIER", Priority: 4},
+		67:  {TokenType: "IDENTIFIER", Priority: 4},
+		68:  {TokenType: "IDENTIFIER", Priority: 4},
+		69:  {TokenType: "IDENTIFIER", Priority: 4},
+		70:  {TokenType: "OR", Priority: 2},
+		71:  {TokenType: "FLOAT", Priority: 2},
+		73:  {TokenType: "FLOAT", Priority: 2},
+		74:  {TokenType: "INT_BINARY", Priority: 3},
+		75:  {TokenType: "INT_HEX", Priority: 3},
+		76:  {TokenType: "IDENTIFIER", Priority: 4},
+		77:  {TokenType: "IDENTIFIER", Priority: 4},
+		78:  {TokenType: "IDENTIFIER", Priority: 4},
+		79:  {TokenType: "IDENTIFIER", Priority: 4},
+		80:  {TokenType: "FOR", Priority: 5},
+		81:  {TokenType: "LET", Priority: 5},
+		82:  {TokenType: "IDENTIFIER", Priority: 4},
+		83:  {TokenType: "MUT", Priority: 5},
+		84:  {TokenType: "IDENTIFIER", Priority: 4},
+		85:  {TokenType: "IDENTIFIER", Priority: 4},
+		86:  {TokenType: "IDENTIFIER", Priority: 4},
+		87:  {TokenType: "IDENTIFIER", Priority: 4},
+		88:  {TokenType: "IDENTIFIER", Priority: 4},
+		89:  {TokenType: "IDENTIFIER", Priority: 4},
+		90:  {TokenType: "ELSE", Priority: 5},
+		91:  {TokenType: "IDENTIFIER", Priority: 4},
+		92:  {TokenType: "IDENTIFIER", Priority: 4},
+		93:  {TokenType: "IDENTIFIER", Priority: 4},
+		94:  {TokenType: "TRUE", Priority: 5},
+		95:  {TokenType: "TYPE", Priority: 5},
+		96:  {TokenType: "WITH", Priority: 5},
+		97:  {TokenType: "BREAK", Priority: 5},
+		98:  {TokenType: "IDENTIFIER", Priority: 4},
+		99:  {TokenType: "FALSE", Priority: 5},
+		100: {TokenType: "MATCH", Priority: 5},
+		101: {TokenType: "IDENTIFIER", Priority: 4},
+		102: {TokenType: "IDENTIFIER", Priority: 4},
+		103: {TokenType: "RETURN", Priority: 5},
+		104: {TokenType: "IDENTIFIER", Priority: 4},
+		105: {TokenType: "CONTINUE", Priority: 5},
+	},
+	Nested: map[grammar.TokenType]grammar.NestedDelimited{
+		"BLOCK_COMMENT": {Open: "/*", Close: "*/"},
+	},
+}
+
+// parseTableProductions holds the productions of parseTable's entries.
+var parseTableProductions = [...]grammar.ProductionRule{
+	grammar.Terminal{TokenType: "MINUS"},
+	grammar.Terminal{TokenType: "PLUS"},
+	grammar.SynSequence{},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "AddOp"}, grammar.NonTerminal{Symbol: "Term"}, grammar.NonTerminal{Symbol: "AddRest"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Expression"}, grammar.NonTerminal{Symbol: "ArgumentRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "COMMA"}, grammar.NonTerminal{Symbol: "Expression"}, grammar.NonTerminal{Symbol: "ArgumentRest"}},
+	grammar.NonTerminal{Symbol: "ArgumentList"},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Term"}, grammar.NonTerminal{Symbol: "AddRest"}},
+	grammar.NonTerminal{Symbol: "ElementList"},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LBRACKET"}, grammar.NonTerminal{Symbol: "ArrayContent"}, grammar.Terminal{TokenType: "RBRACKET"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "LogicalOr"}, grammar.NonTerminal{Symbol: "AssignmentRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "COLON"}, grammar.NonTerminal{Symbol: "Expression"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "EQUALS"}, grammar.NonTerminal{Symbol: "Assignment"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "WITH"}, grammar.NonTerminal{Symbol: "Assignment"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LBRACE"}, grammar.NonTerminal{Symbol: "BlockStatements"}, grammar.Terminal{TokenType: "RBRACE"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Statement"}, grammar.NonTerminal{Symbol: "BlockStmtRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "NEWLINE"}, grammar.NonTerminal{Symbol: "BlockStatements"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LBRACE"}, grammar.NonTerminal{Symbol: "BraceStatements"}, grammar.Terminal{TokenType: "RBRACE"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Statement"}, grammar.NonTerminal{Symbol: "BraceStmtRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "NEWLINE"}, grammar.NonTerminal{Symbol: "BraceStatements"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "COMMA"}, grammar.NonTerminal{Symbol: "BraceStatements"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "BREAK"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Arithmetic"}, grammar.NonTerminal{Symbol: "ComparisonRest"}},
+	grammar.Terminal{TokenType: "GREATER_EQUAL"},
+	grammar.Terminal{TokenType: "GREATER_THAN"},
+	grammar.Terminal{TokenType: "LESS_EQUAL"},
+	grammar.Terminal{TokenType: "LESS_THAN"},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "ComparisonOp"}, grammar.NonTerminal{Symbol: "Arithmetic"}, grammar.NonTerminal{Symbol: "ComparisonRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "CONTINUE"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Expression"}, grammar.NonTerminal{Symbol: "ElementRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "COMMA"}, grammar.NonTerminal{Symbol: "Expression"}, grammar.NonTerminal{Symbol: "ElementRest"}},
+	grammar.NonTerminal{Symbol: "IfExpression"},
+	grammar.NonTerminal{Symbol: "Block"},
+	grammar.SynSequence{grammar.Terminal{TokenType: "ELSE"}, grammar.NonTerminal{Symbol: "ElseBody"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Comparison"}, grammar.NonTerminal{Symbol: "EqualityRest"}},
+	grammar.Terminal{TokenType: "EQUAL_EQUAL"},
+	grammar.Terminal{TokenType: "NOT_EQUAL"},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "EqualityOp"}, grammar.NonTerminal{Symbol: "Comparison"}, grammar.NonTerminal{Symbol: "EqualityRest"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Assignment"}},
+	grammar.NonTerminal{Symbol: "FunctionLiteral"},
+	grammar.NonTerminal{Symbol: "BraceExpression"},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Expression"}},
+	grammar.NonTerminal{Symbol: "Assignment"},
+	grammar.SynSequence{grammar.Terminal{TokenType: "FOR"}, grammar.NonTerminal{Symbol: "ForCondition"}, grammar.NonTerminal{Symbol: "Block"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "FN"}, grammar.Terminal{TokenType: "IDENTIFIER"}, grammar.NonTerminal{Symbol: "TypeParams"}, grammar.Terminal{TokenType: "LPAREN"}, grammar.NonTerminal{Symbol: "ParameterList"}, grammar.Terminal{TokenType: "RPAREN"}, grammar.NonTerminal{Symbol: "ReturnType"}, grammar.NonTerminal{Symbol: "Block"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "FN"}, grammar.Terminal{TokenType: "LPAREN"}, grammar.NonTerminal{Symbol: "ParameterList"}, grammar.Terminal{TokenType: "RPAREN"}, grammar.NonTerminal{Symbol: "ReturnType"}, grammar.NonTerminal{Symbol: "Block"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "IF"}, grammar.NonTerminal{Symbol: "Expression"}, grammar.NonTerminal{Symbol: "Block"}, grammar.NonTerminal{Symbol: "ElseClause"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "IDENTIFIER"}, grammar.NonTerminal{Symbol: "IndexChain"}, grammar.Terminal{TokenType: "EQUALS"}, grammar.NonTerminal{Symbol: "Expression"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LBRACKET"}, grammar.NonTerminal{Symbol: "Expression"}, grammar.Terminal{TokenType: "RBRACKET"}, grammar.NonTerminal{Symbol: "IndexChainRest"}},
+	grammar.NonTerminal{Symbol: "IndexChain"},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LET"}, grammar.NonTerminal{Symbol: "MutModifier"}, grammar.NonTerminal{Symbol: "LetTarget"}, grammar.NonTerminal{Symbol: "TypeAnnotation"}, grammar.Terminal{TokenType: "EQUALS"}, grammar.NonTerminal{Symbol: "Expression"}},
+	grammar.Terminal{TokenType: "IDENTIFIER"},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LPAREN"}, grammar.NonTerminal{Symbol: "PatternList"}, grammar.Terminal{TokenType: "RPAREN"}},
+	grammar.Terminal{TokenType: "FALSE"},
+	grammar.Terminal{TokenType: "FLOAT"},
+	grammar.Terminal{TokenType: "INT_BINARY"},
+	grammar.Terminal{TokenType: "INT_DECIMAL"},
+	grammar.Terminal{TokenType: "INT_HEX"},
+	grammar.Terminal{TokenType: "RAW_STRING"},
+	grammar.Terminal{TokenType: "STRING"},
+	grammar.Terminal{TokenType: "TRUE"},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Equality"}, grammar.NonTerminal{Symbol: "LogicalAndRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "AND"}, grammar.NonTerminal{Symbol: "Equality"}, grammar.NonTerminal{Symbol: "LogicalAndRest"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "LogicalAnd"}, grammar.NonTerminal{Symbol: "LogicalOrRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "OR"}, grammar.NonTerminal{Symbol: "LogicalAnd"}, grammar.NonTerminal{Symbol: "LogicalOrRest"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Pattern"}, grammar.Terminal{TokenType: "FAT_ARROW"}, grammar.NonTerminal{Symbol: "Expression"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "COMMA"}, grammar.NonTerminal{Symbol: "MatchArms"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "NEWLINE"}, grammar.NonTerminal{Symbol: "MatchArms"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "MatchArm"}, grammar.NonTerminal{Symbol: "MatchArmRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "MATCH"}, grammar.NonTerminal{Symbol: "Expression"}, grammar.Terminal{TokenType: "LBRACE"}, grammar.NonTerminal{Symbol: "MatchArms"}, grammar.Terminal{TokenType: "RBRACE"}},
+	grammar.Terminal{TokenType: "DIVIDE"},
+	grammar.Terminal{TokenType: "MODULO"},
+	grammar.Terminal{TokenType: "MULTIPLY"},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "MulOp"}, grammar.NonTerminal{Symbol: "Unary"}, grammar.NonTerminal{Symbol: "MulRest"}},
+	grammar.Terminal{TokenType: "MUT"},
+	grammar.SynSequence{grammar.Terminal{TokenType: "IDENTIFIER"}, grammar.NonTerminal{Symbol: "TypeAnnotation"}, grammar.NonTerminal{Symbol: "ParameterRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "COMMA"}, grammar.Terminal{TokenType: "IDENTIFIER"}, grammar.NonTerminal{Symbol: "TypeAnnotation"}, grammar.NonTerminal{Symbol: "ParameterRest"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Expression"}, grammar.NonTerminal{Symbol: "TupleRest"}},
+	grammar.NonTerminal{Symbol: "Literal"},
+	grammar.SynSequence{grammar.Terminal{TokenType: "IDENTIFIER"}, grammar.NonTerminal{Symbol: "PatternArgs"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LBRACKET"}, grammar.NonTerminal{Symbol: "PatternList"}, grammar.Terminal{TokenType: "RBRACKET"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "MINUS"}, grammar.NonTerminal{Symbol: "Literal"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LPAREN"}, grammar.NonTerminal{Symbol: "Pattern"}, grammar.NonTerminal{Symbol: "PatternRest"}, grammar.Terminal{TokenType: "RPAREN"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Pattern"}, grammar.NonTerminal{Symbol: "PatternRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "COMMA"}, grammar.NonTerminal{Symbol: "Pattern"}, grammar.NonTerminal{Symbol: "PatternRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "IDENTIFIER"}, grammar.NonTerminal{Symbol: "PrimaryRest"}},
+	grammar.NonTerminal{Symbol: "ArrayLiteral"},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LPAREN"}, grammar.NonTerminal{Symbol: "ParenContent"}, grammar.Terminal{TokenType: "RPAREN"}},
+	grammar.NonTerminal{Symbol: "MatchExpression"},
+	grammar.SynSequence{grammar.Terminal{TokenType: "DOT"}, grammar.Terminal{TokenType: "IDENTIFIER"}, grammar.NonTerminal{Symbol: "PrimaryRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LBRACKET"}, grammar.NonTerminal{Symbol: "Expression"}, grammar.Terminal{TokenType: "RBRACKET"}, grammar.NonTerminal{Symbol: "PrimaryRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LPAREN"}, grammar.NonTerminal{Symbol: "Arguments"}, grammar.Terminal{TokenType: "RPAREN"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "TopLevelItem"}, grammar.NonTerminal{Symbol: "TopLevelItemRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "NEWLINE"}, grammar.NonTerminal{Symbol: "Program"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "COMMA"}, grammar.NonTerminal{Symbol: "RecordFields"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "NEWLINE"}, grammar.NonTerminal{Symbol: "RecordFields"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "IDENTIFIER"}, grammar.Terminal{TokenType: "COLON"}, grammar.NonTerminal{Symbol: "TypeExpr"}, grammar.NonTerminal{Symbol: "RecordFieldRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "RETURN"}, grammar.NonTerminal{Symbol: "Expression"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "ARROW"}, grammar.NonTerminal{Symbol: "TypeExpr"}},
+	grammar.NonTerminal{Symbol: "BreakStatement"},
+	grammar.NonTerminal{Symbol: "ContinueStatement"},
+	grammar.NonTerminal{Symbol: "ExpressionStatement"},
+	grammar.NonTerminal{Symbol: "ForStatement"},
+	grammar.NonTerminal{Symbol: "LetStatement"},
+	grammar.NonTerminal{Symbol: "ReturnStatement"},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Unary"}, grammar.NonTerminal{Symbol: "MulRest"}},
+	grammar.NonTerminal{Symbol: "TopLevelExpression"},
+	grammar.NonTerminal{Symbol: "FunctionDef"},
+	grammar.NonTerminal{Symbol: "TypeDecl"},
+	grammar.SynSequence{grammar.Terminal{TokenType: "NEWLINE"}, grammar.NonTerminal{Symbol: "TopLevelItemRest2"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "COMMA"}, grammar.NonTerminal{Symbol: "TupleElements"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "COLON"}, grammar.NonTerminal{Symbol: "TypeExpr"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LESS_THAN"}, grammar.NonTerminal{Symbol: "TypeExpr"}, grammar.NonTerminal{Symbol: "TypeExprRest"}, grammar.Terminal{TokenType: "GREATER_THAN"}},
+	grammar.NonTerminal{Symbol: "VariantList"},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LBRACE"}, grammar.NonTerminal{Symbol: "RecordFields"}, grammar.Terminal{TokenType: "RBRACE"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "PIPE"}, grammar.NonTerminal{Symbol: "VariantList"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "TYPE"}, grammar.Terminal{TokenType: "IDENTIFIER"}, grammar.NonTerminal{Symbol: "TypeParams"}, grammar.Terminal{TokenType: "EQUALS"}, grammar.NonTerminal{Symbol: "TypeBody"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "FN"}, grammar.Terminal{TokenType: "LPAREN"}, grammar.NonTerminal{Symbol: "TypeExprList"}, grammar.Terminal{TokenType: "RPAREN"}, grammar.Terminal{TokenType: "ARROW"}, grammar.NonTerminal{Symbol: "TypeExpr"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "IDENTIFIER"}, grammar.NonTerminal{Symbol: "TypeArgs"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LBRACKET"}, grammar.NonTerminal{Symbol: "TypeExpr"}, grammar.Terminal{TokenType: "RBRACKET"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LPAREN"}, grammar.NonTerminal{Symbol: "TypeExprList"}, grammar.Terminal{TokenType: "RPAREN"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "TypeExpr"}, grammar.NonTerminal{Symbol: "TypeExprRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "COMMA"}, grammar.NonTerminal{Symbol: "TypeExpr"}, grammar.NonTerminal{Symbol: "TypeExprRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "COMMA"}, grammar.Terminal{TokenType: "IDENTIFIER"}, grammar.NonTerminal{Symbol: "TypeParamRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "LESS_THAN"}, grammar.Terminal{TokenType: "IDENTIFIER"}, grammar.NonTerminal{Symbol: "TypeParamRest"}, grammar.Terminal{TokenType: "GREATER_THAN"}},
+	grammar.NonTerminal{Symbol: "Primary"},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "UnaryOp"}, grammar.NonTerminal{Symbol: "Unary"}},
+	grammar.Terminal{TokenType: "NOT"},
+	grammar.SynSequence{grammar.Terminal{TokenType: "IDENTIFIER"}, grammar.NonTerminal{Symbol: "VariantPayload"}},
+	grammar.SynSequence{grammar.NonTerminal{Symbol: "Variant"}, grammar.NonTerminal{Symbol: "VariantRest"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "OF"}, grammar.NonTerminal{Symbol: "TypeExpr"}},
+	grammar.SynSequence{grammar.Terminal{TokenType: "PIPE"}, grammar.NonTerminal{Symbol: "Variant"}, grammar.NonTerminal{Symbol: "VariantRest"}},
+}
+
+// parseTable is the LL(1) parse table.
+var parseTable = ll1.NewParseTableFromEntries(
+	[]grammar.Symbol{
+		"AddOp",
+		"AddRest",
+		"ArgumentList",
+		"ArgumentRest",
+		"Arguments",
+		"Arithmetic",
+		"ArrayContent",
+		"ArrayLiteral",
+		"Assignment",
+		"AssignmentRest",
+		"Block",
+		"BlockStatements",
+		"BlockStmtRest",
+		"BraceExpression",
+		"BraceStatements",
+		"BraceStmtRest",
+		"BreakStatement",
+		"Comparison",
+		"ComparisonOp",
+		"ComparisonRest",
+		"ContinueStatement",
+		"ElementList",
+		"ElementRest",
+		"ElseBody",
+		"ElseClause",
+		"Equality",
+		"EqualityOp",
+		"EqualityRest",
+		"Expression",
+		"ExpressionStatement",
+		"ForCondition",
+		"ForStatement",
+		"FunctionDef",
+		"FunctionLiteral",
+		"IfExpression",
+		"IndexAssignment",
+		"IndexChain",
+		"IndexChainRest",
+		"LetStatement",
+		"LetTarget",
+		"Literal",
+		"LogicalAnd",
+		"LogicalAndRest",
+		"LogicalOr",
+		"LogicalOrRest",
+		"MatchArm",
+		"MatchArmRest",
+		"MatchArms",
+		"MatchExpression",
+		"MulOp",
+		"MulRest",
+		"MutModifier",
+		"ParameterList",
+		"ParameterRest",
+		"ParenContent",
+		"Pattern",
+		"PatternArgs",
+		"PatternList",
+		"PatternRest",
+		"Primary",
+		"PrimaryRest",
+		"Program",
+		"RecordFieldRest",
+		"RecordFields",
+		"ReturnStatement",
+		"ReturnType",
+		"Statement",
+		"Term",
+		"TopLevelExpression",
+		"TopLevelItem",
+		"TopLevelItemRest",
+		"TopLevelItemRest2",
+		"TupleElements",
+		"TupleRest",
+		"TypeAnnotation",
+		"TypeArgs",
+		"TypeBody",
+		"TypeDecl",
+		"TypeExpr",
+		"TypeExprList",
+		"TypeExprRest",
+		"TypeParamRest",
+		"TypeParams",
+		"Unary",
+		"UnaryOp",
+		"Variant",
+		"VariantList",
+		"VariantPayload",
+		"VariantRest",
+	},
+	[]string{
+		"$",
+		"AND",
+		"ARROW",
+		"BREAK",
+		"COLON",
+		"COMMA",
+		"CONTINUE",
+		"DIVIDE",
+		"DOT",
+		"ELSE",
+		"EQUALS",
+		"EQUAL_EQUAL",
+		"FALSE",
+		"FAT_ARROW",
+		"FLOAT",
+		"FN",
+		"FOR",
+		"GREATER_EQUAL",
+		"GREATER_THAN",
+		"IDENTIFIER",
+		"IF",
+		"INT_BINARY",
+		"INT_DECIMAL",
+		"INT_HEX",
+		"LBRACE",
+		"LBRACKET",
+		"LESS_EQUAL",
+		"LESS_THAN",
+		"LET",
+		"LPAREN",
+		"MATCH",
+		"MINUS",
+		"MODULO",
+		"MULTIPLY",
+		"MUT",
+		"NEWLINE",
+		"NOT",
+		"NOT_EQUAL",
+		"OF",
+		"OR",
+		"PIPE",
+		"PLUS",
+		"RAW_STRING",
+		"RBRACE",
+		"RBRACKET",
+		"RETURN",
+		"RPAREN",
+		"STRING",
+		"TRUE",
+		"TYPE",
+		"WITH",
+	},
+	[]ll1.Entry{
+		{NonTerminal: "AddOp", Terminal: "MINUS", Production: parseTableProductions[0]},
+		{NonTerminal: "AddOp", Terminal: "PLUS", Production: parseTableProductions[1]},
+		{NonTerminal: "AddRest", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "AND", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "COLON", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "COMMA", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "EQUALS", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "EQUAL_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "GREATER_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "GREATER_THAN", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "LBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "LESS_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "LESS_THAN", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "MINUS", Production: parseTableProductions[3]},
+		{NonTerminal: "AddRest", Terminal: "NEWLINE", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "NOT_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "OR", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "PLUS", Production: parseTableProductions[3]},
+		{NonTerminal: "AddRest", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "AddRest", Terminal: "WITH", Production: parseTableProductions[2]},
+		{NonTerminal: "ArgumentList", Terminal: "FALSE", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "FLOAT", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "FN", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "IDENTIFIER", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "IF", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "INT_BINARY", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "INT_DECIMAL", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "INT_HEX", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "LBRACE", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "LBRACKET", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "LPAREN", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "MATCH", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "MINUS", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "NOT", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "RAW_STRING", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "STRING", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentList", Terminal: "TRUE", Production: parseTableProductions[4]},
+		{NonTerminal: "ArgumentRest", Terminal: "COMMA", Production: parseTableProductions[5]},
+		{NonTerminal: "ArgumentRest", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "Arguments", Terminal: "FALSE", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "FLOAT", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "FN", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "IDENTIFIER", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "IF", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "INT_BINARY", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "INT_DECIMAL", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "INT_HEX", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "LBRACE", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "LBRACKET", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "LPAREN", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "MATCH", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "MINUS", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "NOT", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "RAW_STRING", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "Arguments", Terminal: "STRING", Production: parseTableProductions[6]},
+		{NonTerminal: "Arguments", Terminal: "TRUE", Production: parseTableProductions[6]},
+		{NonTerminal: "Arithmetic", Terminal: "FALSE", Production: parseTableProductions[7]},
+		{NonTerminal: "Arithmetic", Terminal: "FLOAT", Production: parseTableProductions[7]},
+		{NonTerminal: "Arithmetic", Terminal: "IDENTIFIER", Production: parseTableProductions[7]},
+		{NonTerminal: "Arithmetic", Terminal: "IF", Production: parseTableProductions[7]},
+		{NonTerminal: "Arithmetic", Terminal: "INT_BINARY", Production: parseTableProductions[7]},
+		{NonTerminal: "Arithmetic", Terminal: "INT_DECIMAL", Production: parseTableProductions[7]},
+		{NonTerminal: "Arithmetic", Terminal: "INT_HEX", Production: parseTableProductions[7]},
+		{NonTerminal: "Arithmetic", Terminal: "LBRACKET", Production: parseTableProductions[7]},
+		{NonTerminal: "Arithmetic", Terminal: "LPAREN", Production: parseTableProductions[7]},
+		{NonTerminal: "Arithmetic", Terminal: "MATCH", Production: parseTableProductions[7]},
+		{NonTerminal: "Arithmetic", Terminal: "MINUS", Production: parseTableProductions[7]},
+		{NonTerminal: "Arithmetic", Terminal: "NOT", Production: parseTableProductions[7]},
+		{NonTerminal: "Arithmetic", Terminal: "RAW_STRING", Production: parseTableProductions[7]},
+		{NonTerminal: "Arithmetic", Terminal: "STRING", Production: parseTableProductions[7]},
+		{NonTerminal: "Arithmetic", Terminal: "TRUE", Production: parseTableProductions[7]},
+		{NonTerminal: "ArrayContent", Terminal: "FALSE", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "FLOAT", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "FN", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "IDENTIFIER", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "IF", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "INT_BINARY", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "INT_DECIMAL", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "INT_HEX", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "LBRACE", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "LBRACKET", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "LPAREN", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "MATCH", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "MINUS", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "NOT", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "RAW_STRING", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "ArrayContent", Terminal: "STRING", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayContent", Terminal: "TRUE", Production: parseTableProductions[8]},
+		{NonTerminal: "ArrayLiteral", Terminal: "LBRACKET", Production: parseTableProductions[9]},
+		{NonTerminal: "Assignment", Terminal: "FALSE", Production: parseTableProductions[10]},
+		{NonTerminal: "Assignment", Terminal: "FLOAT", Production: parseTableProductions[10]},
+		{NonTerminal: "Assignment", Terminal: "IDENTIFIER", Production: parseTableProductions[10]},
+		{NonTerminal: "Assignment", Terminal: "IF", Production: parseTableProductions[10]},
+		{NonTerminal: "Assignment", Terminal: "INT_BINARY", Production: parseTableProductions[10]},
+		{NonTerminal: "Assignment", Terminal: "INT_DECIMAL", Production: parseTableProductions[10]},
+		{NonTerminal: "Assignment", Terminal: "INT_HEX", Production: parseTableProductions[10]},
+		{NonTerminal: "Assignment", Terminal: "LBRACKET", Production: parseTableProductions[10]},
+		{NonTerminal: "Assignment", Terminal: "LPAREN", Production: parseTableProductions[10]},
+		{NonTerminal: "Assignment", Terminal: "MATCH", Production: parseTableProductions[10]},
+		{NonTerminal: "Assignment", Terminal: "MINUS", Production: parseTableProductions[10]},
+		{NonTerminal: "Assignment", Terminal: "NOT", Production: parseTableProductions[10]},
+		{NonTerminal: "Assignment", Terminal: "RAW_STRING", Production: parseTableProductions[10]},
+		{NonTerminal: "Assignment", Terminal: "STRING", Production: parseTableProductions[10]},
+		{NonTerminal: "Assignment", Terminal: "TRUE", Production: parseTableProductions[10]},
+		{NonTerminal: "AssignmentRest", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "AssignmentRest", Terminal: "COLON", Production: parseTableProductions[11]},
+		{NonTerminal: "AssignmentRest", Terminal: "COMMA", Production: parseTableProductions[2]},
+		{NonTerminal: "AssignmentRest", Terminal: "EQUALS", Production: parseTableProductions[12]},
+		{NonTerminal: "AssignmentRest", Terminal: "LBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "AssignmentRest", Terminal: "NEWLINE", Production: parseTableProductions[2]},
+		{NonTerminal: "AssignmentRest", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "AssignmentRest", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "AssignmentRest", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "AssignmentRest", Terminal: "WITH", Production: parseTableProductions[13]},
+		{NonTerminal: "Block", Terminal: "LBRACE", Production: parseTableProductions[14]},
+		{NonTerminal: "BlockStatements", Terminal: "BREAK", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "CONTINUE", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "FALSE", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "FLOAT", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "FN", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "FOR", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "IDENTIFIER", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "IF", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "INT_BINARY", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "INT_DECIMAL", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "INT_HEX", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "LBRACE", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "LBRACKET", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "LET", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "LPAREN", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "MATCH", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "MINUS", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "NEWLINE", Production: parseTableProductions[16]},
+		{NonTerminal: "BlockStatements", Terminal: "NOT", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "RAW_STRING", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "BlockStatements", Terminal: "RETURN", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "STRING", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStatements", Terminal: "TRUE", Production: parseTableProductions[15]},
+		{NonTerminal: "BlockStmtRest", Terminal: "NEWLINE", Production: parseTableProductions[16]},
+		{NonTerminal: "BlockStmtRest", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "BraceExpression", Terminal: "LBRACE", Production: parseTableProductions[17]},
+		{NonTerminal: "BraceStatements", Terminal: "BREAK", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "CONTINUE", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "FALSE", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "FLOAT", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "FN", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "FOR", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "IDENTIFIER", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "IF", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "INT_BINARY", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "INT_DECIMAL", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "INT_HEX", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "LBRACE", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "LBRACKET", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "LET", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "LPAREN", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "MATCH", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "MINUS", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "NEWLINE", Production: parseTableProductions[19]},
+		{NonTerminal: "BraceStatements", Terminal: "NOT", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "RAW_STRING", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "BraceStatements", Terminal: "RETURN", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "STRING", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStatements", Terminal: "TRUE", Production: parseTableProductions[18]},
+		{NonTerminal: "BraceStmtRest", Terminal: "COMMA", Production: parseTableProductions[20]},
+		{NonTerminal: "BraceStmtRest", Terminal: "NEWLINE", Production: parseTableProductions[19]},
+		{NonTerminal: "BraceStmtRest", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "BreakStatement", Terminal: "BREAK", Production: parseTableProductions[21]},
+		{NonTerminal: "Comparison", Terminal: "FALSE", Production: parseTableProductions[22]},
+		{NonTerminal: "Comparison", Terminal: "FLOAT", Production: parseTableProductions[22]},
+		{NonTerminal: "Comparison", Terminal: "IDENTIFIER", Production: parseTableProductions[22]},
+		{NonTerminal: "Comparison", Terminal: "IF", Production: parseTableProductions[22]},
+		{NonTerminal: "Comparison", Terminal: "INT_BINARY", Production: parseTableProductions[22]},
+		{NonTerminal: "Comparison", Terminal: "INT_DECIMAL", Production: parseTableProductions[22]},
+		{NonTerminal: "Comparison", Terminal: "INT_HEX", Production: parseTableProductions[22]},
+		{NonTerminal: "Comparison", Terminal: "LBRACKET", Production: parseTableProductions[22]},
+		{NonTerminal: "Comparison", Terminal: "LPAREN", Production: parseTableProductions[22]},
+		{NonTerminal: "Comparison", Terminal: "MATCH", Production: parseTableProductions[22]},
+		{NonTerminal: "Comparison", Terminal: "MINUS", Production: parseTableProductions[22]},
+		{NonTerminal: "Comparison", Terminal: "NOT", Production: parseTableProductions[22]},
+		{NonTerminal: "Comparison", Terminal: "RAW_STRING", Production: parseTableProductions[22]},
+		{NonTerminal: "Comparison", Terminal: "STRING", Production: parseTableProductions[22]},
+		{NonTerminal: "Comparison", Terminal: "TRUE", Production: parseTableProductions[22]},
+		{NonTerminal: "ComparisonOp", Terminal: "GREATER_EQUAL", Production: parseTableProductions[23]},
+		{NonTerminal: "ComparisonOp", Terminal: "GREATER_THAN", Production: parseTableProductions[24]},
+		{NonTerminal: "ComparisonOp", Terminal: "LESS_EQUAL", Production: parseTableProductions[25]},
+		{NonTerminal: "ComparisonOp", Terminal: "LESS_THAN", Production: parseTableProductions[26]},
+		{NonTerminal: "ComparisonRest", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "ComparisonRest", Terminal: "AND", Production: parseTableProductions[2]},
+		{NonTerminal: "ComparisonRest", Terminal: "COLON", Production: parseTableProductions[2]},
+		{NonTerminal: "ComparisonRest", Terminal: "COMMA", Production: parseTableProductions[2]},
+		{NonTerminal: "ComparisonRest", Terminal: "EQUALS", Production: parseTableProductions[2]},
+		{NonTerminal: "ComparisonRest", Terminal: "EQUAL_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "ComparisonRest", Terminal: "GREATER_EQUAL", Production: parseTableProductions[27]},
+		{NonTerminal: "ComparisonRest", Terminal: "GREATER_THAN", Production: parseTableProductions[27]},
+		{NonTerminal: "ComparisonRest", Terminal: "LBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "ComparisonRest", Terminal: "LESS_EQUAL", Production: parseTableProductions[27]},
+		{NonTerminal: "ComparisonRest", Terminal: "LESS_THAN", Production: parseTableProductions[27]},
+		{NonTerminal: "ComparisonRest", Terminal: "NEWLINE", Production: parseTableProductions[2]},
+		{NonTerminal: "ComparisonRest", Terminal: "NOT_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "ComparisonRest", Terminal: "OR", Production: parseTableProductions[2]},
+		{NonTerminal: "ComparisonRest", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "ComparisonRest", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "ComparisonRest", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "ComparisonRest", Terminal: "WITH", Production: parseTableProductions[2]},
+		{NonTerminal: "ContinueStatement", Terminal: "CONTINUE", Production: parseTableProductions[28]},
+		{NonTerminal: "ElementList", Terminal: "FALSE", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "FLOAT", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "FN", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "IDENTIFIER", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "IF", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "INT_BINARY", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "INT_DECIMAL", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "INT_HEX", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "LBRACE", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "LBRACKET", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "LPAREN", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "MATCH", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "MINUS", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "NOT", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "RAW_STRING", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "STRING", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementList", Terminal: "TRUE", Production: parseTableProductions[29]},
+		{NonTerminal: "ElementRest", Terminal: "COMMA", Production: parseTableProductions[30]},
+		{NonTerminal: "ElementRest", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseBody", Terminal: "IF", Production: parseTableProductions[31]},
+		{NonTerminal: "ElseBody", Terminal: "LBRACE", Production: parseTableProductions[32]},
+		{NonTerminal: "ElseClause", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "AND", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "COLON", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "COMMA", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "DIVIDE", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "ELSE", Production: parseTableProductions[33]},
+		{NonTerminal: "ElseClause", Terminal: "EQUALS", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "EQUAL_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "GREATER_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "GREATER_THAN", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "LBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "LESS_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "LESS_THAN", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "MINUS", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "MODULO", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "MULTIPLY", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "NEWLINE", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "NOT_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "OR", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "PLUS", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "ElseClause", Terminal: "WITH", Production: parseTableProductions[2]},
+		{NonTerminal: "Equality", Terminal: "FALSE", Production: parseTableProductions[34]},
+		{NonTerminal: "Equality", Terminal: "FLOAT", Production: parseTableProductions[34]},
+		{NonTerminal: "Equality", Terminal: "IDENTIFIER", Production: parseTableProductions[34]},
+		{NonTerminal: "Equality", Terminal: "IF", Production: parseTableProductions[34]},
+		{NonTerminal: "Equality", Terminal: "INT_BINARY", Production: parseTableProductions[34]},
+		{NonTerminal: "Equality", Terminal: "INT_DECIMAL", Production: parseTableProductions[34]},
+		{NonTerminal: "Equality", Terminal: "INT_HEX", Production: parseTableProductions[34]},
+		{NonTerminal: "Equality", Terminal: "LBRACKET", Production: parseTableProductions[34]},
+		{NonTerminal: "Equality", Terminal: "LPAREN", Production: parseTableProductions[34]},
+		{NonTerminal: "Equality", Terminal: "MATCH", Production: parseTableProductions[34]},
+		{NonTerminal: "Equality", Terminal: "MINUS", Production: parseTableProductions[34]},
+		{NonTerminal: "Equality", Terminal: "NOT", Production: parseTableProductions[34]},
+		{NonTerminal: "Equality", Terminal: "RAW_STRING", Production: parseTableProductions[34]},
+		{NonTerminal: "Equality", Terminal: "STRING", Production: parseTableProductions[34]},
+		{NonTerminal: "Equality", Terminal: "TRUE", Production: parseTableProductions[34]},
+		{NonTerminal: "EqualityOp", Terminal: "EQUAL_EQUAL", Production: parseTableProductions[35]},
+		{NonTerminal: "EqualityOp", Terminal: "NOT_EQUAL", Production: parseTableProductions[36]},
+		{NonTerminal: "EqualityRest", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "EqualityRest", Terminal: "AND", Production: parseTableProductions[2]},
+		{NonTerminal: "EqualityRest", Terminal: "COLON", Production: parseTableProductions[2]},
+		{NonTerminal: "EqualityRest", Terminal: "COMMA", Production: parseTableProductions[2]},
+		{NonTerminal: "EqualityRest", Terminal: "EQUALS", Production: parseTableProductions[2]},
+		{NonTerminal: "EqualityRest", Terminal: "EQUAL_EQUAL", Production: parseTableProductions[37]},
+		{NonTerminal: "EqualityRest", Terminal: "LBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "EqualityRest", Terminal: "NEWLINE", Production: parseTableProductions[2]},
+		{NonTerminal: "EqualityRest", Terminal: "NOT_EQUAL", Production: parseTableProductions[37]},
+		{NonTerminal: "EqualityRest", Terminal: "OR", Production: parseTableProductions[2]},
+		{NonTerminal: "EqualityRest", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "EqualityRest", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "EqualityRest", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "EqualityRest", Terminal: "WITH", Production: parseTableProductions[2]},
+		{NonTerminal: "Expression", Terminal: "FALSE", Production: parseTableProductions[38]},
+		{NonTerminal: "Expression", Terminal: "FLOAT", Production: parseTableProductions[38]},
+		{NonTerminal: "Expression", Terminal: "FN", Production: parseTableProductions[39]},
+		{NonTerminal: "Expression", Terminal: "IDENTIFIER", Production: parseTableProductions[38]},
+		{NonTerminal: "Expression", Terminal: "IF", Production: parseTableProductions[38]},
+		{NonTerminal: "Expression", Terminal: "INT_BINARY", Production: parseTableProductions[38]},
+		{NonTerminal: "Expression", Terminal: "INT_DECIMAL", Production: parseTableProductions[38]},
+		{NonTerminal: "Expression", Terminal: "INT_HEX", Production: parseTableProductions[38]},
+		{NonTerminal: "Expression", Terminal: "LBRACE", Production: parseTableProductions[40]},
+		{NonTerminal: "Expression", Terminal: "LBRACKET", Production: parseTableProductions[38]},
+		{NonTerminal: "Expression", Terminal: "LPAREN", Production: parseTableProductions[38]},
+		{NonTerminal: "Expression", Terminal: "MATCH", Production: parseTableProductions[38]},
+		{NonTerminal: "Expression", Terminal: "MINUS", Production: parseTableProductions[38]},
+		{NonTerminal: "Expression", Terminal: "NOT", Production: parseTableProductions[38]},
+		{NonTerminal: "Expression", Terminal: "RAW_STRING", Production: parseTableProductions[38]},
+		{NonTerminal: "Expression", Terminal: "STRING", Production: parseTableProductions[38]},
+		{NonTerminal: "Expression", Terminal: "TRUE", Production: parseTableProductions[38]},
+		{NonTerminal: "ExpressionStatement", Terminal: "FALSE", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "FLOAT", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "FN", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "IDENTIFIER", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "IF", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "INT_BINARY", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "INT_DECIMAL", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "INT_HEX", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "LBRACE", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "LBRACKET", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "LPAREN", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "MATCH", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "MINUS", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "NOT", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "RAW_STRING", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "STRING", Production: parseTableProductions[41]},
+		{NonTerminal: "ExpressionStatement", Terminal: "TRUE", Production: parseTableProductions[41]},
+		{NonTerminal: "ForCondition", Terminal: "FALSE", Production: parseTableProductions[42]},
+		{NonTerminal: "ForCondition", Terminal: "FLOAT", Production: parseTableProductions[42]},
+		{NonTerminal: "ForCondition", Terminal: "IDENTIFIER", Production: parseTableProductions[42]},
+		{NonTerminal: "ForCondition", Terminal: "IF", Production: parseTableProductions[42]},
+		{NonTerminal: "ForCondition", Terminal: "INT_BINARY", Production: parseTableProductions[42]},
+		{NonTerminal: "ForCondition", Terminal: "INT_DECIMAL", Production: parseTableProductions[42]},
+		{NonTerminal: "ForCondition", Terminal: "INT_HEX", Production: parseTableProductions[42]},
+		{NonTerminal: "ForCondition", Terminal: "LBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "ForCondition", Terminal: "LBRACKET", Production: parseTableProductions[42]},
+		{NonTerminal: "ForCondition", Terminal: "LPAREN", Production: parseTableProductions[42]},
+		{NonTerminal: "ForCondition", Terminal: "MATCH", Production: parseTableProductions[42]},
+		{NonTerminal: "ForCondition", Terminal: "MINUS", Production: parseTableProductions[42]},
+		{NonTerminal: "ForCondition", Terminal: "NOT", Production: parseTableProductions[42]},
+		{NonTerminal: "ForCondition", Terminal: "RAW_STRING", Production: parseTableProductions[42]},
+		{NonTerminal: "ForCondition", Terminal: "STRING", Production: parseTableProductions[42]},
+		{NonTerminal: "ForCondition", Terminal: "TRUE", Production: parseTableProductions[42]},
+		{NonTerminal: "ForStatement", Terminal: "FOR", Production: parseTableProductions[43]},
+		{NonTerminal: "FunctionDef", Terminal: "FN", Production: parseTableProductions[44]},
+		{NonTerminal: "FunctionLiteral", Terminal: "FN", Production: parseTableProductions[45]},
+		{NonTerminal: "IfExpression", Terminal: "IF", Production: parseTableProductions[46]},
+		{NonTerminal: "IndexAssignment", Terminal: "IDENTIFIER", Production: parseTableProductions[47]},
+		{NonTerminal: "IndexChain", Terminal: "LBRACKET", Production: parseTableProductions[48]},
+		{NonTerminal: "IndexChainRest", Terminal: "EQUALS", Production: parseTableProductions[2]},
+		{NonTerminal: "IndexChainRest", Terminal: "LBRACKET", Production: parseTableProductions[49]},
+		{NonTerminal: "LetStatement", Terminal: "LET", Production: parseTableProductions[50]},
+		{NonTerminal: "LetTarget", Terminal: "IDENTIFIER", Production: parseTableProductions[51]},
+		{NonTerminal: "LetTarget", Terminal: "LPAREN", Production: parseTableProductions[52]},
+		{NonTerminal: "Literal", Terminal: "FALSE", Production: parseTableProductions[53]},
+		{NonTerminal: "Literal", Terminal: "FLOAT", Production: parseTableProductions[54]},
+		{NonTerminal: "Literal", Terminal: "INT_BINARY", Production: parseTableProductions[55]},
+		{NonTerminal: "Literal", Terminal: "INT_DECIMAL", Production: parseTableProductions[56]},
+		{NonTerminal: "Literal", Terminal: "INT_HEX", Production: parseTableProductions[57]},
+		{NonTerminal: "Literal", Terminal: "RAW_STRING", Production: parseTableProductions[58]},
+		{NonTerminal: "Literal", Terminal: "STRING", Production: parseTableProductions[59]},
+		{NonTerminal: "Literal", Terminal: "TRUE", Production: parseTableProductions[60]},
+		{NonTerminal: "LogicalAnd", Terminal: "FALSE", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAnd", Terminal: "FLOAT", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAnd", Terminal: "IDENTIFIER", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAnd", Terminal: "IF", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAnd", Terminal: "INT_BINARY", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAnd", Terminal: "INT_DECIMAL", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAnd", Terminal: "INT_HEX", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAnd", Terminal: "LBRACKET", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAnd", Terminal: "LPAREN", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAnd", Terminal: "MATCH", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAnd", Terminal: "MINUS", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAnd", Terminal: "NOT", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAnd", Terminal: "RAW_STRING", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAnd", Terminal: "STRING", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAnd", Terminal: "TRUE", Production: parseTableProductions[61]},
+		{NonTerminal: "LogicalAndRest", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalAndRest", Terminal: "AND", Production: parseTableProductions[62]},
+		{NonTerminal: "LogicalAndRest", Terminal: "COLON", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalAndRest", Terminal: "COMMA", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalAndRest", Terminal: "EQUALS", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalAndRest", Terminal: "LBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalAndRest", Terminal: "NEWLINE", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalAndRest", Terminal: "OR", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalAndRest", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalAndRest", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalAndRest", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalAndRest", Terminal: "WITH", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalOr", Terminal: "FALSE", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOr", Terminal: "FLOAT", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOr", Terminal: "IDENTIFIER", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOr", Terminal: "IF", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOr", Terminal: "INT_BINARY", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOr", Terminal: "INT_DECIMAL", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOr", Terminal: "INT_HEX", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOr", Terminal: "LBRACKET", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOr", Terminal: "LPAREN", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOr", Terminal: "MATCH", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOr", Terminal: "MINUS", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOr", Terminal: "NOT", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOr", Terminal: "RAW_STRING", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOr", Terminal: "STRING", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOr", Terminal: "TRUE", Production: parseTableProductions[63]},
+		{NonTerminal: "LogicalOrRest", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalOrRest", Terminal: "COLON", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalOrRest", Terminal: "COMMA", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalOrRest", Terminal: "EQUALS", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalOrRest", Terminal: "LBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalOrRest", Terminal: "NEWLINE", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalOrRest", Terminal: "OR", Production: parseTableProductions[64]},
+		{NonTerminal: "LogicalOrRest", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalOrRest", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalOrRest", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "LogicalOrRest", Terminal: "WITH", Production: parseTableProductions[2]},
+		{NonTerminal: "MatchArm", Terminal: "FALSE", Production: parseTableProductions[65]},
+		{NonTerminal: "MatchArm", Terminal: "FLOAT", Production: parseTableProductions[65]},
+		{NonTerminal: "MatchArm", Terminal: "IDENTIFIER", Production: parseTableProductions[65]},
+		{NonTerminal: "MatchArm", Terminal: "INT_BINARY", Production: parseTableProductions[65]},
+		{NonTerminal: "MatchArm", Terminal: "INT_DECIMAL", Production: parseTableProductions[65]},
+		{NonTerminal: "MatchArm", Terminal: "INT_HEX", Production: parseTableProductions[65]},
+		{NonTerminal: "MatchArm", Terminal: "LBRACKET", Production: parseTableProductions[65]},
+		{NonTerminal: "MatchArm", Terminal: "LPAREN", Production: parseTableProductions[65]},
+		{NonTerminal: "MatchArm", Terminal: "MINUS", Production: parseTableProductions[65]},
+		{NonTerminal: "MatchArm", Terminal: "RAW_STRING", Production: parseTableProductions[65]},
+		{NonTerminal: "MatchArm", Terminal: "STRING", Production: parseTableProductions[65]},
+		{NonTerminal: "MatchArm", Terminal: "TRUE", Production: parseTableProductions[65]},
+		{NonTerminal: "MatchArmRest", Terminal: "COMMA", Production: parseTableProductions[66]},
+		{NonTerminal: "MatchArmRest", Terminal: "NEWLINE", Production: parseTableProductions[67]},
+		{NonTerminal: "MatchArmRest", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "MatchArms", Terminal: "FALSE", Production: parseTableProductions[68]},
+		{NonTerminal: "MatchArms", Terminal: "FLOAT", Production: parseTableProductions[68]},
+		{NonTerminal: "MatchArms", Terminal: "IDENTIFIER", Production: parseTableProductions[68]},
+		{NonTerminal: "MatchArms", Terminal: "INT_BINARY", Production: parseTableProductions[68]},
+		{NonTerminal: "MatchArms", Terminal: "INT_DECIMAL", Production: parseTableProductions[68]},
+		{NonTerminal: "MatchArms", Terminal: "INT_HEX", Production: parseTableProductions[68]},
+		{NonTerminal: "MatchArms", Terminal: "LBRACKET", Production: parseTableProductions[68]},
+		{NonTerminal: "MatchArms", Terminal: "LPAREN", Production: parseTableProductions[68]},
+		{NonTerminal: "MatchArms", Terminal: "MINUS", Production: parseTableProductions[68]},
+		{NonTerminal: "MatchArms", Terminal: "NEWLINE", Production: parseTableProductions[67]},
+		{NonTerminal: "MatchArms", Terminal: "RAW_STRING", Production: parseTableProductions[68]},
+		{NonTerminal: "MatchArms", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "MatchArms", Terminal: "STRING", Production: parseTableProductions[68]},
+		{NonTerminal: "MatchArms", Terminal: "TRUE", Production: parseTableProductions[68]},
+		{NonTerminal: "MatchExpression", Terminal: "MATCH", Production: parseTableProductions[69]},
+		{NonTerminal: "MulOp", Terminal: "DIVIDE", Production: parseTableProductions[70]},
+		{NonTerminal: "MulOp", Terminal: "MODULO", Production: parseTableProductions[71]},
+		{NonTerminal: "MulOp", Terminal: "MULTIPLY", Production: parseTableProductions[72]},
+		{NonTerminal: "MulRest", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "AND", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "COLON", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "COMMA", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "DIVIDE", Production: parseTableProductions[73]},
+		{NonTerminal: "MulRest", Terminal: "EQUALS", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "EQUAL_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "GREATER_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "GREATER_THAN", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "LBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "LESS_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "LESS_THAN", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "MINUS", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "MODULO", Production: parseTableProductions[73]},
+		{NonTerminal: "MulRest", Terminal: "MULTIPLY", Production: parseTableProductions[73]},
+		{NonTerminal: "MulRest", Terminal: "NEWLINE", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "NOT_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "OR", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "PLUS", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "MulRest", Terminal: "WITH", Production: parseTableProductions[2]},
+		{NonTerminal: "MutModifier", Terminal: "IDENTIFIER", Production: parseTableProductions[2]},
+		{NonTerminal: "MutModifier", Terminal: "LPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "MutModifier", Terminal: "MUT", Production: parseTableProductions[74]},
+		{NonTerminal: "ParameterList", Terminal: "IDENTIFIER", Production: parseTableProductions[75]},
+		{NonTerminal: "ParameterList", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "ParameterRest", Terminal: "COMMA", Production: parseTableProductions[76]},
+		{NonTerminal: "ParameterRest", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "ParenContent", Terminal: "FALSE", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "FLOAT", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "FN", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "IDENTIFIER", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "IF", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "INT_BINARY", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "INT_DECIMAL", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "INT_HEX", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "LBRACE", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "LBRACKET", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "LPAREN", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "MATCH", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "MINUS", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "NOT", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "RAW_STRING", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "ParenContent", Terminal: "STRING", Production: parseTableProductions[77]},
+		{NonTerminal: "ParenContent", Terminal: "TRUE", Production: parseTableProductions[77]},
+		{NonTerminal: "Pattern", Terminal: "FALSE", Production: parseTableProductions[78]},
+		{NonTerminal: "Pattern", Terminal: "FLOAT", Production: parseTableProductions[78]},
+		{NonTerminal: "Pattern", Terminal: "IDENTIFIER", Production: parseTableProductions[79]},
+		{NonTerminal: "Pattern", Terminal: "INT_BINARY", Production: parseTableProductions[78]},
+		{NonTerminal: "Pattern", Terminal: "INT_DECIMAL", Production: parseTableProductions[78]},
+		{NonTerminal: "Pattern", Terminal: "INT_HEX", Production: parseTableProductions[78]},
+		{NonTerminal: "Pattern", Terminal: "LBRACKET", Production: parseTableProductions[80]},
+		{NonTerminal: "Pattern", Terminal: "LPAREN", Production: parseTableProductions[52]},
+		{NonTerminal: "Pattern", Terminal: "MINUS", Production: parseTableProductions[81]},
+		{NonTerminal: "Pattern", Terminal: "RAW_STRING", Production: parseTableProductions[78]},
+		{NonTerminal: "Pattern", Terminal: "STRING", Production: parseTableProductions[78]},
+		{NonTerminal: "Pattern", Terminal: "TRUE", Production: parseTableProductions[78]},
+		{NonTerminal: "PatternArgs", Terminal: "COMMA", Production: parseTableProductions[2]},
+		{NonTerminal: "PatternArgs", Terminal: "FAT_ARROW", Production: parseTableProductions[2]},
+		{NonTerminal: "PatternArgs", Terminal: "LPAREN", Production: parseTableProductions[82]},
+		{NonTerminal: "PatternArgs", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "PatternArgs", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "PatternList", Terminal: "FALSE", Production: parseTableProductions[83]},
+		{NonTerminal: "PatternList", Terminal: "FLOAT", Production: parseTableProductions[83]},
+		{NonTerminal: "PatternList", Terminal: "IDENTIFIER", Production: parseTableProductions[83]},
+		{NonTerminal: "PatternList", Terminal: "INT_BINARY", Production: parseTableProductions[83]},
+		{NonTerminal: "PatternList", Terminal: "INT_DECIMAL", Production: parseTableProductions[83]},
+		{NonTerminal: "PatternList", Terminal: "INT_HEX", Production: parseTableProductions[83]},
+		{NonTerminal: "PatternList", Terminal: "LBRACKET", Production: parseTableProductions[83]},
+		{NonTerminal: "PatternList", Terminal: "LPAREN", Production: parseTableProductions[83]},
+		{NonTerminal: "PatternList", Terminal: "MINUS", Production: parseTableProductions[83]},
+		{NonTerminal: "PatternList", Terminal: "RAW_STRING", Production: parseTableProductions[83]},
+		{NonTerminal: "PatternList", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "PatternList", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "PatternList", Terminal: "STRING", Production: parseTableProductions[83]},
+		{NonTerminal: "PatternList", Terminal: "TRUE", Production: parseTableProductions[83]},
+		{NonTerminal: "PatternRest", Terminal: "COMMA", Production: parseTableProductions[84]},
+		{NonTerminal: "PatternRest", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "PatternRest", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "Primary", Terminal: "FALSE", Production: parseTableProductions[78]},
+		{NonTerminal: "Primary", Terminal: "FLOAT", Production: parseTableProductions[78]},
+		{NonTerminal: "Primary", Terminal: "IDENTIFIER", Production: parseTableProductions[85]},
+		{NonTerminal: "Primary", Terminal: "IF", Production: parseTableProductions[31]},
+		{NonTerminal: "Primary", Terminal: "INT_BINARY", Production: parseTableProductions[78]},
+		{NonTerminal: "Primary", Terminal: "INT_DECIMAL", Production: parseTableProductions[78]},
+		{NonTerminal: "Primary", Terminal: "INT_HEX", Production: parseTableProductions[78]},
+		{NonTerminal: "Primary", Terminal: "LBRACKET", Production: parseTableProductions[86]},
+		{NonTerminal: "Primary", Terminal: "LPAREN", Production: parseTableProductions[87]},
+		{NonTerminal: "Primary", Terminal: "MATCH", Production: parseTableProductions[88]},
+		{NonTerminal: "Primary", Terminal: "RAW_STRING", Production: parseTableProductions[78]},
+		{NonTerminal: "Primary", Terminal: "STRING", Production: parseTableProductions[78]},
+		{NonTerminal: "Primary", Terminal: "TRUE", Production: parseTableProductions[78]},
+		{NonTerminal: "PrimaryRest", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "AND", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "COLON", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "COMMA", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "DIVIDE", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "DOT", Production: parseTableProductions[89]},
+		{NonTerminal: "PrimaryRest", Terminal: "EQUALS", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "EQUAL_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "GREATER_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "GREATER_THAN", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "LBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "LBRACKET", Production: parseTableProductions[90]},
+		{NonTerminal: "PrimaryRest", Terminal: "LESS_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "LESS_THAN", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "LPAREN", Production: parseTableProductions[91]},
+		{NonTerminal: "PrimaryRest", Terminal: "MINUS", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "MODULO", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "MULTIPLY", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "NEWLINE", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "NOT_EQUAL", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "OR", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "PLUS", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "PrimaryRest", Terminal: "WITH", Production: parseTableProductions[2]},
+		{NonTerminal: "Program", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "Program", Terminal: "FALSE", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "FLOAT", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "FN", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "IDENTIFIER", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "IF", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "INT_BINARY", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "INT_DECIMAL", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "INT_HEX", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "LBRACKET", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "LET", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "LPAREN", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "MATCH", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "MINUS", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "NEWLINE", Production: parseTableProductions[93]},
+		{NonTerminal: "Program", Terminal: "NOT", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "RAW_STRING", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "STRING", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "TRUE", Production: parseTableProductions[92]},
+		{NonTerminal: "Program", Terminal: "TYPE", Production: parseTableProductions[92]},
+		{NonTerminal: "RecordFieldRest", Terminal: "COMMA", Production: parseTableProductions[94]},
+		{NonTerminal: "RecordFieldRest", Terminal: "NEWLINE", Production: parseTableProductions[95]},
+		{NonTerminal: "RecordFieldRest", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "RecordFields", Terminal: "IDENTIFIER", Production: parseTableProductions[96]},
+		{NonTerminal: "RecordFields", Terminal: "NEWLINE", Production: parseTableProductions[95]},
+		{NonTerminal: "RecordFields", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "ReturnStatement", Terminal: "RETURN", Production: parseTableProductions[97]},
+		{NonTerminal: "ReturnType", Terminal: "ARROW", Production: parseTableProductions[98]},
+		{NonTerminal: "ReturnType", Terminal: "LBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "Statement", Terminal: "BREAK", Production: parseTableProductions[99]},
+		{NonTerminal: "Statement", Terminal: "CONTINUE", Production: parseTableProductions[100]},
+		{NonTerminal: "Statement", Terminal: "FALSE", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "FLOAT", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "FN", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "FOR", Production: parseTableProductions[102]},
+		{NonTerminal: "Statement", Terminal: "IDENTIFIER", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "IF", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "INT_BINARY", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "INT_DECIMAL", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "INT_HEX", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "LBRACE", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "LBRACKET", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "LET", Production: parseTableProductions[103]},
+		{NonTerminal: "Statement", Terminal: "LPAREN", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "MATCH", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "MINUS", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "NOT", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "RAW_STRING", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "RETURN", Production: parseTableProductions[104]},
+		{NonTerminal: "Statement", Terminal: "STRING", Production: parseTableProductions[101]},
+		{NonTerminal: "Statement", Terminal: "TRUE", Production: parseTableProductions[101]},
+		{NonTerminal: "Term", Terminal: "FALSE", Production: parseTableProductions[105]},
+		{NonTerminal: "Term", Terminal: "FLOAT", Production: parseTableProductions[105]},
+		{NonTerminal: "Term", Terminal: "IDENTIFIER", Production: parseTableProductions[105]},
+		{NonTerminal: "Term", Terminal: "IF", Production: parseTableProductions[105]},
+		{NonTerminal: "Term", Terminal: "INT_BINARY", Production: parseTableProductions[105]},
+		{NonTerminal: "Term", Terminal: "INT_DECIMAL", Production: parseTableProductions[105]},
+		{NonTerminal: "Term", Terminal: "INT_HEX", Production: parseTableProductions[105]},
+		{NonTerminal: "Term", Terminal: "LBRACKET", Production: parseTableProductions[105]},
+		{NonTerminal: "Term", Terminal: "LPAREN", Production: parseTableProductions[105]},
+		{NonTerminal: "Term", Terminal: "MATCH", Production: parseTableProductions[105]},
+		{NonTerminal: "Term", Terminal: "MINUS", Production: parseTableProductions[105]},
+		{NonTerminal: "Term", Terminal: "NOT", Production: parseTableProductions[105]},
+		{NonTerminal: "Term", Terminal: "RAW_STRING", Production: parseTableProductions[105]},
+		{NonTerminal: "Term", Terminal: "STRING", Production: parseTableProductions[105]},
+		{NonTerminal: "Term", Terminal: "TRUE", Production: parseTableProductions[105]},
+		{NonTerminal: "TopLevelExpression", Terminal: "FALSE", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelExpression", Terminal: "FLOAT", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelExpression", Terminal: "IDENTIFIER", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelExpression", Terminal: "IF", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelExpression", Terminal: "INT_BINARY", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelExpression", Terminal: "INT_DECIMAL", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelExpression", Terminal: "INT_HEX", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelExpression", Terminal: "LBRACKET", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelExpression", Terminal: "LPAREN", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelExpression", Terminal: "MATCH", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelExpression", Terminal: "MINUS", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelExpression", Terminal: "NOT", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelExpression", Terminal: "RAW_STRING", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelExpression", Terminal: "STRING", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelExpression", Terminal: "TRUE", Production: parseTableProductions[38]},
+		{NonTerminal: "TopLevelItem", Terminal: "FALSE", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "FLOAT", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "FN", Production: parseTableProductions[107]},
+		{NonTerminal: "TopLevelItem", Terminal: "IDENTIFIER", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "IF", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "INT_BINARY", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "INT_DECIMAL", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "INT_HEX", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "LBRACKET", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "LET", Production: parseTableProductions[103]},
+		{NonTerminal: "TopLevelItem", Terminal: "LPAREN", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "MATCH", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "MINUS", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "NOT", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "RAW_STRING", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "STRING", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "TRUE", Production: parseTableProductions[106]},
+		{NonTerminal: "TopLevelItem", Terminal: "TYPE", Production: parseTableProductions[108]},
+		{NonTerminal: "TopLevelItemRest", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "TopLevelItemRest", Terminal: "NEWLINE", Production: parseTableProductions[109]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "FALSE", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "FLOAT", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "FN", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "IDENTIFIER", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "IF", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "INT_BINARY", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "INT_DECIMAL", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "INT_HEX", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "LBRACKET", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "LET", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "LPAREN", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "MATCH", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "MINUS", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "NEWLINE", Production: parseTableProductions[109]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "NOT", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "RAW_STRING", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "STRING", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "TRUE", Production: parseTableProductions[92]},
+		{NonTerminal: "TopLevelItemRest2", Terminal: "TYPE", Production: parseTableProductions[92]},
+		{NonTerminal: "TupleElements", Terminal: "FALSE", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "FLOAT", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "FN", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "IDENTIFIER", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "IF", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "INT_BINARY", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "INT_DECIMAL", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "INT_HEX", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "LBRACE", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "LBRACKET", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "LPAREN", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "MATCH", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "MINUS", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "NOT", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "RAW_STRING", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "TupleElements", Terminal: "STRING", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleElements", Terminal: "TRUE", Production: parseTableProductions[77]},
+		{NonTerminal: "TupleRest", Terminal: "COMMA", Production: parseTableProductions[110]},
+		{NonTerminal: "TupleRest", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeAnnotation", Terminal: "COLON", Production: parseTableProductions[111]},
+		{NonTerminal: "TypeAnnotation", Terminal: "COMMA", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeAnnotation", Terminal: "EQUALS", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeAnnotation", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeArgs", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeArgs", Terminal: "COMMA", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeArgs", Terminal: "EQUALS", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeArgs", Terminal: "GREATER_THAN", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeArgs", Terminal: "LBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeArgs", Terminal: "LESS_THAN", Production: parseTableProductions[112]},
+		{NonTerminal: "TypeArgs", Terminal: "NEWLINE", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeArgs", Terminal: "PIPE", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeArgs", Terminal: "RBRACE", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeArgs", Terminal: "RBRACKET", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeArgs", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeBody", Terminal: "IDENTIFIER", Production: parseTableProductions[113]},
+		{NonTerminal: "TypeBody", Terminal: "LBRACE", Production: parseTableProductions[114]},
+		{NonTerminal: "TypeBody", Terminal: "PIPE", Production: parseTableProductions[115]},
+		{NonTerminal: "TypeDecl", Terminal: "TYPE", Production: parseTableProductions[116]},
+		{NonTerminal: "TypeExpr", Terminal: "FN", Production: parseTableProductions[117]},
+		{NonTerminal: "TypeExpr", Terminal: "IDENTIFIER", Production: parseTableProductions[118]},
+		{NonTerminal: "TypeExpr", Terminal: "LBRACKET", Production: parseTableProductions[119]},
+		{NonTerminal: "TypeExpr", Terminal: "LPAREN", Production: parseTableProductions[120]},
+		{NonTerminal: "TypeExprList", Terminal: "FN", Production: parseTableProductions[121]},
+		{NonTerminal: "TypeExprList", Terminal: "IDENTIFIER", Production: parseTableProductions[121]},
+		{NonTerminal: "TypeExprList", Terminal: "LBRACKET", Production: parseTableProductions[121]},
+		{NonTerminal: "TypeExprList", Terminal: "LPAREN", Production: parseTableProductions[121]},
+		{NonTerminal: "TypeExprList", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeExprRest", Terminal: "COMMA", Production: parseTableProductions[122]},
+		{NonTerminal: "TypeExprRest", Terminal: "GREATER_THAN", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeExprRest", Terminal: "RPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeParamRest", Terminal: "COMMA", Production: parseTableProductions[123]},
+		{NonTerminal: "TypeParamRest", Terminal: "GREATER_THAN", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeParams", Terminal: "EQUALS", Production: parseTableProductions[2]},
+		{NonTerminal: "TypeParams", Terminal: "LESS_THAN", Production: parseTableProductions[124]},
+		{NonTerminal: "TypeParams", Terminal: "LPAREN", Production: parseTableProductions[2]},
+		{NonTerminal: "Unary", Terminal: "FALSE", Production: parseTableProductions[125]},
+		{NonTerminal: "Unary", Terminal: "FLOAT", Production: parseTableProductions[125]},
+		{NonTerminal: "Unary", Terminal: "IDENTIFIER", Production: parseTableProductions[125]},
+		{NonTerminal: "Unary", Terminal: "IF", Production: parseTableProductions[125]},
+		{NonTerminal: "Unary", Terminal: "INT_BINARY", Production: parseTableProductions[125]},
+		{NonTerminal: "Unary", Terminal: "INT_DECIMAL", Production: parseTableProductions[125]},
+		{NonTerminal: "Unary", Terminal: "INT_HEX", Production: parseTableProductions[125]},
+		{NonTerminal: "Unary", Terminal: "LBRACKET", Production: parseTableProductions[125]},
+		{NonTerminal: "Unary", Terminal: "LPAREN", Production: parseTableProductions[125]},
+		{NonTerminal: "Unary", Terminal: "MATCH", Production: parseTableProductions[125]},
+		{NonTerminal: "Unary", Terminal: "MINUS", Production: parseTableProductions[126]},
+		{NonTerminal: "Unary", Terminal: "NOT", Production: parseTableProductions[126]},
+		{NonTerminal: "Unary", Terminal: "RAW_STRING", Production: parseTableProductions[125]},
+		{NonTerminal: "Unary", Terminal: "STRING", Production: parseTableProductions[125]},
+		{NonTerminal: "Unary", Terminal: "TRUE", Production: parseTableProductions[125]},
+		{NonTerminal: "UnaryOp", Terminal: "MINUS", Production: parseTableProductions[0]},
+		{NonTerminal: "UnaryOp", Terminal: "NOT", Production: parseTableProductions[127]},
+		{NonTerminal: "Variant", Terminal: "IDENTIFIER", Production: parseTableProductions[128]},
+		{NonTerminal: "VariantList", Terminal: "IDENTIFIER", Production: parseTableProductions[129]},
+		{NonTerminal: "VariantPayload", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "VariantPayload", Terminal: "NEWLINE", Production: parseTableProductions[2]},
+		{NonTerminal: "VariantPayload", Terminal: "OF", Production: parseTableProductions[130]},
+		{NonTerminal: "VariantPayload", Terminal: "PIPE", Production: parseTableProductions[2]},
+		{NonTerminal: "VariantRest", Terminal: "$", Production: parseTableProductions[2]},
+		{NonTerminal: "VariantRest", Terminal: "NEWLINE", Production: parseTableProductions[2]},
+		{NonTerminal: "VariantRest", Terminal: "PIPE", Production: parseTableProductions[131]},
+	},
+)
@@ -0,0 +1,97 @@
+package langdef
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/shadowCow/cow-lang-go/tooling/automata"
+	"github.com/shadowCow/cow-lang-go/tooling/lexer"
+	"github.com/shadowCow/cow-lang-go/tooling/ll1"
+)
+
+// runtimeParseTable builds the parse table from the syntactic grammar.
+func runtimeParseTable(t *testing.T) *ll1.ParseTable {
+	t.Helper()
+	g := GetSyntacticGrammar()
+	firstSets := ll1.ComputeFirstSets(g)
+	table, err := ll1.BuildParseTable(g, firstSets, ll1.ComputeFollowSets(g, firstSets))
+	if err != nil {
+		t.Fatalf("Failed to build parse table: %v", err)
+	}
+	return table
+}
+
+// TestGeneratedTablesMatchRuntime tests that the generated tables equal the
+// ones computed from the grammars at runtime.
+func TestGeneratedTablesMatchRuntime(t *testing.T) {
+	if !reflect.DeepEqual(LexerDFA(), automata.CompileLexicalGrammar(GetLexical())) {
+		t.Error("Generated lexer DFA differs from the compiled one (run go generate)")
+	}
+
+	table := runtimeParseTable(t)
+	if !reflect.DeepEqual(ParseTable().NonTerminals(), table.NonTerminals()) {
+		t.Error("Generated parse table has different non-terminals (run go generate)")
+	}
+	if !reflect.DeepEqual(ParseTable().Terminals(), table.Terminals()) {
+		t.Error("Generated parse table has different terminals (run go generate)")
+	}
+	if !reflect.DeepEqual(ParseTable().Entries(), table.Entries()) {
+		t.Error("Generated parse table has different entries (run go generate)")
+	}
+}
+
+// TestGeneratedFileIsUpToDate tests that tables_gen.go is what the generator
+// produces from the current grammars.
+func TestGeneratedFileIsUpToDate(t *testing.T) {
+	generated, err := GenerateTables()
+	if err != nil {
+		t.Fatalf("GenerateTables failed: %v", err)
+	}
+	current, err := os.ReadFile("tables_gen.go")
+	if err != nil {
+		t.Fatalf("Failed to read tables_gen.go: %v", err)
+	}
+	if string(generated) != string(current) {
+		t.Error("tables_gen.go is out of date (run go generate)")
+	}
+}
+
+// TestGeneratedTablesParseExamples tests that the lexer and parser give the
+// same tokens and parse trees with the generated tables as with the runtime
+// ones, for every example program.
+func TestGeneratedTablesParseExamples(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "examples", "*.cow"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("Failed to find example programs: %v", err)
+	}
+
+	g := GetSyntacticGrammar()
+	dfa := automata.CompileLexicalGrammar(GetLexical())
+	table := runtimeParseTable(t)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			source, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("Failed to read %s: %v", file, err)
+			}
+
+			expectedTokens, expectedErr := lexer.NewLexer(dfa, string(source)).Tokenize()
+			tokens, err := lexer.NewLexer(LexerDFA(), string(source)).Tokenize()
+			if !reflect.DeepEqual(tokens, expectedTokens) || !reflect.DeepEqual(err, expectedErr) {
+				t.Fatalf("Expected tokens %v (error %v), got %v (error %v)", expectedTokens, expectedErr, tokens, err)
+			}
+			if err != nil {
+				return
+			}
+
+			expectedTree, expectedErr := ll1.NewParser(table, g, expectedTokens, TriviaTokens()...).Parse()
+			tree, err := ll1.NewParser(ParseTable(), g, tokens, TriviaTokens()...).Parse()
+			if !reflect.DeepEqual(tree, expectedTree) || !reflect.DeepEqual(err, expectedErr) {
+				t.Errorf("Expected the same parse tree and error (%v), got error %v", expectedErr, err)
+			}
+		})
+	}
+}
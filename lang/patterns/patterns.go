@@ -0,0 +1,590 @@
+// Package patterns checks match expressions before evaluation.
+// It reports matches that are not exhaustive, spelling out example values
+// that no arm covers, and arms that can never be reached because earlier
+// arms already cover every value they match.
+//
+// The check follows Maranget's usefulness algorithm ("Warnings for pattern
+// matching", 2007): a pattern row is useful if some value matches it but no
+// earlier row, and a match is exhaustive if the wildcard row is not useful.
+package patterns
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+)
+
+// Error is a problem with a match expression or let pattern at a position
+// in the source code.
+type Error struct {
+	Pos ast.Position
+	Msg string
+}
+
+// Error formats the error as line:column: message.
+func (e *Error) Error() string {
+	if !e.Pos.IsValid() {
+		return e.Msg
+	}
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+// errorf creates an Error at a position.
+func errorf(pos ast.Position, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Checker checks the match expressions of programs.
+// Type declarations seen by Check are remembered, so a Checker can check a
+// program incrementally, one piece at a time.
+type Checker struct {
+	constructors map[string]constructorInfo // Constructor name -> declaration info
+	types        map[string][]string        // Type name -> constructor names in declaration order
+}
+
+// constructorInfo describes a declared constructor.
+type constructorInfo struct {
+	typeName string
+	arity    int
+}
+
+// NewChecker creates a checker with no known type declarations.
+func NewChecker() *Checker {
+	return &Checker{
+		constructors: make(map[string]constructorInfo),
+		types:        make(map[string][]string),
+	}
+}
+
+// Snapshot is the state of a Checker at some point, which it can be
+// restored to.
+type Snapshot struct {
+	constructors map[string]constructorInfo
+	types        map[string][]string
+}
+
+// Snapshot records the type declarations known so far.
+func (c *Checker) Snapshot() Snapshot {
+	return Snapshot{constructors: maps.Clone(c.constructors), types: maps.Clone(c.types)}
+}
+
+// Restore forgets the type declarations seen since the snapshot was taken,
+// such as those of a program that failed to check.
+func (c *Checker) Restore(s Snapshot) {
+	c.constructors = maps.Clone(s.constructors)
+	c.types = maps.Clone(s.types)
+}
+
+// Check checks every match expression in the program.
+// Returns nil if all matches are exhaustive and have no unreachable arms,
+// otherwise an error listing every problem found.
+func (c *Checker) Check(program *ast.Program) error {
+	// Register type declarations first so matches may precede them in the source
+	for _, stmt := range program.Statements {
+		if decl, ok := stmt.(*ast.TypeDeclaration); ok {
+			c.declare(decl)
+		}
+	}
+
+	var problems []error
+	for _, stmt := range program.Statements {
+		problems = append(problems, c.checkStatement(stmt)...)
+	}
+	return errors.Join(problems...)
+}
+
+// declare registers the constructors of a type declaration.
+func (c *Checker) declare(decl *ast.TypeDeclaration) {
+	names := make([]string, len(decl.Variants))
+	for i, variant := range decl.Variants {
+		names[i] = variant.Name
+		c.constructors[variant.Name] = constructorInfo{
+			typeName: decl.Name,
+			arity:    len(variant.Fields),
+		}
+	}
+	c.types[decl.Name] = names
+}
+
+// checkStatement checks the match expressions inside a statement.
+func (c *Checker) checkStatement(stmt ast.Statement) []error {
+	switch s := stmt.(type) {
+	case *ast.LetStatement:
+		problems := c.checkExpression(s.Value)
+		if s.Pattern != nil {
+			problems = append(problems, c.checkLetPattern(s.Pattern)...)
+		}
+		return problems
+	case *ast.Assignment:
+		return c.checkExpression(s.Value)
+	case *ast.IndexAssignment:
+		problems := c.checkExpression(s.Target)
+		return append(problems, c.checkExpression(s.Value)...)
+	case *ast.ExpressionStatement:
+		return c.checkExpression(s.Expression)
+	case *ast.ReturnStatement:
+		return c.checkExpression(s.Value)
+	case *ast.FunctionDef:
+		return c.checkBlock(s.Body)
+	case *ast.Block:
+		return c.checkBlock(s)
+	case *ast.ForStatement:
+		problems := c.checkExpression(s.Condition)
+		return append(problems, c.checkBlock(s.Body)...)
+	case *ast.TypeDeclaration:
+		c.declare(s)
+		return nil
+	default:
+		return nil
+	}
+}
+
+// checkBlock checks the match expressions inside a block.
+func (c *Checker) checkBlock(block *ast.Block) []error {
+	if block == nil {
+		return nil
+	}
+	var problems []error
+	for _, stmt := range block.Statements {
+		problems = append(problems, c.checkStatement(stmt)...)
+	}
+	return problems
+}
+
+// checkExpressions checks the match expressions inside a list of expressions.
+func (c *Checker) checkExpressions(exprs []ast.Expression) []error {
+	var problems []error
+	for _, expr := range exprs {
+		problems = append(problems, c.checkExpression(expr)...)
+	}
+	return problems
+}
+
+// checkExpression checks an expression and any match expressions nested in it.
+func (c *Checker) checkExpression(expr ast.Expression) []error {
+	switch e := expr.(type) {
+	case *ast.MatchExpression:
+		problems := c.checkExpression(e.Subject)
+		problems = append(problems, c.checkMatch(e)...)
+		for _, arm := range e.Arms {
+			problems = append(problems, c.checkBlock(arm.Body)...)
+		}
+		return problems
+	case *ast.FunctionCall:
+		return c.checkExpressions(e.Arguments)
+	case *ast.UnaryExpression:
+		return c.checkExpression(e.Operand)
+	case *ast.BinaryExpression:
+		problems := c.checkExpression(e.Left)
+		return append(problems, c.checkExpression(e.Right)...)
+	case *ast.FunctionLiteral:
+		return c.checkBlock(e.Body)
+	case *ast.ArrayLiteral:
+		return c.checkExpressions(e.Elements)
+	case *ast.TupleLiteral:
+		return c.checkExpressions(e.Elements)
+	case *ast.IndexAccess:
+		problems := c.checkExpression(e.Object)
+		return append(problems, c.checkExpression(e.Index)...)
+	case *ast.MemberAccess:
+		return c.checkExpression(e.Object)
+	case *ast.IfExpression:
+		problems := c.checkExpression(e.Condition)
+		problems = append(problems, c.checkBlock(e.Consequence)...)
+		return append(problems, c.checkBlock(e.Alternative)...)
+	case *ast.RecordLiteral:
+		return c.checkFields(e.Fields)
+	case *ast.RecordUpdate:
+		problems := c.checkExpression(e.Base)
+		return append(problems, c.checkFields(e.Fields)...)
+	case *ast.BlockExpression:
+		return c.checkBlock(e.Block)
+	default:
+		return nil
+	}
+}
+
+// checkFields checks the values of record fields.
+func (c *Checker) checkFields(fields []*ast.FieldValue) []error {
+	var problems []error
+	for _, field := range fields {
+		problems = append(problems, c.checkExpression(field.Value)...)
+	}
+	return problems
+}
+
+// checkMatch checks one match expression for unreachable arms and missing cases.
+func (c *Checker) checkMatch(match *ast.MatchExpression) []error {
+	var problems []error
+	var rows [][]*space
+	invalid := false
+
+	for i, arm := range match.Arms {
+		pattern, err := c.toSpace(arm.Pattern)
+		if err != nil {
+			problems = append(problems, errorf(arm.Pos(), "match arm %d: %v", i+1, err))
+			invalid = true
+			continue
+		}
+
+		row := []*space{pattern}
+		if !c.useful(rows, row) {
+			problems = append(problems, errorf(arm.Pos(),
+				"unreachable match arm %d: pattern %s is already covered by earlier arms",
+				i+1, pattern))
+		}
+		rows = append(rows, row)
+	}
+
+	// Exhaustiveness only makes sense once every arm was understood
+	if invalid {
+		return problems
+	}
+
+	witnesses := c.missing(rows, 1)
+	if len(witnesses) != 0 {
+		cases := make([]string, 0, len(witnesses))
+		seen := make(map[string]bool)
+		for _, w := range witnesses {
+			text := w[0].String()
+			if !seen[text] {
+				seen[text] = true
+				cases = append(cases, text)
+			}
+		}
+		problems = append(problems, errorf(match.Pos(),
+			"match is not exhaustive, missing cases: %s", strings.Join(cases, ", ")))
+	}
+	return problems
+}
+
+// checkLetPattern checks that a destructuring let pattern matches every value.
+func (c *Checker) checkLetPattern(pattern ast.Pattern) []error {
+	s, err := c.toSpace(pattern)
+	if err != nil {
+		return []error{errorf(pattern.Pos(), "let pattern: %v", err)}
+	}
+
+	witnesses := c.missing([][]*space{{s}}, 1)
+	if len(witnesses) == 0 {
+		return nil
+	}
+	cases := make([]string, 0, len(witnesses))
+	seen := make(map[string]bool)
+	for _, w := range witnesses {
+		text := w[0].String()
+		if !seen[text] {
+			seen[text] = true
+			cases = append(cases, text)
+		}
+	}
+	return []error{errorf(pattern.Pos(),
+		"let pattern %s is refutable, missing cases: %s", s, strings.Join(cases, ", "))}
+}
+
+// space is the checker's view of a pattern: either a wildcard or a
+// constructor applied to sub-patterns. Literals are constructors without
+// arguments, an array pattern is a constructor per length, and a tuple
+// pattern is the only constructor of the tuples of its length.
+type space struct {
+	wild   bool
+	key    string   // Identity of the constructor (e.g. "Some", "int:3", "[2]")
+	name   string   // Display text of the constructor
+	domain string   // Type the constructor belongs to (e.g. "Option", "bool", "int")
+	args   []*space // Sub-patterns, one per constructor field
+}
+
+// wildcard is the pattern matching every value.
+var wildcard = &space{wild: true}
+
+// String formats a pattern, e.g. Some(_), [_, 3] or _.
+func (s *space) String() string {
+	if s.wild {
+		return "_"
+	}
+	if s.domain == "array" {
+		return "[" + joinSpaces(s.args) + "]"
+	}
+	if strings.HasPrefix(s.domain, "tuple:") {
+		return "(" + joinSpaces(s.args) + ")"
+	}
+	if len(s.args) == 0 {
+		return s.name
+	}
+	return s.name + "(" + joinSpaces(s.args) + ")"
+}
+
+// joinSpaces formats a list of patterns separated by commas.
+func joinSpaces(spaces []*space) string {
+	parts := make([]string, len(spaces))
+	for i, s := range spaces {
+		parts[i] = s.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// toSpace converts an AST pattern to a space, validating constructor use.
+func (c *Checker) toSpace(pattern ast.Pattern) (*space, error) {
+	switch p := pattern.(type) {
+	case *ast.WildcardPattern, *ast.BindingPattern:
+		return wildcard, nil
+
+	case *ast.LiteralPattern:
+		switch lit := p.Value.(type) {
+		case *ast.IntLiteral:
+			return &space{key: fmt.Sprintf("int:%d", lit.Value), name: lit.Token, domain: "int"}, nil
+		case *ast.FloatLiteral:
+			return &space{key: fmt.Sprintf("float:%g", lit.Value), name: lit.Token, domain: "float"}, nil
+		case *ast.StringLiteral:
+			return &space{key: "string:" + lit.Value, name: fmt.Sprintf("%q", lit.Value), domain: "string"}, nil
+		case *ast.BoolLiteral:
+			return &space{key: fmt.Sprintf("%t", lit.Value), name: fmt.Sprintf("%t", lit.Value), domain: "bool"}, nil
+		default:
+			return nil, fmt.Errorf("unsupported literal pattern %s", p.Token)
+		}
+
+	case *ast.ConstructorPattern:
+		info, ok := c.constructors[p.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown constructor %s in pattern", p.Name)
+		}
+		if len(p.Args) != info.arity {
+			return nil, fmt.Errorf("constructor %s expects %d fields in pattern, got %d",
+				p.Name, info.arity, len(p.Args))
+		}
+		args, err := c.toSpaces(p.Args)
+		if err != nil {
+			return nil, err
+		}
+		return &space{key: p.Name, name: p.Name, domain: info.typeName, args: args}, nil
+
+	case *ast.ArrayPattern:
+		args, err := c.toSpaces(p.Elements)
+		if err != nil {
+			return nil, err
+		}
+		return &space{key: fmt.Sprintf("[%d]", len(args)), domain: "array", args: args}, nil
+
+	case *ast.TuplePattern:
+		args, err := c.toSpaces(p.Elements)
+		if err != nil {
+			return nil, err
+		}
+		return tupleSpace(len(args), args), nil
+
+	default:
+		return nil, fmt.Errorf("unknown pattern type: %T", pattern)
+	}
+}
+
+// toSpaces converts a list of AST patterns.
+func (c *Checker) toSpaces(patterns []ast.Pattern) ([]*space, error) {
+	spaces := make([]*space, len(patterns))
+	for i, p := range patterns {
+		s, err := c.toSpace(p)
+		if err != nil {
+			return nil, err
+		}
+		spaces[i] = s
+	}
+	return spaces, nil
+}
+
+// tupleSpace is the constructor of tuples with n elements; n = 0 is unit.
+func tupleSpace(n int, args []*space) *space {
+	domain := fmt.Sprintf("tuple:%d", n)
+	return &space{key: domain, domain: domain, args: args}
+}
+
+// signature returns every constructor of a domain, or nil if the domain has
+// infinitely many (numbers, strings, arrays).
+func (c *Checker) signature(domain string) []*space {
+	var n int
+	if _, err := fmt.Sscanf(domain, "tuple:%d", &n); err == nil {
+		args := make([]*space, n)
+		for i := range args {
+			args[i] = wildcard
+		}
+		return []*space{tupleSpace(n, args)}
+	}
+
+	if domain == "bool" {
+		return []*space{
+			{key: "true", name: "true", domain: "bool"},
+			{key: "false", name: "false", domain: "bool"},
+		}
+	}
+
+	names, ok := c.types[domain]
+	if !ok {
+		return nil
+	}
+	sig := make([]*space, len(names))
+	for i, name := range names {
+		args := make([]*space, c.constructors[name].arity)
+		for j := range args {
+			args[j] = wildcard
+		}
+		sig[i] = &space{key: name, name: name, domain: domain, args: args}
+	}
+	return sig
+}
+
+// heads returns the distinct constructors in the first column of the rows
+// and the domain they belong to.
+func heads(rows [][]*space) ([]*space, string) {
+	var result []*space
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		head := row[0]
+		if head.wild || seen[head.key] {
+			continue
+		}
+		seen[head.key] = true
+		result = append(result, head)
+	}
+	if len(result) == 0 {
+		return nil, ""
+	}
+	return result, result[0].domain
+}
+
+// missingConstructors returns the constructors of the signature not among the heads.
+func missingConstructors(sig []*space, present []*space) []*space {
+	seen := make(map[string]bool, len(present))
+	for _, h := range present {
+		seen[h.key] = true
+	}
+	var missing []*space
+	for _, ctor := range sig {
+		if !seen[ctor.key] {
+			missing = append(missing, ctor)
+		}
+	}
+	return missing
+}
+
+// specialize keeps the rows that match the constructor, replacing their
+// first column with the constructor's sub-patterns.
+func specialize(rows [][]*space, ctor *space) [][]*space {
+	arity := len(ctor.args)
+	var result [][]*space
+	for _, row := range rows {
+		head := row[0]
+		var expanded []*space
+		if head.wild {
+			expanded = make([]*space, arity, arity+len(row)-1)
+			for i := range expanded {
+				expanded[i] = wildcard
+			}
+		} else if head.key == ctor.key {
+			expanded = append(make([]*space, 0, arity+len(row)-1), head.args...)
+		} else {
+			continue
+		}
+		result = append(result, append(expanded, row[1:]...))
+	}
+	return result
+}
+
+// defaults keeps the rows whose first column is a wildcard, dropping that column.
+func defaults(rows [][]*space) [][]*space {
+	var result [][]*space
+	for _, row := range rows {
+		if row[0].wild {
+			result = append(result, row[1:])
+		}
+	}
+	return result
+}
+
+// useful reports whether some value matches the row q but none of the rows.
+func (c *Checker) useful(rows [][]*space, q []*space) bool {
+	if len(q) == 0 {
+		return len(rows) == 0
+	}
+
+	head := q[0]
+	if !head.wild {
+		return c.useful(specialize(rows, head), append(append([]*space{}, head.args...), q[1:]...))
+	}
+
+	present, domain := heads(rows)
+	sig := c.signature(domain)
+	if len(present) != 0 && sig != nil && len(missingConstructors(sig, present)) == 0 {
+		// Every constructor appears, so a wildcard is useful only if it is
+		// useful under one of them
+		for _, ctor := range sig {
+			if c.useful(specialize(rows, ctor), append(append([]*space{}, ctor.args...), q[1:]...)) {
+				return true
+			}
+		}
+		return false
+	}
+	return c.useful(defaults(rows), q[1:])
+}
+
+// missing returns example value vectors of width n matched by none of the
+// rows. An empty result means the rows are exhaustive.
+func (c *Checker) missing(rows [][]*space, n int) [][]*space {
+	if n == 0 {
+		if len(rows) == 0 {
+			return [][]*space{{}}
+		}
+		return nil
+	}
+
+	present, domain := heads(rows)
+	sig := c.signature(domain)
+
+	if len(present) != 0 && sig != nil && len(missingConstructors(sig, present)) == 0 {
+		// Every constructor appears: look for missing values under each of them
+		var result [][]*space
+		for _, ctor := range sig {
+			result = append(result, c.missingUnder(rows, ctor, n)...)
+		}
+		return result
+	}
+
+	var result [][]*space
+
+	// Also report values missing under the constructors that do appear,
+	// e.g. [Some(_)] next to [None], or Some(Some(_)) next to None
+	for _, ctor := range present {
+		result = append(result, c.missingUnder(rows, ctor, n)...)
+	}
+
+	rest := c.missing(defaults(rows), n-1)
+	if len(rest) == 0 {
+		return result
+	}
+
+	// Name the missing constructors when the domain is finite, otherwise
+	// any value not listed by the arms is missing
+	firsts := []*space{wildcard}
+	if len(present) != 0 && sig != nil {
+		firsts = missingConstructors(sig, present)
+	}
+
+	for _, first := range firsts {
+		for _, w := range rest {
+			result = append(result, append([]*space{first}, w...))
+		}
+	}
+	return result
+}
+
+// missingUnder returns example value vectors of width n, starting with the
+// given constructor, matched by none of the rows.
+func (c *Checker) missingUnder(rows [][]*space, ctor *space, n int) [][]*space {
+	arity := len(ctor.args)
+	var result [][]*space
+	for _, w := range c.missing(specialize(rows, ctor), arity+n-1) {
+		filled := &space{key: ctor.key, name: ctor.name, domain: ctor.domain, args: w[:arity]}
+		result = append(result, append([]*space{filled}, w[arity:]...))
+	}
+	return result
+}
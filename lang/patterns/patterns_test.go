@@ -0,0 +1,198 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+)
+
+// optionDecl declares type Option<T> = Some of T | None.
+var optionDecl = &ast.TypeDeclaration{
+	Token:      "type",
+	Name:       "Option",
+	TypeParams: []string{"T"},
+	Variants: []*ast.VariantDecl{
+		{Token: "Some", Name: "Some", Fields: []ast.TypeExpression{&ast.NamedType{Token: "T", Name: "T"}}},
+		{Token: "None", Name: "None", Fields: []ast.TypeExpression{}},
+	},
+}
+
+func ctor(name string, args ...ast.Pattern) ast.Pattern {
+	return &ast.ConstructorPattern{Token: name, Name: name, Args: args}
+}
+
+func bind(name string) ast.Pattern {
+	return &ast.BindingPattern{Token: name, Name: name}
+}
+
+func intPat(v int64) ast.Pattern {
+	return &ast.LiteralPattern{Token: "n", Value: &ast.IntLiteral{Token: "n", Value: v}}
+}
+
+func boolPat(v bool) ast.Pattern {
+	return &ast.LiteralPattern{Token: "b", Value: &ast.BoolLiteral{Token: "b", Value: v}}
+}
+
+func tuple(elements ...ast.Pattern) ast.Pattern {
+	return &ast.TuplePattern{Token: "(", Elements: elements}
+}
+
+var wild ast.Pattern = &ast.WildcardPattern{Token: "_"}
+
+// matchProgram builds a program declaring Option and matching on x with the given patterns.
+func matchProgram(patterns ...ast.Pattern) *ast.Program {
+	arms := make([]*ast.MatchArm, len(patterns))
+	for i, p := range patterns {
+		arms[i] = &ast.MatchArm{
+			Token:   p.TokenLiteral(),
+			Pattern: p,
+			Body: &ast.Block{Statements: []ast.Statement{
+				&ast.ExpressionStatement{Expression: &ast.IntLiteral{Token: "0", Value: 0}},
+			}},
+		}
+	}
+	return &ast.Program{
+		Statements: []ast.Statement{
+			optionDecl,
+			&ast.ExpressionStatement{
+				Expression: &ast.MatchExpression{
+					Token:   "match",
+					Subject: &ast.Identifier{Token: "x", Name: "x"},
+					Arms:    arms,
+				},
+			},
+		},
+	}
+}
+
+// TestCheck tests exhaustiveness and reachability of match arms.
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []ast.Pattern
+		errors   []string // Expected error substrings; empty means the match is fine
+	}{
+		{
+			name:     "exhaustive option",
+			patterns: []ast.Pattern{ctor("Some", bind("x")), ctor("None")},
+		},
+		{
+			name:     "missing none",
+			patterns: []ast.Pattern{ctor("Some", bind("x"))},
+			errors:   []string{"missing cases: None"},
+		},
+		{
+			name:     "missing nested case",
+			patterns: []ast.Pattern{ctor("Some", ctor("Some", wild)), ctor("None")},
+			errors:   []string{"missing cases: Some(None)"},
+		},
+		{
+			name:     "missing nested cases under every constructor",
+			patterns: []ast.Pattern{ctor("Some", ctor("Some", intPat(1))), ctor("None")},
+			errors:   []string{"missing cases: Some(Some(_)), Some(None)"},
+		},
+		{
+			name:     "unreachable after wildcard",
+			patterns: []ast.Pattern{wild, ctor("None")},
+			errors:   []string{"unreachable match arm 2: pattern None"},
+		},
+		{
+			name:     "bool literals are exhaustive",
+			patterns: []ast.Pattern{boolPat(true), boolPat(false)},
+		},
+		{
+			name:     "missing bool literal",
+			patterns: []ast.Pattern{boolPat(true)},
+			errors:   []string{"missing cases: false"},
+		},
+		{
+			name:     "integers need a wildcard",
+			patterns: []ast.Pattern{intPat(0), intPat(1)},
+			errors:   []string{"missing cases: _"},
+		},
+		{
+			name:     "duplicate literal",
+			patterns: []ast.Pattern{intPat(1), intPat(1), wild},
+			errors:   []string{"unreachable match arm 2"},
+		},
+		{
+			name: "arrays by length",
+			patterns: []ast.Pattern{
+				&ast.ArrayPattern{Token: "[", Elements: []ast.Pattern{}},
+				&ast.ArrayPattern{Token: "[", Elements: []ast.Pattern{ctor("None")}},
+			},
+			errors: []string{"missing cases: [Some(_)], _"},
+		},
+		{
+			name: "tuple of bools",
+			patterns: []ast.Pattern{
+				tuple(boolPat(true), wild),
+				tuple(boolPat(false), boolPat(true)),
+			},
+			errors: []string{"missing cases: (false, false)"},
+		},
+		{
+			name:     "tuple wildcard after full cover",
+			patterns: []ast.Pattern{tuple(bind("a"), bind("b")), tuple(wild, ctor("None"))},
+			errors:   []string{"unreachable match arm 2"},
+		},
+		{
+			name:     "unknown constructor",
+			patterns: []ast.Pattern{ctor("Nothing")},
+			errors:   []string{"unknown constructor Nothing"},
+		},
+		{
+			name:     "constructor arity",
+			patterns: []ast.Pattern{ctor("Some"), ctor("None")},
+			errors:   []string{"constructor Some expects 1 fields in pattern, got 0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewChecker().Check(matchProgram(tt.patterns...))
+
+			if len(tt.errors) == 0 {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("Expected errors %q, got nil", tt.errors)
+			}
+			for _, want := range tt.errors {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected error containing %q, got %v", want, err)
+				}
+			}
+		})
+	}
+}
+
+// TestCheckLetPattern tests that destructuring let patterns must match every value.
+func TestCheckLetPattern(t *testing.T) {
+	letProgram := func(pattern ast.Pattern) *ast.Program {
+		return &ast.Program{
+			Statements: []ast.Statement{
+				optionDecl,
+				&ast.LetStatement{
+					Token:   "let",
+					Pattern: pattern,
+					Value:   &ast.Identifier{Token: "x", Name: "x"},
+				},
+			},
+		}
+	}
+
+	if err := NewChecker().Check(letProgram(tuple(bind("a"), tuple(wild, bind("b"))))); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	err := NewChecker().Check(letProgram(tuple(bind("a"), ctor("Some", bind("b")))))
+	if err == nil || !strings.Contains(err.Error(), "let pattern (_, Some(_)) is refutable, missing cases: (_, None)") {
+		t.Fatalf("Expected refutable let pattern error, got %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+	"github.com/shadowCow/cow-lang-go/lang/compiler"
+	"github.com/shadowCow/cow-lang-go/lang/converter"
+	"github.com/shadowCow/cow-lang-go/lang/eval"
+	"github.com/shadowCow/cow-lang-go/lang/langdef"
+	"github.com/shadowCow/cow-lang-go/lang/patterns"
+	"github.com/shadowCow/cow-lang-go/lang/types"
+	"github.com/shadowCow/cow-lang-go/lang/vm"
+	"github.com/shadowCow/cow-lang-go/tooling/automata"
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+	"github.com/shadowCow/cow-lang-go/tooling/lexer"
+	"github.com/shadowCow/cow-lang-go/tooling/ll1"
+	"github.com/shadowCow/cow-lang-go/tooling/parsetree"
+)
+
+// Engine runs Cow programs. It uses the lexer DFA and LL(1) parse table that
+// langdef generates ahead of time, so creating one does no grammar
+// computation, and reuses them for every program.
+//
+// An Engine is never changed after it is created, so it is safe for
+// concurrent use: many goroutines may run programs on one Engine at once.
+// Each run has its own checkers and evaluator, and writes to its own output.
+type Engine struct {
+	grammar grammar.SyntacticGrammar
+	table   *ll1.ParseTable
+	dfa     automata.DfaWithTokens
+	names   map[grammar.TokenType]string // Token display names for syntax errors
+	backend Backend
+}
+
+// New creates an Engine that evaluates programs with the tree-walking
+// evaluator.
+func New() *Engine {
+	return &Engine{
+		grammar: langdef.GetSyntacticGrammar(),
+		table:   langdef.ParseTable(),
+		dfa:     langdef.LexerDFA(),
+		names:   grammar.DisplayNames(langdef.GetLexicalGrammar()),
+		backend: TreeWalker,
+	}
+}
+
+// WithBackend returns an Engine that shares e's grammar artifacts and
+// evaluates programs with the given backend.
+func (e *Engine) WithBackend(backend Backend) *Engine {
+	copied := *e
+	copied.backend = backend
+	return &copied
+}
+
+// Tokenize converts source code to tokens, including whitespace and comments.
+func (e *Engine) Tokenize(source string) ([]lexer.Token, error) {
+	tokens, err := lexer.NewLexer(e.dfa, source).Tokenize()
+	if err != nil {
+		return nil, stageError("lexer", "", err)
+	}
+	return tokens, nil
+}
+
+// ParseTree converts source code to a generic parse tree.
+func (e *Engine) ParseTree(source string) (*parsetree.ProgramNode, error) {
+	return e.parseTree("", source, false)
+}
+
+// Parse converts source code to an AST, without checking it.
+func (e *Engine) Parse(source string) (*ast.Program, error) {
+	return e.parse("", source, false)
+}
+
+// RunSource executes a Cow program given as source code, writing its output
+// to output. Errors are located by line and column only.
+func (e *Engine) RunSource(source string, output io.Writer) error {
+	return e.run("", source, output, false, nil)
+}
+
+// RunFile executes a Cow program from a file, writing its output to output.
+func (e *Engine) RunFile(filePath string, output io.Writer) error {
+	source, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file %q: %w", filePath, err)
+	}
+	return e.run(filePath, string(source), output, false, nil)
+}
+
+// run executes a program through the whole pipeline: lex → parse → check →
+// evaluate. The name is the file path, or "" for source code without one.
+// If evaluator is not nil, the program is evaluated by it, whatever the
+// backend, and its native functions are declared to the type checker.
+// If debug is true, prints grammar information, FIRST/FOLLOW sets, parse
+// table, and parse trace, and the bytecode backend prints the compiled
+// bytecode.
+func (e *Engine) run(name, source string, output io.Writer, debug bool, evaluator *eval.Evaluator) error {
+	if debug {
+		// The FIRST and FOLLOW sets and the lexer's state counts are only
+		// needed for debug output
+		firstSets := ll1.ComputeFirstSets(e.grammar)
+		_, dfaStats := automata.CompileLexicalGrammarWithStats(langdef.GetLexical())
+		automata.PrintMinimizeStats(dfaStats, output)
+		ll1.PrintGrammar(e.grammar, output)
+		ll1.PrintFirstSets(firstSets, output)
+		ll1.PrintFollowSets(ll1.ComputeFollowSets(e.grammar, firstSets), output)
+		ll1.PrintParseTable(e.table, output)
+	}
+
+	program, err := e.parse(name, source, debug)
+	if err != nil {
+		return err
+	}
+
+	// Check that the program is well typed
+	checker := types.NewChecker()
+	if evaluator != nil {
+		for _, native := range evaluator.Natives() {
+			checker.Declare(native.Name, native.Type)
+		}
+	}
+	if err := checker.Check(program); err != nil {
+		return stageError("type", name, locateErrors(name, err))
+	}
+
+	// Check match expressions for missing cases and unreachable arms
+	if err := patterns.NewChecker().Check(program); err != nil {
+		return stageError("pattern check", name, locateErrors(name, err))
+	}
+
+	// Evaluate the program
+	switch {
+	case evaluator != nil:
+		err = evaluator.Eval(program)
+	case e.backend == TreeWalker:
+		err = eval.NewEvaluator(output).Eval(program)
+	case e.backend == BytecodeVM:
+		compiled, compileErr := compiler.Compile(program)
+		if compileErr != nil {
+			return stageError("compile", name, compileErr)
+		}
+		if debug {
+			compiler.Disassemble(compiled.Main, output)
+		}
+		err = vm.New(output).Run(compiled)
+	default:
+		return fmt.Errorf("unknown backend %q", e.backend)
+	}
+	if err != nil {
+		return stageError("evaluation", name, locateError(name, err))
+	}
+	return nil
+}
+
+// parseTree lexes and parses source code, tracing the parse if trace is true.
+func (e *Engine) parseTree(name, source string, trace bool) (*parsetree.ProgramNode, error) {
+	tokens, err := lexer.NewLexer(e.dfa, source).Tokenize()
+	if err != nil {
+		return nil, stageError("lexer", name, err)
+	}
+
+	// Whitespace and comments are skipped but kept as trivia on the tokens
+	p := ll1.NewParser(e.table, e.grammar, tokens, langdef.TriviaTokens()...)
+	p.SetDisplayNames(e.names)
+	p.SetTrace(trace)
+	tree, err := p.Parse()
+	if err != nil {
+		return nil, stageError("parser", name, err)
+	}
+	return tree, nil
+}
+
+// parse converts source code to an AST.
+func (e *Engine) parse(name, source string, trace bool) (*ast.Program, error) {
+	tree, err := e.parseTree(name, source, trace)
+	if err != nil {
+		return nil, err
+	}
+	program, err := converter.ParseTreeToAST(tree)
+	if err != nil {
+		return nil, stageError("AST conversion", name, err)
+	}
+	return program, nil
+}
+
+// stageError reports an error in a stage of the pipeline, naming the file
+// if there is one.
+func stageError(stage, name string, err error) error {
+	if name == "" {
+		return fmt.Errorf("%s error: %w", stage, err)
+	}
+	return fmt.Errorf("%s error in %q: %w", stage, name, err)
+}
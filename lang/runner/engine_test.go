@@ -0,0 +1,152 @@
+package runner
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestEngineRunSource(t *testing.T) {
+	engine := New()
+
+	var output bytes.Buffer
+	if err := engine.RunSource("let x = 40\nprintln(x + 2)\n", &output); err != nil {
+		t.Fatalf("RunSource failed: %v", err)
+	}
+	if output.String() != "42\n" {
+		t.Errorf("Expected output %q, got %q", "42\n", output.String())
+	}
+
+	err := engine.RunSource("let xs = [1]\nprintln(xs[3])\n", &output)
+	var sourceErr *SourceError
+	if !errors.As(err, &sourceErr) {
+		t.Fatalf("Expected a SourceError, got %T: %v", err, err)
+	}
+	if !strings.HasPrefix(err.Error(), "evaluation error: 2:9: ") {
+		t.Errorf("Expected the error to be located by line and column only, got %v", err)
+	}
+
+	err = engine.RunSource("let = 1\n", &output)
+	if err == nil || !strings.HasPrefix(err.Error(), "parser error: ") {
+		t.Errorf("Expected a parser error, got %v", err)
+	}
+}
+
+func TestEngineRunFile(t *testing.T) {
+	engine := New()
+
+	var output bytes.Buffer
+	if err := engine.WithBackend(BytecodeVM).RunFile("../examples/hello_println.cow", &output); err != nil {
+		t.Fatalf("RunFile failed: %v", err)
+	}
+	if output.String() != "42\n" {
+		t.Errorf("Expected output %q, got %q", "42\n", output.String())
+	}
+
+	if err := engine.RunFile("nonexistent.cow", &output); err == nil || !strings.Contains(err.Error(), "nonexistent.cow") {
+		t.Errorf("Expected an error naming the file, got %v", err)
+	}
+}
+
+func TestEngineParse(t *testing.T) {
+	engine := New()
+
+	// Parse does not type check
+	program, err := engine.Parse("let s: string = 1\nprintln(s)\n")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(program.Statements) != 2 {
+		t.Errorf("Expected 2 statements, got %d", len(program.Statements))
+	}
+
+	if _, err := engine.Parse("let x = @"); err == nil || !strings.HasPrefix(err.Error(), "lexer error: ") {
+		t.Errorf("Expected a lexer error, got %v", err)
+	}
+}
+
+// TestEngineParseErrors tests that syntax errors list the expected tokens,
+// spelling out tokens that are not only binary operators.
+func TestEngineParseErrors(t *testing.T) {
+	engine := New()
+
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "after a type",
+			source:   "type Shape = Circle of f64 | Rect of f64 * f64\n",
+			expected: "parser error: expected `<`, `|`, a newline or end of input, found `*` at line 1, column 42",
+		},
+		{
+			name:     "in type arguments",
+			source:   "let xs: Array<i64 = [1]\n",
+			expected: "parser error: expected `,`, `<` or `>`, found `=` at line 1, column 19",
+		},
+		{
+			name:     "after a binary operator",
+			source:   "1 +\n",
+			expected: "parser error: expected `!`, `(`, `-`, `[`, `false`, `if`, `match`, `true`, a number, a string or an identifier, found a newline at line 1, column 4",
+		},
+		{
+			name:     "after an operand",
+			source:   "(1 2)\n",
+			expected: "parser error: expected `)`, `,`, `-`, `:`, `<`, `=`, `>`, `with` or an operator, found `2` at line 1, column 4",
+		},
+		{
+			name:     "in arguments",
+			source:   "println(1 let)\n",
+			expected: "parser error: expected `)`, `,`, `-`, `:`, `<`, `=`, `>`, `with` or an operator, found `let` at line 1, column 11",
+		},
+		{
+			name:     "in an array",
+			source:   "[1, 2 3]\n",
+			expected: "parser error: expected `,`, `-`, `:`, `<`, `=`, `>`, `]`, `with` or an operator, found `3` at line 1, column 7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := engine.Parse(tt.source); err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected error %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+// TestEngineConcurrentRuns runs programs on one engine from many goroutines
+// at once; run it with -race to check that runs do not share state.
+func TestEngineConcurrentRuns(t *testing.T) {
+	engine := New()
+	backends := []*Engine{engine, engine.WithBackend(BytecodeVM)}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			source := fmt.Sprintf("fn sum(n) {\n  let mut total = 0\n  let mut i = 0\n  for i <= n {\n    total = total + i\n    i = i + 1\n  }\n  total\n}\nprintln(sum(%d))\n", i)
+
+			var output bytes.Buffer
+			if err := backends[i%2].RunSource(source, &output); err != nil {
+				errs <- fmt.Errorf("run %d: %w", i, err)
+				return
+			}
+			if expected := fmt.Sprintf("%d\n", i*(i+1)/2); output.String() != expected {
+				errs <- fmt.Errorf("run %d: expected output %q, got %q", i, expected, output.String())
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
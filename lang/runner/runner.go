@@ -2,16 +2,28 @@
 package runner
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"sync"
 
-	"github.com/shadowCow/cow-lang-go/lang/converter"
+	"github.com/shadowCow/cow-lang-go/lang/ast"
 	"github.com/shadowCow/cow-lang-go/lang/eval"
-	"github.com/shadowCow/cow-lang-go/lang/langdef"
-	"github.com/shadowCow/cow-lang-go/tooling/automata"
-	"github.com/shadowCow/cow-lang-go/tooling/lexer"
-	"github.com/shadowCow/cow-lang-go/tooling/ll1"
+	"github.com/shadowCow/cow-lang-go/lang/patterns"
+	"github.com/shadowCow/cow-lang-go/lang/types"
+)
+
+// Backend selects how a program is executed.
+type Backend string
+
+const (
+	// TreeWalker evaluates the AST directly with package eval.
+	TreeWalker Backend = "tree"
+
+	// BytecodeVM compiles the AST to bytecode with package compiler and
+	// runs it on the stack machine in package vm.
+	BytecodeVM Backend = "vm"
 )
 
 // Run executes a Cow language program from a file.
@@ -22,74 +34,100 @@ import (
 //
 // Returns an error if any stage fails (file reading, lexing, parsing, or evaluation).
 func Run(filePath string, output io.Writer, debug bool) error {
-	// Read the source file
-	source, err := os.ReadFile(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to read file %q: %w", filePath, err)
-	}
-
-	// Get the syntactic grammar
-	synGrammar := langdef.GetSyntacticGrammar()
+	return RunWithBackend(filePath, output, debug, TreeWalker)
+}
 
-	// Debug: Print grammar
-	if debug {
-		ll1.PrintGrammar(synGrammar, output)
-	}
+// RunWithBackend executes a Cow language program from a file like Run,
+// evaluating it with the given backend. Both backends produce the same output.
+// In debug mode, the bytecode backend also prints the compiled bytecode.
+func RunWithBackend(filePath string, output io.Writer, debug bool, backend Backend) error {
+	return runFile(filePath, output, debug, backend, nil)
+}
 
-	// Compute FIRST sets
-	firstSets := ll1.ComputeFirstSets(synGrammar)
-	if debug {
-		ll1.PrintFirstSets(firstSets, output)
-	}
+// RunWith executes a Cow language program from a file on the given
+// evaluator, for Go programs that embed the interpreter.
+// The program may call the native functions registered with the evaluator,
+// and its definitions stay in the evaluator afterwards, so they can be
+// looked up and called from Go.
+func RunWith(filePath string, evaluator *eval.Evaluator) error {
+	return runFile(filePath, io.Discard, false, TreeWalker, evaluator)
+}
 
-	// Compute FOLLOW sets
-	followSets := ll1.ComputeFollowSets(synGrammar, firstSets)
-	if debug {
-		ll1.PrintFollowSets(followSets, output)
-	}
+// The package-level functions share one Engine, built on first use.
+var (
+	sharedOnce   sync.Once
+	sharedEngine *Engine
+)
 
-	// Build LL(1) parse table
-	parseTable, err := ll1.BuildParseTable(synGrammar, firstSets, followSets)
+// runFile reads a file and runs it on the shared Engine.
+func runFile(filePath string, output io.Writer, debug bool, backend Backend, evaluator *eval.Evaluator) error {
+	source, err := os.ReadFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to build LL(1) parse table: %w", err)
-	}
-	if debug {
-		ll1.PrintParseTable(parseTable, output)
+		return fmt.Errorf("failed to read file %q: %w", filePath, err)
 	}
 
-	// Compile the lexical grammar to a DFA
-	lexGrammar := langdef.GetLexical()
-	dfa := automata.CompileLexicalGrammar(lexGrammar)
+	sharedOnce.Do(func() { sharedEngine = New() })
+	return sharedEngine.WithBackend(backend).run(filePath, string(source), output, debug, evaluator)
+}
 
-	// Tokenize the source code
-	lex := lexer.NewLexer(dfa, string(source))
-	tokens, err := lex.Tokenize()
-	if err != nil {
-		return fmt.Errorf("lexer error in %q: %w", filePath, err)
+// locateError converts a runtime error with a position to a SourceError.
+func locateError(filePath string, err error) error {
+	var evalErr *eval.Error
+	if errors.As(err, &evalErr) {
+		return &SourceError{File: filePath, Span: evalErr.Span, Err: evalErr.Err}
 	}
+	return err
+}
 
-	// Parse tokens into a generic parse tree using LL(1) parser
-	p := ll1.NewParser(parseTable, synGrammar, tokens, "WHITESPACE")
-	if debug {
-		p.SetTrace(true) // Enable parse tracing in debug mode
-	}
-	parseTree, err := p.Parse()
-	if err != nil {
-		return fmt.Errorf("parser error in %q: %w", filePath, err)
-	}
+// SourceError is an error at a location in a Cow source file.
+// The CLI uses the location to show the offending source line.
+type SourceError struct {
+	File string   // Path of the source file ("" for source code without a file)
+	Span ast.Span // Location of the error; Stop is unknown (zero) for a single point
+	Err  error    // The error, without its location
+}
 
-	// Convert parse tree to Cow-specific AST
-	program, err := converter.ParseTreeToAST(parseTree)
-	if err != nil {
-		return fmt.Errorf("AST conversion error in %q: %w", filePath, err)
+// Error formats the error as file:line:column: message, or line:column:
+// message without a file.
+func (e *SourceError) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("%d:%d: %v", e.Span.Start.Line, e.Span.Start.Column, e.Err)
 	}
+	return fmt.Sprintf("%s:%d:%d: %v", e.File, e.Span.Start.Line, e.Span.Start.Column, e.Err)
+}
 
-	// Evaluate the program
-	evaluator := eval.NewEvaluator(output)
-	err = evaluator.Eval(program)
-	if err != nil {
-		return fmt.Errorf("evaluation error in %q: %w", filePath, err)
+// Unwrap returns the underlying error.
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// locateErrors converts the errors reported by the type and pattern
+// checkers to SourceErrors.
+func locateErrors(filePath string, err error) error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return err
 	}
 
-	return nil
+	var located []error
+	for _, problem := range joined.Unwrap() {
+		var typeErr *types.Error
+		var patternErr *patterns.Error
+		switch {
+		case errors.As(problem, &typeErr) && typeErr.Pos.IsValid():
+			problem = &SourceError{
+				File: filePath,
+				Span: ast.Span{Start: typeErr.Pos},
+				Err:  errors.New(typeErr.Msg),
+			}
+		case errors.As(problem, &patternErr) && patternErr.Pos.IsValid():
+			problem = &SourceError{
+				File: filePath,
+				Span: ast.Span{Start: patternErr.Pos},
+				Err:  errors.New(patternErr.Msg),
+			}
+		}
+		located = append(located, problem)
+	}
+	return errors.Join(located...)
 }
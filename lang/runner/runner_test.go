@@ -2,9 +2,14 @@ package runner
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/shadowCow/cow-lang-go/lang/eval"
+	"github.com/shadowCow/cow-lang-go/lang/types"
 )
 
 // TestRun tests running a Cow program from a file.
@@ -115,3 +120,360 @@ func TestRunParserError(t *testing.T) {
 		t.Fatal("Expected parser error, got nil")
 	}
 }
+
+// TestRunRejectsNonExhaustiveMatch tests that match problems stop the program before it runs.
+func TestRunRejectsNonExhaustiveMatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.cow")
+
+	source := "type Option<T> = Some of T | None\nprintln(\"started\")\nprintln(match None { Some(x) => x })\n"
+
+	err := os.WriteFile(testFile, []byte(source), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	var output bytes.Buffer
+	err = Run(testFile, &output, false)
+	if err == nil {
+		t.Fatal("Expected error for non-exhaustive match, got nil")
+	}
+	if !strings.Contains(err.Error(), "3:9: match is not exhaustive, missing cases: None") {
+		t.Errorf("Expected missing case with position in error, got %v", err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("Expected no output before the check fails, got %q", output.String())
+	}
+}
+
+func TestRunRejectsIllTypedProgram(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.cow")
+
+	source := "fn inc(x: i32) -> i32 { x + 1 }\nprintln(\"started\")\nprintln(inc(\"one\"))\n"
+
+	err := os.WriteFile(testFile, []byte(source), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	var output bytes.Buffer
+	err = Run(testFile, &output, false)
+	if err == nil {
+		t.Fatal("Expected error for ill-typed program, got nil")
+	}
+	if !strings.Contains(err.Error(), "3:13: argument 1 to inc: expected i64, found string") {
+		t.Errorf("Expected type error with position, got %v", err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("Expected no output before the check fails, got %q", output.String())
+	}
+}
+
+func TestRunReportsRuntimeErrorPosition(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.cow")
+
+	source := "let xs = [1, 2, 3]\nlet i = 5\nprintln(xs[i])\n"
+
+	err := os.WriteFile(testFile, []byte(source), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	var output bytes.Buffer
+	err = Run(testFile, &output, false)
+	if err == nil {
+		t.Fatal("Expected runtime error, got nil")
+	}
+
+	var sourceErr *SourceError
+	if !errors.As(err, &sourceErr) {
+		t.Fatalf("Expected a SourceError, got %T: %v", err, err)
+	}
+	start, stop := sourceErr.Span.Start, sourceErr.Span.Stop
+	if start.Line != 3 || start.Column != 9 || stop.Line != 3 || stop.Column != 14 {
+		t.Errorf("Expected span 3:9 to 3:14, got %v to %v", start, stop)
+	}
+	if !strings.Contains(err.Error(), testFile+":3:9: ") {
+		t.Errorf("Expected error to start with the file and position, got %v", err)
+	}
+}
+
+func TestRunRejectsMutationOfImmutableArray(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.cow")
+
+	source := "let xs = [1, 2]\nlet mut ys = xs\nys.push(3)\nprintln(ys)\nxs[0] = 5\n"
+
+	err := os.WriteFile(testFile, []byte(source), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	var output bytes.Buffer
+	err = Run(testFile, &output, false)
+	if err == nil {
+		t.Fatal("Expected error for assignment to an element of an immutable array, got nil")
+	}
+	if !strings.Contains(err.Error(), "5:1: cannot assign to immutable variable 'xs'") {
+		t.Errorf("Expected immutable variable error, got %v", err)
+	}
+	if output.String() != "[1, 2, 3]\n" {
+		t.Errorf("Expected output %q, got %q", "[1, 2, 3]\n", output.String())
+	}
+}
+
+// TestRunBackendsAgreeOnExamples tests that the bytecode VM produces the
+// same output and errors as the tree-walking evaluator on every example.
+func TestRunBackendsAgreeOnExamples(t *testing.T) {
+	files, err := filepath.Glob("../examples/*.cow")
+	if err != nil {
+		t.Fatalf("Failed to list examples: %v", err)
+	}
+	if len(files) == 0 {
+		t.Skip("No example files found, skipping test")
+	}
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			var treeOutput, vmOutput bytes.Buffer
+			treeErr := RunWithBackend(file, &treeOutput, false, TreeWalker)
+			vmErr := RunWithBackend(file, &vmOutput, false, BytecodeVM)
+
+			if vmOutput.String() != treeOutput.String() {
+				t.Errorf("Output differs\ntree:\n%s\nvm:\n%s", treeOutput.String(), vmOutput.String())
+			}
+			if (treeErr == nil) != (vmErr == nil) || (treeErr != nil && treeErr.Error() != vmErr.Error()) {
+				t.Errorf("Errors differ\ntree: %v\nvm:   %v", treeErr, vmErr)
+			}
+		})
+	}
+}
+
+// TestRunLoopBodyScope tests that both backends scope a loop body as the type
+// checker does: bindings made in the body are gone after the loop.
+func TestRunLoopBodyScope(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.cow")
+
+	source := `fn shadowType() {
+  let x = 1
+  for {
+    let x = "s"
+    break
+  }
+  x + 1
+}
+fn shadowValue() {
+  let x = 1
+  for {
+    let x = 2
+    break
+  }
+  x
+}
+println(shadowType())
+println(shadowValue())
+`
+
+	err := os.WriteFile(testFile, []byte(source), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	for _, backend := range []Backend{TreeWalker, BytecodeVM} {
+		t.Run(string(backend), func(t *testing.T) {
+			var output bytes.Buffer
+			if err := RunWithBackend(testFile, &output, false, backend); err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if output.String() != "2\n1\n" {
+				t.Errorf("Expected output %q, got %q", "2\n1\n", output.String())
+			}
+		})
+	}
+}
+
+// TestRunPrintsNestedFunctions tests that both backends print functions,
+// closures and constructors inside other values the same way.
+func TestRunPrintsNestedFunctions(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.cow")
+
+	source := `type Option<T> = Some of T | None
+fn id(x) { x }
+println([id])
+println([Some])
+println((1, fn(y) { y + 1 }))
+`
+
+	err := os.WriteFile(testFile, []byte(source), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	expected := "[<function>]\n[<function>]\n(1, <function>)\n"
+	for _, backend := range []Backend{TreeWalker, BytecodeVM} {
+		t.Run(string(backend), func(t *testing.T) {
+			var output bytes.Buffer
+			if err := RunWithBackend(testFile, &output, false, backend); err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if output.String() != expected {
+				t.Errorf("Expected output %q, got %q", expected, output.String())
+			}
+		})
+	}
+}
+
+func TestRunBytecodeVMClosuresAndLoops(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.cow")
+
+	source := `fn makeCounter() {
+  let mut count = 0
+  fn() {
+    count = count + 1
+    count
+  }
+}
+fn find(xs, target) {
+  let mut i = 0
+  for i < xs.len() {
+    if xs[i] == target { return i }
+    i = i + 1
+  }
+  -1
+}
+fn skipThree() {
+  let mut i = 0
+  let mut out = []
+  for i < 5 {
+    i = i + 1
+    out.push(1 + if i == 3 { continue } else { i })
+  }
+  out
+}
+fn tens() {
+  let mut i = 0
+  let mut fs = []
+  for i < 3 {
+    let j = i
+    i = i + 1
+    fs.push(fn() { j * 10 })
+  }
+  fs
+}
+let c = makeCounter()
+c()
+println(c())
+println(find([4, 5, 6], 6))
+println(skipThree())
+let mut grid = [[1], [2]]
+grid[1].push(3)
+println(grid)
+let fs = tens()
+let first = fs[0]
+let last = fs[2]
+println(first())
+println(last())
+`
+
+	err := os.WriteFile(testFile, []byte(source), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	var output bytes.Buffer
+	err = RunWithBackend(testFile, &output, false, BytecodeVM)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	expected := "2\n2\n[2, 3, 5, 6]\n[[1], [2, 3]]\n0\n20\n"
+	if output.String() != expected {
+		t.Errorf("Expected output %q, got %q", expected, output.String())
+	}
+}
+
+func TestRunBytecodeVMReportsRuntimeErrorPosition(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.cow")
+
+	source := "let xs = [1, 2, 3]\nlet i = 5\nprintln(xs[i])\n"
+
+	err := os.WriteFile(testFile, []byte(source), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	var output bytes.Buffer
+	err = RunWithBackend(testFile, &output, false, BytecodeVM)
+	if err == nil {
+		t.Fatal("Expected runtime error, got nil")
+	}
+
+	var sourceErr *SourceError
+	if !errors.As(err, &sourceErr) {
+		t.Fatalf("Expected a SourceError, got %T: %v", err, err)
+	}
+	start, stop := sourceErr.Span.Start, sourceErr.Span.Stop
+	if start.Line != 3 || start.Column != 9 || stop.Line != 3 || stop.Column != 14 {
+		t.Errorf("Expected span 3:9 to 3:14, got %v to %v", start, stop)
+	}
+}
+
+func TestRunWithNativeFunctions(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.cow")
+
+	source := "fn scaled(x: i64) -> i64 { x * factor() }\nprintln(scaled(2))\n"
+
+	err := os.WriteFile(testFile, []byte(source), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	var output bytes.Buffer
+	evaluator := eval.NewEvaluator(&output)
+	err = evaluator.Register("factor", types.Func(nil, types.Int), func(args []eval.Value) (eval.Value, error) {
+		return eval.IntValue(10), nil
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	if err := RunWith(testFile, evaluator); err != nil {
+		t.Fatalf("RunWith failed: %v", err)
+	}
+	if output.String() != "20\n" {
+		t.Errorf("Expected output %q, got %q", "20\n", output.String())
+	}
+
+	// The program's functions can be called from Go afterwards
+	scaled, ok := evaluator.Lookup("scaled")
+	if !ok {
+		t.Fatal("Expected scaled to be defined")
+	}
+	result, err := evaluator.Call(scaled, eval.IntValue(5))
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if n, _ := result.AsInt(); n != 50 {
+		t.Errorf("Expected 50, got %v", result)
+	}
+
+	// Calls are type checked against the registered signature
+	if err := os.WriteFile(testFile, []byte("let s: string = factor()\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	err = RunWith(testFile, eval.NewEvaluator(&output))
+	if err == nil || !strings.Contains(err.Error(), "undefined variable: factor") {
+		t.Errorf("Expected factor to be undefined without registering it, got %v", err)
+	}
+	err = RunWith(testFile, evaluator)
+	if err == nil || !strings.Contains(err.Error(), "expected string, found i64") {
+		t.Errorf("Expected a type error, got %v", err)
+	}
+}
@@ -0,0 +1,492 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+)
+
+// Error is a type error at a position in the source code.
+type Error struct {
+	Pos ast.Position
+	Msg string
+}
+
+// Error formats the error as line:column: message.
+func (e *Error) Error() string {
+	if !e.Pos.IsValid() {
+		return e.Msg
+	}
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+// Checker type checks programs.
+// Declarations and top-level bindings seen by Check are remembered, so a
+// Checker can check a program incrementally, one piece at a time.
+type Checker struct {
+	nextID       int
+	level        int                          // Current let-nesting depth; top-level bindings are at depth 0
+	globals      *scope                       // Top-level bindings declared so far
+	types        map[string]*typeInfo         // Declared type name -> declaration
+	constructors map[string]*scheme           // Constructor name -> type (a function type unless nullary)
+	records      []*typeInfo                  // Record types in declaration order
+	functions    map[string]*topLevelFunction // Top-level function name -> definition
+	returnType   Type                         // Return type of the function being checked (nil at top level)
+	typeParams   map[string]Type              // Type parameters in scope for annotations
+}
+
+// typeInfo describes a declared type.
+type typeInfo struct {
+	name   string
+	params []*TVar // Quantified variables standing for the type parameters
+	fields []field // Record fields (nil for variant types)
+	decl   *ast.TypeDeclaration
+}
+
+// field is a record field and its type in terms of the record's parameters.
+type field struct {
+	name string
+	typ  Type
+}
+
+// instance returns the declared type applied to the given type arguments.
+func (ti *typeInfo) instance(args []Type) Type {
+	return &TCon{Name: ti.name, Args: args}
+}
+
+// topLevelFunction is a top-level function definition.
+// Top-level functions may be used before they are defined, so each one is
+// checked when first needed, together with the functions it is mutually
+// recursive with, and then generalized.
+type topLevelFunction struct {
+	def     *ast.FunctionDef
+	env     *scope              // Top-level bindings at the definition (nil until it is reached)
+	group   []*topLevelFunction // The mutually recursive functions, including this one
+	state   functionState
+	typ     Type      // The monomorphic type while the group is checked
+	params  []*TParam // The declared type parameters, once checked
+	scheme  *scheme   // The generalized type once checked
+	problem error     // The first type error in the body
+}
+
+// functionState tracks the progress of checking a top-level function.
+type functionState int
+
+const (
+	pending functionState = iota
+	checking
+	checked
+)
+
+// scope maps names to their types.
+type scope struct {
+	parent *scope
+	names  map[string]*binding
+}
+
+// binding is a name in scope: a variable, or a top-level function.
+type binding struct {
+	scheme   *scheme
+	function *topLevelFunction // Set for top-level functions, whose scheme is computed lazily
+}
+
+// newScope creates a scope nested in parent.
+func newScope(parent *scope) *scope {
+	return &scope{parent: parent, names: make(map[string]*binding)}
+}
+
+// define binds a name to a type scheme in the scope.
+func (s *scope) define(name string, sch *scheme) {
+	s.names[name] = &binding{scheme: sch}
+}
+
+// lookup finds the innermost binding of a name.
+func (s *scope) lookup(name string) (*binding, bool) {
+	for current := s; current != nil; current = current.parent {
+		if b, ok := current.names[name]; ok {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// NewChecker creates a checker with no known declarations or bindings.
+func NewChecker() *Checker {
+	return &Checker{
+		globals:      newScope(nil),
+		types:        make(map[string]*typeInfo),
+		constructors: make(map[string]*scheme),
+		functions:    make(map[string]*topLevelFunction),
+	}
+}
+
+// Snapshot is the state of a Checker at some point, which it can be
+// restored to.
+type Snapshot struct {
+	nextID       int
+	globals      *scope
+	types        map[string]*typeInfo
+	constructors map[string]*scheme
+	records      int
+	functions    map[string]*topLevelFunction
+}
+
+// Snapshot records the declarations and top-level bindings known so far.
+func (c *Checker) Snapshot() Snapshot {
+	return Snapshot{
+		nextID:       c.nextID,
+		globals:      c.globals,
+		types:        maps.Clone(c.types),
+		constructors: maps.Clone(c.constructors),
+		records:      len(c.records),
+		functions:    maps.Clone(c.functions),
+	}
+}
+
+// Restore forgets the declarations and top-level bindings seen since the
+// snapshot was taken, such as those of a program that failed to check.
+func (c *Checker) Restore(s Snapshot) {
+	c.nextID = s.nextID
+	c.globals = s.globals
+	c.types = maps.Clone(s.types)
+	c.constructors = maps.Clone(s.constructors)
+	c.records = c.records[:s.records]
+	c.functions = maps.Clone(s.functions)
+}
+
+// Check type checks a program.
+// Returns nil if the program is well typed, otherwise an error joining one
+// *Error per top-level statement or function that has a type error, in
+// source order.
+func (c *Checker) Check(program *ast.Program) error {
+	var problems []error
+
+	// Declare types first so they may be used before their declaration,
+	// and by each other
+	var decls []*ast.TypeDeclaration
+	for _, stmt := range program.Statements {
+		if decl, ok := stmt.(*ast.TypeDeclaration); ok {
+			if err := c.declareType(decl); err != nil {
+				problems = append(problems, err)
+				continue
+			}
+			decls = append(decls, decl)
+		}
+	}
+	for _, decl := range decls {
+		if err := c.defineType(decl); err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	// Register top-level functions so bodies may call functions defined later
+	var functions []*topLevelFunction
+	byDef := make(map[*ast.FunctionDef]*topLevelFunction)
+	for _, stmt := range program.Statements {
+		if def, ok := stmt.(*ast.FunctionDef); ok {
+			fn := &topLevelFunction{def: def}
+			c.functions[def.Name] = fn
+			functions = append(functions, fn)
+			byDef[def] = fn
+		}
+	}
+	groupFunctions(functions)
+
+	for _, stmt := range program.Statements {
+		switch s := stmt.(type) {
+		case *ast.TypeDeclaration:
+			// Already declared
+
+		case *ast.FunctionDef:
+			// Each definition gets its own layer of top-level bindings, so
+			// that the function sees exactly the bindings declared before it
+			fn := byDef[s]
+			c.globals = newScope(c.globals)
+			c.globals.names[s.Name] = &binding{function: fn}
+			fn.env = c.globals
+
+		case *ast.LetStatement:
+			c.globals = newScope(c.globals)
+			if err := c.checkStatement(c.globals, stmt); err != nil {
+				problems = append(problems, err)
+			}
+
+		default:
+			if err := c.checkStatement(c.globals, stmt); err != nil {
+				problems = append(problems, err)
+			}
+		}
+	}
+
+	// Check the functions that were never used
+	for _, fn := range functions {
+		c.checkFunction(fn)
+		if fn.problem != nil {
+			problems = append(problems, fn.problem)
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := position(problems[i]), position(problems[j])
+		return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+	})
+	return errors.Join(problems...)
+}
+
+// Declare binds a name in the top-level scope to a value of the given type,
+// for values provided by the host program, such as native functions.
+// The type may use type parameters, making the binding polymorphic; uses of
+// the same *TParam stand for the same type.
+func (c *Checker) Declare(name string, t Type) {
+	var params []*TParam
+	var collect func(t Type)
+	collect = func(t Type) {
+		switch t := t.(type) {
+		case *TParam:
+			for _, p := range params {
+				if p == t {
+					return
+				}
+			}
+			params = append(params, t)
+		case *TCon:
+			for _, arg := range t.Args {
+				collect(arg)
+			}
+		}
+	}
+	collect(t)
+
+	c.globals = newScope(c.globals)
+	c.globals.define(name, c.generalize(t, params))
+}
+
+// position returns the position of a type error.
+func position(err error) ast.Position {
+	var typeErr *Error
+	if errors.As(err, &typeErr) {
+		return typeErr.Pos
+	}
+	return ast.Position{}
+}
+
+// errorf creates a type error at a position.
+func errorf(pos ast.Position, format string, args ...interface{}) error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// fresh creates an unbound type variable at the current level.
+func (c *Checker) fresh() *TVar {
+	c.nextID++
+	return &TVar{id: c.nextID, level: c.level}
+}
+
+// instantiate replaces the quantified variables of a scheme with fresh ones.
+func (c *Checker) instantiate(sch *scheme) Type {
+	if len(sch.vars) == 0 {
+		return sch.typ
+	}
+	vars := make(map[*TVar]Type, len(sch.vars))
+	for _, v := range sch.vars {
+		fresh := c.fresh()
+		fresh.class = v.class
+		vars[v] = fresh
+	}
+	return substitute(sch.typ, vars, nil)
+}
+
+// generalize quantifies a type over its variables created deeper than the
+// current level, and over the given type parameters.
+func (c *Checker) generalize(t Type, params []*TParam) *scheme {
+	sch := &scheme{}
+	var collect func(t Type)
+	collect = func(t Type) {
+		switch t := resolve(t).(type) {
+		case *TVar:
+			if t.level > c.level {
+				for _, v := range sch.vars {
+					if v == t {
+						return
+					}
+				}
+				sch.vars = append(sch.vars, t)
+			}
+		case *TCon:
+			for _, arg := range t.Args {
+				collect(arg)
+			}
+		}
+	}
+	collect(t)
+
+	if len(params) == 0 {
+		sch.typ = t
+		return sch
+	}
+	replacements := make(map[*TParam]Type, len(params))
+	for _, param := range params {
+		v := c.fresh()
+		sch.vars = append(sch.vars, v)
+		replacements[param] = v
+	}
+	sch.typ = substitute(t, nil, replacements)
+	return sch
+}
+
+// unify makes two types equal, reporting a mismatch as expected versus found.
+func (c *Checker) unify(pos ast.Position, expected, found Type) error {
+	if err := unify(expected, found); err != nil {
+		if err == errMismatch {
+			return errorf(pos, "expected %s, found %s", expected, found)
+		}
+		return errorf(pos, "%v", err)
+	}
+	return nil
+}
+
+// lookup finds the type of a name in scope, instantiating polymorphic types.
+// Top-level functions not in scope (because they are defined later) and
+// constructors are found too.
+func (c *Checker) lookup(env *scope, name string, pos ast.Position) (Type, error) {
+	if b, ok := env.lookup(name); ok {
+		if b.function != nil {
+			return c.functionType(b.function, pos)
+		}
+		return c.instantiate(b.scheme), nil
+	}
+	if fn, ok := c.functions[name]; ok {
+		return c.functionType(fn, pos)
+	}
+	if sch, ok := c.constructors[name]; ok {
+		return c.instantiate(sch), nil
+	}
+	return nil, errorf(pos, "undefined variable: %s", name)
+}
+
+// functionType returns the type of a top-level function, checking it first if needed.
+func (c *Checker) functionType(fn *topLevelFunction, pos ast.Position) (Type, error) {
+	switch fn.state {
+	case checking:
+		// A recursive call: the type is not generalized yet
+		return fn.typ, nil
+	case pending:
+		if fn.env == nil {
+			return nil, errorf(pos, "function %s is used before its definition", fn.def.Name)
+		}
+		c.checkFunction(fn)
+	}
+	return c.instantiate(fn.scheme), nil
+}
+
+// checkFunction checks a top-level function and the functions it is
+// mutually recursive with, then generalizes their types.
+// A type error in a body is recorded on its function, whose type then
+// becomes fully polymorphic so that its uses do not report further errors.
+func (c *Checker) checkFunction(fn *topLevelFunction) {
+	if fn.state != pending {
+		return
+	}
+
+	savedLevel, savedReturn := c.level, c.returnType
+	defer func() { c.level, c.returnType = savedLevel, savedReturn }()
+
+	// Bodies see the top-level bindings at their definition, at level 0
+	c.level = 1
+	for _, member := range fn.group {
+		member.state = checking
+		member.typ = c.fresh()
+	}
+
+	for _, member := range fn.group {
+		def := member.def
+		if member.env == nil {
+			member.problem = errorf(def.Pos(), "function %s is used before its definition", def.Name)
+			continue
+		}
+		t, params, err := c.inferFunction(member.env, def.TypeParams, def.Parameters, def.ParamTypes, def.ReturnType, def.Body)
+		if err == nil {
+			err = c.unify(def.Pos(), member.typ, t)
+		}
+		if err != nil {
+			member.problem = err
+			continue
+		}
+		member.params = params
+	}
+
+	c.level = 0
+	for _, member := range fn.group {
+		if member.problem != nil {
+			v := c.fresh()
+			member.scheme = &scheme{vars: []*TVar{v}, typ: v}
+		} else {
+			member.scheme = c.generalize(member.typ, member.params)
+		}
+		member.state = checked
+	}
+}
+
+// groupFunctions finds the groups of mutually recursive functions with
+// Tarjan's strongly connected components algorithm over the calls and
+// references between them.
+func groupFunctions(functions []*topLevelFunction) {
+	byName := make(map[string]*topLevelFunction, len(functions))
+	for _, fn := range functions {
+		byName[fn.def.Name] = fn
+	}
+
+	index := make(map[*topLevelFunction]int)
+	lowLink := make(map[*topLevelFunction]int)
+	onStack := make(map[*topLevelFunction]bool)
+	var stack []*topLevelFunction
+	next := 0
+
+	var visit func(fn *topLevelFunction)
+	visit = func(fn *topLevelFunction) {
+		index[fn] = next
+		lowLink[fn] = next
+		next++
+		stack = append(stack, fn)
+		onStack[fn] = true
+
+		for name := range referencedNames(fn.def.Body) {
+			callee, ok := byName[name]
+			if !ok {
+				continue
+			}
+			if _, visited := index[callee]; !visited {
+				visit(callee)
+				if lowLink[callee] < lowLink[fn] {
+					lowLink[fn] = lowLink[callee]
+				}
+			} else if onStack[callee] && index[callee] < lowLink[fn] {
+				lowLink[fn] = index[callee]
+			}
+		}
+
+		if lowLink[fn] == index[fn] {
+			var group []*topLevelFunction
+			for {
+				member := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[member] = false
+				group = append(group, member)
+				if member == fn {
+					break
+				}
+			}
+			for _, member := range group {
+				member.group = group
+			}
+		}
+	}
+
+	for _, fn := range functions {
+		if _, visited := index[fn]; !visited {
+			visit(fn)
+		}
+	}
+}
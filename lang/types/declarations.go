@@ -0,0 +1,237 @@
+package types
+
+import (
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+)
+
+// This file handles type declarations and type annotations.
+
+// declareType registers the name and type parameters of a type declaration.
+// Its constructors and fields are defined by defineType once every type in
+// the program is declared, so declarations may refer to each other.
+func (c *Checker) declareType(decl *ast.TypeDeclaration) error {
+	if _, ok := builtinTypes[decl.Name]; ok {
+		return errorf(decl.Pos(), "cannot redeclare built-in type %s", decl.Name)
+	}
+
+	info := &typeInfo{name: decl.Name, decl: decl}
+	seen := make(map[string]bool, len(decl.TypeParams))
+	for _, param := range decl.TypeParams {
+		if seen[param] {
+			return errorf(decl.Pos(), "duplicate type parameter %s in type %s", param, decl.Name)
+		}
+		seen[param] = true
+		info.params = append(info.params, c.fresh())
+	}
+	c.types[decl.Name] = info
+	return nil
+}
+
+// defineType defines the constructors or record fields of a declared type.
+func (c *Checker) defineType(decl *ast.TypeDeclaration) error {
+	info := c.types[decl.Name]
+	params := make(map[string]Type, len(decl.TypeParams))
+	for i, param := range decl.TypeParams {
+		params[param] = info.params[i]
+	}
+	self := info.instance(varTypes(info.params))
+
+	if decl.Fields != nil {
+		info.fields = make([]field, len(decl.Fields))
+		for i, fieldDecl := range decl.Fields {
+			t, err := c.resolveType(fieldDecl.Type, params)
+			if err != nil {
+				return err
+			}
+			info.fields[i] = field{name: fieldDecl.Name, typ: t}
+		}
+		c.records = append(c.records, info)
+		return nil
+	}
+
+	seen := make(map[string]bool, len(decl.Variants))
+	for _, variant := range decl.Variants {
+		if seen[variant.Name] {
+			return errorf(variant.Pos(), "duplicate constructor %s in type %s", variant.Name, decl.Name)
+		}
+		seen[variant.Name] = true
+
+		fieldTypes := make([]Type, len(variant.Fields))
+		for i, fieldExpr := range variant.Fields {
+			t, err := c.resolveType(fieldExpr, params)
+			if err != nil {
+				return err
+			}
+			fieldTypes[i] = t
+		}
+
+		t := self
+		if len(fieldTypes) > 0 {
+			t = fnOf(fieldTypes, self)
+		}
+		c.constructors[variant.Name] = &scheme{vars: info.params, typ: t}
+	}
+	return nil
+}
+
+// varTypes converts type variables to a slice of types.
+func varTypes(vars []*TVar) []Type {
+	types := make([]Type, len(vars))
+	for i, v := range vars {
+		types[i] = v
+	}
+	return types
+}
+
+// resolveType converts a type written in source code to a Type.
+// params maps the type parameters in scope to their types.
+func (c *Checker) resolveType(expr ast.TypeExpression, params map[string]Type) (Type, error) {
+	switch t := expr.(type) {
+	case *ast.NamedType:
+		if param, ok := params[t.Name]; ok {
+			if len(t.Args) != 0 {
+				return nil, errorf(t.Pos(), "type parameter %s does not take type arguments", t.Name)
+			}
+			return param, nil
+		}
+		if builtin, ok := builtinTypes[t.Name]; ok {
+			if len(t.Args) != 0 {
+				return nil, errorf(t.Pos(), "type %s does not take type arguments", t.Name)
+			}
+			return builtin, nil
+		}
+		info, ok := c.types[t.Name]
+		if !ok {
+			return nil, errorf(t.Pos(), "unknown type %s", t.Name)
+		}
+		if len(t.Args) != len(info.params) {
+			return nil, errorf(t.Pos(), "type %s expects %d type arguments, got %d", t.Name, len(info.params), len(t.Args))
+		}
+		args, err := c.resolveTypes(t.Args, params)
+		if err != nil {
+			return nil, err
+		}
+		return info.instance(args), nil
+
+	case *ast.TupleType:
+		elements, err := c.resolveTypes(t.Elements, params)
+		if err != nil {
+			return nil, err
+		}
+		return tupleOf(elements), nil
+
+	case *ast.ArrayType:
+		element, err := c.resolveType(t.Element, params)
+		if err != nil {
+			return nil, err
+		}
+		return arrayOf(element), nil
+
+	case *ast.FunctionType:
+		paramTypes, err := c.resolveTypes(t.Params, params)
+		if err != nil {
+			return nil, err
+		}
+		result, err := c.resolveType(t.Return, params)
+		if err != nil {
+			return nil, err
+		}
+		return fnOf(paramTypes, result), nil
+
+	default:
+		return nil, errorf(expr.Pos(), "unsupported type expression %T", expr)
+	}
+}
+
+// resolveTypes converts a list of types written in source code.
+func (c *Checker) resolveTypes(exprs []ast.TypeExpression, params map[string]Type) ([]Type, error) {
+	types := make([]Type, len(exprs))
+	for i, expr := range exprs {
+		t, err := c.resolveType(expr, params)
+		if err != nil {
+			return nil, err
+		}
+		types[i] = t
+	}
+	return types, nil
+}
+
+// findRecordType finds the record type of a record literal: the most recently
+// declared record type with exactly the given fields, as in the evaluator.
+func (c *Checker) findRecordType(names []string) *typeInfo {
+	for i := len(c.records) - 1; i >= 0; i-- {
+		info := c.records[i]
+		if len(info.fields) != len(names) {
+			continue
+		}
+		matches := true
+		for _, name := range names {
+			if _, ok := info.field(name); !ok {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return info
+		}
+	}
+	return nil
+}
+
+// field finds a record field by name.
+func (ti *typeInfo) field(name string) (field, bool) {
+	for _, f := range ti.fields {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+// recordTypesWith returns the record types that have all the given fields.
+func (c *Checker) recordTypesWith(names []string) []*typeInfo {
+	var matches []*typeInfo
+	for _, info := range c.records {
+		hasAll := true
+		for _, name := range names {
+			if _, ok := info.field(name); !ok {
+				hasAll = false
+				break
+			}
+		}
+		if hasAll {
+			matches = append(matches, info)
+		}
+	}
+	return matches
+}
+
+// instantiateRecord returns a fresh instance of a record type and the
+// types of its fields in that instance.
+func (c *Checker) instantiateRecord(info *typeInfo) (Type, map[string]Type) {
+	vars := make(map[*TVar]Type, len(info.params))
+	args := make([]Type, len(info.params))
+	for i, param := range info.params {
+		v := c.fresh()
+		vars[param] = v
+		args[i] = v
+	}
+	fields := make(map[string]Type, len(info.fields))
+	for _, f := range info.fields {
+		fields[f.name] = substitute(f.typ, vars, nil)
+	}
+	return info.instance(args), fields
+}
+
+// recordInfo returns the declaration of a record type, or nil if t is not a record type.
+func (c *Checker) recordInfo(t Type) *typeInfo {
+	con, ok := resolve(t).(*TCon)
+	if !ok {
+		return nil
+	}
+	info, ok := c.types[con.Name]
+	if !ok || info.fields == nil {
+		return nil
+	}
+	return info
+}
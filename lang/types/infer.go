@@ -0,0 +1,833 @@
+package types
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+)
+
+// This file infers the types of statements, expressions and patterns.
+
+// arrayMethods are the methods of arrays, called as xs.len(), xs.push(x),
+// xs.pop() and xs.sorted().
+var arrayMethods = map[string]bool{"len": true, "push": true, "pop": true, "sorted": true}
+
+// checkStatement checks a statement, defining any names it binds in env.
+func (c *Checker) checkStatement(env *scope, stmt ast.Statement) error {
+	switch s := stmt.(type) {
+	case *ast.LetStatement:
+		return c.checkLet(env, s)
+
+	case *ast.ExpressionStatement:
+		_, err := c.infer(env, s.Expression)
+		return err
+
+	case *ast.Assignment:
+		target, err := c.lookup(env, s.Name, s.Pos())
+		if err != nil {
+			return err
+		}
+		value, err := c.infer(env, s.Value)
+		if err != nil {
+			return err
+		}
+		return c.unify(s.Value.Pos(), target, value)
+
+	case *ast.IndexAssignment:
+		target, err := c.infer(env, s.Target)
+		if err != nil {
+			return err
+		}
+		return c.checkExpression(env, s.Value, target)
+
+	case *ast.ReturnStatement:
+		if c.returnType == nil {
+			return errorf(s.Pos(), "return statement outside function")
+		}
+		return c.checkExpression(env, s.Value, c.returnType)
+
+	case *ast.ForStatement:
+		if s.Condition != nil {
+			if err := c.checkExpression(env, s.Condition, tBool); err != nil {
+				return err
+			}
+		}
+		// The body is a scope of its own, as each iteration is in both backends
+		_, err := c.inferBlock(env, s.Body)
+		return err
+
+	case *ast.BreakStatement, *ast.ContinueStatement:
+		return nil
+
+	case *ast.Block:
+		_, err := c.inferBlock(env, s)
+		return err
+
+	case *ast.TypeDeclaration:
+		return errorf(s.Pos(), "type %s must be declared at top level", s.Name)
+
+	case *ast.FunctionDef:
+		return errorf(s.Pos(), "function %s must be defined at top level", s.Name)
+
+	default:
+		return errorf(stmt.Pos(), "unsupported statement %T", stmt)
+	}
+}
+
+// checkLet checks a let statement and binds its names.
+// A binding is generalized when it is immutable and its value is a function
+// literal, a name or a literal, whose evaluation cannot create values of a
+// type that is only partially known (the value restriction).
+func (c *Checker) checkLet(env *scope, s *ast.LetStatement) error {
+	c.level++
+	value, err := c.infer(env, s.Value)
+	if err == nil && s.Type != nil {
+		var annotated Type
+		annotated, err = c.resolveType(s.Type, c.typeParams)
+		if err == nil {
+			err = c.unify(s.Value.Pos(), annotated, value)
+		}
+	}
+	c.level--
+
+	if err != nil {
+		// Bind the names anyway, so their uses do not report further errors
+		if s.Pattern != nil {
+			c.inferPattern(env, s.Pattern, c.fresh())
+		} else {
+			env.define(s.Name, anything(c.fresh()))
+		}
+		return err
+	}
+
+	if s.Pattern != nil {
+		adjustLevels(value, c.level)
+		return c.inferPattern(env, s.Pattern, value)
+	}
+	if !s.Mutable && isGeneralizable(s.Value) {
+		env.define(s.Name, c.generalize(value, nil))
+		return nil
+	}
+	adjustLevels(value, c.level)
+	env.define(s.Name, mono(value))
+	return nil
+}
+
+// anything returns a scheme that instantiates to any type, for names whose
+// type could not be inferred.
+func anything(v *TVar) *scheme {
+	return &scheme{vars: []*TVar{v}, typ: v}
+}
+
+// isGeneralizable reports whether a let binding of the expression may be polymorphic.
+func isGeneralizable(expr ast.Expression) bool {
+	switch expr.(type) {
+	case *ast.FunctionLiteral, *ast.Identifier, *ast.IntLiteral, *ast.FloatLiteral, *ast.BoolLiteral, *ast.StringLiteral:
+		return true
+	default:
+		return false
+	}
+}
+
+// inferBlock infers the type of a block in a new scope nested in env.
+// The type is that of its trailing expression, or unit if it has none.
+// A block ending in return, break or continue never produces a value, so
+// its type is unconstrained.
+func (c *Checker) inferBlock(env *scope, block *ast.Block) (Type, error) {
+	blockEnv := newScope(env)
+	result := Type(tUnit)
+	for i, stmt := range block.Statements {
+		if i < len(block.Statements)-1 {
+			if err := c.checkStatement(blockEnv, stmt); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		switch last := stmt.(type) {
+		case *ast.ExpressionStatement:
+			t, err := c.infer(blockEnv, last.Expression)
+			if err != nil {
+				return nil, err
+			}
+			result = t
+		case *ast.ReturnStatement, *ast.BreakStatement, *ast.ContinueStatement:
+			if err := c.checkStatement(blockEnv, stmt); err != nil {
+				return nil, err
+			}
+			result = c.fresh()
+		default:
+			if err := c.checkStatement(blockEnv, stmt); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// inferFunction infers the type of a function from its parameters, their
+// annotations and its body, which is checked in a scope nested in env.
+// Returns the function type and the rigid type parameters it is generic over.
+func (c *Checker) inferFunction(env *scope, typeParams []string, params []string, paramTypes []ast.TypeExpression, returnType ast.TypeExpression, body *ast.Block) (Type, []*TParam, error) {
+	savedParams, savedReturn := c.typeParams, c.returnType
+	defer func() { c.typeParams, c.returnType = savedParams, savedReturn }()
+
+	var rigid []*TParam
+	if len(typeParams) > 0 {
+		inScope := make(map[string]Type, len(c.typeParams)+len(typeParams))
+		for name, t := range c.typeParams {
+			inScope[name] = t
+		}
+		for _, name := range typeParams {
+			param := &TParam{Name: name}
+			rigid = append(rigid, param)
+			inScope[name] = param
+		}
+		c.typeParams = inScope
+	}
+
+	fnEnv := newScope(env)
+	types := make([]Type, len(params))
+	for i, name := range params {
+		var t Type = c.fresh()
+		if i < len(paramTypes) && paramTypes[i] != nil {
+			annotated, err := c.resolveType(paramTypes[i], c.typeParams)
+			if err != nil {
+				return nil, nil, err
+			}
+			t = annotated
+		}
+		types[i] = t
+		fnEnv.define(name, mono(t))
+	}
+
+	var result Type = c.fresh()
+	if returnType != nil {
+		annotated, err := c.resolveType(returnType, c.typeParams)
+		if err != nil {
+			return nil, nil, err
+		}
+		result = annotated
+	}
+	c.returnType = result
+
+	bodyType, err := c.inferBlock(fnEnv, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	pos := body.Pos()
+	if n := len(body.Statements); n > 0 {
+		pos = body.Statements[n-1].Pos()
+	}
+	if err := c.unify(pos, result, bodyType); err != nil {
+		return nil, nil, err
+	}
+	return fnOf(types, result), rigid, nil
+}
+
+// checkExpression infers the type of an expression and unifies it with the expected type.
+func (c *Checker) checkExpression(env *scope, expr ast.Expression, expected Type) error {
+	t, err := c.infer(env, expr)
+	if err != nil {
+		return err
+	}
+	return c.unify(expr.Pos(), expected, t)
+}
+
+// mismatch unifies two types, describing a mismatch with a custom message.
+func (c *Checker) mismatch(pos ast.Position, a, b Type, format string, args ...interface{}) error {
+	if err := unify(a, b); err != nil {
+		if err == errMismatch {
+			return errorf(pos, format, args...)
+		}
+		return errorf(pos, "%v", err)
+	}
+	return nil
+}
+
+// infer infers the type of an expression.
+func (c *Checker) infer(env *scope, expr ast.Expression) (Type, error) {
+	switch e := expr.(type) {
+	case *ast.IntLiteral:
+		return tInt, nil
+	case *ast.FloatLiteral:
+		return tFloat, nil
+	case *ast.BoolLiteral:
+		return tBool, nil
+	case *ast.StringLiteral:
+		return tString, nil
+
+	case *ast.Identifier:
+		return c.lookup(env, e.Name, e.Pos())
+
+	case *ast.FunctionCall:
+		return c.inferCall(env, e)
+
+	case *ast.UnaryExpression:
+		operand, err := c.infer(env, e.Operand)
+		if err != nil {
+			return nil, err
+		}
+		if e.Operator == "NOT" || e.Operator == "!" {
+			if err := c.unify(e.Operand.Pos(), tBool, operand); err != nil {
+				return nil, err
+			}
+			return tBool, nil
+		}
+		if err := constrain(operand, numericClass); err != nil {
+			return nil, errorf(e.Operand.Pos(), "operator - expects a number, found %s", operand)
+		}
+		return operand, nil
+
+	case *ast.BinaryExpression:
+		return c.inferBinary(env, e)
+
+	case *ast.FunctionLiteral:
+		c.level++
+		t, _, err := c.inferFunction(env, nil, e.Parameters, e.ParamTypes, e.ReturnType, e.Body)
+		c.level--
+		return t, err
+
+	case *ast.IfExpression:
+		if err := c.checkExpression(env, e.Condition, tBool); err != nil {
+			return nil, err
+		}
+		consequence, err := c.inferBlock(env, e.Consequence)
+		if err != nil {
+			return nil, err
+		}
+		if e.Alternative == nil {
+			// Without an else, the value is unit whichever branch runs
+			if err := c.mismatch(e.Pos(), consequence, tUnit,
+				"if without else must have type (), got %s", consequence); err != nil {
+				return nil, err
+			}
+			return tUnit, nil
+		}
+		alternative, err := c.inferBlock(env, e.Alternative)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.mismatch(e.Pos(), consequence, alternative,
+			"if and else branches have different types: %s and %s", consequence, alternative); err != nil {
+			return nil, err
+		}
+		return consequence, nil
+
+	case *ast.MatchExpression:
+		subject, err := c.infer(env, e.Subject)
+		if err != nil {
+			return nil, err
+		}
+		var result Type = c.fresh()
+		for i, arm := range e.Arms {
+			armEnv := newScope(env)
+			if err := c.inferPattern(armEnv, arm.Pattern, subject); err != nil {
+				return nil, err
+			}
+			body, err := c.inferBlock(armEnv, arm.Body)
+			if err != nil {
+				return nil, err
+			}
+			if err := c.mismatch(arm.Pos(), result, body,
+				"match arm %d has type %s, but earlier arms have type %s", i+1, body, result); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+
+	case *ast.ArrayLiteral:
+		element := Type(c.fresh())
+		for _, elemExpr := range e.Elements {
+			t, err := c.infer(env, elemExpr)
+			if err != nil {
+				return nil, err
+			}
+			if err := c.mismatch(elemExpr.Pos(), element, t,
+				"array elements must have the same type: expected %s, found %s", element, t); err != nil {
+				return nil, err
+			}
+		}
+		return arrayOf(element), nil
+
+	case *ast.TupleLiteral:
+		elements := make([]Type, len(e.Elements))
+		for i, elemExpr := range e.Elements {
+			t, err := c.infer(env, elemExpr)
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = t
+		}
+		return tupleOf(elements), nil
+
+	case *ast.RecordLiteral:
+		return c.inferRecordLiteral(env, e)
+
+	case *ast.RecordUpdate:
+		base, err := c.infer(env, e.Base)
+		if err != nil {
+			return nil, err
+		}
+		if info := c.recordInfo(base); info == nil {
+			names := make([]string, len(e.Fields))
+			for i, fieldValue := range e.Fields {
+				names[i] = fieldValue.Name
+			}
+			if err := c.inferRecordOf(e.Base.Pos(), base, names); err != nil {
+				return nil, err
+			}
+		}
+		for _, fieldValue := range e.Fields {
+			fieldType, err := c.fieldType(fieldValue.Pos(), base, fieldValue.Name)
+			if err != nil {
+				return nil, err
+			}
+			if err := c.checkExpression(env, fieldValue.Value, fieldType); err != nil {
+				return nil, err
+			}
+		}
+		return base, nil
+
+	case *ast.MemberAccess:
+		object, err := c.infer(env, e.Object)
+		if err != nil {
+			return nil, err
+		}
+		if arrayMethods[e.Member] {
+			if con, ok := resolve(object).(*TCon); ok && con.Name == "array" {
+				return nil, errorf(e.Pos(), "method %s must be called", e.Member)
+			}
+		}
+		if c.recordInfo(object) == nil {
+			if err := c.inferRecordOf(e.Pos(), object, []string{e.Member}); err != nil {
+				return nil, err
+			}
+		}
+		return c.fieldType(e.Pos(), object, e.Member)
+
+	case *ast.IndexAccess:
+		element := c.fresh()
+		if err := c.checkExpression(env, e.Object, arrayOf(element)); err != nil {
+			return nil, err
+		}
+		if err := c.checkExpression(env, e.Index, tInt); err != nil {
+			return nil, err
+		}
+		return element, nil
+
+	case *ast.BlockExpression:
+		return c.inferBlock(env, e.Block)
+
+	default:
+		return nil, errorf(expr.Pos(), "unsupported expression %T", expr)
+	}
+}
+
+// inferCall infers the type of a call: to println, to an array method, or
+// to a function or constructor.
+func (c *Checker) inferCall(env *scope, call *ast.FunctionCall) (Type, error) {
+	if call.Name == "println" {
+		if _, shadowed := env.lookup("println"); !shadowed {
+			for _, arg := range call.Arguments {
+				if _, err := c.infer(env, arg); err != nil {
+					return nil, err
+				}
+			}
+			return tUnit, nil
+		}
+	}
+
+	// xs.push(x) is a call of push whose first argument is xs.push
+	if len(call.Arguments) > 0 && arrayMethods[call.Name] {
+		if member, ok := call.Arguments[0].(*ast.MemberAccess); ok && member.Member == call.Name {
+			return c.inferMethodCall(env, call, member)
+		}
+	}
+
+	callee, err := c.lookup(env, call.Name, call.Pos())
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]Type, len(call.Arguments))
+	for i, arg := range call.Arguments {
+		t, err := c.infer(env, arg)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = t
+	}
+
+	switch fn := resolve(callee).(type) {
+	case *TCon:
+		if fn.Name != "fn" {
+			return nil, errorf(call.Pos(), "%s is not a function (it has type %s)", call.Name, callee)
+		}
+		params, result := fn.Args[:len(fn.Args)-1], fn.Args[len(fn.Args)-1]
+		if len(params) != len(args) {
+			return nil, errorf(call.Pos(), "%s expects %d arguments, got %d", call.Name, len(params), len(args))
+		}
+		for i := range params {
+			if err := c.mismatch(call.Arguments[i].Pos(), params[i], args[i],
+				"argument %d to %s: expected %s, found %s", i+1, call.Name, params[i], args[i]); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+
+	case *TParam:
+		return nil, errorf(call.Pos(), "%s is not a function (it has type %s)", call.Name, callee)
+
+	default:
+		// The callee's type is not known yet
+		result := c.fresh()
+		if err := c.unify(call.Pos(), callee, fnOf(args, result)); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+}
+
+// inferMethodCall infers the type of a call of an array method.
+func (c *Checker) inferMethodCall(env *scope, call *ast.FunctionCall, member *ast.MemberAccess) (Type, error) {
+	element := c.fresh()
+	if err := c.checkExpression(env, member.Object, arrayOf(element)); err != nil {
+		return nil, err
+	}
+
+	args := call.Arguments[1:]
+	switch call.Name {
+	case "push":
+		if len(args) != 1 {
+			return nil, errorf(call.Pos(), "push expects 1 argument, got %d", len(args))
+		}
+		if err := c.checkExpression(env, args[0], element); err != nil {
+			return nil, err
+		}
+		return tUnit, nil
+	case "pop":
+		if len(args) != 0 {
+			return nil, errorf(call.Pos(), "pop expects 0 arguments, got %d", len(args))
+		}
+		return element, nil
+	case "sorted":
+		if len(args) != 0 {
+			return nil, errorf(call.Pos(), "sorted expects 0 arguments, got %d", len(args))
+		}
+		if err := constrain(element, comparableClass); err != nil {
+			return nil, errorf(member.Object.Pos(), "sorted: %v", err)
+		}
+		return arrayOf(element), nil
+	default:
+		if len(args) != 0 {
+			return nil, errorf(call.Pos(), "len expects 0 arguments, got %d", len(args))
+		}
+		return tInt, nil
+	}
+}
+
+// inferBinary infers the type of a binary expression.
+// Arithmetic on an i64 and an f64 yields an f64, as in the evaluator; they
+// can also be compared, by value.
+func (c *Checker) inferBinary(env *scope, e *ast.BinaryExpression) (Type, error) {
+	left, err := c.infer(env, e.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.infer(env, e.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.Operator {
+	case "AND", "OR", "&&", "||":
+		if err := c.unify(e.Left.Pos(), tBool, left); err != nil {
+			return nil, err
+		}
+		if err := c.unify(e.Right.Pos(), tBool, right); err != nil {
+			return nil, err
+		}
+		return tBool, nil
+
+	case "==", "!=":
+		if isNumber(left) && isNumber(right) {
+			return tBool, nil
+		}
+		if err := c.mismatch(e.Pos(), left, right, "cannot compare %s with %s", left, right); err != nil {
+			return nil, err
+		}
+		return tBool, nil
+
+	case "%":
+		if err := c.unify(e.Left.Pos(), tInt, left); err != nil {
+			return nil, err
+		}
+		if err := c.unify(e.Right.Pos(), tInt, right); err != nil {
+			return nil, err
+		}
+		return tInt, nil
+	}
+
+	comparison := e.Operator == "<" || e.Operator == "<=" || e.Operator == ">" || e.Operator == ">="
+	operandClass := numericClass
+	if comparison {
+		operandClass = comparableClass
+	} else if e.Operator == "+" {
+		operandClass = addableClass
+	}
+	if err := constrain(left, operandClass); err != nil {
+		return nil, errorf(e.Left.Pos(), "operator %s expects %s, found %s", e.Operator, operandClass.describe(), left)
+	}
+	if err := constrain(right, operandClass); err != nil {
+		return nil, errorf(e.Right.Pos(), "operator %s expects %s, found %s", e.Operator, operandClass.describe(), right)
+	}
+
+	result := left
+	if isNumber(left) && isNumber(right) {
+		if resolve(left) != resolve(right) {
+			result = tFloat
+		}
+	} else if err := c.mismatch(e.Pos(), left, right,
+		"operator %s operands have different types: %s and %s", e.Operator, left, right); err != nil {
+		return nil, err
+	}
+
+	if comparison {
+		return tBool, nil
+	}
+	return result, nil
+}
+
+// inferRecordLiteral infers the type of a record literal: the most recently
+// declared record type with exactly its fields.
+func (c *Checker) inferRecordLiteral(env *scope, e *ast.RecordLiteral) (Type, error) {
+	names := make([]string, len(e.Fields))
+	for i, fieldValue := range e.Fields {
+		names[i] = fieldValue.Name
+	}
+	info := c.findRecordType(names)
+	if info == nil {
+		sorted := append([]string{}, names...)
+		sort.Strings(sorted)
+		return nil, errorf(e.Pos(), "no record type has exactly the fields {%s}", strings.Join(sorted, ", "))
+	}
+
+	record, fields := c.instantiateRecord(info)
+	for _, fieldValue := range e.Fields {
+		if err := c.checkExpression(env, fieldValue.Value, fields[fieldValue.Name]); err != nil {
+			return nil, err
+		}
+	}
+	return record, nil
+}
+
+// inferRecordOf determines the record type of a value whose type is not yet
+// known from the fields used on it: it must be the only record type with
+// those fields.
+func (c *Checker) inferRecordOf(pos ast.Position, t Type, names []string) error {
+	if _, unknown := resolve(t).(*TVar); !unknown {
+		return errorf(pos, "%s is not a record type, so it has no field %s", t, names[0])
+	}
+	candidates := c.recordTypesWith(names)
+	switch len(candidates) {
+	case 0:
+		return errorf(pos, "no record type has the field %s", strings.Join(names, ", "))
+	case 1:
+		record, _ := c.instantiateRecord(candidates[0])
+		return c.unify(pos, record, t)
+	default:
+		return errorf(pos, "cannot tell which record type has field %s; add a type annotation", names[0])
+	}
+}
+
+// fieldType returns the type of a field of a record type.
+func (c *Checker) fieldType(pos ast.Position, t Type, name string) (Type, error) {
+	info := c.recordInfo(t)
+	if _, ok := info.field(name); !ok {
+		return nil, errorf(pos, "record %s has no field '%s'", info.name, name)
+	}
+
+	// Substitute the record's type arguments into the declared field type
+	con := resolve(t).(*TCon)
+	vars := make(map[*TVar]Type, len(info.params))
+	for i, param := range info.params {
+		vars[param] = con.Args[i]
+	}
+	f, _ := info.field(name)
+	return substitute(f.typ, vars, nil), nil
+}
+
+// inferPattern checks a pattern against the type of the value it matches,
+// defining the names it binds in env.
+func (c *Checker) inferPattern(env *scope, pattern ast.Pattern, t Type) error {
+	switch p := pattern.(type) {
+	case *ast.WildcardPattern:
+		return nil
+
+	case *ast.BindingPattern:
+		env.define(p.Name, mono(t))
+		return nil
+
+	case *ast.LiteralPattern:
+		literal, err := c.infer(env, p.Value)
+		if err != nil {
+			return err
+		}
+		return c.mismatch(p.Pos(), t, literal, "pattern of type %s cannot match a value of type %s", literal, t)
+
+	case *ast.ConstructorPattern:
+		sch, ok := c.constructors[p.Name]
+		if !ok {
+			return errorf(p.Pos(), "unknown constructor %s", p.Name)
+		}
+		ctor := c.instantiate(sch)
+		fields := []Type{}
+		result := ctor
+		if fn, ok := ctor.(*TCon); ok && fn.Name == "fn" {
+			fields, result = fn.Args[:len(fn.Args)-1], fn.Args[len(fn.Args)-1]
+		}
+		if len(fields) != len(p.Args) {
+			return errorf(p.Pos(), "constructor %s has %d fields, but the pattern has %d", p.Name, len(fields), len(p.Args))
+		}
+		if err := c.mismatch(p.Pos(), t, result, "pattern of type %s cannot match a value of type %s", result, t); err != nil {
+			return err
+		}
+		for i, arg := range p.Args {
+			if err := c.inferPattern(env, arg, fields[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *ast.ArrayPattern:
+		element := c.fresh()
+		if err := c.mismatch(p.Pos(), t, arrayOf(element), "array pattern cannot match a value of type %s", t); err != nil {
+			return err
+		}
+		for _, elem := range p.Elements {
+			if err := c.inferPattern(env, elem, element); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *ast.TuplePattern:
+		elements := make([]Type, len(p.Elements))
+		for i := range elements {
+			elements[i] = c.fresh()
+		}
+		tuple := tupleOf(elements)
+		if err := c.mismatch(p.Pos(), t, tuple, "pattern of type %s cannot match a value of type %s", tuple, t); err != nil {
+			return err
+		}
+		for i, elem := range p.Elements {
+			if err := c.inferPattern(env, elem, elements[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return errorf(pattern.Pos(), "unsupported pattern %T", pattern)
+	}
+}
+
+// referencedNames returns the names used in a block as variables or called
+// as functions. Shadowing is ignored, so the result may include names that
+// refer to local bindings.
+func referencedNames(block *ast.Block) map[string]bool {
+	names := make(map[string]bool)
+	var visitBlock func(block *ast.Block)
+	var visitExpr func(expr ast.Expression)
+	var visitStmt func(stmt ast.Statement)
+
+	visitExpr = func(expr ast.Expression) {
+		switch e := expr.(type) {
+		case *ast.Identifier:
+			names[e.Name] = true
+		case *ast.FunctionCall:
+			names[e.Name] = true
+			for _, arg := range e.Arguments {
+				visitExpr(arg)
+			}
+		case *ast.UnaryExpression:
+			visitExpr(e.Operand)
+		case *ast.BinaryExpression:
+			visitExpr(e.Left)
+			visitExpr(e.Right)
+		case *ast.FunctionLiteral:
+			visitBlock(e.Body)
+		case *ast.IfExpression:
+			visitExpr(e.Condition)
+			visitBlock(e.Consequence)
+			if e.Alternative != nil {
+				visitBlock(e.Alternative)
+			}
+		case *ast.MatchExpression:
+			visitExpr(e.Subject)
+			for _, arm := range e.Arms {
+				visitBlock(arm.Body)
+			}
+		case *ast.ArrayLiteral:
+			for _, elem := range e.Elements {
+				visitExpr(elem)
+			}
+		case *ast.TupleLiteral:
+			for _, elem := range e.Elements {
+				visitExpr(elem)
+			}
+		case *ast.RecordLiteral:
+			for _, fieldValue := range e.Fields {
+				visitExpr(fieldValue.Value)
+			}
+		case *ast.RecordUpdate:
+			visitExpr(e.Base)
+			for _, fieldValue := range e.Fields {
+				visitExpr(fieldValue.Value)
+			}
+		case *ast.MemberAccess:
+			visitExpr(e.Object)
+		case *ast.IndexAccess:
+			visitExpr(e.Object)
+			visitExpr(e.Index)
+		case *ast.BlockExpression:
+			visitBlock(e.Block)
+		}
+	}
+
+	visitStmt = func(stmt ast.Statement) {
+		switch s := stmt.(type) {
+		case *ast.LetStatement:
+			visitExpr(s.Value)
+		case *ast.ExpressionStatement:
+			visitExpr(s.Expression)
+		case *ast.Assignment:
+			names[s.Name] = true
+			visitExpr(s.Value)
+		case *ast.IndexAssignment:
+			names[s.Name] = true
+			visitExpr(s.Target)
+			visitExpr(s.Value)
+		case *ast.ReturnStatement:
+			visitExpr(s.Value)
+		case *ast.ForStatement:
+			if s.Condition != nil {
+				visitExpr(s.Condition)
+			}
+			visitBlock(s.Body)
+		case *ast.Block:
+			visitBlock(s)
+		}
+	}
+
+	visitBlock = func(block *ast.Block) {
+		for _, stmt := range block.Statements {
+			visitStmt(stmt)
+		}
+	}
+
+	visitBlock(block)
+	return names
+}
@@ -0,0 +1,369 @@
+// Package types statically type checks Cow programs before evaluation.
+//
+// Types are inferred with Hindley-Milner inference (Algorithm J with
+// level-based generalization), so annotations are optional: an unannotated
+// function is as general as its body allows, and an annotated one is checked
+// against its annotations. Functions, constructors and let bindings of
+// function literals are polymorphic; a generic function may be annotated with
+// explicit type parameters, as in fn first<T>(xs: [T]) -> T.
+//
+// Every integer is 64 bits and every float is 64 bits at runtime, so i32, int
+// and i64 name the same type (shown as i64), as do f32, float and f64 (shown
+// as f64). Arithmetic on an integer and a float yields a float, mirroring the
+// evaluator, when both operand types are known at that point; otherwise the
+// operands must have the same type.
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Type is a Cow type: a type variable, a type constructor applied to type
+// arguments, or a type parameter of an annotated generic function.
+type Type interface {
+	String() string
+}
+
+// class restricts which types a type variable may stand for.
+// Classes are ordered from least to most restrictive.
+type class int
+
+const (
+	anyClass        class = iota // Any type
+	comparableClass              // Any type without functions: supports comparisons
+	addableClass                 // i64, f64 or string: supports +
+	numericClass                 // i64 or f64: supports arithmetic
+)
+
+// describe returns a description of the types in a class, for error messages.
+func (c class) describe() string {
+	switch c {
+	case comparableClass:
+		return "a comparable type"
+	case addableClass:
+		return "a number or string"
+	case numericClass:
+		return "a number"
+	default:
+		return "any type"
+	}
+}
+
+// admits reports whether a type constructor belongs to the class.
+// A comparable type must also have comparable type arguments.
+func (c class) admits(name string) bool {
+	switch c {
+	case comparableClass:
+		return name != "fn"
+	case addableClass:
+		return name == "i64" || name == "f64" || name == "string"
+	case numericClass:
+		return name == "i64" || name == "f64"
+	default:
+		return true
+	}
+}
+
+// TVar is a type variable. Unification binds it to a type by setting instance.
+type TVar struct {
+	id       int
+	level    int   // Let-nesting depth where the variable was created, for generalization
+	class    class // Restriction on the types the variable may stand for
+	instance Type  // The type the variable is bound to, or nil
+}
+
+// String returns the bound type, or a name for an unbound variable.
+func (v *TVar) String() string {
+	if v.instance != nil {
+		return v.instance.String()
+	}
+	return fmt.Sprintf("'t%d", v.id)
+}
+
+// TCon is a type constructor applied to type arguments.
+// Built-in constructors are i64, f64, bool, string, array (one argument),
+// tuple (any number of arguments; none for the unit type) and fn (the
+// parameter types followed by the return type). Declared types use their name.
+type TCon struct {
+	Name string
+	Args []Type
+}
+
+// String formats the type the way it is written in source code.
+func (t *TCon) String() string {
+	switch t.Name {
+	case "array":
+		return "[" + t.Args[0].String() + "]"
+	case "tuple":
+		if len(t.Args) == 1 {
+			return "(" + t.Args[0].String() + ",)"
+		}
+		return "(" + joinTypes(t.Args) + ")"
+	case "fn":
+		last := len(t.Args) - 1
+		return "fn(" + joinTypes(t.Args[:last]) + ") -> " + t.Args[last].String()
+	}
+	if len(t.Args) == 0 {
+		return t.Name
+	}
+	return t.Name + "<" + joinTypes(t.Args) + ">"
+}
+
+// TParam is a type parameter of an annotated generic function.
+// Inside the function it stands for an unknown type, so it only unifies with
+// itself; the function's type is generalized over it afterwards.
+type TParam struct {
+	Name string
+}
+
+// String returns the parameter name.
+func (p *TParam) String() string {
+	return p.Name
+}
+
+// joinTypes formats a comma-separated list of types.
+func joinTypes(types []Type) string {
+	parts := make([]string, len(types))
+	for i, t := range types {
+		parts[i] = t.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Built-in types.
+var (
+	tInt    = &TCon{Name: "i64"}
+	tFloat  = &TCon{Name: "f64"}
+	tBool   = &TCon{Name: "bool"}
+	tString = &TCon{Name: "string"}
+	tUnit   = &TCon{Name: "tuple"}
+)
+
+// Types for declaring the signatures of host-provided values with
+// Checker.Declare.
+var (
+	Int    Type = tInt
+	Float  Type = tFloat
+	Bool   Type = tBool
+	String Type = tString
+	Unit   Type = tUnit
+)
+
+// Array returns the type of arrays of the given element type.
+func Array(element Type) Type {
+	return arrayOf(element)
+}
+
+// Tuple returns the type of tuples of the given element types.
+func Tuple(elements ...Type) Type {
+	return tupleOf(elements)
+}
+
+// Func returns the type of functions from the given parameter types to the result type.
+func Func(params []Type, result Type) Type {
+	return fnOf(params, result)
+}
+
+// builtinTypes maps the type names usable in annotations to built-in types.
+var builtinTypes = map[string]Type{
+	"i64":    tInt,
+	"i32":    tInt,
+	"int":    tInt,
+	"f64":    tFloat,
+	"f32":    tFloat,
+	"float":  tFloat,
+	"bool":   tBool,
+	"string": tString,
+	"String": tString,
+}
+
+// arrayOf returns the type of arrays of the given element type.
+func arrayOf(element Type) Type {
+	return &TCon{Name: "array", Args: []Type{element}}
+}
+
+// tupleOf returns the type of tuples of the given element types.
+func tupleOf(elements []Type) Type {
+	return &TCon{Name: "tuple", Args: elements}
+}
+
+// fnOf returns the type of functions from the given parameter types to the result type.
+func fnOf(params []Type, result Type) Type {
+	args := make([]Type, len(params), len(params)+1)
+	copy(args, params)
+	return &TCon{Name: "fn", Args: append(args, result)}
+}
+
+// resolve follows bound type variables to the type they stand for.
+func resolve(t Type) Type {
+	for {
+		v, ok := t.(*TVar)
+		if !ok || v.instance == nil {
+			return t
+		}
+		t = v.instance
+	}
+}
+
+// isNumber reports whether a type is known to be i64 or f64.
+func isNumber(t Type) bool {
+	con, ok := resolve(t).(*TCon)
+	return ok && numericClass.admits(con.Name) && len(con.Args) == 0
+}
+
+// errMismatch reports that two types have different shapes.
+// Callers describe the mismatch in terms of the types they were unifying.
+var errMismatch = fmt.Errorf("type mismatch")
+
+// unify makes two types equal by binding type variables.
+// Returns errMismatch if the types differ, or a descriptive error if a
+// variable's class does not admit a type or a type would contain itself.
+func unify(a, b Type) error {
+	a, b = resolve(a), resolve(b)
+	if a == b {
+		return nil
+	}
+
+	if v, ok := a.(*TVar); ok {
+		return bind(v, b)
+	}
+	if v, ok := b.(*TVar); ok {
+		return bind(v, a)
+	}
+
+	ca, okA := a.(*TCon)
+	cb, okB := b.(*TCon)
+	if !okA || !okB || ca.Name != cb.Name || len(ca.Args) != len(cb.Args) {
+		// Distinct type parameters, or a parameter and a constructor
+		return errMismatch
+	}
+	for i := range ca.Args {
+		if err := unify(ca.Args[i], cb.Args[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bind binds an unbound type variable to a type.
+func bind(v *TVar, t Type) error {
+	if other, ok := t.(*TVar); ok {
+		// Merge the two variables, keeping the stricter class and lower level
+		if v.class > other.class {
+			other.class = v.class
+		}
+		if v.level < other.level {
+			other.level = v.level
+		}
+		v.instance = other
+		return nil
+	}
+
+	if occurs(v, t) {
+		return fmt.Errorf("recursive type: %s would contain itself in %s", v, t)
+	}
+	if err := constrain(t, v.class); err != nil {
+		return err
+	}
+	adjustLevels(t, v.level)
+	v.instance = t
+	return nil
+}
+
+// constrain checks that a type belongs to a class, restricting type
+// variables in it as needed.
+func constrain(t Type, c class) error {
+	if c == anyClass {
+		return nil
+	}
+	switch t := resolve(t).(type) {
+	case *TVar:
+		if t.class < c {
+			t.class = c
+		}
+		return nil
+	case *TCon:
+		if c == comparableClass && c.admits(t.Name) {
+			for _, arg := range t.Args {
+				if err := constrain(arg, c); err != nil {
+					return fmt.Errorf("expected %s, found %s", c.describe(), t)
+				}
+			}
+			return nil
+		}
+		if len(t.Args) == 0 && c.admits(t.Name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("expected %s, found %s", c.describe(), t)
+}
+
+// occurs reports whether a type variable appears in a type.
+func occurs(v *TVar, t Type) bool {
+	switch t := resolve(t).(type) {
+	case *TVar:
+		return t == v
+	case *TCon:
+		for _, arg := range t.Args {
+			if occurs(v, arg) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// adjustLevels lowers the level of the type variables in a type to at most
+// level, so they are not generalized while a variable at that level refers to them.
+func adjustLevels(t Type, level int) {
+	switch t := resolve(t).(type) {
+	case *TVar:
+		if t.level > level {
+			t.level = level
+		}
+	case *TCon:
+		for _, arg := range t.Args {
+			adjustLevels(arg, level)
+		}
+	}
+}
+
+// scheme is a polymorphic type: a type with universally quantified variables.
+// Each use of a scheme instantiates the variables with fresh ones.
+type scheme struct {
+	vars []*TVar
+	typ  Type
+}
+
+// mono returns a scheme with no quantified variables.
+func mono(t Type) *scheme {
+	return &scheme{typ: t}
+}
+
+// substitute copies a type, replacing the given variables and parameters.
+func substitute(t Type, vars map[*TVar]Type, params map[*TParam]Type) Type {
+	switch t := resolve(t).(type) {
+	case *TVar:
+		if replacement, ok := vars[t]; ok {
+			return replacement
+		}
+		return t
+	case *TParam:
+		if replacement, ok := params[t]; ok {
+			return replacement
+		}
+		return t
+	case *TCon:
+		if len(t.Args) == 0 {
+			return t
+		}
+		args := make([]Type, len(t.Args))
+		for i, arg := range t.Args {
+			args[i] = substitute(arg, vars, params)
+		}
+		return &TCon{Name: t.Name, Args: args}
+	default:
+		return t
+	}
+}
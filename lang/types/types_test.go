@@ -0,0 +1,285 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+	"github.com/shadowCow/cow-lang-go/lang/converter"
+	"github.com/shadowCow/cow-lang-go/lang/langdef"
+	"github.com/shadowCow/cow-lang-go/tooling/automata"
+	"github.com/shadowCow/cow-lang-go/tooling/lexer"
+	"github.com/shadowCow/cow-lang-go/tooling/ll1"
+)
+
+// parse converts Cow source code to an AST.
+func parse(t *testing.T, source string) *ast.Program {
+	t.Helper()
+
+	grammar := langdef.GetSyntacticGrammar()
+	firstSets := ll1.ComputeFirstSets(grammar)
+	followSets := ll1.ComputeFollowSets(grammar, firstSets)
+	table, err := ll1.BuildParseTable(grammar, firstSets, followSets)
+	if err != nil {
+		t.Fatalf("failed to build parse table: %v", err)
+	}
+
+	dfa := automata.CompileLexicalGrammar(langdef.GetLexical())
+	tokens, err := lexer.NewLexer(dfa, source).Tokenize()
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	tree, err := ll1.NewParser(table, grammar, tokens, langdef.TriviaTokens()...).Parse()
+	if err != nil {
+		t.Fatalf("parser error: %v", err)
+	}
+	program, err := converter.ParseTreeToAST(tree)
+	if err != nil {
+		t.Fatalf("conversion error: %v", err)
+	}
+	return program
+}
+
+// TestCheck tests type checking of whole programs.
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		errors []string // Expected error substrings; empty means the program is well typed
+	}{
+		{
+			name:   "inferred arithmetic",
+			source: "let x = 1 + 2\nlet y = x * 3\n",
+		},
+		{
+			name:   "mixed int and float arithmetic",
+			source: "let area = 3.14 * 2\nlet total: f64 = 10 + 2.5\n",
+		},
+		{
+			name:   "annotated function",
+			source: "fn add(x: i32, y: i32) -> i32 { x + y }\nlet z: int = add(1, 2)\n",
+		},
+		{
+			name:   "argument type mismatch",
+			source: "fn inc(x: i32) -> i32 { x + 1 }\nlet y = inc(\"one\")\n",
+			errors: []string{"2:13: argument 1 to inc: expected i64, found string"},
+		},
+		{
+			name:   "annotation mismatch",
+			source: "let x: f64 = 1.5\nlet s: string = x\n",
+			errors: []string{"2:17: expected string, found f64"},
+		},
+		{
+			name:   "wrong return type",
+			source: "fn name() -> string {\n  42\n}\n",
+			errors: []string{"2:3: expected string, found i64"},
+		},
+		{
+			name:   "polymorphic function used at two types",
+			source: "fn id(x) { x }\nlet a = id(1) + 1\nlet b = id(\"s\") + \"t\"\n",
+		},
+		{
+			name:   "polymorphic let-bound function literal",
+			source: "let twice = fn(f, x) { f(f(x)) }\nlet a = twice(fn(n) { n + 1 }, 3)\nlet b = twice(fn(s) { s + \"!\" }, \"hi\")\n",
+		},
+		{
+			name:   "lambda parameter is not polymorphic",
+			source: "let both = fn(f) { (f(1), f(\"s\")) }\n",
+			errors: []string{"expected i64, found string"},
+		},
+		{
+			name:   "generic function",
+			source: "type Option<T> = Some of T | None\nfn first<T>(xs: [T]) -> Option<T> {\n  if xs.len() == 0 { return None }\n  Some(xs[0])\n}\nlet a = first([1, 2])\nlet b = first([\"x\"])\n",
+		},
+		{
+			name:   "type parameter is rigid",
+			source: "fn inc<T>(x: T) -> T { x + 1 }\n",
+			errors: []string{"1:24: operator + expects a number or string, found T"},
+		},
+		{
+			name:   "mutual recursion",
+			source: "fn isEven(n) { if n == 0 { true } else { isOdd(n - 1) } }\nfn isOdd(n) { if n == 0 { false } else { isEven(n - 1) } }\nlet b: bool = isEven(4)\n",
+		},
+		{
+			name:   "function used before its definition",
+			source: "println(later())\nfn later() { 1 }\n",
+			errors: []string{"1:9: function later is used before its definition"},
+		},
+		{
+			name:   "if without else used for effect",
+			source: "fn f(x) { if x > 0 { println(x) } }\nlet u: () = f(1)\n",
+		},
+		{
+			name:   "if without else with a value",
+			source: "fn f(x) { if x > 0 { 1 } }\n",
+			errors: []string{"1:11: if without else must have type (), got i64"},
+		},
+		{
+			name:   "if branches differ",
+			source: "let x = if true { 1 } else { \"one\" }\n",
+			errors: []string{"1:9: if and else branches have different types: i64 and string"},
+		},
+		{
+			name:   "condition must be bool",
+			source: "let x = if 1 { 1 } else { 2 }\n",
+			errors: []string{"1:12: expected bool, found i64"},
+		},
+		{
+			name:   "heterogeneous array",
+			source: "let xs = [1, \"two\"]\n",
+			errors: []string{"1:14: array elements must have the same type: expected i64, found string"},
+		},
+		{
+			name:   "structural comparison",
+			source: "type Option<T> = Some of T | None\nlet a: bool = [1, 2] < [1, 3]\nlet b: bool = Some((1, \"x\")) >= None\nlet c: bool = 1 == 1.0\nlet xs = [[2], [1]]\nlet ys: [[i64]] = xs.sorted()\n",
+		},
+		{
+			name:   "functions cannot be ordered",
+			source: "let f = fn(x) { x + 1 }\nlet b = [f] < [f]\n",
+			errors: []string{"2:9: operator < expects a comparable type, found [fn(i64) -> i64]"},
+		},
+		{
+			name:   "sorting functions",
+			source: "let fs = [fn(x) { x + 1 }]\nlet sorted = fs.sorted()\n",
+			errors: []string{"2:14: sorted: expected a comparable type, found fn(i64) -> i64"},
+		},
+		{
+			name:   "array methods",
+			source: "let mut xs = []\nxs.push(1)\nlet n: i64 = xs.len() + xs.pop()\n",
+		},
+		{
+			name:   "assignment to elements and fields",
+			source: "type Bag = { name: string, items: [i64] }\nlet mut bags = [{ name: \"a\", items: [] }]\nbags[0].items.push(1)\nbags[0].items[0] = 2\nbags[0].name = \"b\"\n",
+		},
+		{
+			name:   "field assignment of the wrong type",
+			source: "type Bag = { name: string, items: [i64] }\nlet mut bag = { name: \"a\", items: [] }\nbag.items[0] = \"x\"\n",
+			errors: []string{"3:16: expected i64, found string"},
+		},
+		{
+			name:   "push of wrong element type",
+			source: "let mut xs = [1]\nxs.push(true)\n",
+			errors: []string{"2:9: expected i64, found bool"},
+		},
+		{
+			name:   "generic constructor and match",
+			source: "type Option<T> = Some of T | None\nlet x = Some(1)\nlet y = match x { Some(v) => v + 1, None => 0 }\n",
+		},
+		{
+			name:   "match arm types differ",
+			source: "type Option<T> = Some of T | None\nlet y = match Some(1) { Some(v) => v, None => \"none\" }\n",
+			errors: []string{"match arm 2 has type string, but earlier arms have type i64"},
+		},
+		{
+			name:   "pattern of the wrong type",
+			source: "let y = match (1, 2) { (a, b, c) => a, _ => 0 }\n",
+			errors: []string{"pattern of type ('t"},
+		},
+		{
+			name:   "records",
+			source: "type Point = { x: f64, y: f64 }\nlet p = { x: 1.0, y: 2.0 }\nlet q = { p with x: 3.0 }\nfn norm(pt) { pt.x * pt.x + pt.y * pt.y }\nlet n: f64 = norm(q)\n",
+		},
+		{
+			name:   "record field of the wrong type",
+			source: "type Point = { x: f64, y: f64 }\nlet p = { x: 1.0, y: \"two\" }\n",
+			errors: []string{"2:22: expected f64, found string"},
+		},
+		{
+			name:   "unknown record field",
+			source: "type Point = { x: f64, y: f64 }\nlet p = { x: 1.0, y: 2.0 }\nlet z = p.z\n",
+			errors: []string{"record Point has no field 'z'"},
+		},
+		{
+			name:   "tuple destructuring",
+			source: "let (a, b) = (1, \"two\")\nlet c: string = b\nlet d: i64 = a\n",
+		},
+		{
+			name:   "reassignment keeps the type",
+			source: "let mut x = 1\nx = \"one\"\n",
+			errors: []string{"2:5: expected i64, found string"},
+		},
+		{
+			name:   "unknown type in annotation",
+			source: "let x: Foo = 1\n",
+			errors: []string{"1:8: unknown type Foo"},
+		},
+		{
+			name:   "one error per statement",
+			source: "let a = 1 + true\nlet b = a + 1\nlet c = -\"s\"\n",
+			errors: []string{"1:13: operator + expects a number or string, found bool", "3:10: operator - expects a number, found string"},
+		},
+		{
+			name:   "undefined variable",
+			source: "println(missing)\n",
+			errors: []string{"1:9: undefined variable: missing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewChecker().Check(parse(t, tt.source))
+			if len(tt.errors) == 0 {
+				if err != nil {
+					t.Fatalf("expected no errors, got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected errors %v, got none", tt.errors)
+			}
+			lines := strings.Split(err.Error(), "\n")
+			if len(lines) != len(tt.errors) {
+				t.Fatalf("expected %d errors, got %d: %v", len(tt.errors), len(lines), err)
+			}
+			for i, want := range tt.errors {
+				if !strings.Contains(lines[i], want) {
+					t.Errorf("error %d: expected %q in %q", i, want, lines[i])
+				}
+			}
+		})
+	}
+}
+
+// TestTypeString tests formatting of types the way they are written in source code.
+func TestTypeString(t *testing.T) {
+	tests := []struct {
+		typ      Type
+		expected string
+	}{
+		{tInt, "i64"},
+		{tUnit, "()"},
+		{arrayOf(tString), "[string]"},
+		{tupleOf([]Type{tInt}), "(i64,)"},
+		{tupleOf([]Type{tInt, tBool}), "(i64, bool)"},
+		{fnOf([]Type{tInt, tFloat}, tBool), "fn(i64, f64) -> bool"},
+		{&TCon{Name: "Option", Args: []Type{tInt}}, "Option<i64>"},
+	}
+
+	for _, tt := range tests {
+		if actual := tt.typ.String(); actual != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, actual)
+		}
+	}
+}
+
+// TestDeclare tests checking programs that use values declared by the host.
+func TestDeclare(t *testing.T) {
+	elem := &TParam{Name: "T"}
+	declare := func(c *Checker) {
+		c.Declare("now", Func(nil, Int))
+		c.Declare("first", Func([]Type{Array(elem)}, elem))
+	}
+
+	c := NewChecker()
+	declare(c)
+	if err := c.Check(parse(t, "let t: i64 = now()\nlet s: string = first([\"a\"])\nlet b = first([true])\n")); err != nil {
+		t.Errorf("expected no errors, got %v", err)
+	}
+
+	c = NewChecker()
+	declare(c)
+	err := c.Check(parse(t, "let s: string = now()\n"))
+	if err == nil || !strings.Contains(err.Error(), "expected string, found i64") {
+		t.Errorf("expected a mismatch with the declared type, got %v", err)
+	}
+}
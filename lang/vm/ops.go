@@ -0,0 +1,415 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/shadowCow/cow-lang-go/lang/compiler"
+	"github.com/shadowCow/cow-lang-go/lang/eval"
+)
+
+// This file implements the instructions that do more than move values, with
+// the semantics and error messages of the evaluator.
+
+// operators are the source operators of the binary instructions, for error
+// messages.
+var operators = map[compiler.Opcode]string{
+	compiler.OpAdd: "+", compiler.OpSub: "-", compiler.OpMul: "*", compiler.OpDiv: "/", compiler.OpMod: "%",
+	compiler.OpEqual: "==", compiler.OpNotEqual: "!=",
+	compiler.OpLess: "<", compiler.OpLessEqual: "<=", compiler.OpGreater: ">", compiler.OpGreaterEqual: ">=",
+}
+
+// intBinary applies a binary operator other than / and % to two integers.
+func intBinary(op compiler.Opcode, left, right int64) interface{} {
+	switch op {
+	case compiler.OpAdd:
+		return left + right
+	case compiler.OpSub:
+		return left - right
+	case compiler.OpMul:
+		return left * right
+	case compiler.OpLess:
+		return left < right
+	case compiler.OpLessEqual:
+		return left <= right
+	case compiler.OpGreater:
+		return left > right
+	case compiler.OpGreaterEqual:
+		return left >= right
+	case compiler.OpEqual:
+		return left == right
+	default:
+		return left != right
+	}
+}
+
+// binary applies a binary operator to two values.
+func binary(op compiler.Opcode, left, right interface{}) (interface{}, error) {
+	operator := operators[op]
+	switch op {
+	case compiler.OpEqual:
+		return eval.Equal(left, right), nil
+	case compiler.OpNotEqual:
+		return !eval.Equal(left, right), nil
+	case compiler.OpLess, compiler.OpLessEqual, compiler.OpGreater, compiler.OpGreaterEqual:
+		order, err := eval.Compare(left, right)
+		if err != nil {
+			return nil, fmt.Errorf("operator %s: %w", operator, err)
+		}
+		switch op {
+		case compiler.OpLess:
+			return order < 0, nil
+		case compiler.OpLessEqual:
+			return order <= 0, nil
+		case compiler.OpGreater:
+			return order > 0, nil
+		default:
+			return order >= 0, nil
+		}
+	}
+
+	leftStr, leftIsStr := left.(string)
+	rightStr, rightIsStr := right.(string)
+	if leftIsStr || rightIsStr {
+		if !leftIsStr || !rightIsStr {
+			return nil, fmt.Errorf("type mismatch: cannot use %s operator with %T and %T", operator, left, right)
+		}
+		if op != compiler.OpAdd {
+			return nil, fmt.Errorf("operator %s not supported for strings", operator)
+		}
+		return leftStr + rightStr, nil
+	}
+
+	leftInt, leftIsInt := left.(int64)
+	leftFloat, leftIsFloat := left.(float64)
+	rightInt, rightIsInt := right.(int64)
+	rightFloat, rightIsFloat := right.(float64)
+	if !leftIsInt && !leftIsFloat {
+		return nil, fmt.Errorf("left operand of %s has invalid type: %T (expected number)", operator, left)
+	}
+	if !rightIsInt && !rightIsFloat {
+		return nil, fmt.Errorf("right operand of %s has invalid type: %T (expected number)", operator, right)
+	}
+
+	if leftIsInt && rightIsInt {
+		switch op {
+		case compiler.OpDiv:
+			if rightInt == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return leftInt / rightInt, nil
+		case compiler.OpMod:
+			if rightInt == 0 {
+				return nil, fmt.Errorf("modulo by zero")
+			}
+			return leftInt % rightInt, nil
+		default:
+			return intBinary(op, leftInt, rightInt), nil
+		}
+	}
+
+	// If either operand is a float, both are used as floats
+	if leftIsInt {
+		leftFloat = float64(leftInt)
+	}
+	if rightIsInt {
+		rightFloat = float64(rightInt)
+	}
+	switch op {
+	case compiler.OpAdd:
+		return leftFloat + rightFloat, nil
+	case compiler.OpSub:
+		return leftFloat - rightFloat, nil
+	case compiler.OpMul:
+		return leftFloat * rightFloat, nil
+	case compiler.OpDiv:
+		if rightFloat == 0.0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return leftFloat / rightFloat, nil
+	default:
+		return nil, fmt.Errorf("modulo operator not supported for floating-point numbers")
+	}
+}
+
+// conditionError describes a condition of a jump that is not a boolean.
+func conditionError(condition int, value interface{}) error {
+	switch condition {
+	case compiler.ConditionIf:
+		return fmt.Errorf("if condition must be boolean, got %T", value)
+	case compiler.ConditionLoop:
+		return fmt.Errorf("loop condition must be boolean, got %T", value)
+	case compiler.ConditionAnd:
+		return fmt.Errorf("logical AND requires boolean operands, got %T", value)
+	default:
+		return fmt.Errorf("logical OR requires boolean operands, got %T", value)
+	}
+}
+
+// checkCallee checks that the value of name is a function taking argc arguments.
+func checkCallee(name string, callee interface{}, argc int) error {
+	switch fn := callee.(type) {
+	case nil:
+		return fmt.Errorf("undefined function: %s", name)
+	case *Closure:
+		if argc != fn.Function.Arity {
+			return fmt.Errorf("function expects %d arguments, got %d", fn.Function.Arity, argc)
+		}
+	case *eval.Constructor:
+		if argc != fn.Arity {
+			return fmt.Errorf("constructor %s expects %d arguments, got %d", fn.Name, fn.Arity, argc)
+		}
+	default:
+		return fmt.Errorf("%s is not a function (it's a %T)", name, callee)
+	}
+	return nil
+}
+
+// index returns the element of an array at an index.
+func index(object, indexValue interface{}) (interface{}, error) {
+	arr, ok := object.(*eval.Array)
+	if !ok {
+		return nil, fmt.Errorf("cannot index non-array type: %T", object)
+	}
+	i, ok := indexValue.(int64)
+	if !ok {
+		return nil, fmt.Errorf("array index must be an integer, got %T", indexValue)
+	}
+	if i < 0 || i >= int64(arr.Len()) {
+		return nil, fmt.Errorf("array index out of bounds: index %d, length %d", i, arr.Len())
+	}
+	return arr.Get(int(i)), nil
+}
+
+// field returns the field of a record.
+func field(object interface{}, name string) (interface{}, error) {
+	switch obj := object.(type) {
+	case *eval.Record:
+		value, ok := obj.Get(name)
+		if !ok {
+			return nil, fmt.Errorf("record %s has no field '%s'", obj.TypeName, name)
+		}
+		return value, nil
+	case *eval.Array:
+		return nil, fmt.Errorf("method %s must be called", name)
+	default:
+		return nil, fmt.Errorf("member access only supported on arrays, got %T", object)
+	}
+}
+
+// updateRecord returns a copy of a record with the named fields replaced.
+func updateRecord(base interface{}, names []string, values []interface{}) (interface{}, error) {
+	record, ok := base.(*eval.Record)
+	if !ok {
+		return nil, fmt.Errorf("record update requires a record, got %T", base)
+	}
+
+	updated := &eval.Record{
+		TypeName: record.TypeName,
+		Fields:   record.Fields,
+		Values:   append([]interface{}{}, record.Values...),
+	}
+	for i, name := range names {
+		index := -1
+		for j, field := range updated.Fields {
+			if field == name {
+				index = j
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("record %s has no field '%s'", record.TypeName, name)
+		}
+		updated.Values[index] = values[i]
+	}
+	return updated, nil
+}
+
+// arrayMethod calls an array method that does not update the array: len or sorted.
+func arrayMethod(object interface{}, name string) (interface{}, error) {
+	arr, ok := object.(*eval.Array)
+	if !ok {
+		if _, err := field(object, name); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("member access only supported on arrays, got %T", object)
+	}
+	if name == "len" {
+		return int64(arr.Len()), nil
+	}
+	return arr.Sorted()
+}
+
+// checkMethod checks that a place holds an array, before calling an array
+// method that updates it.
+func checkMethod(path *compiler.Path, root interface{}, indices []interface{}) error {
+	value := root
+	i := 0
+	for _, step := range path.Steps {
+		var err error
+		if value, err = get(step, value, indices, &i); err != nil {
+			return err
+		}
+	}
+	_, err := arrayMethod(value, "len")
+	return err
+}
+
+// updatePath returns a copy of value with the value at the end of the path
+// replaced by update(value at path). The path's index values are taken from
+// indices, in order.
+func updatePath(value interface{}, steps []compiler.PathStep, indices []interface{}, update func(interface{}) (interface{}, error)) (interface{}, error) {
+	i := 0
+	var walk func(value interface{}, steps []compiler.PathStep) (interface{}, error)
+	walk = func(value interface{}, steps []compiler.PathStep) (interface{}, error) {
+		if len(steps) == 0 {
+			return update(value)
+		}
+		step := steps[0]
+		at := i
+		inner, err := get(step, value, indices, &i)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := walk(inner, steps[1:])
+		if err != nil {
+			return nil, err
+		}
+		if step.Index {
+			return value.(*eval.Array).Set(int(indices[at].(int64)), updated), nil
+		}
+		record := value.(*eval.Record)
+		copied := &eval.Record{
+			TypeName: record.TypeName,
+			Fields:   record.Fields,
+			Values:   append([]interface{}{}, record.Values...),
+		}
+		for j, name := range record.Fields {
+			if name == step.Field {
+				copied.Values[j] = updated
+			}
+		}
+		return copied, nil
+	}
+	return walk(value, steps)
+}
+
+// get returns the array element or record field a path step accesses in
+// value. An index step takes the next value of indices, counted by i.
+func get(step compiler.PathStep, value interface{}, indices []interface{}, i *int) (interface{}, error) {
+	if !step.Index {
+		record, ok := value.(*eval.Record)
+		if !ok {
+			return nil, &located{step.Span, fmt.Errorf("cannot access field '%s' of %s", step.Field, describe(value))}
+		}
+		field, ok := record.Get(step.Field)
+		if !ok {
+			return nil, &located{step.Span, fmt.Errorf("record %s has no field '%s'", record.TypeName, step.Field)}
+		}
+		return field, nil
+	}
+
+	indexValue := indices[*i]
+	*i++
+	if _, ok := indexValue.(int64); !ok {
+		return nil, &located{step.Span, fmt.Errorf("array index must be an integer, got %T", indexValue)}
+	}
+	element, err := index(value, indexValue)
+	if err != nil {
+		return nil, &located{step.Span, err}
+	}
+	return element, nil
+}
+
+// describe names the type of a runtime value, as the evaluator does in
+// error messages.
+func describe(value interface{}) string {
+	switch v := value.(type) {
+	case int64:
+		return "i64"
+	case float64:
+		return "f64"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case eval.Unit:
+		return "()"
+	case *eval.Array:
+		return "array"
+	case *eval.Tuple:
+		return "tuple"
+	case *eval.Variant:
+		return v.TypeName
+	case *eval.Record:
+		return v.TypeName
+	case *Closure, *eval.Constructor:
+		return "function"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// match reports whether a value matches a pattern, binding the names in the
+// pattern as it goes. Local targets are slots of locals.
+func (vm *VM) match(pattern *compiler.Pattern, value interface{}, locals []interface{}) (bool, error) {
+	switch pattern.Kind {
+	case compiler.PatternWildcard:
+		return true, nil
+
+	case compiler.PatternBinding:
+		target := pattern.Target
+		switch target.Kind {
+		case compiler.TargetLocal:
+			locals[target.Index] = value
+		case compiler.TargetCell:
+			locals[target.Index].(*Cell).Value = value
+		default:
+			vm.globals[target.Index] = value
+			vm.mutable[target.Index] = target.Mutable
+		}
+		return true, nil
+
+	case compiler.PatternLiteral:
+		return eval.Equal(pattern.Value, value), nil
+
+	case compiler.PatternConstructor:
+		variant, ok := value.(*eval.Variant)
+		if !ok || variant.Constructor != pattern.Name {
+			return false, nil
+		}
+		if len(pattern.Elements) != len(variant.Fields) {
+			return false, fmt.Errorf("pattern %s expects %d fields, value has %d",
+				pattern.Name, len(pattern.Elements), len(variant.Fields))
+		}
+		return vm.matchAll(pattern.Elements, variant.Fields, locals)
+
+	case compiler.PatternArray:
+		arr, ok := value.(*eval.Array)
+		if !ok || arr.Len() != len(pattern.Elements) {
+			return false, nil
+		}
+		return vm.matchAll(pattern.Elements, arr.Values(), locals)
+
+	default:
+		if len(pattern.Elements) == 0 {
+			_, ok := value.(eval.Unit)
+			return ok, nil
+		}
+		tuple, ok := value.(*eval.Tuple)
+		if !ok || len(tuple.Elements) != len(pattern.Elements) {
+			return false, nil
+		}
+		return vm.matchAll(pattern.Elements, tuple.Elements, locals)
+	}
+}
+
+// matchAll matches each pattern against the value at the same position.
+func (vm *VM) matchAll(patterns []*compiler.Pattern, values []interface{}, locals []interface{}) (bool, error) {
+	for i, pattern := range patterns {
+		matched, err := vm.match(pattern, values[i], locals)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,573 @@
+// Package vm implements a stack machine that runs Cow programs compiled by
+// package compiler.
+//
+// It produces the same output as the tree-walking evaluator in package
+// eval, and shares its runtime values: arrays, tuples, records and variants
+// are eval values, and are printed, compared and sorted the same way.
+// Functions are *Closure values.
+//
+// Runtime errors are *eval.Error values located at the same expression or
+// statement as the evaluator's. Their message is that of the innermost
+// failure, without the context the evaluator adds as an error propagates.
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+	"github.com/shadowCow/cow-lang-go/lang/compiler"
+	"github.com/shadowCow/cow-lang-go/lang/eval"
+)
+
+// Closure is a function value: a compiled function with the cells it
+// captured from the functions it is nested in.
+type Closure struct {
+	Function *compiler.Function
+	Upvalues []*Cell
+}
+
+// Cell holds a local variable that closures capture, so that the function
+// defining it and the closures share it.
+type Cell struct {
+	Value interface{}
+}
+
+// frame is the state of a function call.
+type frame struct {
+	closure *Closure
+	ip      int // Offset of the next instruction; saved while the frame calls another function
+	base    int // Stack index of the frame's first local slot
+}
+
+// VM runs compiled programs.
+type VM struct {
+	output  io.Writer     // Where to write println output
+	globals []interface{} // Global variables by index; nil until defined
+	mutable []bool        // Whether each global was declared with 'let mut'
+	names   []string      // Global variable names, for error messages
+	stack   []interface{}
+	frames  []frame
+}
+
+// New creates a VM.
+// The output writer is where println statements will write to.
+func New(output io.Writer) *VM {
+	return &VM{output: output}
+}
+
+// Run runs a compiled program.
+// A runtime error is returned as an *eval.Error locating the expression or
+// statement that failed.
+func (vm *VM) Run(program *compiler.Program) error {
+	vm.names = program.Globals
+	vm.globals = make([]interface{}, len(program.Globals))
+	vm.mutable = make([]bool, len(program.Globals))
+
+	main := program.Main
+	vm.stack = make([]interface{}, 1+main.NumLocals+main.MaxStack+256)
+	vm.stack[0] = &Closure{Function: main}
+	vm.frames = append(vm.frames[:0], frame{closure: vm.stack[0].(*Closure), base: 1})
+	return vm.run(1 + main.NumLocals)
+}
+
+// run executes instructions until the main function returns.
+// sp is the index of the first free stack slot.
+func (vm *VM) run(sp int) error {
+	fr := &vm.frames[len(vm.frames)-1]
+	fn := fr.closure.Function
+	code, constants := fn.Code, fn.Constants
+	base, ip := fr.base, fr.ip
+	stack := vm.stack
+
+	for {
+		start := ip
+		op := compiler.Opcode(code[ip])
+		ip++
+
+		switch op {
+		case compiler.OpConstant:
+			stack[sp] = constants[operand(code, ip)]
+			ip += 2
+			sp++
+
+		case compiler.OpPop:
+			sp--
+
+		case compiler.OpPopN:
+			sp -= operand(code, ip)
+			ip += 2
+
+		case compiler.OpLoadLocal:
+			slot := operand(code, ip)
+			ip += 2
+			value := stack[base+slot]
+			if value == nil {
+				return vm.fail(start, fmt.Errorf("undefined variable: %s", fn.LocalNames[slot]))
+			}
+			stack[sp] = value
+			sp++
+
+		case compiler.OpStoreLocal:
+			sp--
+			stack[base+operand(code, ip)] = stack[sp]
+			ip += 2
+
+		case compiler.OpLoadCell:
+			slot := operand(code, ip)
+			ip += 2
+			value := stack[base+slot].(*Cell).Value
+			if value == nil {
+				return vm.fail(start, fmt.Errorf("undefined variable: %s", fn.LocalNames[slot]))
+			}
+			stack[sp] = value
+			sp++
+
+		case compiler.OpStoreCell:
+			sp--
+			stack[base+operand(code, ip)].(*Cell).Value = stack[sp]
+			ip += 2
+
+		case compiler.OpMakeCells:
+			for _, slot := range constants[operand(code, ip)].([]int) {
+				stack[base+slot] = &Cell{}
+			}
+			ip += 2
+
+		case compiler.OpLoadUpvalue:
+			index := operand(code, ip)
+			ip += 2
+			value := fr.closure.Upvalues[index].Value
+			if value == nil {
+				return vm.fail(start, fmt.Errorf("undefined variable: %s", fn.Upvalues[index].Name))
+			}
+			stack[sp] = value
+			sp++
+
+		case compiler.OpStoreUpvalue:
+			sp--
+			fr.closure.Upvalues[operand(code, ip)].Value = stack[sp]
+			ip += 2
+
+		case compiler.OpLoadGlobal:
+			index := operand(code, ip)
+			ip += 2
+			value := vm.globals[index]
+			if value == nil {
+				return vm.fail(start, fmt.Errorf("undefined variable: %s", vm.names[index]))
+			}
+			stack[sp] = value
+			sp++
+
+		case compiler.OpDefineGlobal:
+			index := operand(code, ip)
+			sp--
+			vm.globals[index] = stack[sp]
+			vm.mutable[index] = operand(code, ip+2) == 1
+			ip += 4
+
+		case compiler.OpSetGlobal:
+			sp--
+			vm.globals[operand(code, ip)] = stack[sp]
+			ip += 2
+
+		case compiler.OpAssignGlobal:
+			index := operand(code, ip)
+			ip += 2
+			if vm.globals[index] == nil {
+				return vm.fail(start, fmt.Errorf("cannot assign to undefined variable: %s", vm.names[index]))
+			}
+			if !vm.mutable[index] {
+				return vm.fail(start, fmt.Errorf("cannot assign to immutable variable '%s' (declare it with 'let mut')", vm.names[index]))
+			}
+			sp--
+			vm.globals[index] = stack[sp]
+
+		case compiler.OpAdd, compiler.OpSub, compiler.OpMul, compiler.OpLess, compiler.OpLessEqual,
+			compiler.OpGreater, compiler.OpGreaterEqual, compiler.OpEqual, compiler.OpNotEqual:
+			// Integer operands are the common case
+			if left, ok := stack[sp-2].(int64); ok {
+				if right, ok := stack[sp-1].(int64); ok {
+					stack[sp-2] = intBinary(op, left, right)
+					sp--
+					continue
+				}
+			}
+			result, err := binary(op, stack[sp-2], stack[sp-1])
+			if err != nil {
+				return vm.fail(start, err)
+			}
+			stack[sp-2] = result
+			sp--
+
+		case compiler.OpDiv, compiler.OpMod:
+			result, err := binary(op, stack[sp-2], stack[sp-1])
+			if err != nil {
+				return vm.fail(start, err)
+			}
+			stack[sp-2] = result
+			sp--
+
+		case compiler.OpNeg:
+			switch v := stack[sp-1].(type) {
+			case int64:
+				stack[sp-1] = -v
+			case float64:
+				stack[sp-1] = -v
+			default:
+				return vm.fail(start, fmt.Errorf("unary minus operator requires numeric operand, got %T", v))
+			}
+
+		case compiler.OpNot:
+			v, ok := stack[sp-1].(bool)
+			if !ok {
+				return vm.fail(start, fmt.Errorf("logical NOT operator requires boolean operand, got %T", stack[sp-1]))
+			}
+			stack[sp-1] = !v
+
+		case compiler.OpJump:
+			ip = operand(code, ip)
+
+		case compiler.OpJumpIfFalse:
+			sp--
+			condition, ok := stack[sp].(bool)
+			if !ok {
+				return vm.fail(start, conditionError(operand(code, ip+2), stack[sp]))
+			}
+			if condition {
+				ip += 4
+			} else {
+				ip = operand(code, ip)
+			}
+
+		case compiler.OpJumpIfFalseKeep, compiler.OpJumpIfTrueKeep:
+			condition, ok := stack[sp-1].(bool)
+			if !ok {
+				return vm.fail(start, conditionError(operand(code, ip+2), stack[sp-1]))
+			}
+			if condition == (op == compiler.OpJumpIfTrueKeep) {
+				ip = operand(code, ip)
+			} else {
+				sp--
+				ip += 4
+			}
+
+		case compiler.OpCheckBool:
+			if _, ok := stack[sp-1].(bool); !ok {
+				return vm.fail(start, conditionError(operand(code, ip), stack[sp-1]))
+			}
+			ip += 2
+
+		case compiler.OpFail:
+			return vm.fail(start, errors.New(constants[operand(code, ip)].(string)))
+
+		case compiler.OpEscape:
+			err := errors.New(constants[operand(code, ip)].(string))
+			if len(vm.frames) > 1 {
+				// Located at the call, which is just before where the caller continues
+				caller := vm.frames[len(vm.frames)-2]
+				return vm.failIn(caller.closure.Function, caller.ip-3, err)
+			}
+			return vm.fail(start, err)
+
+		case compiler.OpCallee:
+			kind, index := operand(code, ip), operand(code, ip+2)
+			argc, name := operand(code, ip+4), constants[operand(code, ip+6)].(string)
+			ip += 8
+			var callee interface{}
+			switch kind {
+			case compiler.CalleeLocal:
+				callee = stack[base+index]
+			case compiler.CalleeCell:
+				callee = stack[base+index].(*Cell).Value
+			case compiler.CalleeUpvalue:
+				callee = fr.closure.Upvalues[index].Value
+			default:
+				callee = vm.globals[index]
+			}
+			if err := checkCallee(name, callee, argc); err != nil {
+				return vm.fail(start, err)
+			}
+			stack[sp] = callee
+			sp++
+
+		case compiler.OpCall:
+			argc := operand(code, ip)
+			ip += 2
+			switch callee := stack[sp-argc-1].(type) {
+			case *eval.Constructor:
+				fields := make([]interface{}, argc)
+				copy(fields, stack[sp-argc:sp])
+				sp -= argc
+				stack[sp-1] = &eval.Variant{
+					TypeName:    callee.TypeName,
+					Constructor: callee.Name,
+					Tag:         callee.Tag,
+					Fields:      fields,
+				}
+
+			case *Closure:
+				// Enter the function: its arguments are its first local slots
+				fr.ip = ip
+				base = sp - argc
+				fn = callee.Function
+				if need := base + fn.NumLocals + fn.MaxStack; need > len(stack) {
+					vm.stack = make([]interface{}, 2*need)
+					copy(vm.stack, stack[:sp])
+					stack = vm.stack
+				}
+				for i := sp; i < base+fn.NumLocals; i++ {
+					stack[i] = nil
+				}
+				for _, slot := range fn.Boxed {
+					stack[base+slot] = &Cell{Value: stack[base+slot]}
+				}
+				sp = base + fn.NumLocals
+				vm.frames = append(vm.frames, frame{closure: callee, base: base})
+				fr = &vm.frames[len(vm.frames)-1]
+				code, constants, ip = fn.Code, fn.Constants, 0
+			}
+
+		case compiler.OpReturn:
+			result := stack[sp-1]
+			// Clear the frame so that the values in it can be collected
+			for i := base - 1; i < sp; i++ {
+				stack[i] = nil
+			}
+			sp = base
+			stack[sp-1] = result
+			vm.frames = vm.frames[:len(vm.frames)-1]
+			if len(vm.frames) == 0 {
+				return nil
+			}
+			fr = &vm.frames[len(vm.frames)-1]
+			fn = fr.closure.Function
+			code, constants = fn.Code, fn.Constants
+			base, ip = fr.base, fr.ip
+
+		case compiler.OpClosure:
+			proto := constants[operand(code, ip)].(*compiler.Function)
+			ip += 2
+			closure := &Closure{Function: proto, Upvalues: make([]*Cell, len(proto.Upvalues))}
+			for i, upvalue := range proto.Upvalues {
+				if upvalue.FromLocal {
+					closure.Upvalues[i] = stack[base+upvalue.Index].(*Cell)
+				} else {
+					closure.Upvalues[i] = fr.closure.Upvalues[upvalue.Index]
+				}
+			}
+			stack[sp] = closure
+			sp++
+
+		case compiler.OpPrint:
+			sp--
+			str, err := eval.Format(stack[sp])
+			if err != nil {
+				return vm.fail(start, err)
+			}
+			if _, err := vm.output.Write([]byte(str + "\n")); err != nil {
+				return vm.fail(start, err)
+			}
+
+		case compiler.OpArray:
+			n := operand(code, ip)
+			ip += 2
+			elements := make([]interface{}, n)
+			copy(elements, stack[sp-n:sp])
+			sp -= n
+			stack[sp] = eval.NewArray(elements)
+			sp++
+
+		case compiler.OpTuple:
+			n := operand(code, ip)
+			ip += 2
+			elements := make([]interface{}, n)
+			copy(elements, stack[sp-n:sp])
+			sp -= n
+			stack[sp] = &eval.Tuple{Elements: elements}
+			sp++
+
+		case compiler.OpRecord:
+			shape := constants[operand(code, ip)].(*compiler.RecordShape)
+			ip += 2
+			n := len(shape.Fields)
+			values := make([]interface{}, n)
+			copy(values, stack[sp-n:sp])
+			sp -= n
+			stack[sp] = &eval.Record{TypeName: shape.TypeName, Fields: shape.Fields, Values: values}
+			sp++
+
+		case compiler.OpUpdateRecord:
+			names := constants[operand(code, ip)].([]string)
+			ip += 2
+			n := len(names)
+			updated, err := updateRecord(stack[sp-n-1], names, stack[sp-n:sp])
+			if err != nil {
+				return vm.fail(start, err)
+			}
+			sp -= n
+			stack[sp-1] = updated
+
+		case compiler.OpIndex:
+			element, err := index(stack[sp-2], stack[sp-1])
+			if err != nil {
+				return vm.fail(start, err)
+			}
+			sp--
+			stack[sp-1] = element
+
+		case compiler.OpField:
+			name := constants[operand(code, ip)].(string)
+			ip += 2
+			value, err := field(stack[sp-1], name)
+			if err != nil {
+				return vm.fail(start, err)
+			}
+			stack[sp-1] = value
+
+		case compiler.OpArrayMethod:
+			name := constants[operand(code, ip)].(string)
+			ip += 2
+			result, err := arrayMethod(stack[sp-1], name)
+			if err != nil {
+				return vm.fail(start, err)
+			}
+			stack[sp-1] = result
+
+		case compiler.OpCheckMethod:
+			path := constants[operand(code, ip)].(*compiler.Path)
+			ip += 2
+			k := path.Indices()
+			if err := checkMethod(path, stack[sp-1], stack[sp-1-k:sp-1]); err != nil {
+				return vm.fail(start, err)
+			}
+
+		case compiler.OpSetPath:
+			path := constants[operand(code, ip)].(*compiler.Path)
+			ip += 2
+			k := path.Indices()
+			value := stack[sp-2]
+			root, err := updatePath(stack[sp-1], path.Steps, stack[sp-2-k:sp-2], func(interface{}) (interface{}, error) {
+				return value, nil
+			})
+			if err != nil {
+				return vm.fail(start, err)
+			}
+			sp -= k + 1
+			stack[sp-1] = root
+
+		case compiler.OpPushPath:
+			path := constants[operand(code, ip)].(*compiler.Path)
+			ip += 2
+			k := path.Indices()
+			item := stack[sp-1]
+			root, err := updatePath(stack[sp-2], path.Steps, stack[sp-2-k:sp-2], func(value interface{}) (interface{}, error) {
+				arr, ok := value.(*eval.Array)
+				if !ok {
+					return nil, fmt.Errorf("cannot push to non-array type: %T", value)
+				}
+				return arr.Push(item), nil
+			})
+			if err != nil {
+				return vm.fail(start, err)
+			}
+			sp -= k + 1
+			stack[sp-1] = root
+
+		case compiler.OpPopPath:
+			path := constants[operand(code, ip)].(*compiler.Path)
+			ip += 2
+			k := path.Indices()
+			var last interface{}
+			root, err := updatePath(stack[sp-1], path.Steps, stack[sp-1-k:sp-1], func(value interface{}) (interface{}, error) {
+				arr, ok := value.(*eval.Array)
+				if !ok {
+					return nil, fmt.Errorf("cannot pop from non-array type: %T", value)
+				}
+				if arr.Len() == 0 {
+					return nil, fmt.Errorf("cannot pop from empty array")
+				}
+				var rest *eval.Array
+				rest, last = arr.Pop()
+				return rest, nil
+			})
+			if err != nil {
+				return vm.fail(start, err)
+			}
+			sp -= k + 1
+			stack[sp] = last
+			stack[sp+1] = root
+			sp += 2
+
+		case compiler.OpMatch:
+			subject := stack[base+operand(code, ip)]
+			pattern := constants[operand(code, ip+2)].(*compiler.Pattern)
+			matched, err := vm.match(pattern, subject, stack[base:])
+			if err != nil {
+				return vm.fail(start, err)
+			}
+			if matched {
+				ip += 6
+			} else {
+				ip = operand(code, ip+4)
+			}
+
+		case compiler.OpNoMatch:
+			subject := stack[base+operand(code, ip)]
+			return vm.fail(start, fmt.Errorf("no match arm matched value %s", eval.FormatElement(subject)))
+
+		case compiler.OpDestructure:
+			pattern := constants[operand(code, ip)].(*compiler.Pattern)
+			ip += 2
+			sp--
+			matched, err := vm.match(pattern, stack[sp], stack[base:])
+			if err != nil {
+				return vm.fail(start, err)
+			}
+			if !matched {
+				return vm.fail(start, fmt.Errorf("let pattern does not match value %s", eval.FormatElement(stack[sp])))
+			}
+
+		default:
+			return vm.fail(start, fmt.Errorf("unknown opcode %s", op))
+		}
+	}
+}
+
+// operand decodes the operand at a code offset.
+func operand(code []byte, offset int) int {
+	return int(code[offset])<<8 | int(code[offset+1])
+}
+
+// fail returns a runtime error raised by the instruction at a code offset
+// of the current function.
+func (vm *VM) fail(offset int, err error) error {
+	return vm.failIn(vm.frames[len(vm.frames)-1].closure.Function, offset, err)
+}
+
+// failIn returns a runtime error raised by the instruction at a code offset
+// of a function, located at the instruction's span unless the error has a
+// more precise location.
+func (vm *VM) failIn(fn *compiler.Function, offset int, err error) error {
+	span := fn.SpanAt(offset)
+	var loc *located
+	if errors.As(err, &loc) {
+		span, err = loc.span, loc.err
+	}
+	if !span.Start.IsValid() {
+		return err
+	}
+	return &eval.Error{Span: span, Err: err}
+}
+
+// located marks an error with the span of the part of a place expression
+// where it happened.
+type located struct {
+	span ast.Span
+	err  error
+}
+
+func (l *located) Error() string { return l.err.Error() }
+
+func (l *located) Unwrap() error { return l.err }
@@ -0,0 +1,65 @@
+package vm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/shadowCow/cow-lang-go/lang/ast"
+	"github.com/shadowCow/cow-lang-go/lang/compiler"
+)
+
+// TestVMIfWithoutElseIsUnit tests that an if without an else has the value
+// unit whichever branch runs, as in the tree-walking evaluator.
+func TestVMIfWithoutElseIsUnit(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition bool
+	}{
+		{"condition true", true},
+		{"condition false", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// println(if condition { 1 })
+			program := &ast.Program{
+				Statements: []ast.Statement{
+					&ast.ExpressionStatement{
+						Token: "println",
+						Expression: &ast.FunctionCall{
+							Token: "println",
+							Name:  "println",
+							Arguments: []ast.Expression{
+								&ast.IfExpression{
+									Token:     "if",
+									Condition: &ast.BoolLiteral{Token: "b", Value: tt.condition},
+									Consequence: &ast.Block{
+										Token: "{",
+										Statements: []ast.Statement{
+											&ast.ExpressionStatement{
+												Token:      "1",
+												Expression: &ast.IntLiteral{Token: "1", Value: 1},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			compiled, err := compiler.Compile(program)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var output bytes.Buffer
+			if err := New(&output).Run(compiled); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if output.String() != "()\n" {
+				t.Errorf("Expected output %q, got %q", "()\n", output.String())
+			}
+		})
+	}
+}
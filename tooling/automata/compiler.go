@@ -1,6 +1,11 @@
 package automata
 
-import "github.com/shadowCow/cow-lang-go/tooling/grammar"
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+)
 
 // CompilePatternToNFA converts a LexicalPattern into an NFA using Thompson's construction.
 // Each pattern type is converted into a simple NFA fragment, then combined.
@@ -16,6 +21,8 @@ func CompilePatternToNFA(pattern grammar.LexicalPattern) *NFA {
 		return nfaFromAnyChar(p)
 	case grammar.AnyCharExcept:
 		return nfaFromAnyCharExcept(p)
+	case grammar.UnicodeClass:
+		return nfaFromUnicodeClass(p)
 	case grammar.LexSequence:
 		return nfaFromSequence(p)
 	case grammar.LexAlternative:
@@ -26,6 +33,10 @@ func CompilePatternToNFA(pattern grammar.LexicalPattern) *NFA {
 		return nfaFromZeroOrMore(p)
 	case grammar.LexOneOrMore:
 		return nfaFromOneOrMore(p)
+	case grammar.NestedDelimited:
+		// Only the opening delimiter is recognized by the automaton;
+		// the lexer matches the rest (see DfaWithTokens.Nested)
+		return nfaFromLiteral(grammar.Literal(p.Open))
 	default:
 		// Should never happen if all pattern types are handled
 		panic("unknown lexical pattern type")
@@ -65,60 +76,45 @@ func nfaFromLiteral(lit grammar.Literal) *NFA {
 // nfaFromCharRange creates an NFA that matches any character in a range.
 // start --[from-to]--> accept
 func nfaFromCharRange(cr grammar.CharRange) *NFA {
-	nfa := NewNFA()
-
-	// Add transition for each character in range
-	for r := cr.From; r <= cr.To; r++ {
-		nfa.AddTransition(nfa.Start, r, nfa.Accept)
-	}
-
-	return nfa
+	return nfaFromRuneSet(NewRuneSet(RuneRange{Lo: cr.From, Hi: cr.To}))
 }
 
 // nfaFromCharSet creates an NFA that matches any character in a set.
 func nfaFromCharSet(cs grammar.CharSet) *NFA {
-	nfa := NewNFA()
-
-	for _, r := range cs {
-		nfa.AddTransition(nfa.Start, r, nfa.Accept)
-	}
-
-	return nfa
+	return nfaFromRuneSet(RuneSetOf(cs...))
 }
 
-// nfaFromAnyChar creates an NFA that matches any single character.
-// This is tricky - we'll handle it during DFA construction by making
-// the transition function check all possible characters.
+// nfaFromAnyChar creates an NFA that matches any single character, across
+// the whole Unicode range.
 func nfaFromAnyChar(ac grammar.AnyChar) *NFA {
-	nfa := NewNFA()
-
-	// Add transitions for all printable ASCII and common Unicode ranges
-	// In practice, this should cover a reasonable range of characters
-	for r := rune(0); r <= rune(127); r++ {
-		nfa.AddTransition(nfa.Start, r, nfa.Accept)
-	}
-
-	// TODO: Could extend to more Unicode ranges if needed
-	return nfa
+	return nfaFromRuneSet(AllRunes())
 }
 
 // nfaFromAnyCharExcept creates an NFA that matches any character except those in the set.
 func nfaFromAnyCharExcept(ace grammar.AnyCharExcept) *NFA {
-	nfa := NewNFA()
-
-	// Create set for quick lookup
-	excluded := make(map[rune]bool)
-	for _, r := range ace {
-		excluded[r] = true
-	}
+	return nfaFromRuneSet(RuneSetOf(ace...).Complement())
+}
 
-	// Add transitions for all characters except the excluded ones
-	for r := rune(0); r <= rune(127); r++ {
-		if !excluded[r] {
-			nfa.AddTransition(nfa.Start, r, nfa.Accept)
+// nfaFromUnicodeClass creates an NFA that matches any character in one of
+// the Unicode general categories, such as "L" (letters) or "Nd" (decimal
+// digits).
+func nfaFromUnicodeClass(uc grammar.UnicodeClass) *NFA {
+	var set RuneSet
+	for _, category := range uc {
+		table, ok := unicode.Categories[category]
+		if !ok {
+			panic(fmt.Sprintf("unknown Unicode category %q", category))
 		}
+		set = set.Union(RuneSetFromTable(table))
 	}
+	return nfaFromRuneSet(set)
+}
 
+// nfaFromRuneSet creates an NFA that matches any single character in a set.
+// start --[set]--> accept
+func nfaFromRuneSet(set RuneSet) *NFA {
+	nfa := NewNFA()
+	nfa.AddSetTransition(nfa.Start, set, nfa.Accept)
 	return nfa
 }
 
@@ -286,16 +282,26 @@ func nfaFromOneOrMore(oom grammar.LexOneOrMore) *NFA {
 	return nfa
 }
 
-// CompileLexicalGrammar compiles a lexical grammar into a DFA.
-// All token patterns are combined into a single NFA, then converted to DFA.
+// CompileLexicalGrammar compiles a lexical grammar into a minimal DFA.
+// All token patterns are combined into a single NFA, then converted to DFA,
+// which is minimized.
 func CompileLexicalGrammar(lexGrammar grammar.LexicalGrammar) DfaWithTokens {
+	dfa, _ := CompileLexicalGrammarWithStats(lexGrammar)
+	return dfa
+}
+
+// CompileLexicalGrammarWithStats is like CompileLexicalGrammar, and also
+// reports how many states the DFA had before and after minimization.
+func CompileLexicalGrammarWithStats(lexGrammar grammar.LexicalGrammar) (DfaWithTokens, MinimizeStats) {
 	if len(lexGrammar.Tokens) == 0 {
-		// Empty grammar
-		return DfaWithTokens{
-			InitialState:    "start",
-			States:          make(map[string]DfaStateWithToken),
-			AcceptingStates: make(map[string]AcceptingState),
+		// Empty grammar: a single state that accepts nothing
+		dfa := DfaWithTokens{
+			InitialState:    0,
+			States:          []DfaStateWithToken{{DefaultTransition: NoState}},
+			AcceptingStates: make(map[int]AcceptingState),
+			Nested:          make(map[grammar.TokenType]grammar.NestedDelimited),
 		}
+		return dfa, MinimizeStats{Before: 1, After: 1}
 	}
 
 	// Compile each token pattern to NFA
@@ -315,10 +321,19 @@ func CompileLexicalGrammar(lexGrammar grammar.LexicalGrammar) DfaWithTokens {
 	// Create a new start state with epsilon transitions to each NFA
 	combined := combineNFAs(nfas)
 
-	// Convert combined NFA to DFA
+	// Convert combined NFA to DFA, and merge its equivalent states
 	dfa := NFAToDFAWithTokens(combined)
+	minimized := Minimize(dfa)
+	stats := MinimizeStats{Before: len(dfa.States), After: len(minimized.States)}
 
-	return dfa
+	// Remember tokens whose remainder must be matched by the lexer
+	for _, tokenDef := range lexGrammar.Tokens {
+		if nested, ok := tokenDef.Pattern.(grammar.NestedDelimited); ok {
+			minimized.Nested[tokenDef.Name] = nested
+		}
+	}
+
+	return minimized, stats
 }
 
 // combineNFAs combines multiple NFAs into a single NFA using alternation.
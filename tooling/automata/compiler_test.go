@@ -2,6 +2,7 @@ package automata
 
 import (
 	"testing"
+	"unicode"
 
 	"github.com/shadowCow/cow-lang-go/tooling/grammar"
 )
@@ -78,6 +79,51 @@ func TestCompileAlternative(t *testing.T) {
 	}
 }
 
+// TestCompileUnicodePatterns tests that character patterns cover the whole
+// Unicode range.
+func TestCompileUnicodePatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  grammar.LexicalPattern
+		accepted []rune
+		rejected []rune
+	}{
+		{"any char", grammar.AnyChar{}, []rune{'a', 'é', '中', '😁', unicode.MaxRune}, nil},
+		{"any char except", grammar.AnyCharExcept{'"', '\\'}, []rune{'a', 'é', '😁'}, []rune{'"', '\\'}},
+		{"letters", grammar.UnicodeClass{"L"}, []rune{'a', 'Z', 'é', 'Ж', '中'}, []rune{'1', '_', '😁'}},
+		{"several classes", grammar.UnicodeClass{"Lu", "Nd"}, []rune{'A', 'É', '7', '٣'}, []rune{'a', 'é', '_'}},
+		{"char range", grammar.CharRange{From: 0x1F600, To: 0x1F64F}, []rune{'😁'}, []rune{'a', '中'}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dfa := CompileLexicalGrammar(grammar.LexicalGrammar{
+				Tokens: []grammar.TokenDefinition{{Name: "CHAR", Pattern: tt.pattern, Priority: 1}},
+			})
+			for _, r := range tt.accepted {
+				if next := dfa.NextState(dfa.InitialState, r); !dfa.IsAccepting(next) {
+					t.Errorf("Expected %q to be accepted", r)
+				}
+			}
+			for _, r := range tt.rejected {
+				if next := dfa.NextState(dfa.InitialState, r); next != NoState {
+					t.Errorf("Expected %q to be rejected, got state %d", r, next)
+				}
+			}
+		})
+	}
+}
+
+// TestCompileUnknownUnicodeClass tests that an unknown category is reported.
+func TestCompileUnknownUnicodeClass(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an unknown Unicode category")
+		}
+	}()
+	CompilePatternToNFA(grammar.UnicodeClass{"Letters"})
+}
+
 // TestNFAToDFA tests converting an NFA to DFA.
 func TestNFAToDFA(t *testing.T) {
 	// Create simple NFA for literal "ab"
@@ -93,7 +139,7 @@ func TestNFAToDFA(t *testing.T) {
 	// Convert to DFA
 	dfa := NFAToDFAWithTokens(nfa)
 
-	if dfa.InitialState == "" {
+	if dfa.InitialState < 0 || dfa.InitialState >= len(dfa.States) {
 		t.Error("DFA should have an initial state")
 	}
 
@@ -125,7 +171,7 @@ func TestCompileLexicalGrammar(t *testing.T) {
 
 	dfa := CompileLexicalGrammar(lexGrammar)
 
-	if dfa.InitialState == "" {
+	if dfa.InitialState < 0 || dfa.InitialState >= len(dfa.States) {
 		t.Error("Compiled DFA should have an initial state")
 	}
 
@@ -0,0 +1,16 @@
+package automata
+
+import (
+	"fmt"
+	"io"
+)
+
+// PrintMinimizeStats prints the number of DFA states before and after
+// minimization.
+func PrintMinimizeStats(stats MinimizeStats, out io.Writer) {
+	fmt.Fprintln(out, "LEXER DFA:")
+	fmt.Fprintln(out, "==========")
+	fmt.Fprintf(out, "  States before minimization: %d\n", stats.Before)
+	fmt.Fprintf(out, "  States after minimization:  %d\n", stats.After)
+	fmt.Fprintln(out, "")
+}
@@ -0,0 +1,262 @@
+package automata
+
+import (
+	"fmt"
+	"sort"
+	"unicode"
+)
+
+// MinimizeStats records how many states a DFA had before and after
+// minimization.
+type MinimizeStats struct {
+	Before int
+	After  int
+}
+
+// Minimize returns the smallest DFA that tokenizes input exactly like dfa,
+// using Hopcroft's partition refinement algorithm.
+//
+// Two states are merged only if they accept the same token with the same
+// priority, and lead to equivalent states on every input. States that can
+// never reach an accepting state are removed, since the lexer stops at them
+// anyway. The remaining states are renumbered densely, in breadth-first
+// order from the initial state, which is 0.
+func Minimize(dfa DfaWithTokens) DfaWithTokens {
+	if len(dfa.States) == 0 {
+		return dfa
+	}
+
+	// Make the DFA complete: a dead state takes every missing transition
+	dead := len(dfa.States)
+	total := dead + 1
+
+	// The symbols of the alphabet are ranges of runes that every state
+	// treats alike; a symbol stands for its first rune
+	alphabet := dfaAlphabet(dfa)
+	target := func(state, symbol int) int {
+		if state == dead {
+			return dead
+		}
+		if next := dfa.NextState(state, alphabet[symbol].Lo); next != NoState {
+			return next
+		}
+		return dead
+	}
+
+	// inverse[symbol][state] lists the states with a transition to state
+	inverse := make([][][]int, len(alphabet))
+	for symbol := range inverse {
+		inverse[symbol] = make([][]int, total)
+		for state := 0; state < total; state++ {
+			next := target(state, symbol)
+			inverse[symbol][next] = append(inverse[symbol][next], state)
+		}
+	}
+
+	// Start with one block of non-accepting states, and one block of
+	// accepting states per token and priority
+	p := newPartition(total)
+	blockFor := make(map[string]int)
+	for state := 0; state < total; state++ {
+		key := ""
+		if accepting, ok := dfa.AcceptingStates[state]; ok && state != dead {
+			key = fmt.Sprintf("%s/%d", accepting.TokenType, accepting.Priority)
+		}
+		block, ok := blockFor[key]
+		if !ok {
+			block = p.addBlock()
+			blockFor[key] = block
+		}
+		p.add(state, block)
+	}
+
+	// Refine the partition until no block can be split
+	work := make([]int, 0, len(p.blocks))
+	inWork := make([]bool, len(p.blocks))
+	for block := range p.blocks {
+		work = append(work, block)
+		inWork[block] = true
+	}
+	for len(work) > 0 {
+		splitter := work[len(work)-1]
+		work = work[:len(work)-1]
+		inWork[splitter] = false
+		members := append([]int{}, p.blocks[splitter]...)
+
+		for symbol := range inverse {
+			// The states leading into the splitter on this symbol
+			var sources []int
+			for _, state := range members {
+				sources = append(sources, inverse[symbol][state]...)
+			}
+
+			splits := p.split(sources)
+			for len(inWork) < len(p.blocks) {
+				inWork = append(inWork, false)
+			}
+			for _, split := range splits {
+				// Hopcroft's trick: if the original block is not waiting to
+				// be used as a splitter, only the smaller half needs to be
+				if inWork[split.old] {
+					work = append(work, split.new)
+					inWork[split.new] = true
+					continue
+				}
+				smaller := split.new
+				if len(p.blocks[split.old]) < len(p.blocks[split.new]) {
+					smaller = split.old
+				}
+				work = append(work, smaller)
+				inWork[smaller] = true
+			}
+		}
+	}
+
+	return p.quotient(dfa, alphabet, target, p.blockOf[dead])
+}
+
+// dfaAlphabet divides the Unicode range into the fewest ranges such that
+// every state of dfa has the same transition on all runes of a range.
+func dfaAlphabet(dfa DfaWithTokens) []RuneRange {
+	seen := map[rune]bool{0: true, unicode.MaxRune + 1: true}
+	cuts := []rune{0, unicode.MaxRune + 1}
+	for _, state := range dfa.States {
+		for _, transition := range state.Transitions {
+			for _, r := range []rune{transition.Lo, transition.Hi + 1} {
+				if !seen[r] {
+					seen[r] = true
+					cuts = append(cuts, r)
+				}
+			}
+		}
+	}
+	sort.Slice(cuts, func(i, j int) bool { return cuts[i] < cuts[j] })
+
+	alphabet := make([]RuneRange, len(cuts)-1)
+	for i := range alphabet {
+		alphabet[i] = RuneRange{Lo: cuts[i], Hi: cuts[i+1] - 1}
+	}
+	return alphabet
+}
+
+// partition divides DFA states into blocks of states believed equivalent.
+type partition struct {
+	blocks  [][]int
+	blockOf []int
+	marked  []bool
+}
+
+func newPartition(states int) *partition {
+	return &partition{
+		blockOf: make([]int, states),
+		marked:  make([]bool, states),
+	}
+}
+
+func (p *partition) addBlock() int {
+	p.blocks = append(p.blocks, nil)
+	return len(p.blocks) - 1
+}
+
+func (p *partition) add(state, block int) {
+	p.blocks[block] = append(p.blocks[block], state)
+	p.blockOf[state] = block
+}
+
+// blockSplit records that some states of block old moved to block new.
+type blockSplit struct {
+	old, new int
+}
+
+// split splits every block that has some but not all of its states in
+// states, moving those states to a new block.
+func (p *partition) split(states []int) []blockSplit {
+	var touched []int
+	count := make(map[int]int)
+	for _, state := range states {
+		if p.marked[state] {
+			continue
+		}
+		p.marked[state] = true
+		block := p.blockOf[state]
+		if count[block] == 0 {
+			touched = append(touched, block)
+		}
+		count[block]++
+	}
+
+	var splits []blockSplit
+	for _, block := range touched {
+		if count[block] == len(p.blocks[block]) {
+			continue
+		}
+		var kept, moved []int
+		for _, state := range p.blocks[block] {
+			if p.marked[state] {
+				moved = append(moved, state)
+			} else {
+				kept = append(kept, state)
+			}
+		}
+		p.blocks[block] = kept
+		newBlock := p.addBlock()
+		for _, state := range moved {
+			p.add(state, newBlock)
+		}
+		splits = append(splits, blockSplit{old: block, new: newBlock})
+	}
+
+	for _, state := range states {
+		p.marked[state] = false
+	}
+	return splits
+}
+
+// quotient builds the DFA whose states are the blocks of the partition,
+// leaving out the dead block. States are numbered in breadth-first order.
+func (p *partition) quotient(dfa DfaWithTokens, alphabet []RuneRange, target func(state, symbol int) int, deadBlock int) DfaWithTokens {
+	result := DfaWithTokens{
+		AcceptingStates: make(map[int]AcceptingState),
+		Nested:          dfa.Nested,
+	}
+
+	ids := make(map[int]int)
+	var order []int
+	idFor := func(block int) int {
+		if id, ok := ids[block]; ok {
+			return id
+		}
+		id := len(order)
+		ids[block] = id
+		order = append(order, block)
+		return id
+	}
+	result.InitialState = idFor(p.blockOf[dfa.InitialState])
+
+	for id := 0; id < len(order); id++ {
+		// All states of a block behave the same, so any one will do
+		representative := p.blocks[order[id]][0]
+
+		// The alphabet covers every rune, so no default transition is needed
+		state := DfaStateWithToken{DefaultTransition: NoState}
+		for symbol, r := range alphabet {
+			block := p.blockOf[target(representative, symbol)]
+			if block == deadBlock {
+				continue
+			}
+			next := idFor(block)
+			if n := len(state.Transitions); n > 0 && state.Transitions[n-1].Hi+1 == r.Lo && state.Transitions[n-1].To == next {
+				state.Transitions[n-1].Hi = r.Hi
+				continue
+			}
+			state.Transitions = append(state.Transitions, RangeTransition{Lo: r.Lo, Hi: r.Hi, To: next})
+		}
+		result.States = append(result.States, state)
+
+		if accepting, ok := dfa.AcceptingStates[representative]; ok {
+			result.AcceptingStates[id] = accepting
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,144 @@
+package automata
+
+import (
+	"testing"
+
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+)
+
+// unminimized compiles a lexical grammar to a DFA without minimizing it.
+func unminimized(lexGrammar grammar.LexicalGrammar) DfaWithTokens {
+	nfas := make([]*NFA, 0, len(lexGrammar.Tokens))
+	for _, tokenDef := range lexGrammar.Tokens {
+		nfas = append(nfas, CompileTokenDefinition(tokenDef))
+	}
+	return NFAToDFAWithTokens(combineNFAs(nfas))
+}
+
+// longestMatch returns the token type and length of the longest prefix of
+// input the DFA accepts, as the lexer would find it.
+func longestMatch(dfa DfaWithTokens, input string) (grammar.TokenType, int) {
+	state := dfa.InitialState
+	var tokenType grammar.TokenType
+	length := 0
+	for i, r := range []rune(input) {
+		state = dfa.NextState(state, r)
+		if state == NoState {
+			break
+		}
+		if dfa.IsAccepting(state) {
+			tokenType, length = dfa.GetTokenType(state), i+1
+		}
+	}
+	return tokenType, length
+}
+
+// TestMinimizeMergesEquivalentStates tests that states accepting the same
+// token are merged.
+func TestMinimizeMergesEquivalentStates(t *testing.T) {
+	// "ab" and "cb" lead to the same states after their first character
+	lexGrammar := grammar.LexicalGrammar{
+		Tokens: []grammar.TokenDefinition{
+			{Name: "WORD", Pattern: grammar.Literal("ab"), Priority: 1},
+			{Name: "WORD", Pattern: grammar.Literal("cb"), Priority: 1},
+		},
+	}
+
+	dfa, stats := CompileLexicalGrammarWithStats(lexGrammar)
+	if stats.Before != len(unminimized(lexGrammar).States) {
+		t.Errorf("Expected %d states before minimization, got %d", len(unminimized(lexGrammar).States), stats.Before)
+	}
+	if stats.After != 3 || len(dfa.States) != 3 {
+		t.Errorf("Expected 3 states after minimization, got %d (%d in the DFA)", stats.After, len(dfa.States))
+	}
+}
+
+// TestMinimizeKeepsTokensApart tests that accepting states are only merged
+// if they accept the same token with the same priority.
+func TestMinimizeKeepsTokensApart(t *testing.T) {
+	tests := []struct {
+		name   string
+		tokens []grammar.TokenDefinition
+		states int
+	}{
+		{
+			name: "different tokens",
+			tokens: []grammar.TokenDefinition{
+				{Name: "A", Pattern: grammar.Literal("a"), Priority: 1},
+				{Name: "B", Pattern: grammar.Literal("b"), Priority: 1},
+			},
+			states: 3,
+		},
+		{
+			name: "different priorities",
+			tokens: []grammar.TokenDefinition{
+				{Name: "A", Pattern: grammar.Literal("a"), Priority: 1},
+				{Name: "A", Pattern: grammar.Literal("b"), Priority: 2},
+			},
+			states: 3,
+		},
+		{
+			name: "same token and priority",
+			tokens: []grammar.TokenDefinition{
+				{Name: "A", Pattern: grammar.Literal("a"), Priority: 1},
+				{Name: "A", Pattern: grammar.Literal("b"), Priority: 1},
+			},
+			states: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dfa := CompileLexicalGrammar(grammar.LexicalGrammar{Tokens: tt.tokens})
+			if len(dfa.States) != tt.states {
+				t.Errorf("Expected %d states, got %d", tt.states, len(dfa.States))
+			}
+		})
+	}
+}
+
+// TestMinimizePreservesTokenization tests that the minimized DFA matches
+// the same tokens as the unminimized one.
+func TestMinimizePreservesTokenization(t *testing.T) {
+	lexGrammar := grammar.ExampleLexicalGrammar()
+	original := unminimized(lexGrammar)
+	minimized := Minimize(original)
+
+	if len(minimized.States) >= len(original.States) {
+		t.Errorf("Expected fewer than %d states, got %d", len(original.States), len(minimized.States))
+	}
+
+	inputs := []string{
+		"", "x", "x1_y", "_", "42", "007", "+", "-", "*", "/", "(", ")", "=", ";",
+		" \t\n", "12ab", "ab12", "a+b", "@", "é", "Z9 ", "3.14",
+	}
+	for _, input := range inputs {
+		expectedType, expectedLength := longestMatch(original, input)
+		tokenType, length := longestMatch(minimized, input)
+		if tokenType != expectedType || length != expectedLength {
+			t.Errorf("%q: expected %s of length %d, got %s of length %d", input, expectedType, expectedLength, tokenType, length)
+		}
+	}
+}
+
+// TestMinimizeNumbersStatesDensely tests that the minimized DFA's states are
+// numbered from 0, starting with the initial state.
+func TestMinimizeNumbersStatesDensely(t *testing.T) {
+	dfa := CompileLexicalGrammar(grammar.ExampleLexicalGrammar())
+
+	if dfa.InitialState != 0 {
+		t.Errorf("Expected initial state 0, got %d", dfa.InitialState)
+	}
+	for id, state := range dfa.States {
+		for _, transition := range state.Transitions {
+			if transition.To < 0 || transition.To >= len(dfa.States) {
+				t.Errorf("State %d has a transition on %q-%q to state %d, out of range", id, transition.Lo, transition.Hi, transition.To)
+			}
+		}
+	}
+	for id := range dfa.AcceptingStates {
+		if id < 0 || id >= len(dfa.States) {
+			t.Errorf("Accepting state %d is out of range", id)
+		}
+	}
+}
@@ -16,12 +16,18 @@ type NFA struct {
 // NFAState represents a state in an NFA.
 type NFAState struct {
 	ID int
-	// Transitions maps input runes to sets of next states
-	Transitions map[rune]map[int]bool
+	// Transitions lead to other states on runes in their input sets
+	Transitions []NFATransition
 	// Epsilon transitions don't consume input
 	Epsilon map[int]bool
 }
 
+// NFATransition is a transition to state To on any rune in Input.
+type NFATransition struct {
+	Input RuneSet
+	To    int
+}
+
 // AcceptInfo stores token information for an accepting state.
 type AcceptInfo struct {
 	TokenType grammar.TokenType
@@ -37,14 +43,12 @@ func NewNFA() *NFA {
 		AcceptStates: make(map[int]AcceptInfo),
 	}
 	nfa.States[0] = &NFAState{
-		ID:          0,
-		Transitions: make(map[rune]map[int]bool),
-		Epsilon:     make(map[int]bool),
+		ID:      0,
+		Epsilon: make(map[int]bool),
 	}
 	nfa.States[1] = &NFAState{
-		ID:          1,
-		Transitions: make(map[rune]map[int]bool),
-		Epsilon:     make(map[int]bool),
+		ID:      1,
+		Epsilon: make(map[int]bool),
 	}
 	return nfa
 }
@@ -53,19 +57,25 @@ func NewNFA() *NFA {
 func (nfa *NFA) AddState() int {
 	id := len(nfa.States)
 	nfa.States[id] = &NFAState{
-		ID:          id,
-		Transitions: make(map[rune]map[int]bool),
-		Epsilon:     make(map[int]bool),
+		ID:      id,
+		Epsilon: make(map[int]bool),
 	}
 	return id
 }
 
 // AddTransition adds a transition from one state to another on input rune.
 func (nfa *NFA) AddTransition(from int, input rune, to int) {
-	if nfa.States[from].Transitions[input] == nil {
-		nfa.States[from].Transitions[input] = make(map[int]bool)
+	nfa.AddSetTransition(from, RuneSetOf(input), to)
+}
+
+// AddSetTransition adds a transition from one state to another on any rune
+// in the input set.
+func (nfa *NFA) AddSetTransition(from int, input RuneSet, to int) {
+	if len(input) == 0 {
+		return
 	}
-	nfa.States[from].Transitions[input][to] = true
+	state := nfa.States[from]
+	state.Transitions = append(state.Transitions, NFATransition{Input: input, To: to})
 }
 
 // AddEpsilonTransition adds an epsilon transition from one state to another.
@@ -88,17 +98,16 @@ func (nfa *NFA) RenumberStates(offset int) (newStart, newAccept int) {
 	for oldID, state := range nfa.States {
 		newID := mapping[oldID]
 		newState := &NFAState{
-			ID:          newID,
-			Transitions: make(map[rune]map[int]bool),
-			Epsilon:     make(map[int]bool),
+			ID:      newID,
+			Epsilon: make(map[int]bool),
 		}
 
 		// Update transitions
-		for r, targets := range state.Transitions {
-			newState.Transitions[r] = make(map[int]bool)
-			for targetID := range targets {
-				newState.Transitions[r][mapping[targetID]] = true
-			}
+		for _, transition := range state.Transitions {
+			newState.Transitions = append(newState.Transitions, NFATransition{
+				Input: transition.Input,
+				To:    mapping[transition.To],
+			})
 		}
 
 		// Update epsilon transitions
@@ -134,18 +143,12 @@ func (nfa *NFA) Copy() *NFA {
 
 	for id, state := range nfa.States {
 		newState := &NFAState{
-			ID:          state.ID,
-			Transitions: make(map[rune]map[int]bool),
-			Epsilon:     make(map[int]bool),
+			ID:      state.ID,
+			Epsilon: make(map[int]bool),
 		}
 
-		// Copy transitions
-		for r, targets := range state.Transitions {
-			newState.Transitions[r] = make(map[int]bool)
-			for target := range targets {
-				newState.Transitions[r][target] = true
-			}
-		}
+		// Copy transitions; rune sets are never changed, so they are shared
+		newState.Transitions = append(newState.Transitions, state.Transitions...)
 
 		// Copy epsilon transitions
 		for target := range state.Epsilon {
@@ -8,100 +8,38 @@ import (
 	"github.com/shadowCow/cow-lang-go/tooling/grammar"
 )
 
-// NFAToDFA converts an NFA to a DFA using subset construction.
-func NFAToDFA(nfa *NFA) Dfa {
-	// Compute epsilon closure of start state
-	startClosure := epsilonClosure(nfa, map[int]bool{nfa.Start: true})
-
-	// DFA states are sets of NFA states
-	// We'll use a string representation of the set as the DFA state name
-	dfa := Dfa{
-		InitialState: stateSetToString(startClosure),
-		States:       make(map[string]DfaState),
-	}
-
-	// Queue of DFA states to process (as NFA state sets)
-	queue := []map[int]bool{startClosure}
-	processed := make(map[string]bool)
-
-	for len(queue) > 0 {
-		// Pop from queue
-		currentSet := queue[0]
-		queue = queue[1:]
-
-		currentName := stateSetToString(currentSet)
-		if processed[currentName] {
-			continue
-		}
-		processed[currentName] = true
-
-		// Find all possible transitions from this set
-		transitions := make(map[rune]string)
-		symbolsMap := make(map[rune]map[int]bool)
-
-		// Collect all possible symbols and their target states
-		for stateID := range currentSet {
-			state := nfa.States[stateID]
-			for symbol, targets := range state.Transitions {
-				if symbolsMap[symbol] == nil {
-					symbolsMap[symbol] = make(map[int]bool)
-				}
-				for target := range targets {
-					symbolsMap[symbol][target] = true
-				}
-			}
-		}
-
-		// For each symbol, compute epsilon closure of target states
-		for symbol, targets := range symbolsMap {
-			closure := epsilonClosure(nfa, targets)
-			nextName := stateSetToString(closure)
-			transitions[symbol] = nextName
-
-			// Add to queue if not processed
-			if !processed[nextName] {
-				queue = append(queue, closure)
-			}
-		}
-
-		// Create DFA state
-		dfa.States[currentName] = DfaState{
-			Name:              currentName,
-			Transitions:       transitions,
-			DefaultTransition: "", // Dead state - no transition
-		}
-	}
-
-	return dfa
-}
-
 // NFAToDFAWithTokens converts an NFA with token information to a DFA.
 // Accepting states in the DFA remember which token they matched.
+// States are numbered in the order the subset construction finds them,
+// so the initial state is 0.
 func NFAToDFAWithTokens(nfa *NFA) DfaWithTokens {
 	// Compute epsilon closure of start state
 	startClosure := epsilonClosure(nfa, map[int]bool{nfa.Start: true})
 
-	// DFA states are sets of NFA states
 	dfa := DfaWithTokens{
-		InitialState:    stateSetToString(startClosure),
-		States:          make(map[string]DfaStateWithToken),
-		AcceptingStates: make(map[string]AcceptingState),
+		AcceptingStates: make(map[int]AcceptingState),
+		Nested:          make(map[grammar.TokenType]grammar.NestedDelimited),
 	}
 
-	// Queue of DFA states to process
-	queue := []map[int]bool{startClosure}
-	processed := make(map[string]bool)
-
-	for len(queue) > 0 {
-		// Pop from queue
-		currentSet := queue[0]
-		queue = queue[1:]
-
-		currentName := stateSetToString(currentSet)
-		if processed[currentName] {
-			continue
+	// DFA states are sets of NFA states, identified by a string
+	// representation of the set until they are numbered
+	ids := make(map[string]int)
+	var sets []map[int]bool
+	stateFor := func(set map[int]bool) int {
+		name := stateSetToString(set)
+		if id, ok := ids[name]; ok {
+			return id
 		}
-		processed[currentName] = true
+		id := len(sets)
+		ids[name] = id
+		sets = append(sets, set)
+		return id
+	}
+	dfa.InitialState = stateFor(startClosure)
+
+	// States are processed in the order they are numbered
+	for id := 0; id < len(sets); id++ {
+		currentSet := sets[id]
 
 		// Check if this DFA state contains any NFA accept states
 		var tokenType grammar.TokenType
@@ -119,42 +57,47 @@ func NFAToDFAWithTokens(nfa *NFA) DfaWithTokens {
 		}
 
 		// Find all possible transitions
-		transitions := make(map[rune]string)
-		symbolsMap := make(map[rune]map[int]bool)
-
+		var nfaTransitions []NFATransition
 		for stateID := range currentSet {
-			state := nfa.States[stateID]
-			for symbol, targets := range state.Transitions {
-				if symbolsMap[symbol] == nil {
-					symbolsMap[symbol] = make(map[int]bool)
-				}
-				for target := range targets {
-					symbolsMap[symbol][target] = true
-				}
-			}
+			nfaTransitions = append(nfaTransitions, nfa.States[stateID].Transitions...)
 		}
 
-		// For each symbol, compute epsilon closure
-		for symbol, targets := range symbolsMap {
-			closure := epsilonClosure(nfa, targets)
-			nextName := stateSetToString(closure)
-			transitions[symbol] = nextName
+		// Runes between two consecutive cuts lead to the same NFA states.
+		// Visiting them in order numbers states the same way every time.
+		var transitions []RangeTransition
+		cuts := transitionCuts(nfaTransitions)
+		for i := 0; i+1 < len(cuts); i++ {
+			lo, hi := cuts[i], cuts[i+1]-1
+
+			targets := make(map[int]bool)
+			for _, transition := range nfaTransitions {
+				if transition.Input.Contains(lo) {
+					targets[transition.To] = true
+				}
+			}
+			if len(targets) == 0 {
+				continue
+			}
 
-			if !processed[nextName] {
-				queue = append(queue, closure)
+			// Compute epsilon closure, and extend the previous range if it
+			// leads to the same state
+			next := stateFor(epsilonClosure(nfa, targets))
+			if n := len(transitions); n > 0 && transitions[n-1].Hi+1 == lo && transitions[n-1].To == next {
+				transitions[n-1].Hi = hi
+				continue
 			}
+			transitions = append(transitions, RangeTransition{Lo: lo, Hi: hi, To: next})
 		}
 
 		// Create DFA state
-		dfa.States[currentName] = DfaStateWithToken{
-			Name:              currentName,
+		dfa.States = append(dfa.States, DfaStateWithToken{
 			Transitions:       transitions,
-			DefaultTransition: "",
-		}
+			DefaultTransition: NoState,
+		})
 
 		// Mark as accepting if needed
 		if isAccepting {
-			dfa.AcceptingStates[currentName] = AcceptingState{
+			dfa.AcceptingStates[id] = AcceptingState{
 				TokenType: tokenType,
 				Priority:  maxPriority,
 			}
@@ -164,6 +107,27 @@ func NFAToDFAWithTokens(nfa *NFA) DfaWithTokens {
 	return dfa
 }
 
+// transitionCuts returns, sorted, the runes where some transition's input
+// ranges start or end (one past their last rune).
+func transitionCuts(transitions []NFATransition) []rune {
+	seen := make(map[rune]bool)
+	var cuts []rune
+	add := func(r rune) {
+		if !seen[r] {
+			seen[r] = true
+			cuts = append(cuts, r)
+		}
+	}
+	for _, transition := range transitions {
+		for _, r := range transition.Input {
+			add(r.Lo)
+			add(r.Hi + 1)
+		}
+	}
+	sort.Slice(cuts, func(i, j int) bool { return cuts[i] < cuts[j] })
+	return cuts
+}
+
 // epsilonClosure computes the epsilon closure of a set of NFA states.
 // This is all states reachable by following zero or more epsilon transitions.
 func epsilonClosure(nfa *NFA, states map[int]bool) map[int]bool {
@@ -217,18 +181,35 @@ func stateSetToString(states map[int]bool) string {
 	return "{" + strings.Join(parts, ",") + "}"
 }
 
+// NoState is the state ID of a missing transition: the DFA rejects the input.
+const NoState = -1
+
 // DfaWithTokens is a DFA that tracks which tokens are accepted by which states.
+// States are identified by dense integer IDs: the ID of a state is its index
+// in States.
 type DfaWithTokens struct {
-	InitialState    string
-	States          map[string]DfaStateWithToken
-	AcceptingStates map[string]AcceptingState
+	InitialState    int
+	States          []DfaStateWithToken
+	AcceptingStates map[int]AcceptingState
+	// Nested maps token types defined by a NestedDelimited pattern to their
+	// delimiters. The DFA accepts the opening delimiter; the lexer matches the rest.
+	Nested map[grammar.TokenType]grammar.NestedDelimited
 }
 
 // DfaStateWithToken is a DFA state that can have associated token information.
+// Transitions are sorted and do not overlap. DefaultTransition is taken on
+// any input without a transition of its own, and is NoState if there is none.
 type DfaStateWithToken struct {
-	Name              string
-	Transitions       map[rune]string
-	DefaultTransition string
+	Transitions       []RangeTransition
+	DefaultTransition int
+}
+
+// RangeTransition is a transition to state To on any rune from Lo to Hi,
+// inclusive.
+type RangeTransition struct {
+	Lo rune
+	Hi rune
+	To int
 }
 
 // AcceptingState tracks token information for accepting states.
@@ -237,29 +218,32 @@ type AcceptingState struct {
 	Priority  int
 }
 
-// NextState returns the next state given current state and input rune.
-func (d *DfaWithTokens) NextState(currentState string, input rune) string {
-	state, exists := d.States[currentState]
-	if !exists {
-		return ""
+// NextState returns the next state given current state and input rune,
+// or NoState if there is no transition.
+func (d *DfaWithTokens) NextState(currentState int, input rune) int {
+	if currentState < 0 || currentState >= len(d.States) {
+		return NoState
 	}
+	state := d.States[currentState]
 
-	next, exists := state.Transitions[input]
-	if exists {
-		return next
+	// Find the first transition that does not end before the input
+	transitions := state.Transitions
+	i := sort.Search(len(transitions), func(i int) bool { return transitions[i].Hi >= input })
+	if i < len(transitions) && transitions[i].Lo <= input {
+		return transitions[i].To
 	}
 
 	return state.DefaultTransition
 }
 
 // IsAccepting returns true if the state is an accepting state.
-func (d *DfaWithTokens) IsAccepting(state string) bool {
+func (d *DfaWithTokens) IsAccepting(state int) bool {
 	_, ok := d.AcceptingStates[state]
 	return ok
 }
 
 // GetTokenType returns the token type for an accepting state.
-func (d *DfaWithTokens) GetTokenType(state string) grammar.TokenType {
+func (d *DfaWithTokens) GetTokenType(state int) grammar.TokenType {
 	if acc, ok := d.AcceptingStates[state]; ok {
 		return acc.TokenType
 	}
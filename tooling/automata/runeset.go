@@ -0,0 +1,109 @@
+package automata
+
+import (
+	"sort"
+	"unicode"
+)
+
+// RuneRange is an inclusive range of runes.
+type RuneRange struct {
+	Lo rune
+	Hi rune
+}
+
+// RuneSet is a set of runes stored as sorted, disjoint, non-adjacent ranges,
+// so that large sets such as "any character" stay small.
+type RuneSet []RuneRange
+
+// NewRuneSet returns the set of runes in any of the ranges, which may overlap
+// and come in any order. Ranges with Lo > Hi are empty.
+func NewRuneSet(ranges ...RuneRange) RuneSet {
+	sorted := make([]RuneRange, 0, len(ranges))
+	for _, r := range ranges {
+		if r.Lo <= r.Hi {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Lo < sorted[j].Lo })
+
+	var set RuneSet
+	for _, r := range sorted {
+		// Merge ranges that overlap or touch the previous one
+		if n := len(set); n > 0 && r.Lo <= set[n-1].Hi+1 {
+			if r.Hi > set[n-1].Hi {
+				set[n-1].Hi = r.Hi
+			}
+			continue
+		}
+		set = append(set, r)
+	}
+	return set
+}
+
+// RuneSetOf returns the set of the given runes.
+func RuneSetOf(runes ...rune) RuneSet {
+	ranges := make([]RuneRange, len(runes))
+	for i, r := range runes {
+		ranges[i] = RuneRange{Lo: r, Hi: r}
+	}
+	return NewRuneSet(ranges...)
+}
+
+// AllRunes returns the set of every Unicode code point.
+func AllRunes() RuneSet {
+	return RuneSet{{Lo: 0, Hi: unicode.MaxRune}}
+}
+
+// RuneSetFromTable returns the set of runes in a Unicode range table, such
+// as unicode.Letter.
+func RuneSetFromTable(table *unicode.RangeTable) RuneSet {
+	var ranges []RuneRange
+	for _, r := range table.R16 {
+		ranges = appendStrided(ranges, rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	for _, r := range table.R32 {
+		ranges = appendStrided(ranges, rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	return NewRuneSet(ranges...)
+}
+
+// appendStrided appends the runes lo, lo+stride, ... up to hi.
+func appendStrided(ranges []RuneRange, lo, hi, stride rune) []RuneRange {
+	if stride == 1 {
+		return append(ranges, RuneRange{Lo: lo, Hi: hi})
+	}
+	for r := lo; r <= hi; r += stride {
+		ranges = append(ranges, RuneRange{Lo: r, Hi: r})
+	}
+	return ranges
+}
+
+// Contains reports whether r is in the set.
+func (s RuneSet) Contains(r rune) bool {
+	i := sort.Search(len(s), func(i int) bool { return s[i].Hi >= r })
+	return i < len(s) && s[i].Lo <= r
+}
+
+// Union returns the runes in s or other.
+func (s RuneSet) Union(other RuneSet) RuneSet {
+	ranges := make([]RuneRange, 0, len(s)+len(other))
+	ranges = append(ranges, s...)
+	ranges = append(ranges, other...)
+	return NewRuneSet(ranges...)
+}
+
+// Complement returns the Unicode code points that are not in s.
+func (s RuneSet) Complement() RuneSet {
+	var complement RuneSet
+	next := rune(0)
+	for _, r := range s {
+		if r.Lo > next {
+			complement = append(complement, RuneRange{Lo: next, Hi: r.Lo - 1})
+		}
+		next = r.Hi + 1
+	}
+	if next <= unicode.MaxRune {
+		complement = append(complement, RuneRange{Lo: next, Hi: unicode.MaxRune})
+	}
+	return complement
+}
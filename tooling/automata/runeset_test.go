@@ -0,0 +1,80 @@
+package automata
+
+import (
+	"reflect"
+	"testing"
+	"unicode"
+)
+
+// TestNewRuneSet tests that ranges are sorted and merged.
+func TestNewRuneSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		ranges   []RuneRange
+		expected RuneSet
+	}{
+		{"empty", nil, nil},
+		{"single", []RuneRange{{'a', 'z'}}, RuneSet{{'a', 'z'}}},
+		{"unsorted", []RuneRange{{'x', 'z'}, {'a', 'c'}}, RuneSet{{'a', 'c'}, {'x', 'z'}}},
+		{"overlapping", []RuneRange{{'a', 'm'}, {'k', 'z'}}, RuneSet{{'a', 'z'}}},
+		{"adjacent", []RuneRange{{'a', 'c'}, {'d', 'f'}}, RuneSet{{'a', 'f'}}},
+		{"contained", []RuneRange{{'a', 'z'}, {'c', 'd'}}, RuneSet{{'a', 'z'}}},
+		{"empty range", []RuneRange{{'z', 'a'}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if set := NewRuneSet(tt.ranges...); !reflect.DeepEqual(set, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, set)
+			}
+		})
+	}
+}
+
+// TestRuneSetComplement tests complementing sets over the Unicode range.
+func TestRuneSetComplement(t *testing.T) {
+	set := RuneSetOf('"', '\\', '\n')
+	complement := set.Complement()
+
+	for _, r := range []rune{'"', '\\', '\n'} {
+		if complement.Contains(r) {
+			t.Errorf("Expected the complement not to contain %q", r)
+		}
+	}
+	for _, r := range []rune{0, 'a', 'é', '😁', unicode.MaxRune} {
+		if !complement.Contains(r) {
+			t.Errorf("Expected the complement to contain %q", r)
+		}
+	}
+
+	if !reflect.DeepEqual(complement.Complement(), set) {
+		t.Errorf("Expected the complement of the complement to be %v, got %v", set, complement.Complement())
+	}
+	if !reflect.DeepEqual(RuneSet(nil).Complement(), AllRunes()) {
+		t.Error("Expected the complement of the empty set to be every rune")
+	}
+}
+
+// TestRuneSetFromTable tests converting Unicode range tables, including
+// ranges with a stride.
+func TestRuneSetFromTable(t *testing.T) {
+	letters := RuneSetFromTable(unicode.Letter)
+	for _, r := range []rune{'a', 'Z', 'é', 'Ж', '中', 'ǅ'} {
+		if !letters.Contains(r) {
+			t.Errorf("Expected letters to contain %q", r)
+		}
+	}
+	for _, r := range []rune{'1', '_', ' ', '😁'} {
+		if letters.Contains(r) {
+			t.Errorf("Expected letters not to contain %q", r)
+		}
+	}
+
+	// Every rune of the set is in the table, and the other way round
+	upper := RuneSetFromTable(unicode.Upper)
+	for r := rune(0); r < 0x3000; r++ {
+		if upper.Contains(r) != unicode.IsUpper(r) {
+			t.Errorf("Expected Contains(%q) to be %v", r, unicode.IsUpper(r))
+		}
+	}
+}
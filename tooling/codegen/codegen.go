@@ -0,0 +1,237 @@
+// Package codegen generates Go source code holding a lexer DFA and an LL(1)
+// parse table, so that a language implementation can start without
+// compiling its grammars.
+//
+// The generated file declares two package-level variables: an
+// automata.DfaWithTokens for lexer.NewLexer and an *ll1.ParseTable for
+// ll1.NewParser. They are equal to the tables they were generated from.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shadowCow/cow-lang-go/tooling/automata"
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+	"github.com/shadowCow/cow-lang-go/tooling/ll1"
+)
+
+// Tables describes the file to generate.
+type Tables struct {
+	Package   string                 // Package of the generated file
+	Command   string                 // Command that generates the file, named in its header
+	DFAName   string                 // Name of the variable holding the DFA
+	DFA       automata.DfaWithTokens // The lexer DFA
+	TableName string                 // Name of the variable holding the parse table
+	Table     *ll1.ParseTable        // The parse table
+}
+
+// Generate returns the formatted Go source code of the tables.
+// The output depends only on the tables, not on map iteration order.
+func Generate(t Tables) ([]byte, error) {
+	g := &generator{}
+	g.printf("// Code generated by %s; DO NOT EDIT.\n\n", t.Command)
+	g.printf("package %s\n\n", t.Package)
+	g.printf("import (\n")
+	g.printf("\t%q\n", "github.com/shadowCow/cow-lang-go/tooling/automata")
+	g.printf("\t%q\n", "github.com/shadowCow/cow-lang-go/tooling/grammar")
+	g.printf("\t%q\n", "github.com/shadowCow/cow-lang-go/tooling/ll1")
+	g.printf(")\n\n")
+
+	g.dfa(t.DFAName, t.DFA)
+	if err := g.table(t.TableName, t.Table); err != nil {
+		return nil, err
+	}
+
+	source, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return source, nil
+}
+
+// generator accumulates generated source code.
+type generator struct {
+	buf bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// transitionsPerLine is how many transitions are written on each line.
+const transitionsPerLine = 4
+
+// dfa writes the DFA.
+func (g *generator) dfa(name string, dfa automata.DfaWithTokens) {
+	g.printf("// %s is the lexer DFA.\n", name)
+	g.printf("var %s = automata.DfaWithTokens{\n", name)
+	g.printf("\tInitialState: %d,\n", dfa.InitialState)
+
+	if dfa.States != nil {
+		g.printf("\tStates: []automata.DfaStateWithToken{\n")
+		for id, s := range dfa.States {
+			g.printf("\t\t{ // %d\n", id)
+			if s.Transitions != nil {
+				g.printf("\t\t\tTransitions: []automata.RangeTransition{")
+				for i, transition := range s.Transitions {
+					if i%transitionsPerLine == 0 {
+						g.printf("\n\t\t\t\t")
+					} else {
+						g.printf(" ")
+					}
+					g.printf("{Lo: %s, Hi: %s, To: %d},", runeLiteral(transition.Lo), runeLiteral(transition.Hi), transition.To)
+				}
+				g.printf("\n\t\t\t},\n")
+			}
+			g.printf("\t\t\tDefaultTransition: %s,\n", stateRef(s.DefaultTransition))
+			g.printf("\t\t},\n")
+		}
+		g.printf("\t},\n")
+	}
+
+	if dfa.AcceptingStates != nil {
+		accepting := make([]int, 0, len(dfa.AcceptingStates))
+		for state := range dfa.AcceptingStates {
+			accepting = append(accepting, state)
+		}
+		sort.Ints(accepting)
+
+		g.printf("\tAcceptingStates: map[int]automata.AcceptingState{\n")
+		for _, state := range accepting {
+			a := dfa.AcceptingStates[state]
+			g.printf("\t\t%d: {TokenType: %q, Priority: %d},\n", state, a.TokenType, a.Priority)
+		}
+		g.printf("\t},\n")
+	}
+
+	if dfa.Nested != nil {
+		tokenTypes := make([]string, 0, len(dfa.Nested))
+		for tokenType := range dfa.Nested {
+			tokenTypes = append(tokenTypes, string(tokenType))
+		}
+		sort.Strings(tokenTypes)
+
+		g.printf("\tNested: map[grammar.TokenType]grammar.NestedDelimited{\n")
+		for _, tokenType := range tokenTypes {
+			nested := dfa.Nested[grammar.TokenType(tokenType)]
+			g.printf("\t\t%q: {Open: %q, Close: %q},\n", tokenType, nested.Open, nested.Close)
+		}
+		g.printf("\t},\n")
+	}
+	g.printf("}\n\n")
+}
+
+// runeLiteral returns a Go expression for a rune. Runes that are not valid
+// Unicode code points, such as surrogates, which a rune literal cannot hold,
+// are written in hexadecimal.
+func runeLiteral(r rune) string {
+	if !utf8.ValidRune(r) {
+		return fmt.Sprintf("%#x", r)
+	}
+	return strconv.QuoteRune(r)
+}
+
+// stateRef returns a Go expression for a state ID.
+func stateRef(state int) string {
+	if state == automata.NoState {
+		return "automata.NoState"
+	}
+	return strconv.Itoa(state)
+}
+
+// table writes the parse table. Productions used by several entries are
+// written once in an array and referred to by index.
+func (g *generator) table(name string, table *ll1.ParseTable) error {
+	entries := table.Entries()
+	productionsName := name + "Productions"
+
+	var productions []string
+	index := make(map[string]int)
+	refs := make([]string, len(entries))
+	for i, entry := range entries {
+		literal, err := production(entry.Production)
+		if err != nil {
+			return fmt.Errorf("entry [%s, %s]: %w", entry.NonTerminal, entry.Terminal, err)
+		}
+		j, ok := index[literal]
+		if !ok {
+			j = len(productions)
+			index[literal] = j
+			productions = append(productions, literal)
+		}
+		refs[i] = fmt.Sprintf("%s[%d]", productionsName, j)
+	}
+
+	g.printf("// %s holds the productions of %s's entries.\n", productionsName, name)
+	g.printf("var %s = [...]grammar.ProductionRule{\n", productionsName)
+	for _, literal := range productions {
+		g.printf("\t%s,\n", literal)
+	}
+	g.printf("}\n\n")
+
+	g.printf("// %s is the LL(1) parse table.\n", name)
+	g.printf("var %s = ll1.NewParseTableFromEntries(\n", name)
+	g.printf("\t[]grammar.Symbol{\n")
+	for _, nonTerminal := range table.NonTerminals() {
+		g.printf("\t\t%q,\n", nonTerminal)
+	}
+	g.printf("\t},\n")
+	g.printf("\t[]string{\n")
+	for _, terminal := range table.Terminals() {
+		g.printf("\t\t%q,\n", terminal)
+	}
+	g.printf("\t},\n")
+	g.printf("\t[]ll1.Entry{\n")
+	for i, entry := range entries {
+		g.printf("\t\t{NonTerminal: %q, Terminal: %q, Production: %s},\n", entry.NonTerminal, entry.Terminal, refs[i])
+	}
+	g.printf("\t},\n")
+	g.printf(")\n")
+	return nil
+}
+
+// production returns a Go expression for a production rule.
+func production(rule grammar.ProductionRule) (string, error) {
+	switch r := rule.(type) {
+	case grammar.Terminal:
+		return fmt.Sprintf("grammar.Terminal{TokenType: %q}", r.TokenType), nil
+	case grammar.NonTerminal:
+		return fmt.Sprintf("grammar.NonTerminal{Symbol: %q}", r.Symbol), nil
+	case grammar.SynSequence:
+		return productionList("grammar.SynSequence", r)
+	case grammar.SynAlternative:
+		return productionList("grammar.SynAlternative", r)
+	case grammar.SynOptional:
+		inner, err := production(r.Inner)
+		return "grammar.SynOptional{Inner: " + inner + "}", err
+	case grammar.SynZeroOrMore:
+		inner, err := production(r.Inner)
+		return "grammar.SynZeroOrMore{Inner: " + inner + "}", err
+	case grammar.SynOneOrMore:
+		inner, err := production(r.Inner)
+		return "grammar.SynOneOrMore{Inner: " + inner + "}", err
+	default:
+		return "", fmt.Errorf("unsupported production rule type %T", rule)
+	}
+}
+
+// productionList returns a Go expression for a sequence or alternative.
+func productionList(typeName string, rules []grammar.ProductionRule) (string, error) {
+	if rules == nil {
+		return typeName + "(nil)", nil
+	}
+	parts := make([]string, len(rules))
+	for i, rule := range rules {
+		var err error
+		if parts[i], err = production(rule); err != nil {
+			return "", err
+		}
+	}
+	return typeName + "{" + strings.Join(parts, ", ") + "}", nil
+}
@@ -0,0 +1,90 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shadowCow/cow-lang-go/tooling/automata"
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+	"github.com/shadowCow/cow-lang-go/tooling/ll1"
+)
+
+// exampleTables returns tables generated from the example grammars.
+func exampleTables(t *testing.T) Tables {
+	t.Helper()
+	g := grammar.ExampleSyntacticGrammar()
+	firstSets := ll1.ComputeFirstSets(g)
+	table, err := ll1.BuildParseTable(g, firstSets, ll1.ComputeFollowSets(g, firstSets))
+	if err != nil {
+		t.Fatalf("failed to build parse table: %v", err)
+	}
+	return Tables{
+		Package:   "example",
+		Command:   "test",
+		DFAName:   "exampleDFA",
+		DFA:       automata.CompileLexicalGrammar(grammar.ExampleLexicalGrammar()),
+		TableName: "exampleTable",
+		Table:     table,
+	}
+}
+
+// TestGenerate checks the shape of the generated code.
+func TestGenerate(t *testing.T) {
+	tables := exampleTables(t)
+	source, err := Generate(tables)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	code := string(source)
+
+	for _, want := range []string{
+		"// Code generated by test; DO NOT EDIT.\n\npackage example\n",
+		"var exampleDFA = automata.DfaWithTokens{\n\tInitialState: 0,\n",
+		"var exampleTableProductions = [...]grammar.ProductionRule{\n",
+		"var exampleTable = ll1.NewParseTableFromEntries(\n",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("Expected generated code to contain %q", want)
+		}
+	}
+
+	// Every state and every entry is written
+	if n := strings.Count(code, "\t\t\tDefaultTransition: "); n != len(tables.DFA.States) {
+		t.Errorf("Expected %d states, found %d", len(tables.DFA.States), n)
+	}
+	if n := strings.Count(code, "{NonTerminal: "); n != len(tables.Table.Entries()) {
+		t.Errorf("Expected %d entries, found %d", len(tables.Table.Entries()), n)
+	}
+}
+
+// TestGenerateIsDeterministic checks that the output does not depend on
+// map iteration order.
+func TestGenerateIsDeterministic(t *testing.T) {
+	first, err := Generate(exampleTables(t))
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		again, err := Generate(exampleTables(t))
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if string(again) != string(first) {
+			t.Fatal("Expected the same code from the same tables")
+		}
+	}
+}
+
+// TestGenerateRejectsUnknownProduction checks that a production the
+// generator cannot write is reported.
+func TestGenerateRejectsUnknownProduction(t *testing.T) {
+	tables := exampleTables(t)
+	tables.Table = ll1.NewParseTableFromEntries(
+		[]grammar.Symbol{"S"},
+		[]string{"a"},
+		[]ll1.Entry{{NonTerminal: "S", Terminal: "a", Production: nil}},
+	)
+	if _, err := Generate(tables); err == nil {
+		t.Error("Expected an error for an unsupported production")
+	}
+}
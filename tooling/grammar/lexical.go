@@ -1,5 +1,10 @@
 package grammar
 
+import (
+	"strings"
+	"unicode"
+)
+
 // TokenType represents a category of tokens in the lexical grammar.
 type TokenType string
 
@@ -13,6 +18,43 @@ type TokenDefinition struct {
 	Name     TokenType
 	Pattern  LexicalPattern
 	Priority int // Higher priority wins when multiple patterns match
+
+	// DisplayName optionally names the token type in error messages, such
+	// as "`(`" or "an operator". Token types may share a display name.
+	DisplayName string
+}
+
+// DisplayNames returns the name of each token type of the grammar for error
+// messages: its DisplayName if it has one, or else its text in backquotes if
+// it is a single visible Literal, or else the token type itself.
+func DisplayNames(g LexicalGrammar) map[TokenType]string {
+	names := make(map[TokenType]string)
+	definitions := make(map[TokenType]int)
+	for _, def := range g.Tokens {
+		definitions[def.Name]++
+		if def.DisplayName != "" {
+			names[def.Name] = def.DisplayName
+		}
+	}
+	for _, def := range g.Tokens {
+		if names[def.Name] != "" {
+			continue
+		}
+		if literal, ok := def.Pattern.(Literal); ok && definitions[def.Name] == 1 && isVisible(string(literal)) {
+			names[def.Name] = "`" + string(literal) + "`"
+		} else {
+			names[def.Name] = string(def.Name)
+		}
+	}
+	return names
+}
+
+// isVisible reports whether text is not empty and has no whitespace or
+// control characters.
+func isVisible(text string) bool {
+	return text != "" && strings.IndexFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || !unicode.IsPrint(r)
+	}) == -1
 }
 
 // LexicalPattern is a marker interface for all lexical pattern types.
@@ -48,6 +90,13 @@ type AnyCharExcept []rune
 
 func (AnyCharExcept) IsLexicalPattern() {}
 
+// UnicodeClass matches any single character in one of the named Unicode
+// general categories, such as "L" (letters), "Lu" (uppercase letters) or
+// "Nd" (decimal digits). The names are those of unicode.Categories.
+type UnicodeClass []string
+
+func (UnicodeClass) IsLexicalPattern() {}
+
 // LexSequence matches a series of patterns in order.
 type LexSequence []LexicalPattern
 
@@ -78,3 +127,15 @@ type LexOneOrMore struct {
 }
 
 func (LexOneOrMore) IsLexicalPattern() {}
+
+// NestedDelimited matches text enclosed by Open and Close delimiters, where
+// the delimiters may nest (e.g., /* outer /* inner */ still outer */).
+// Nesting is not a regular language, so the DFA only recognizes Open and the
+// lexer scans the balanced remainder. It is only meaningful as the top-level
+// pattern of a TokenDefinition.
+type NestedDelimited struct {
+	Open  string
+	Close string
+}
+
+func (NestedDelimited) IsLexicalPattern() {}
@@ -148,3 +148,36 @@ func TestExampleLexicalGrammar(t *testing.T) {
 		}
 	})
 }
+
+func TestDisplayNames(t *testing.T) {
+	grammar := LexicalGrammar{
+		Tokens: []TokenDefinition{
+			{Name: TOKEN_NUMBER, Pattern: LexOneOrMore{Inner: CharRange{From: '0', To: '9'}}, Priority: 1},
+			{Name: TOKEN_PLUS, Pattern: Literal("+"), Priority: 2},
+			{Name: "MINUS", Pattern: Literal("-"), Priority: 2, DisplayName: "an operator"},
+			{Name: "NEWLINE", Pattern: Literal("\n"), Priority: 1},
+			{Name: "WORD", Pattern: Literal("ab"), Priority: 1},
+			{Name: "WORD", Pattern: Literal("cd"), Priority: 1},
+			{Name: "COLON", Pattern: Literal(":"), Priority: 1},
+			{Name: "COLON", Pattern: Literal("::"), Priority: 1, DisplayName: "a colon"},
+		},
+	}
+
+	expected := map[TokenType]string{
+		TOKEN_NUMBER: string(TOKEN_NUMBER),
+		TOKEN_PLUS:   "`+`",
+		"MINUS":      "an operator",
+		"NEWLINE":    "NEWLINE",
+		"WORD":       "WORD",
+		"COLON":      "a colon",
+	}
+	names := DisplayNames(grammar)
+	if len(names) != len(expected) {
+		t.Errorf("expected %d names, got %d: %v", len(expected), len(names), names)
+	}
+	for tokenType, name := range expected {
+		if names[tokenType] != name {
+			t.Errorf("expected %s to be named %q, got %q", tokenType, name, names[tokenType])
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package transform
+
+import "github.com/shadowCow/cow-lang-go/tooling/grammar"
+
+// leftFactor left-factors every rule, including the helpers it adds.
+func (t *transformer) leftFactor() {
+	for i := 0; i < len(t.symbols); i++ {
+		for t.factorOnce(t.symbols[i]) {
+		}
+	}
+}
+
+// factorOnce takes the longest common prefix out of the first alternatives
+// of a rule that start with the same symbol, and reports whether there were
+// any.
+func (t *transformer) factorOnce(symbol grammar.Symbol) bool {
+	alts := t.rules[symbol]
+	for i, alt := range alts {
+		if len(alt.symbols) == 0 {
+			continue
+		}
+		group := []int{i}
+		for j := i + 1; j < len(alts); j++ {
+			if len(alts[j].symbols) > 0 && alts[j].symbols[0] == alt.symbols[0] {
+				group = append(group, j)
+			}
+		}
+		if len(group) == 1 {
+			continue
+		}
+
+		prefix := commonPrefix(alts, group)
+		helper := t.helper(symbol, factorMarker, "factor")
+		t.m.factors[helper] = true
+
+		suffixes := make([]alternative, len(group))
+		inGroup := make(map[int]bool, len(group))
+		for k, j := range group {
+			suffixes[k].symbols = alts[j].symbols[prefix:]
+			inGroup[j] = true
+		}
+
+		var factored []alternative
+		for j, other := range alts {
+			switch {
+			case j == i:
+				symbols := append(append([]grammar.ProductionRule{}, alt.symbols[:prefix]...), grammar.NonTerminal{Symbol: helper})
+				factored = append(factored, alternative{symbols: symbols})
+			case !inGroup[j]:
+				factored = append(factored, other)
+			}
+		}
+		t.set(symbol, factored)
+		t.set(helper, suffixes)
+		return true
+	}
+	return false
+}
+
+// commonPrefix returns the length of the longest common prefix of a group
+// of alternatives.
+func commonPrefix(alts []alternative, group []int) int {
+	first := alts[group[0]].symbols
+	n := len(first)
+	for _, j := range group[1:] {
+		symbols := alts[j].symbols
+		k := 0
+		for k < n && k < len(symbols) && symbols[k] == first[k] {
+			k++
+		}
+		n = k
+	}
+	return n
+}
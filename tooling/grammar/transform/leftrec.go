@@ -0,0 +1,241 @@
+package transform
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+	"github.com/shadowCow/cow-lang-go/tooling/ll1"
+)
+
+// eliminateLeftRecursion rewrites each group of rules that are
+// left-recursive together.
+func (t *transformer) eliminateLeftRecursion() error {
+	before := t.reachable()
+	for _, group := range t.leftRecursiveGroups() {
+		for i, symbol := range group {
+			for _, earlier := range group[:i] {
+				t.substitute(symbol, earlier)
+			}
+			if err := t.eliminateDirect(symbol); err != nil {
+				return err
+			}
+		}
+	}
+	t.remove(before)
+	return t.checkLeftRecursion()
+}
+
+// leftRecursiveGroups returns the groups of rules that can derive sequences
+// starting with each other, helpers first and then by name.
+func (t *transformer) leftRecursiveGroups() [][]grammar.Symbol {
+	reach := t.leftCornerClosure(nil)
+	var groups [][]grammar.Symbol
+	grouped := make(map[grammar.Symbol]bool)
+	for _, symbol := range t.symbols {
+		if grouped[symbol] || !reach[symbol][symbol] {
+			continue
+		}
+		var group []grammar.Symbol
+		for _, other := range t.symbols {
+			if reach[symbol][other] && reach[other][symbol] {
+				group = append(group, other)
+				grouped[other] = true
+			}
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			return ll1.IsHelper(group[i]) && !ll1.IsHelper(group[j])
+		})
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// leftCornerClosure returns, for each rule, the rules that can start the
+// sequences it derives. Leading nullable rules are looked through.
+func (t *transformer) leftCornerClosure(nullable map[grammar.Symbol]bool) map[grammar.Symbol]map[grammar.Symbol]bool {
+	corners := make(map[grammar.Symbol][]grammar.Symbol)
+	for _, symbol := range t.symbols {
+		for _, alt := range t.rules[symbol] {
+			for _, elem := range alt.symbols {
+				nt, ok := elem.(grammar.NonTerminal)
+				if !ok {
+					break
+				}
+				corners[symbol] = append(corners[symbol], nt.Symbol)
+				if !nullable[nt.Symbol] {
+					break
+				}
+			}
+		}
+	}
+
+	reach := make(map[grammar.Symbol]map[grammar.Symbol]bool)
+	for _, symbol := range t.symbols {
+		reached := make(map[grammar.Symbol]bool)
+		work := append([]grammar.Symbol{}, corners[symbol]...)
+		for len(work) > 0 {
+			next := work[len(work)-1]
+			work = work[:len(work)-1]
+			if !reached[next] {
+				reached[next] = true
+				work = append(work, corners[next]...)
+			}
+		}
+		reach[symbol] = reached
+	}
+	return reach
+}
+
+// substitute replaces the alternatives of a rule that start with earlier by
+// one alternative for each of earlier's.
+func (t *transformer) substitute(symbol, earlier grammar.Symbol) {
+	var alts []alternative
+	substituted := false
+	for _, alt := range t.rules[symbol] {
+		if !startsWith(alt, earlier) {
+			alts = append(alts, alt)
+			continue
+		}
+		substituted = true
+		for _, inner := range t.rules[earlier] {
+			symbols := append(append([]grammar.ProductionRule{}, inner.symbols...), alt.symbols[1:]...)
+			innerShape := inner.shapeOf(earlier)
+			alts = append(alts, alternative{
+				symbols: symbols,
+				shape: alt.shapeOf(symbol).remap(func(child int) part {
+					if child == 0 {
+						return part{shape: innerShape}
+					}
+					return part{child: child - 1 + len(inner.symbols)}
+				}),
+			})
+		}
+	}
+	if substituted {
+		t.set(symbol, alts)
+	}
+}
+
+// eliminateDirect replaces the alternatives of a rule that start with the
+// rule itself by a tail helper.
+func (t *transformer) eliminateDirect(symbol grammar.Symbol) error {
+	var recursive, others []alternative
+	for _, alt := range t.rules[symbol] {
+		if startsWith(alt, symbol) {
+			if len(alt.symbols) == 1 {
+				return fmt.Errorf("%s derives itself", t.m.Origin(symbol))
+			}
+			recursive = append(recursive, alt)
+		} else {
+			others = append(others, alt)
+		}
+	}
+	if len(recursive) == 0 {
+		t.record(symbol, false)
+		return nil
+	}
+	if len(others) == 0 {
+		return fmt.Errorf("every alternative of %s starts with itself", t.m.Origin(symbol))
+	}
+
+	tail := t.helper(symbol, tailMarker, "tail")
+	t.m.tails[tail] = symbol
+	tailRef := grammar.NonTerminal{Symbol: tail}
+
+	// A -> β becomes A -> β T
+	alts := make([]alternative, len(others))
+	for i, alt := range others {
+		alts[i].symbols = append(append([]grammar.ProductionRule{}, alt.symbols...), tailRef)
+		if alt.shape != nil {
+			alts[i].shape = &shape{symbol: symbol, parts: append(append([]part{}, alt.shape.parts...), part{child: len(alt.symbols)})}
+		}
+	}
+
+	// A -> A α becomes T -> α T, where A is the node built so far
+	tailAlts := make([]alternative, 0, len(recursive)+1)
+	for _, alt := range recursive {
+		tailAlts = append(tailAlts, alternative{
+			symbols: append(append([]grammar.ProductionRule{}, alt.symbols[1:]...), tailRef),
+			shape: alt.shapeOf(symbol).remap(func(child int) part {
+				if child == 0 {
+					return part{child: headChild}
+				}
+				return part{child: child - 1}
+			}),
+		})
+	}
+	tailAlts = append(tailAlts, alternative{})
+
+	t.set(symbol, alts)
+	t.set(tail, tailAlts)
+	t.record(symbol, false)
+	t.record(tail, true)
+	return nil
+}
+
+// record adds the alternatives of a rule that build nodes of the original
+// grammar differently to the source map. The alternatives of a tail are
+// recorded without the tail itself, since folding takes it apart.
+func (t *transformer) record(symbol grammar.Symbol, isTail bool) {
+	for _, alt := range t.rules[symbol] {
+		if alt.shape == nil {
+			continue
+		}
+		symbols := alt.symbols
+		if isTail {
+			symbols = symbols[:len(symbols)-1]
+		}
+		t.m.variants[symbol] = append(t.m.variants[symbol], variant{symbols: names(symbols), shape: alt.shape})
+	}
+}
+
+// checkLeftRecursion reports left recursion that is left, which can only go
+// through nullable rules.
+func (t *transformer) checkLeftRecursion() error {
+	nullable := make(map[grammar.Symbol]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, symbol := range t.symbols {
+			if !nullable[symbol] && t.derivesEmpty(symbol, nullable) {
+				nullable[symbol] = true
+				changed = true
+			}
+		}
+	}
+
+	reach := t.leftCornerClosure(nullable)
+	for _, symbol := range t.symbols {
+		if reach[symbol][symbol] {
+			return fmt.Errorf("%s is left-recursive through a nullable rule", t.m.Origin(symbol))
+		}
+	}
+	return nil
+}
+
+// derivesEmpty reports whether some alternative of a rule has only nullable
+// rules.
+func (t *transformer) derivesEmpty(symbol grammar.Symbol, nullable map[grammar.Symbol]bool) bool {
+	for _, alt := range t.rules[symbol] {
+		empty := true
+		for _, elem := range alt.symbols {
+			if nt, ok := elem.(grammar.NonTerminal); !ok || !nullable[nt.Symbol] {
+				empty = false
+				break
+			}
+		}
+		if empty {
+			return true
+		}
+	}
+	return false
+}
+
+// startsWith reports whether an alternative starts with a non-terminal.
+func startsWith(alt alternative, symbol grammar.Symbol) bool {
+	if len(alt.symbols) == 0 {
+		return false
+	}
+	nt, ok := alt.symbols[0].(grammar.NonTerminal)
+	return ok && nt.Symbol == symbol
+}
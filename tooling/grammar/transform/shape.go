@@ -0,0 +1,55 @@
+package transform
+
+import "github.com/shadowCow/cow-lang-go/tooling/grammar"
+
+// headChild stands for the node a tail folds into, in place of a child.
+const headChild = -1
+
+// shape describes a node of the original grammar built from the children of
+// an alternative of the rewritten one.
+type shape struct {
+	symbol grammar.Symbol
+	parts  []part
+}
+
+// part is a child of a shape: either a child of the alternative, by index,
+// or a nested node.
+type part struct {
+	child int
+	shape *shape
+}
+
+// identity returns the shape of a node with the n children of an
+// alternative, in order.
+func identity(symbol grammar.Symbol, n int) *shape {
+	parts := make([]part, n)
+	for i := range parts {
+		parts[i].child = i
+	}
+	return &shape{symbol: symbol, parts: parts}
+}
+
+// shapeOf returns the shape of the node an alternative of a rule builds.
+func (alt alternative) shapeOf(symbol grammar.Symbol) *shape {
+	if alt.shape != nil {
+		return alt.shape
+	}
+	return identity(symbol, len(alt.symbols))
+}
+
+// remap returns a copy of the shape with the children of the alternative
+// replaced by f.
+func (s *shape) remap(f func(child int) part) *shape {
+	parts := make([]part, len(s.parts))
+	for i, p := range s.parts {
+		switch {
+		case p.shape != nil:
+			parts[i].shape = p.shape.remap(f)
+		case p.child == headChild:
+			parts[i] = p
+		default:
+			parts[i] = f(p.child)
+		}
+	}
+	return &shape{symbol: s.symbol, parts: parts}
+}
@@ -0,0 +1,204 @@
+package transform
+
+import (
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+	"github.com/shadowCow/cow-lang-go/tooling/ll1"
+	"github.com/shadowCow/cow-lang-go/tooling/parsetree"
+)
+
+// SourceMap relates a rewritten grammar to the grammar it was rewritten
+// from.
+type SourceMap struct {
+	origins  map[grammar.Symbol]grammar.Symbol // Rule each helper was taken out of
+	tails    map[grammar.Symbol]grammar.Symbol // Left-recursive rule of each tail helper
+	factors  map[grammar.Symbol]bool           // Helpers holding the alternatives after a common prefix
+	variants map[grammar.Symbol][]variant      // Alternatives whose nodes are built differently
+}
+
+// variant is an alternative of a rewritten rule, by the names of its
+// symbols, and the nodes of the original grammar it stands for.
+type variant struct {
+	symbols []string
+	shape   *shape
+}
+
+func newSourceMap() *SourceMap {
+	return &SourceMap{
+		origins:  make(map[grammar.Symbol]grammar.Symbol),
+		tails:    make(map[grammar.Symbol]grammar.Symbol),
+		factors:  make(map[grammar.Symbol]bool),
+		variants: make(map[grammar.Symbol][]variant),
+	}
+}
+
+// Origin returns the rule of the original grammar a helper was taken out
+// of, or symbol itself if it is not a helper. Helpers of ll1.Desugar are
+// mapped too.
+func (m *SourceMap) Origin(symbol grammar.Symbol) grammar.Symbol {
+	if origin, ok := m.origins[symbol]; ok {
+		return origin
+	}
+	return ll1.Owner(symbol)
+}
+
+// Refold returns the parse tree of the original grammar for a parse tree of
+// the rewritten one, such as a *parsetree.ProgramNode from ll1.Parser.
+//
+// The alternatives of a common prefix are spliced back into their rule, and
+// the tails of left-recursive rules are folded into nested nodes:
+//
+//	Expr(Term Expr'tail1(PLUS Term Expr'tail1(PLUS Term Expr'tail1())))
+//
+// becomes
+//
+//	Expr(Expr(Expr(Term) PLUS Term) PLUS Term)
+//
+// Rules substituted for references to them are rebuilt as nodes, except for
+// helpers of ll1.Desugar, whose children are spliced as the parser does.
+// Error nodes are kept, named after the original rule; a node whose children
+// do not match its rule, because of an error node or a helper of
+// ll1.Desugar, is folded without rebuilding substituted rules.
+func (m *SourceMap) Refold(tree parsetree.ParseTree) parsetree.ParseTree {
+	switch n := tree.(type) {
+	case *parsetree.ProgramNode:
+		return &parsetree.ProgramNode{Root: m.Refold(n.Root), TrailingTrivia: n.TrailingTrivia}
+	case *parsetree.NonTerminalNode:
+		var children []parsetree.ParseTree
+		for _, child := range n.Children {
+			child = m.Refold(child)
+			if m.factors[symbolOf(child)] {
+				children = append(children, childrenOf(child)...)
+			} else {
+				children = append(children, child)
+			}
+		}
+		return m.rebuild(n.Symbol, children)
+	case *parsetree.EmptyNode:
+		return m.rebuild(n.Symbol, nil)
+	case *parsetree.ErrorNode:
+		if origin := m.Origin(n.Symbol); origin != n.Symbol {
+			return &parsetree.ErrorNode{Symbol: origin, Tokens: n.Tokens}
+		}
+		return n
+	default:
+		return tree
+	}
+}
+
+// rebuild returns the node of the original grammar for a node of the
+// rewritten one, whose children are refolded. Tails are left for their rule
+// to fold.
+func (m *SourceMap) rebuild(symbol grammar.Symbol, children []parsetree.ParseTree) parsetree.ParseTree {
+	if _, ok := m.tails[symbol]; ok {
+		return node(symbol, children)
+	}
+	if v := m.match(symbol, children); v != nil {
+		return m.build(v.shape, children, nil)
+	}
+	return m.fold(node(symbol, children))
+}
+
+// match returns the variant of a rule whose symbols are those of children.
+func (m *SourceMap) match(symbol grammar.Symbol, children []parsetree.ParseTree) *variant {
+	for i, v := range m.variants[symbol] {
+		if len(v.symbols) != len(children) {
+			continue
+		}
+		matches := true
+		for j, child := range children {
+			if string(symbolOf(child)) != v.symbols[j] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return &m.variants[symbol][i]
+		}
+	}
+	return nil
+}
+
+// build returns the node a shape describes, from the children of an
+// alternative and the node a tail folds into.
+func (m *SourceMap) build(s *shape, children []parsetree.ParseTree, head parsetree.ParseTree) parsetree.ParseTree {
+	var parts []parsetree.ParseTree
+	for _, p := range s.parts {
+		switch {
+		case p.shape != nil:
+			nested := m.build(p.shape, children, head)
+			if ll1.IsHelper(p.shape.symbol) {
+				parts = append(parts, childrenOf(nested)...)
+			} else {
+				parts = append(parts, nested)
+			}
+		case p.child == headChild:
+			parts = append(parts, head)
+		default:
+			parts = append(parts, children[p.child])
+		}
+	}
+	return m.fold(node(s.symbol, parts))
+}
+
+// fold folds the tail a node of a left-recursive rule ends with, if any,
+// into nested nodes of the rule.
+func (m *SourceMap) fold(tree parsetree.ParseTree) parsetree.ParseTree {
+	n, ok := tree.(*parsetree.NonTerminalNode)
+	if !ok || len(n.Children) == 0 || m.tails[symbolOf(n.Children[len(n.Children)-1])] != n.Symbol {
+		return tree
+	}
+
+	head := node(n.Symbol, n.Children[:len(n.Children)-1])
+	rest := n.Children[len(n.Children)-1]
+	for {
+		tail, ok := rest.(*parsetree.NonTerminalNode)
+		if !ok || len(tail.Children) == 0 || m.tails[tail.Symbol] != n.Symbol {
+			break
+		}
+		alpha := tail.Children[:len(tail.Children)-1]
+		if v := m.match(tail.Symbol, alpha); v != nil {
+			head = m.build(v.shape, alpha, head)
+		} else {
+			head = node(n.Symbol, append([]parsetree.ParseTree{head}, alpha...))
+		}
+		rest = tail.Children[len(tail.Children)-1]
+	}
+
+	// An error node in place of a tail is kept
+	if _, ok := rest.(*parsetree.EmptyNode); !ok {
+		head = node(n.Symbol, []parsetree.ParseTree{head, rest})
+	}
+	return head
+}
+
+// node returns a node of a rule with children, or an empty node if there
+// are none, as the parser builds them.
+func node(symbol grammar.Symbol, children []parsetree.ParseTree) parsetree.ParseTree {
+	if len(children) == 0 {
+		return &parsetree.EmptyNode{Symbol: symbol}
+	}
+	return &parsetree.NonTerminalNode{Symbol: symbol, Children: children}
+}
+
+// symbolOf returns the name of the terminal or non-terminal a node is for.
+func symbolOf(tree parsetree.ParseTree) grammar.Symbol {
+	switch n := tree.(type) {
+	case *parsetree.TerminalNode:
+		return grammar.Symbol(n.Token.Type)
+	case *parsetree.NonTerminalNode:
+		return n.Symbol
+	case *parsetree.EmptyNode:
+		return n.Symbol
+	case *parsetree.ErrorNode:
+		return n.Symbol
+	default:
+		return ""
+	}
+}
+
+func childrenOf(tree parsetree.ParseTree) []parsetree.ParseTree {
+	if n, ok := tree.(*parsetree.NonTerminalNode); ok {
+		return n.Children
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+// Package transform rewrites syntactic grammars into equivalent grammars
+// that an LL(1) parser can use. It eliminates direct and indirect left
+// recursion, and left-factors alternatives that start with the same symbols.
+//
+// The rewritten rules use helper non-terminals. The SourceMap returned with
+// a rewritten grammar records where they came from, and refolds parse trees
+// of the rewritten grammar into the shape of the grammar as written.
+//
+// Helpers are named after the rule they were taken out of. The tails of
+// left-recursive rules are marked with a "'", as in "Expr'tail1". The
+// alternatives after a common prefix go in helpers marked with a "$", as in
+// "Stmt$factor1", like those of ll1.Desugar, so the parser splices them into
+// their parent. Grammar symbols must not contain either marker.
+package transform
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+	"github.com/shadowCow/cow-lang-go/tooling/ll1"
+)
+
+const (
+	tailMarker   = "'"
+	factorMarker = "$" // The parser splices helpers with ll1's marker
+)
+
+// EliminateLeftRecursion returns an equivalent grammar in which no rule
+// derives a sequence starting with itself.
+//
+// Rules that are left-recursive together are ordered, helpers of
+// ll1.Desugar first, and each one has the rules before it substituted for
+// its leading references to them. Direct left recursion is then replaced by
+// a tail helper:
+//
+//	A -> A α | β  becomes  A -> β T, where T -> α T | ε
+//
+// Rules that are no longer used are removed. It fails if a rule only derives
+// itself, or is left-recursive through nullable symbols.
+func EliminateLeftRecursion(g grammar.SyntacticGrammar) (grammar.SyntacticGrammar, *SourceMap, error) {
+	t := newTransformer(g)
+	if err := t.eliminateLeftRecursion(); err != nil {
+		return grammar.SyntacticGrammar{}, nil, err
+	}
+	return t.result(), t.m, nil
+}
+
+// LeftFactor returns an equivalent grammar in which no two alternatives of a
+// rule start with the same symbol. The longest common prefix of such
+// alternatives is taken out, and the rest goes in a helper:
+//
+//	A -> α β | α γ  becomes  A -> α H, where H -> β | γ
+//
+// Only the symbols written in the alternatives are compared, not what their
+// non-terminals derive.
+func LeftFactor(g grammar.SyntacticGrammar) (grammar.SyntacticGrammar, *SourceMap) {
+	t := newTransformer(g)
+	t.leftFactor()
+	return t.result(), t.m
+}
+
+// ForLL1 eliminates left recursion and then left-factors the result, with
+// a single source map for both.
+func ForLL1(g grammar.SyntacticGrammar) (grammar.SyntacticGrammar, *SourceMap, error) {
+	t := newTransformer(g)
+	if err := t.eliminateLeftRecursion(); err != nil {
+		return grammar.SyntacticGrammar{}, nil, err
+	}
+	t.leftFactor()
+	return t.result(), t.m, nil
+}
+
+// alternative is one alternative of a rule.
+type alternative struct {
+	symbols []grammar.ProductionRule // Terminals and non-terminals
+	shape   *shape                   // Nodes of the original grammar, or nil if they are the same
+}
+
+// transformer holds the rules of a grammar while it is rewritten. The
+// grammar is desugared first, so that every rule is a list of alternatives.
+type transformer struct {
+	original grammar.SyntacticGrammar // The desugared grammar
+	rules    map[grammar.Symbol][]alternative
+	symbols  []grammar.Symbol // Rules in sorted order, then helpers in order of creation
+	changed  map[grammar.Symbol]bool
+	m        *SourceMap
+}
+
+func newTransformer(g grammar.SyntacticGrammar) *transformer {
+	g = ll1.Desugar(g)
+	t := &transformer{
+		original: g,
+		rules:    make(map[grammar.Symbol][]alternative, len(g.Productions)),
+		changed:  make(map[grammar.Symbol]bool),
+		m:        newSourceMap(),
+	}
+	for symbol, production := range g.Productions {
+		t.rules[symbol] = alternatives(production)
+		t.symbols = append(t.symbols, symbol)
+	}
+	sort.Slice(t.symbols, func(i, j int) bool { return t.symbols[i] < t.symbols[j] })
+	return t
+}
+
+// alternatives returns the alternatives of a desugared production.
+func alternatives(production grammar.ProductionRule) []alternative {
+	switch p := production.(type) {
+	case grammar.Terminal, grammar.NonTerminal:
+		return []alternative{{symbols: []grammar.ProductionRule{p}}}
+	case grammar.SynSequence:
+		return []alternative{{symbols: p}}
+	case grammar.SynAlternative:
+		var alts []alternative
+		for _, alt := range p {
+			alts = append(alts, alternatives(alt)...)
+		}
+		return alts
+	default:
+		panic(fmt.Sprintf("unknown production type: %T", production))
+	}
+}
+
+// result returns the rewritten grammar. Rules that were not rewritten keep
+// their production as written.
+func (t *transformer) result() grammar.SyntacticGrammar {
+	productions := make(map[grammar.Symbol]grammar.ProductionRule, len(t.symbols))
+	for _, symbol := range t.symbols {
+		if !t.changed[symbol] {
+			productions[symbol] = t.original.Productions[symbol]
+			continue
+		}
+		alts := t.rules[symbol]
+		if len(alts) == 1 {
+			productions[symbol] = sequence(alts[0].symbols)
+			continue
+		}
+		production := make(grammar.SynAlternative, len(alts))
+		for i, alt := range alts {
+			production[i] = sequence(alt.symbols)
+		}
+		productions[symbol] = production
+	}
+	return grammar.SyntacticGrammar{Productions: productions, StartSymbol: t.original.StartSymbol}
+}
+
+func sequence(symbols []grammar.ProductionRule) grammar.SynSequence {
+	return append(grammar.SynSequence{}, symbols...)
+}
+
+// set replaces the alternatives of a rule.
+func (t *transformer) set(symbol grammar.Symbol, alts []alternative) {
+	t.rules[symbol] = alts
+	t.changed[symbol] = true
+}
+
+// helper adds a fresh helper rule, such as "Expr'tail1", taken out of parent.
+func (t *transformer) helper(parent grammar.Symbol, marker, kind string) grammar.Symbol {
+	for n := 1; ; n++ {
+		symbol := grammar.Symbol(fmt.Sprintf("%s%s%s%d", parent, marker, kind, n))
+		if _, ok := t.rules[symbol]; !ok {
+			t.set(symbol, nil)
+			t.symbols = append(t.symbols, symbol)
+			t.m.origins[symbol] = t.m.Origin(parent)
+			return symbol
+		}
+	}
+}
+
+// reachable returns the rules that can be reached from the start symbol.
+func (t *transformer) reachable() map[grammar.Symbol]bool {
+	reached := map[grammar.Symbol]bool{t.original.StartSymbol: true}
+	work := []grammar.Symbol{t.original.StartSymbol}
+	for len(work) > 0 {
+		symbol := work[len(work)-1]
+		work = work[:len(work)-1]
+		for _, alt := range t.rules[symbol] {
+			for _, elem := range alt.symbols {
+				if nt, ok := elem.(grammar.NonTerminal); ok && !reached[nt.Symbol] {
+					reached[nt.Symbol] = true
+					work = append(work, nt.Symbol)
+				}
+			}
+		}
+	}
+	return reached
+}
+
+// remove removes rules that could be reached before rewriting but no longer
+// can, such as rules substituted for their only reference.
+func (t *transformer) remove(before map[grammar.Symbol]bool) {
+	after := t.reachable()
+	kept := t.symbols[:0]
+	for _, symbol := range t.symbols {
+		if before[symbol] && !after[symbol] {
+			delete(t.rules, symbol)
+			continue
+		}
+		kept = append(kept, symbol)
+	}
+	t.symbols = kept
+}
+
+// names returns the terminal and non-terminal names of symbols, as they
+// appear in parse tree nodes.
+func names(symbols []grammar.ProductionRule) []string {
+	result := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		switch s := symbol.(type) {
+		case grammar.Terminal:
+			result[i] = string(s.TokenType)
+		case grammar.NonTerminal:
+			result[i] = string(s.Symbol)
+		}
+	}
+	return result
+}
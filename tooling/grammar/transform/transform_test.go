@@ -0,0 +1,314 @@
+package transform
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+	"github.com/shadowCow/cow-lang-go/tooling/lexer"
+	"github.com/shadowCow/cow-lang-go/tooling/ll1"
+	"github.com/shadowCow/cow-lang-go/tooling/parsetree"
+)
+
+// tok returns a terminal. The tests' tokens are single characters, named
+// after themselves.
+func tok(tokenType string) grammar.Terminal {
+	return grammar.Terminal{TokenType: grammar.TokenType(tokenType)}
+}
+
+// ref returns a non-terminal.
+func ref(symbol string) grammar.NonTerminal {
+	return grammar.NonTerminal{Symbol: grammar.Symbol(symbol)}
+}
+
+// exprGrammar returns a left-recursive grammar for sums.
+//
+//	Expr -> Expr + Term | Term
+//	Term -> n
+func exprGrammar() grammar.SyntacticGrammar {
+	return grammar.SyntacticGrammar{
+		StartSymbol: "Expr",
+		Productions: map[grammar.Symbol]grammar.ProductionRule{
+			"Expr": grammar.SynAlternative{
+				grammar.SynSequence{ref("Expr"), tok("+"), ref("Term")},
+				ref("Term"),
+			},
+			"Term": tok("n"),
+		},
+	}
+}
+
+// indirectGrammar returns a grammar whose rules are left-recursive through
+// each other.
+//
+//	B -> A y | b
+//	A -> B x | a
+func indirectGrammar() grammar.SyntacticGrammar {
+	return grammar.SyntacticGrammar{
+		StartSymbol: "B",
+		Productions: map[grammar.Symbol]grammar.ProductionRule{
+			"A": grammar.SynAlternative{grammar.SynSequence{ref("B"), tok("x")}, tok("a")},
+			"B": grammar.SynAlternative{grammar.SynSequence{ref("A"), tok("y")}, tok("b")},
+		},
+	}
+}
+
+// TestEliminateLeftRecursion tests that left-recursive rules are replaced by
+// tails, and that rules substituted for their only reference are removed.
+func TestEliminateLeftRecursion(t *testing.T) {
+	list := grammar.SyntacticGrammar{
+		StartSymbol: "List",
+		Productions: map[grammar.Symbol]grammar.ProductionRule{
+			"List": grammar.SynSequence{tok("n"), grammar.SynZeroOrMore{Inner: tok("n")}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		grammar  grammar.SyntacticGrammar
+		expected map[grammar.Symbol]grammar.ProductionRule
+	}{
+		{
+			name:    "direct",
+			grammar: exprGrammar(),
+			expected: map[grammar.Symbol]grammar.ProductionRule{
+				"Expr": grammar.SynSequence{ref("Term"), ref("Expr'tail1")},
+				"Expr'tail1": grammar.SynAlternative{
+					grammar.SynSequence{tok("+"), ref("Term"), ref("Expr'tail1")},
+					grammar.SynSequence{},
+				},
+				"Term": tok("n"),
+			},
+		},
+		{
+			name:    "indirect",
+			grammar: indirectGrammar(),
+			expected: map[grammar.Symbol]grammar.ProductionRule{
+				"B": grammar.SynAlternative{
+					grammar.SynSequence{tok("a"), tok("y"), ref("B'tail1")},
+					grammar.SynSequence{tok("b"), ref("B'tail1")},
+				},
+				"B'tail1": grammar.SynAlternative{
+					grammar.SynSequence{tok("x"), tok("y"), ref("B'tail1")},
+					grammar.SynSequence{},
+				},
+			},
+		},
+		{
+			name:     "not left-recursive",
+			grammar:  list,
+			expected: ll1.Desugar(list).Productions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _, err := EliminateLeftRecursion(tt.grammar)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result.Productions, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result.Productions)
+			}
+			if result.StartSymbol != tt.grammar.StartSymbol {
+				t.Errorf("Expected start symbol %s, got %s", tt.grammar.StartSymbol, result.StartSymbol)
+			}
+		})
+	}
+}
+
+// TestEliminateLeftRecursionErrors tests that left recursion that cannot be
+// eliminated is reported.
+func TestEliminateLeftRecursionErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		productions map[grammar.Symbol]grammar.ProductionRule
+		expected    string
+	}{
+		{
+			name: "derives itself",
+			productions: map[grammar.Symbol]grammar.ProductionRule{
+				"A": grammar.SynAlternative{ref("B"), tok("a")},
+				"B": ref("A"),
+			},
+			expected: "B derives itself",
+		},
+		{
+			name: "no way out",
+			productions: map[grammar.Symbol]grammar.ProductionRule{
+				"A": grammar.SynSequence{ref("A"), tok("a")},
+			},
+			expected: "every alternative of A starts with itself",
+		},
+		{
+			name: "through a nullable rule",
+			productions: map[grammar.Symbol]grammar.ProductionRule{
+				"A": grammar.SynAlternative{grammar.SynSequence{ref("B"), ref("A"), tok("x")}, tok("y")},
+				"B": grammar.SynOptional{Inner: tok("b")},
+			},
+			expected: "A is left-recursive through a nullable rule",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := EliminateLeftRecursion(grammar.SyntacticGrammar{StartSymbol: "A", Productions: tt.productions})
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected error %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+// TestLeftFactor tests that common prefixes are taken out of alternatives,
+// repeatedly, and that helpers map back to their rule.
+func TestLeftFactor(t *testing.T) {
+	g := grammar.SyntacticGrammar{
+		StartSymbol: "Stmt",
+		Productions: map[grammar.Symbol]grammar.ProductionRule{
+			"Stmt": grammar.SynAlternative{
+				grammar.SynSequence{tok("i"), tok("="), tok("n")},
+				tok("n"),
+				grammar.SynSequence{tok("i"), tok("("), tok(")")},
+				grammar.SynSequence{tok("i"), tok("("), tok("n"), tok(")")},
+			},
+		},
+	}
+
+	result, sourceMap := LeftFactor(g)
+	expected := map[grammar.Symbol]grammar.ProductionRule{
+		"Stmt": grammar.SynAlternative{
+			grammar.SynSequence{tok("i"), ref("Stmt$factor1")},
+			grammar.SynSequence{tok("n")},
+		},
+		"Stmt$factor1": grammar.SynAlternative{
+			grammar.SynSequence{tok("="), tok("n")},
+			grammar.SynSequence{tok("("), ref("Stmt$factor1$factor1")},
+		},
+		"Stmt$factor1$factor1": grammar.SynAlternative{
+			grammar.SynSequence{tok(")")},
+			grammar.SynSequence{tok("n"), tok(")")},
+		},
+	}
+	if !reflect.DeepEqual(result.Productions, expected) {
+		t.Errorf("Expected %v, got %v", expected, result.Productions)
+	}
+
+	for _, symbol := range []grammar.Symbol{"Stmt", "Stmt$factor1", "Stmt$factor1$factor1"} {
+		if origin := sourceMap.Origin(symbol); origin != "Stmt" {
+			t.Errorf("Expected %s to come from Stmt, got %s", symbol, origin)
+		}
+	}
+}
+
+// outline returns the shape of a parse tree: terminals by token type, and
+// non-terminals with their children in parentheses.
+func outline(node parsetree.ParseTree) string {
+	switch n := node.(type) {
+	case *parsetree.NonTerminalNode:
+		children := make([]string, len(n.Children))
+		for i, child := range n.Children {
+			children[i] = outline(child)
+		}
+		return string(n.Symbol) + "(" + strings.Join(children, " ") + ")"
+	case *parsetree.TerminalNode:
+		return n.Token.Type
+	case *parsetree.EmptyNode:
+		return string(n.Symbol) + "()"
+	default:
+		return node.String()
+	}
+}
+
+// TestRefold tests that parse trees of a grammar rewritten by ForLL1 are
+// refolded into the shape of the grammar as written.
+func TestRefold(t *testing.T) {
+	tests := []struct {
+		name     string
+		grammar  grammar.SyntacticGrammar
+		source   string
+		expected string
+	}{
+		{
+			name:     "direct",
+			grammar:  exprGrammar(),
+			source:   "n+n+n",
+			expected: "Expr(Expr(Expr(Term(n)) + Term(n)) + Term(n))",
+		},
+		{
+			name:     "direct without repetition",
+			grammar:  exprGrammar(),
+			source:   "n",
+			expected: "Expr(Term(n))",
+		},
+		{
+			name:     "indirect",
+			grammar:  indirectGrammar(),
+			source:   "ayxy",
+			expected: "B(A(B(A(a) y) x) y)",
+		},
+		{
+			name: "through a group",
+			grammar: grammar.SyntacticGrammar{
+				StartSymbol: "Expr",
+				Productions: map[grammar.Symbol]grammar.ProductionRule{
+					// Expr -> (Expr + | -) n
+					"Expr": grammar.SynSequence{
+						grammar.SynAlternative{grammar.SynSequence{ref("Expr"), tok("+")}, tok("-")},
+						tok("n"),
+					},
+				},
+			},
+			source:   "-n+n+n",
+			expected: "Expr(Expr(Expr(- n) + n) + n)",
+		},
+		{
+			name: "factored",
+			grammar: grammar.SyntacticGrammar{
+				StartSymbol: "Stmt",
+				Productions: map[grammar.Symbol]grammar.ProductionRule{
+					"Stmt": grammar.SynAlternative{
+						grammar.SynSequence{tok("i"), tok("="), ref("Expr")},
+						grammar.SynSequence{tok("i"), tok("("), tok(")")},
+					},
+					"Expr": exprGrammar().Productions["Expr"],
+					"Term": exprGrammar().Productions["Term"],
+				},
+			},
+			source:   "i=n+n",
+			expected: "Stmt(i = Expr(Expr(Term(n)) + Term(n)))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, sourceMap, err := ForLL1(tt.grammar)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			firstSets := ll1.ComputeFirstSets(result)
+			table, err := ll1.BuildParseTable(result, firstSets, ll1.ComputeFollowSets(result, firstSets))
+			if err != nil {
+				t.Fatalf("Failed to build parse table: %v", err)
+			}
+
+			var tokens []lexer.Token
+			for i, r := range tt.source {
+				tokens = append(tokens, lexer.Token{Type: string(r), Value: string(r), Line: 1, Column: i + 1, Offset: i})
+			}
+			tree, err := ll1.NewParser(table, result, tokens).Parse()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			refolded, ok := sourceMap.Refold(tree).(*parsetree.ProgramNode)
+			if !ok {
+				t.Fatalf("Expected a program node, got %T", sourceMap.Refold(tree))
+			}
+			if got := outline(refolded.Root); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -1,10 +1,13 @@
 package lexer
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"unicode/utf8"
 
 	"github.com/shadowCow/cow-lang-go/tooling/automata"
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
 )
 
 // Token represents a lexical token with its type, value, and position.
@@ -14,22 +17,55 @@ type Token struct {
 	Line   int    // Line number (1-indexed)
 	Column int    // Column number (1-indexed)
 	Offset int    // Byte offset in source (0-indexed)
+
+	// LeadingTrivia holds skipped tokens (e.g., whitespace, comments) that
+	// immediately precede this token. Populated by AttachTrivia.
+	LeadingTrivia []Token
 }
 
-// Lexer tokenizes source code using a compiled DFA.
+// Lexer tokenizes source code using a compiled DFA. The source is either a
+// string, given whole to NewLexer, or a stream read by NewReaderLexer as
+// tokens are requested.
 type Lexer struct {
 	dfa    automata.DfaWithTokens
-	source string
-	offset int    // Current position in source
-	line   int    // Current line (1-indexed)
-	column int    // Current column (1-indexed)
+	reader io.Reader // Where more input comes from, or nil if it is all in buf
+	buf    []byte    // Input read but not yet tokenized
+	err    error     // Error reading input, other than io.EOF
+	offset int       // Current position in source
+	line   int       // Current line (1-indexed)
+	column int       // Current column (1-indexed)
+
+	recovery    bool         // Emit ERROR tokens instead of failing (see SetRecovery)
+	diagnostics []Diagnostic // Problems found in recovery mode
+}
+
+// TokenSource produces tokens one at a time. Next returns io.EOF after the
+// last token.
+type TokenSource interface {
+	Next() (Token, error)
 }
 
+// readSize is how many bytes a reader lexer asks for at a time.
+const readSize = 4096
+
 // NewLexer creates a new lexer with a compiled DFA.
 func NewLexer(dfa automata.DfaWithTokens, source string) *Lexer {
 	return &Lexer{
 		dfa:    dfa,
-		source: source,
+		buf:    []byte(source),
+		offset: 0,
+		line:   1,
+		column: 1,
+	}
+}
+
+// NewReaderLexer creates a lexer that reads source code from r as tokens are
+// requested with Next. It only keeps the input of the token being matched:
+// as much as longest-match tokenization needs to look ahead.
+func NewReaderLexer(dfa automata.DfaWithTokens, r io.Reader) *Lexer {
+	return &Lexer{
+		dfa:    dfa,
+		reader: r,
 		offset: 0,
 		line:   1,
 		column: 1,
@@ -40,23 +76,68 @@ func NewLexer(dfa automata.DfaWithTokens, source string) *Lexer {
 func (l *Lexer) Tokenize() ([]Token, error) {
 	tokens := make([]Token, 0)
 
-	for l.offset < len(l.source) {
-		token, err := l.nextToken()
+	for {
+		token, err := l.Next()
+		if err == io.EOF {
+			return tokens, nil
+		}
 		if err != nil {
 			return tokens, err
 		}
-		if token != nil {
-			tokens = append(tokens, *token)
+		tokens = append(tokens, token)
+	}
+}
+
+// Next returns the next token, or io.EOF after the last one. After an
+// error, Next keeps returning it.
+func (l *Lexer) Next() (Token, error) {
+	token, err := l.nextToken()
+	if err != nil {
+		return Token{}, err
+	}
+	if token == nil {
+		return Token{}, io.EOF
+	}
+	return *token, nil
+}
+
+// available reports whether at least n bytes of input are buffered,
+// reading more if needed.
+func (l *Lexer) available(n int) bool {
+	for len(l.buf) < n && l.reader != nil {
+		// Make room by moving the buffered input to a new array, which
+		// drops the input already tokenized
+		if cap(l.buf)-len(l.buf) < readSize {
+			grown := make([]byte, len(l.buf), 2*len(l.buf)+readSize)
+			copy(grown, l.buf)
+			l.buf = grown
+		}
+		read, err := l.reader.Read(l.buf[len(l.buf):cap(l.buf)])
+		l.buf = l.buf[:len(l.buf)+read]
+		if err != nil {
+			if err != io.EOF {
+				l.err = err
+			}
+			l.reader = nil
 		}
 	}
+	return len(l.buf) >= n
+}
 
-	return tokens, nil
+// decodeRune decodes the rune at byte i of the buffer, reading more input
+// if the rune is incomplete. The size is 0 at the end of input.
+func (l *Lexer) decodeRune(i int) (rune, int) {
+	l.available(i + utf8.UTFMax)
+	if i >= len(l.buf) {
+		return utf8.RuneError, 0
+	}
+	return utf8.DecodeRune(l.buf[i:])
 }
 
 // nextToken returns the next token using longest-match tokenization.
 func (l *Lexer) nextToken() (*Token, error) {
-	if l.offset >= len(l.source) {
-		return nil, nil
+	if !l.available(1) {
+		return nil, l.readError()
 	}
 
 	startOffset := l.offset
@@ -64,57 +145,60 @@ func (l *Lexer) nextToken() (*Token, error) {
 	startColumn := l.column
 
 	state := l.dfa.InitialState
-	lastAcceptState := ""
-	lastAcceptOffset := -1
-	lastAcceptLine := l.line
-	lastAcceptColumn := l.column
+	lastAcceptState := automata.NoState
+	lastAcceptLength := 0
 
 	// Try to match as long as possible (longest match)
-	for l.offset < len(l.source) {
+	for length := 0; ; {
 		// Decode the next UTF-8 rune
-		r, size := utf8.DecodeRuneInString(l.source[l.offset:])
-		if r == utf8.RuneError && size == 1 {
-			// Invalid UTF-8 sequence
+		r, size := l.decodeRune(length)
+		if size == 0 && l.err != nil {
+			// The token might have gone on
+			return nil, l.readError()
+		}
+		if size == 0 || (r == utf8.RuneError && size == 1) {
+			// End of input or invalid UTF-8 sequence
 			break
 		}
 
 		nextState := l.dfa.NextState(state, r)
 
-		if nextState == "" {
+		if nextState == automata.NoState {
 			// No valid transition
 			break
 		}
 
 		// Move to next state
 		state = nextState
-		l.offset += size // Advance by the actual number of bytes for this rune
-
-		// Track position
-		if r == '\n' {
-			l.line++
-			l.column = 1
-		} else {
-			l.column++
-		}
+		length += size // Advance by the actual number of bytes for this rune
 
 		// Check if this is an accepting state
 		if l.dfa.IsAccepting(state) {
 			lastAcceptState = state
-			lastAcceptOffset = l.offset
-			lastAcceptLine = l.line
-			lastAcceptColumn = l.column
+			lastAcceptLength = length
 		}
 	}
 
 	// If we found an accepting state, create token
-	if lastAcceptOffset > startOffset {
+	if lastAcceptLength > 0 {
 		tokenType := l.dfa.GetTokenType(lastAcceptState)
-		value := l.source[startOffset:lastAcceptOffset]
+		length := lastAcceptLength
 
-		// Reset to end of last accept
-		l.offset = lastAcceptOffset
-		l.line = lastAcceptLine
-		l.column = lastAcceptColumn
+		// Nested-delimited tokens only matched their opening delimiter so far
+		if nested, ok := l.dfa.Nested[tokenType]; ok {
+			end, err := l.scanNested(nested, length)
+			if err != nil {
+				if l.recovery && l.err == nil {
+					// The rest of the input is an unterminated token
+					return l.errorToken(end, err.Error()), nil
+				}
+				return nil, fmt.Errorf("%w starting at line %d, column %d", err, startLine, startColumn)
+			}
+			length = end
+		}
+
+		value := string(l.buf[:length])
+		l.advance(length)
 
 		return &Token{
 			Type:   string(tokenType), // Convert grammar.TokenType to string
@@ -126,12 +210,77 @@ func (l *Lexer) nextToken() (*Token, error) {
 	}
 
 	// No token matched - error
+	if l.recovery {
+		return l.skipUnmatched(), nil
+	}
+
 	// Decode the rune at the error position for a better error message
-	r, _ := utf8.DecodeRuneInString(l.source[startOffset:])
+	r, _ := l.decodeRune(0)
 	if r == utf8.RuneError {
 		return nil, fmt.Errorf("invalid UTF-8 sequence at line %d, column %d",
 			startLine, startColumn)
 	}
 	return nil, fmt.Errorf("unexpected character at line %d, column %d: %q",
 		startLine, startColumn, r)
-}
\ No newline at end of file
+}
+
+// readError returns the error reading input, if any.
+func (l *Lexer) readError() error {
+	if l.err != nil {
+		return fmt.Errorf("error reading input at line %d, column %d: %w", l.line, l.column, l.err)
+	}
+	return nil
+}
+
+// scanNested finds the matching closing delimiter of a token whose opening
+// delimiter ends at byte i of the buffer, allowing the delimiters to nest.
+// It returns the length of the whole token, or of the rest of the input if
+// the token is unterminated.
+func (l *Lexer) scanNested(nested grammar.NestedDelimited, i int) (int, error) {
+	openDelim, closeDelim := []byte(nested.Open), []byte(nested.Close)
+	lookahead := len(openDelim)
+	if len(closeDelim) > lookahead {
+		lookahead = len(closeDelim)
+	}
+	depth := 1
+	for {
+		// Buffer enough input to recognize either delimiter
+		l.available(i + lookahead)
+		if i >= len(l.buf) {
+			break
+		}
+		rest := l.buf[i:]
+		switch {
+		case bytes.HasPrefix(rest, closeDelim):
+			i += len(closeDelim)
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		case bytes.HasPrefix(rest, openDelim):
+			i += len(openDelim)
+			depth++
+		default:
+			_, size := l.decodeRune(i)
+			i += size
+		}
+	}
+	if l.err != nil {
+		return i, l.readError()
+	}
+	return i, fmt.Errorf("unterminated %s...%s", nested.Open, nested.Close)
+}
+
+// advance consumes n bytes of input, keeping line and column up to date.
+func (l *Lexer) advance(n int) {
+	for _, r := range string(l.buf[:n]) {
+		if r == '\n' {
+			l.line++
+			l.column = 1
+		} else {
+			l.column++
+		}
+	}
+	l.offset += n
+	l.buf = l.buf[n:]
+}
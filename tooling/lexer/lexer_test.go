@@ -1,7 +1,12 @@
 package lexer
 
 import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/shadowCow/cow-lang-go/tooling/automata"
 	"github.com/shadowCow/cow-lang-go/tooling/grammar"
@@ -108,4 +113,174 @@ func TestLexerError(t *testing.T) {
 	if len(tokens) != 1 {
 		t.Errorf("Expected 1 token before error, got %d", len(tokens))
 	}
-}
\ No newline at end of file
+}
+
+// TestLexerNestedDelimited tests tokens whose delimiters may nest.
+func TestLexerNestedDelimited(t *testing.T) {
+	lexGrammar := grammar.LexicalGrammar{
+		Tokens: []grammar.TokenDefinition{
+			{
+				Name:     "COMMENT",
+				Pattern:  grammar.NestedDelimited{Open: "/*", Close: "*/"},
+				Priority: 2,
+			},
+			{
+				Name:     "DIGIT",
+				Pattern:  grammar.CharRange{From: '0', To: '9'},
+				Priority: 1,
+			},
+		},
+	}
+
+	dfa := automata.CompileLexicalGrammar(lexGrammar)
+
+	lex := NewLexer(dfa, "1/* a /* b */\n c */2")
+	tokens, err := lex.Tokenize()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []Token{
+		{Type: "DIGIT", Value: "1", Line: 1, Column: 1, Offset: 0},
+		{Type: "COMMENT", Value: "/* a /* b */\n c */", Line: 1, Column: 2, Offset: 1},
+		{Type: "DIGIT", Value: "2", Line: 2, Column: 6, Offset: 19},
+	}
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %d", len(expected), len(tokens))
+	}
+	for i, e := range expected {
+		a := tokens[i]
+		if a.Type != e.Type || a.Value != e.Value || a.Line != e.Line || a.Column != e.Column || a.Offset != e.Offset {
+			t.Errorf("Token %d: expected %+v, got %+v", i, e, a)
+		}
+	}
+
+	lex = NewLexer(dfa, "/* a /* b */")
+	if _, err := lex.Tokenize(); err == nil {
+		t.Fatal("Expected error for unterminated comment, got nil")
+	}
+}
+
+// TestAttachTrivia tests that trivia is attached to the following token.
+func TestAttachTrivia(t *testing.T) {
+	tokens := []Token{
+		{Type: "WS", Value: " "},
+		{Type: "COMMENT", Value: "/* c */"},
+		{Type: "DIGIT", Value: "1"},
+		{Type: "DIGIT", Value: "2"},
+		{Type: "WS", Value: " "},
+	}
+
+	significant, trailing := AttachTrivia(tokens, "WS", "COMMENT")
+
+	if len(significant) != 2 {
+		t.Fatalf("Expected 2 significant tokens, got %d", len(significant))
+	}
+	if len(significant[0].LeadingTrivia) != 2 {
+		t.Errorf("Expected 2 leading trivia tokens, got %d", len(significant[0].LeadingTrivia))
+	}
+	if len(significant[1].LeadingTrivia) != 0 {
+		t.Errorf("Expected no leading trivia, got %d", len(significant[1].LeadingTrivia))
+	}
+	if len(trailing) != 1 || trailing[0].Type != "WS" {
+		t.Errorf("Expected 1 trailing WS token, got %+v", trailing)
+	}
+}
+
+// TestReaderLexer tests that a lexer reading from an io.Reader produces the
+// same tokens as one given the whole source, however the input is split.
+func TestReaderLexer(t *testing.T) {
+	lexGrammar := grammar.LexicalGrammar{
+		Tokens: []grammar.TokenDefinition{
+			{Name: "COMMENT", Pattern: grammar.NestedDelimited{Open: "/*", Close: "*/"}, Priority: 3},
+			{Name: "ARROW", Pattern: grammar.Literal("-->"), Priority: 2},
+			{Name: "DASH", Pattern: grammar.Literal("-"), Priority: 1},
+			{Name: "WORD", Pattern: grammar.LexOneOrMore{Inner: grammar.UnicodeClass{"L"}}, Priority: 1},
+			{Name: "SPACE", Pattern: grammar.LexOneOrMore{Inner: grammar.CharSet{' ', '\n'}}, Priority: 1},
+		},
+	}
+	dfa := automata.CompileLexicalGrammar(lexGrammar)
+
+	// "--" backtracks to two dashes, and multi-byte runes are split across reads
+	source := "héllo --> wörld --\n/* a /* ☃ */ b */ 日本"
+	expected, err := NewLexer(dfa, source).Tokenize()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	readers := map[string]func() io.Reader{
+		"whole":    func() io.Reader { return strings.NewReader(source) },
+		"one byte": func() io.Reader { return iotest.OneByteReader(strings.NewReader(source)) },
+		"half":     func() io.Reader { return iotest.HalfReader(strings.NewReader(source)) },
+	}
+	for name, reader := range readers {
+		t.Run(name, func(t *testing.T) {
+			lex := NewReaderLexer(dfa, reader())
+			var tokens []Token
+			for {
+				token, err := lex.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				tokens = append(tokens, token)
+			}
+			if !reflect.DeepEqual(tokens, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, tokens)
+			}
+		})
+	}
+}
+
+// TestReaderLexerBuffersOneToken tests that a reader lexer does not keep
+// input it has already tokenized.
+func TestReaderLexerBuffersOneToken(t *testing.T) {
+	lexGrammar := grammar.LexicalGrammar{
+		Tokens: []grammar.TokenDefinition{
+			{Name: "DIGITS", Pattern: grammar.LexOneOrMore{Inner: grammar.CharRange{From: '0', To: '9'}}, Priority: 1},
+			{Name: "SPACE", Pattern: grammar.Literal(" "), Priority: 1},
+		},
+	}
+	dfa := automata.CompileLexicalGrammar(lexGrammar)
+
+	source := strings.Repeat("12345 ", 100000)
+	lex := NewReaderLexer(dfa, strings.NewReader(source))
+	count := 0
+	for {
+		_, err := lex.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		count++
+		if cap(lex.buf) > 4*readSize {
+			t.Fatalf("Expected the buffer to stay small, got %d bytes after %d tokens", cap(lex.buf), count)
+		}
+	}
+	if count != 200000 {
+		t.Errorf("Expected 200000 tokens, got %d", count)
+	}
+}
+
+// TestReaderLexerReadError tests that errors reading input are returned.
+func TestReaderLexerReadError(t *testing.T) {
+	dfa := automata.CompileLexicalGrammar(grammar.LexicalGrammar{
+		Tokens: []grammar.TokenDefinition{
+			{Name: "DIGIT", Pattern: grammar.CharRange{From: '0', To: '9'}, Priority: 1},
+		},
+	})
+
+	failure := errors.New("disk on fire")
+	lex := NewReaderLexer(dfa, io.MultiReader(strings.NewReader("12"), iotest.ErrReader(failure)))
+	tokens, err := lex.Tokenize()
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected the read error, got %v", err)
+	}
+	if len(tokens) != 1 {
+		t.Errorf("Expected 1 token before the error, got %d", len(tokens))
+	}
+}
@@ -0,0 +1,84 @@
+package lexer
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/shadowCow/cow-lang-go/tooling/automata"
+)
+
+// ErrorTokenType is the type of the tokens that hold input no token matches,
+// in recovery mode.
+const ErrorTokenType = "ERROR"
+
+// Diagnostic is a problem found in the source code: its message, and the
+// span of the input it concerns.
+type Diagnostic struct {
+	Message string
+	Line    int // Line number (1-indexed)
+	Column  int // Column number (1-indexed)
+	Offset  int // Byte offset in source (0-indexed)
+	Length  int // Length of the span in bytes
+}
+
+// Error formats the diagnostic as line:column: message.
+func (d Diagnostic) Error() string {
+	return fmt.Sprintf("%d:%d: %s", d.Line, d.Column, d.Message)
+}
+
+// SetRecovery enables/disables recovery mode. By default, the lexer stops
+// at the first input that no token matches. In recovery mode, it wraps that
+// input in an ERROR token instead, records a diagnostic, and goes on at the
+// next character that can start a token, so that every problem in the source
+// is found. Errors reading input still stop the lexer.
+func (l *Lexer) SetRecovery(enabled bool) {
+	l.recovery = enabled
+}
+
+// Diagnostics returns the problems found so far in recovery mode, in the
+// order of the source.
+func (l *Lexer) Diagnostics() []Diagnostic {
+	return l.diagnostics
+}
+
+// skipUnmatched returns an ERROR token for the input at the current
+// position, which no token matches, up to the next character that can start
+// a token.
+func (l *Lexer) skipUnmatched() *Token {
+	r, size := l.decodeRune(0)
+	message := fmt.Sprintf("unexpected character %q", r)
+	if r == utf8.RuneError && size == 1 {
+		message = "invalid UTF-8 sequence"
+	}
+
+	length := size
+	for {
+		r, size := l.decodeRune(length)
+		if size == 0 || (r != utf8.RuneError || size != 1) && l.dfa.NextState(l.dfa.InitialState, r) != automata.NoState {
+			break
+		}
+		length += size
+	}
+	return l.errorToken(length, message)
+}
+
+// errorToken consumes length bytes of input as an ERROR token, and records
+// a diagnostic for it.
+func (l *Lexer) errorToken(length int, message string) *Token {
+	token := &Token{
+		Type:   ErrorTokenType,
+		Value:  string(l.buf[:length]),
+		Line:   l.line,
+		Column: l.column,
+		Offset: l.offset,
+	}
+	l.diagnostics = append(l.diagnostics, Diagnostic{
+		Message: message,
+		Line:    token.Line,
+		Column:  token.Column,
+		Offset:  token.Offset,
+		Length:  length,
+	})
+	l.advance(length)
+	return token
+}
@@ -0,0 +1,149 @@
+package lexer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/shadowCow/cow-lang-go/tooling/automata"
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+)
+
+// recoveryDFA compiles a grammar of numbers, spaces and nested comments.
+func recoveryDFA() automata.DfaWithTokens {
+	return automata.CompileLexicalGrammar(grammar.LexicalGrammar{
+		Tokens: []grammar.TokenDefinition{
+			{Name: "COMMENT", Pattern: grammar.NestedDelimited{Open: "/*", Close: "*/"}, Priority: 2},
+			{Name: "NUM", Pattern: grammar.LexOneOrMore{Inner: grammar.CharRange{From: '0', To: '9'}}, Priority: 1},
+			{Name: "SPACE", Pattern: grammar.LexOneOrMore{Inner: grammar.CharSet{' ', '\n'}}, Priority: 1},
+		},
+	})
+}
+
+// TestLexerRecovery tests that in recovery mode, input no token matches
+// becomes ERROR tokens, with a diagnostic each, and lexing goes on.
+func TestLexerRecovery(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		tokens      []Token
+		diagnostics []Diagnostic
+	}{
+		{
+			name:  "stray characters",
+			input: "1 @ 2 $$ 3",
+			tokens: []Token{
+				{Type: "NUM", Value: "1", Line: 1, Column: 1, Offset: 0},
+				{Type: "SPACE", Value: " ", Line: 1, Column: 2, Offset: 1},
+				{Type: ErrorTokenType, Value: "@", Line: 1, Column: 3, Offset: 2},
+				{Type: "SPACE", Value: " ", Line: 1, Column: 4, Offset: 3},
+				{Type: "NUM", Value: "2", Line: 1, Column: 5, Offset: 4},
+				{Type: "SPACE", Value: " ", Line: 1, Column: 6, Offset: 5},
+				{Type: ErrorTokenType, Value: "$$", Line: 1, Column: 7, Offset: 6},
+				{Type: "SPACE", Value: " ", Line: 1, Column: 9, Offset: 8},
+				{Type: "NUM", Value: "3", Line: 1, Column: 10, Offset: 9},
+			},
+			diagnostics: []Diagnostic{
+				{Message: "unexpected character '@'", Line: 1, Column: 3, Offset: 2, Length: 1},
+				{Message: "unexpected character '$'", Line: 1, Column: 7, Offset: 6, Length: 2},
+			},
+		},
+		{
+			name:  "resync at a character that starts a token",
+			input: "@12\n/x",
+			tokens: []Token{
+				{Type: ErrorTokenType, Value: "@", Line: 1, Column: 1, Offset: 0},
+				{Type: "NUM", Value: "12", Line: 1, Column: 2, Offset: 1},
+				{Type: "SPACE", Value: "\n", Line: 1, Column: 4, Offset: 3},
+				{Type: ErrorTokenType, Value: "/x", Line: 2, Column: 1, Offset: 4},
+			},
+			diagnostics: []Diagnostic{
+				{Message: "unexpected character '@'", Line: 1, Column: 1, Offset: 0, Length: 1},
+				{Message: "unexpected character '/'", Line: 2, Column: 1, Offset: 4, Length: 2},
+			},
+		},
+		{
+			name:  "invalid UTF-8",
+			input: "1\xff\xfe2",
+			tokens: []Token{
+				{Type: "NUM", Value: "1", Line: 1, Column: 1, Offset: 0},
+				{Type: ErrorTokenType, Value: "\xff\xfe", Line: 1, Column: 2, Offset: 1},
+				{Type: "NUM", Value: "2", Line: 1, Column: 4, Offset: 3},
+			},
+			diagnostics: []Diagnostic{
+				{Message: "invalid UTF-8 sequence", Line: 1, Column: 2, Offset: 1, Length: 2},
+			},
+		},
+		{
+			name:  "unterminated comment",
+			input: "1 /* a /* b */",
+			tokens: []Token{
+				{Type: "NUM", Value: "1", Line: 1, Column: 1, Offset: 0},
+				{Type: "SPACE", Value: " ", Line: 1, Column: 2, Offset: 1},
+				{Type: ErrorTokenType, Value: "/* a /* b */", Line: 1, Column: 3, Offset: 2},
+			},
+			diagnostics: []Diagnostic{
+				{Message: "unterminated /*...*/", Line: 1, Column: 3, Offset: 2, Length: 12},
+			},
+		},
+		{
+			name:  "no errors",
+			input: "1 /* a */ 2",
+			tokens: []Token{
+				{Type: "NUM", Value: "1", Line: 1, Column: 1, Offset: 0},
+				{Type: "SPACE", Value: " ", Line: 1, Column: 2, Offset: 1},
+				{Type: "COMMENT", Value: "/* a */", Line: 1, Column: 3, Offset: 2},
+				{Type: "SPACE", Value: " ", Line: 1, Column: 10, Offset: 9},
+				{Type: "NUM", Value: "2", Line: 1, Column: 11, Offset: 10},
+			},
+		},
+	}
+
+	dfa := recoveryDFA()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexers := map[string]*Lexer{
+				"string": NewLexer(dfa, tt.input),
+				"reader": NewReaderLexer(dfa, iotest.OneByteReader(strings.NewReader(tt.input))),
+			}
+			for kind, lex := range lexers {
+				lex.SetRecovery(true)
+				tokens, err := lex.Tokenize()
+				if err != nil {
+					t.Fatalf("%s: unexpected error: %v", kind, err)
+				}
+				if !reflect.DeepEqual(tokens, tt.tokens) {
+					t.Errorf("%s: expected tokens %+v, got %+v", kind, tt.tokens, tokens)
+				}
+				if !reflect.DeepEqual(lex.Diagnostics(), tt.diagnostics) {
+					t.Errorf("%s: expected diagnostics %+v, got %+v", kind, tt.diagnostics, lex.Diagnostics())
+				}
+			}
+		})
+	}
+}
+
+// TestLexerStrictByDefault tests that without recovery mode, the lexer still
+// stops at the first input no token matches.
+func TestLexerStrictByDefault(t *testing.T) {
+	lex := NewLexer(recoveryDFA(), "1 @ 2")
+	tokens, err := lex.Tokenize()
+	if err == nil {
+		t.Fatal("Expected error for unexpected character, got nil")
+	}
+	if len(tokens) != 2 {
+		t.Errorf("Expected 2 tokens before error, got %d", len(tokens))
+	}
+	if len(lex.Diagnostics()) != 0 {
+		t.Errorf("Expected no diagnostics, got %+v", lex.Diagnostics())
+	}
+}
+
+// TestDiagnosticError tests the formatting of diagnostics.
+func TestDiagnosticError(t *testing.T) {
+	d := Diagnostic{Message: "unexpected character '@'", Line: 3, Column: 7, Offset: 20, Length: 1}
+	if got, expected := d.Error(), "3:7: unexpected character '@'"; got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,27 @@
+package lexer
+
+// AttachTrivia separates trivia tokens (such as whitespace and comments) from
+// the significant tokens of a stream. Each significant token keeps the trivia
+// that precedes it in its LeadingTrivia field, so tools like formatters can
+// recover comments after parsing. Trivia after the last significant token is
+// returned separately as trailing trivia.
+func AttachTrivia(tokens []Token, triviaTypes ...string) (significant []Token, trailing []Token) {
+	isTrivia := make(map[string]bool, len(triviaTypes))
+	for _, t := range triviaTypes {
+		isTrivia[t] = true
+	}
+
+	significant = make([]Token, 0, len(tokens))
+	var pending []Token
+	for _, tok := range tokens {
+		if isTrivia[tok.Type] {
+			pending = append(pending, tok)
+			continue
+		}
+		tok.LeadingTrivia = pending
+		pending = nil
+		significant = append(significant, tok)
+	}
+
+	return significant, pending
+}
@@ -122,6 +122,57 @@ func TestUnicodeSupport(t *testing.T) {
 	}
 }
 
+// TestUnicodeInAnyCharPatterns tests that patterns built from AnyChar and
+// AnyCharExcept match characters beyond ASCII.
+func TestUnicodeInAnyCharPatterns(t *testing.T) {
+	lexGrammar := grammar.LexicalGrammar{
+		Tokens: []grammar.TokenDefinition{
+			{
+				Name: "STRING",
+				Pattern: grammar.LexSequence{
+					grammar.Literal("\""),
+					grammar.LexZeroOrMore{Inner: grammar.AnyCharExcept{'"'}},
+					grammar.Literal("\""),
+				},
+				Priority: 1,
+			},
+			{
+				Name: "WORD",
+				Pattern: grammar.LexOneOrMore{
+					Inner: grammar.UnicodeClass{"L"},
+				},
+				Priority: 1,
+			},
+			{
+				Name:     "ANY",
+				Pattern:  grammar.AnyChar{},
+				Priority: 0,
+			},
+		},
+	}
+
+	dfa := automata.CompileLexicalGrammar(lexGrammar)
+	tokens, err := NewLexer(dfa, `"café 😁"Привет€`).Tokenize()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []Token{
+		{Type: "STRING", Value: `"café 😁"`, Line: 1, Column: 1, Offset: 0},
+		{Type: "WORD", Value: "Привет", Line: 1, Column: 9, Offset: 12},
+		{Type: "ANY", Value: "€", Line: 1, Column: 15, Offset: 24},
+	}
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %d: %v", len(expected), len(tokens), tokens)
+	}
+	for i, token := range tokens {
+		if token.Type != expected[i].Type || token.Value != expected[i].Value ||
+			token.Column != expected[i].Column || token.Offset != expected[i].Offset {
+			t.Errorf("Token %d: expected %+v, got %+v", i, expected[i], token)
+		}
+	}
+}
+
 // TestUnicodeColumnTracking verifies that columns are counted correctly for Unicode.
 func TestUnicodeColumnTracking(t *testing.T) {
 	// Create grammar that accepts digits
@@ -0,0 +1,176 @@
+package ll1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+)
+
+// helperMarker separates the name of a helper non-terminal from the name of
+// the non-terminal it was taken out of, as in "Args$rep1". Like the end of
+// input marker, it is reserved: grammar symbols must not contain it.
+const helperMarker = "$"
+
+// IsHelper reports whether a non-terminal is a helper introduced by
+// Desugar. The parser splices the children of helpers into their parent's,
+// so helpers never appear in parse trees.
+func IsHelper(symbol grammar.Symbol) bool {
+	return strings.Contains(string(symbol), helperMarker)
+}
+
+// Owner returns the non-terminal a helper was taken out of, or symbol itself
+// if it is not a helper.
+func Owner(symbol grammar.Symbol) grammar.Symbol {
+	if i := strings.Index(string(symbol), helperMarker); i >= 0 {
+		return symbol[:i]
+	}
+	return symbol
+}
+
+// Desugar returns an equivalent grammar without the EBNF operators
+// SynOptional, SynZeroOrMore and SynOneOrMore, or alternatives nested in
+// sequences. Each production becomes a terminal, a non-terminal, a sequence
+// of terminals and non-terminals, or an alternative of those.
+//
+// The operators are replaced by fresh helper non-terminals (see IsHelper),
+// named after the non-terminal they appear in:
+//
+//	X?      becomes  H, where H -> X | ε
+//	X*      becomes  H, where H -> X H | ε
+//	X+      becomes  X H, where H -> X H | ε
+//	(X | Y) becomes  H, where H -> X | Y
+//
+// Productions that use none of them are kept as they are. The result does
+// not depend on map iteration order, so desugaring the same grammar always
+// gives the same helper names.
+func Desugar(g grammar.SyntacticGrammar) grammar.SyntacticGrammar {
+	symbols := make([]grammar.Symbol, 0, len(g.Productions))
+	for symbol := range g.Productions {
+		symbols = append(symbols, symbol)
+	}
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i] < symbols[j] })
+
+	d := &desugarer{productions: make(map[grammar.Symbol]grammar.ProductionRule, len(g.Productions))}
+	for _, symbol := range symbols {
+		production := g.Productions[symbol]
+		if isPlain(production, true) {
+			d.productions[symbol] = production
+			continue
+		}
+		d.parent, d.helpers = symbol, 0
+		d.productions[symbol] = d.rule(production)
+	}
+	return grammar.SyntacticGrammar{Productions: d.productions, StartSymbol: g.StartSymbol}
+}
+
+// isPlain reports whether a production needs no desugaring. Alternatives
+// are only allowed at the top.
+func isPlain(production grammar.ProductionRule, top bool) bool {
+	switch p := production.(type) {
+	case grammar.Terminal, grammar.NonTerminal:
+		return true
+	case grammar.SynSequence:
+		for _, elem := range p {
+			switch elem.(type) {
+			case grammar.Terminal, grammar.NonTerminal:
+			default:
+				return false
+			}
+		}
+		return true
+	case grammar.SynAlternative:
+		if !top {
+			return false
+		}
+		for _, alt := range p {
+			if !isPlain(alt, false) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// desugarer accumulates the productions of a desugared grammar.
+type desugarer struct {
+	productions map[grammar.Symbol]grammar.ProductionRule
+	parent      grammar.Symbol // Non-terminal being desugared
+	helpers     int            // Number of helpers taken out of parent so far
+}
+
+// rule desugars the production of a non-terminal.
+func (d *desugarer) rule(production grammar.ProductionRule) grammar.ProductionRule {
+	switch p := production.(type) {
+	case grammar.Terminal, grammar.NonTerminal:
+		return p
+	case grammar.SynAlternative:
+		var alts grammar.SynAlternative
+		for _, alt := range p {
+			// Alternatives of alternatives are flattened
+			desugared := d.rule(alt)
+			if inner, ok := desugared.(grammar.SynAlternative); ok {
+				alts = append(alts, inner...)
+			} else {
+				alts = append(alts, desugared)
+			}
+		}
+		return alts
+	case grammar.SynOptional:
+		return grammar.SynAlternative{d.sequence(p.Inner), grammar.SynSequence{}}
+	default:
+		return d.sequence(production)
+	}
+}
+
+// sequence desugars a production to a sequence of terminals and
+// non-terminals.
+func (d *desugarer) sequence(production grammar.ProductionRule) grammar.SynSequence {
+	seq := grammar.SynSequence{}
+	switch p := production.(type) {
+	case grammar.Terminal, grammar.NonTerminal:
+		seq = append(seq, p)
+	case grammar.SynSequence:
+		for _, elem := range p {
+			seq = append(seq, d.sequence(elem)...)
+		}
+	case grammar.SynAlternative:
+		seq = append(seq, d.helper("group", d.rule(p)))
+	case grammar.SynOptional:
+		seq = append(seq, d.helper("opt", d.rule(p)))
+	case grammar.SynZeroOrMore:
+		seq = append(seq, d.repetition(p.Inner))
+	case grammar.SynOneOrMore:
+		seq = append(seq, d.sequence(p.Inner)...)
+		seq = append(seq, d.repetition(p.Inner))
+	default:
+		panic(fmt.Sprintf("unknown production type: %T", production))
+	}
+	return seq
+}
+
+// repetition returns a helper matching zero or more repetitions of inner.
+func (d *desugarer) repetition(inner grammar.ProductionRule) grammar.NonTerminal {
+	helper := d.newHelper("rep")
+	d.productions[helper.Symbol] = grammar.SynAlternative{
+		append(d.sequence(inner), helper),
+		grammar.SynSequence{},
+	}
+	return helper
+}
+
+// helper returns a new helper non-terminal for a production.
+func (d *desugarer) helper(kind string, production grammar.ProductionRule) grammar.NonTerminal {
+	helper := d.newHelper(kind)
+	d.productions[helper.Symbol] = production
+	return helper
+}
+
+// newHelper returns a fresh helper non-terminal, such as "Args$rep1".
+func (d *desugarer) newHelper(kind string) grammar.NonTerminal {
+	d.helpers++
+	return grammar.NonTerminal{Symbol: grammar.Symbol(fmt.Sprintf("%s%s%s%d", d.parent, helperMarker, kind, d.helpers))}
+}
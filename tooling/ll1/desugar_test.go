@@ -0,0 +1,159 @@
+package ll1
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shadowCow/cow-lang-go/tooling/automata"
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+	"github.com/shadowCow/cow-lang-go/tooling/lexer"
+	"github.com/shadowCow/cow-lang-go/tooling/parsetree"
+)
+
+// entryGrammar returns a syntactic grammar for listGrammars' tokens written
+// with EBNF operators.
+//
+//	List  -> Entry (COMMA Entry)*
+//	Entry -> WORD+ NUMBER?
+func entryGrammar() grammar.SyntacticGrammar {
+	return grammar.SyntacticGrammar{
+		StartSymbol: "List",
+		Productions: map[grammar.Symbol]grammar.ProductionRule{
+			"List": grammar.SynSequence{
+				grammar.NonTerminal{Symbol: "Entry"},
+				grammar.SynZeroOrMore{Inner: grammar.SynSequence{
+					grammar.Terminal{TokenType: "COMMA"},
+					grammar.NonTerminal{Symbol: "Entry"},
+				}},
+			},
+			"Entry": grammar.SynSequence{
+				grammar.SynOneOrMore{Inner: grammar.Terminal{TokenType: "WORD"}},
+				grammar.SynOptional{Inner: grammar.Terminal{TokenType: "NUMBER"}},
+			},
+		},
+	}
+}
+
+// outline returns the shape of a parse tree: terminals by token type, and
+// non-terminals with their children in parentheses.
+func outline(node parsetree.ParseTree) string {
+	switch n := node.(type) {
+	case *parsetree.NonTerminalNode:
+		children := make([]string, len(n.Children))
+		for i, child := range n.Children {
+			children[i] = outline(child)
+		}
+		return string(n.Symbol) + "(" + strings.Join(children, " ") + ")"
+	case *parsetree.TerminalNode:
+		return n.Token.Type
+	case *parsetree.EmptyNode:
+		return string(n.Symbol) + "()"
+	default:
+		return node.String()
+	}
+}
+
+// TestDesugar tests that EBNF operators are replaced by helper
+// non-terminals, and that BNF productions are kept as they are.
+func TestDesugar(t *testing.T) {
+	_, bnf := listGrammars()
+	if desugared := Desugar(bnf); !reflect.DeepEqual(desugared, bnf) {
+		t.Errorf("Expected a BNF grammar to be unchanged, got %v", desugared.Productions)
+	}
+
+	desugared := Desugar(grammar.ExampleSyntacticGrammar())
+	expected := map[grammar.Symbol]grammar.ProductionRule{
+		"Program": grammar.SynSequence{grammar.NonTerminal{Symbol: "Program$rep1"}},
+		"Program$rep1": grammar.SynAlternative{
+			grammar.SynSequence{grammar.NonTerminal{Symbol: "Statement"}, grammar.NonTerminal{Symbol: "Program$rep1"}},
+			grammar.SynSequence{},
+		},
+		"Expression": grammar.SynSequence{grammar.NonTerminal{Symbol: "Term"}, grammar.NonTerminal{Symbol: "Expression$rep1"}},
+		"Expression$rep1": grammar.SynAlternative{
+			grammar.SynSequence{
+				grammar.NonTerminal{Symbol: "Expression$group2"},
+				grammar.NonTerminal{Symbol: "Term"},
+				grammar.NonTerminal{Symbol: "Expression$rep1"},
+			},
+			grammar.SynSequence{},
+		},
+		"Expression$group2": grammar.SynAlternative{grammar.Terminal{TokenType: "PLUS"}, grammar.Terminal{TokenType: "MINUS"}},
+	}
+	for symbol, production := range expected {
+		if !reflect.DeepEqual(desugared.Productions[symbol], production) {
+			t.Errorf("Expected %s -> %s, got %s", symbol, formatProduction(production), formatProduction(desugared.Productions[symbol]))
+		}
+	}
+	if len(desugared.Productions) != 11 {
+		t.Errorf("Expected 11 productions, got %d", len(desugared.Productions))
+	}
+
+	if !IsHelper("Program$rep1") || IsHelper("Program") {
+		t.Errorf("Expected only Program$rep1 to be a helper")
+	}
+	if !reflect.DeepEqual(Desugar(grammar.ExampleSyntacticGrammar()), desugared) {
+		t.Errorf("Expected desugaring to be deterministic")
+	}
+}
+
+// TestParserFlattensRepetitions tests that helper non-terminals are left out
+// of parse trees, so that repeated children form a flat list.
+func TestParserFlattensRepetitions(t *testing.T) {
+	tests := []struct {
+		name      string
+		lexical   grammar.LexicalGrammar
+		syntactic grammar.SyntacticGrammar
+		trivia    string
+		source    string
+		expected  string
+	}{
+		{
+			name:      "example statements",
+			lexical:   grammar.ExampleLexicalGrammar(),
+			syntactic: grammar.ExampleSyntacticGrammar(),
+			trivia:    "WHITESPACE",
+			source:    "x = 1 + 2 * 3 - y; z = (4);",
+			expected: "Program(" +
+				"Statement(Assignment(IDENTIFIER EQUALS Expression(Term(Factor(NUMBER)) PLUS Term(Factor(NUMBER) STAR Factor(NUMBER)) MINUS Term(Factor(IDENTIFIER)))) SEMICOLON) " +
+				"Statement(Assignment(IDENTIFIER EQUALS Expression(Term(Factor(LPAREN Expression(Term(Factor(NUMBER))) RPAREN)))) SEMICOLON))",
+		},
+		{
+			name:      "no statements",
+			lexical:   grammar.ExampleLexicalGrammar(),
+			syntactic: grammar.ExampleSyntacticGrammar(),
+			trivia:    "WHITESPACE",
+			source:    " ",
+			expected:  "Program()",
+		},
+		{
+			name:      "one or more and optional",
+			lexical:   func() grammar.LexicalGrammar { lexical, _ := listGrammars(); return lexical }(),
+			syntactic: entryGrammar(),
+			trivia:    "SPACE",
+			source:    "a b 1, c",
+			expected:  "List(Entry(WORD WORD NUMBER) COMMA Entry(WORD))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			firstSets := ComputeFirstSets(tt.syntactic)
+			table, err := BuildParseTable(tt.syntactic, firstSets, ComputeFollowSets(tt.syntactic, firstSets))
+			if err != nil {
+				t.Fatalf("Failed to build parse table: %v", err)
+			}
+			tokens, err := lexer.NewLexer(automata.CompileLexicalGrammar(tt.lexical), tt.source).Tokenize()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			tree, err := NewParser(table, tt.syntactic, tokens, tt.trivia).Parse()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := outline(tree.Root); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -38,7 +38,10 @@ func (fs *FirstSets) IsNullable(symbol grammar.Symbol) bool {
 
 // ComputeFirstSets computes FIRST sets for all symbols in the grammar.
 // Returns the FirstSets structure.
+// The grammar is desugared first (see Desugar), so the sets include the
+// helper non-terminals that BuildParseTable uses.
 func ComputeFirstSets(g grammar.SyntacticGrammar) *FirstSets {
+	g = Desugar(g)
 	fs := NewFirstSets()
 
 	// Initialize: For each terminal, FIRST(terminal) = {terminal}
@@ -131,33 +134,6 @@ func (fs *FirstSets) computeFirstOfProduction(prod grammar.ProductionRule) (map[
 				nullable = true
 			}
 		}
-
-	case grammar.SynOptional:
-		// FIRST(A?) = FIRST(A)
-		// Always nullable since it's optional
-		firstInner, _ := fs.computeFirstOfProduction(p.Inner)
-		for term := range firstInner {
-			result[term] = true
-		}
-		nullable = true
-
-	case grammar.SynZeroOrMore:
-		// FIRST(A*) = FIRST(A)
-		// Always nullable since it can match zero times
-		firstInner, _ := fs.computeFirstOfProduction(p.Inner)
-		for term := range firstInner {
-			result[term] = true
-		}
-		nullable = true
-
-	case grammar.SynOneOrMore:
-		// FIRST(A+) = FIRST(A)
-		// Nullable if A is nullable
-		firstInner, nullableInner := fs.computeFirstOfProduction(p.Inner)
-		for term := range firstInner {
-			result[term] = true
-		}
-		nullable = nullableInner
 	}
 
 	return result, nullable
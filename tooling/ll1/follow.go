@@ -31,7 +31,9 @@ const EndOfInputMarker = "$"
 
 // ComputeFollowSets computes FOLLOW sets for all non-terminals in the grammar.
 // Requires FIRST sets to be computed first.
+// Like ComputeFirstSets, it desugars the grammar first.
 func ComputeFollowSets(g grammar.SyntacticGrammar, firstSets *FirstSets) *FollowSets {
+	g = Desugar(g)
 	fs := NewFollowSets()
 
 	// Initialize FOLLOW sets for all non-terminals
@@ -110,43 +112,6 @@ func (fs *FollowSets) addFollowsFromProduction(
 				changed = true
 			}
 		}
-
-	case grammar.SynOptional:
-		// A -> B? means FOLLOW(A) ⊆ FOLLOW(B)
-		nonterminals := collectNonTerminalsFromProduction(p.Inner)
-		for _, nt := range nonterminals {
-			if fs.addToFollow(nt, fs.Get(leftSide)) {
-				changed = true
-			}
-		}
-
-	case grammar.SynZeroOrMore:
-		// A -> B* means FOLLOW(A) ⊆ FOLLOW(B)
-		// Also B can be followed by FIRST(B) due to repetition
-		nonterminals := collectNonTerminalsFromProduction(p.Inner)
-		firstOfInner, _ := firstSets.computeFirstOfProduction(p.Inner)
-		for _, nt := range nonterminals {
-			if fs.addToFollow(nt, fs.Get(leftSide)) {
-				changed = true
-			}
-			if fs.addToFollow(nt, firstOfInner) {
-				changed = true
-			}
-		}
-
-	case grammar.SynOneOrMore:
-		// A -> B+ means FOLLOW(A) ⊆ FOLLOW(B)
-		// Also B can be followed by FIRST(B) due to repetition
-		nonterminals := collectNonTerminalsFromProduction(p.Inner)
-		firstOfInner, _ := firstSets.computeFirstOfProduction(p.Inner)
-		for _, nt := range nonterminals {
-			if fs.addToFollow(nt, fs.Get(leftSide)) {
-				changed = true
-			}
-			if fs.addToFollow(nt, firstOfInner) {
-				changed = true
-			}
-		}
 	}
 
 	return changed
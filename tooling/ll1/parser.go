@@ -1,7 +1,11 @@
 package ll1
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"sort"
+	"strings"
 
 	"github.com/shadowCow/cow-lang-go/tooling/grammar"
 	"github.com/shadowCow/cow-lang-go/tooling/lexer"
@@ -10,43 +14,106 @@ import (
 
 // Parser implements a table-driven LL(1) parser that returns generic parse trees.
 type Parser struct {
-	table         *ParseTable
-	grammar       grammar.SyntacticGrammar
-	tokens        []lexer.Token
-	pos           int    // Current position in token stream
-	trace         bool   // Optional: trace parsing steps for debugging
-	filterToken   string // Token type to filter (e.g., "WHITESPACE"), empty for no filtering
+	table    *ParseTable
+	grammar  grammar.SyntacticGrammar
+	source   lexer.TokenSource // Where tokens come from
+	current  lexer.Token       // Lookahead token, unless atEnd
+	atEnd    bool              // True once the source has no more significant tokens
+	trace    bool              // Optional: trace parsing steps for debugging
+	isFilter map[string]bool   // Token types to filter (e.g., "WHITESPACE"), empty for no filtering
+	trailing []lexer.Token     // Filtered tokens after the last significant token
+	last     lexer.Token       // Last token read from the source, if read
+	read     bool              // True once a token has been read from the source
+	viable   bool              // True once the lookahead is known to be matched further on
+
+	names       map[grammar.TokenType]string // Display names of token types in syntax errors
+	follow      *FollowSets                  // FOLLOW sets for error recovery, nil for no recovery
+	isSync      map[string]bool              // Token types to resynchronize at in error recovery
+	diagnostics []lexer.Diagnostic           // Syntax errors found in recovery mode
 }
 
 // NewParser creates a new LL(1) parser.
-// filterToken specifies a token type to filter out (e.g., "WHITESPACE"), or empty string for no filtering.
+// filterTokens specifies token types to filter out (e.g., "WHITESPACE", comments).
+// Filtered tokens are not parsed but are kept as leading trivia on the following
+// token (see lexer.AttachTrivia).
 func NewParser(
 	table *ParseTable,
 	grammar grammar.SyntacticGrammar,
 	tokens []lexer.Token,
-	filterToken string,
+	filterTokens ...string,
 ) *Parser {
-	// Filter tokens if specified
-	filtered := tokens
-	if filterToken != "" {
-		filtered = make([]lexer.Token, 0, len(tokens))
-		for _, tok := range tokens {
-			if tok.Type != filterToken {
-				filtered = append(filtered, tok)
-			}
-		}
+	return NewStreamParser(table, grammar, &sliceSource{tokens: tokens}, filterTokens...)
+}
+
+// NewStreamParser creates an LL(1) parser that pulls tokens from source as
+// it needs them, such as a lexer reading from an io.Reader, instead of
+// taking them all up front. Errors from the source are returned by Parse.
+func NewStreamParser(
+	table *ParseTable,
+	grammar grammar.SyntacticGrammar,
+	source lexer.TokenSource,
+	filterTokens ...string,
+) *Parser {
+	isFilter := make(map[string]bool, len(filterTokens))
+	for _, t := range filterTokens {
+		isFilter[t] = true
 	}
 
 	return &Parser{
-		table:       table,
-		grammar:     grammar,
-		tokens:      filtered,
-		pos:         0,
-		trace:       false,
-		filterToken: filterToken,
+		table:    table,
+		grammar:  grammar,
+		source:   source,
+		trace:    false,
+		isFilter: isFilter,
 	}
 }
 
+// sliceSource is a TokenSource over tokens that are already lexed.
+type sliceSource struct {
+	tokens []lexer.Token
+	pos    int
+}
+
+func (s *sliceSource) Next() (lexer.Token, error) {
+	if s.pos >= len(s.tokens) {
+		return lexer.Token{}, io.EOF
+	}
+	s.pos++
+	return s.tokens[s.pos-1], nil
+}
+
+// advance reads the next significant token into p.current, keeping the
+// filtered tokens before it as its leading trivia.
+func (p *Parser) advance() error {
+	p.viable = false
+	var pending []lexer.Token
+	for {
+		token, err := p.source.Next()
+		if err == io.EOF {
+			p.atEnd = true
+			p.trailing = pending
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		p.last, p.read = token, true
+		if p.isFilter[token.Type] || p.follow != nil && token.Type == lexer.ErrorTokenType {
+			pending = append(pending, token)
+			continue
+		}
+		token.LeadingTrivia = pending
+		p.current = token
+		return nil
+	}
+}
+
+// SetDisplayNames sets the names of token types in syntax errors, such as
+// those from grammar.DisplayNames. Token types without one are named as is.
+func (p *Parser) SetDisplayNames(names map[grammar.TokenType]string) {
+	p.names = names
+}
+
 // SetTrace enables/disables parse tracing for debugging.
 func (p *Parser) SetTrace(enabled bool) {
 	p.trace = enabled
@@ -54,6 +121,10 @@ func (p *Parser) SetTrace(enabled bool) {
 
 // Parse parses the token stream and returns a generic parse tree.
 func (p *Parser) Parse() (*parsetree.ProgramNode, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
 	// The stack holds symbols to be processed and their corresponding parse tree nodes
 	stack := []stackItem{
 		{symbol: symbolEOF, isTerminal: true},
@@ -80,7 +151,12 @@ func (p *Parser) Parse() (*parsetree.ProgramNode, error) {
 			// Top is a terminal - match it with input
 			if top.symbol == symbolEOF {
 				// Expect end of input
-				if p.pos >= len(p.tokens) {
+				if !p.atEnd && p.follow != nil {
+					if err := p.skipToEnd(); err != nil {
+						return nil, err
+					}
+				}
+				if p.atEnd {
 					// Success! Build final program node
 					if len(nodeStack) == 0 {
 						return nil, fmt.Errorf("parse completed but no parse tree was built")
@@ -88,80 +164,94 @@ func (p *Parser) Parse() (*parsetree.ProgramNode, error) {
 					if len(nodeStack) > 1 {
 						return nil, fmt.Errorf("parse completed but multiple trees remain: %d", len(nodeStack))
 					}
-					return &parsetree.ProgramNode{Root: nodeStack[0]}, nil
+					return &parsetree.ProgramNode{Root: nodeStack[0], TrailingTrivia: p.trailing}, nil
 				}
-				return nil, fmt.Errorf("unexpected token %q at line %d, column %d (expected end of input)",
-					p.tokens[p.pos].Value, p.tokens[p.pos].Line, p.tokens[p.pos].Column)
+				return nil, p.syntaxError([]string{symbolEOF})
 			}
 
 			// Match terminal with current token
-			if p.pos >= len(p.tokens) {
-				return nil, fmt.Errorf("unexpected end of input (expected %s)", top.symbol)
-			}
+			if p.follow != nil && (p.atEnd || p.current.Type != top.symbol) {
+				// Recover by acting as if the token was there
+				nodeStack = append(nodeStack, p.missingTerminal(top.symbol))
+			} else {
+				if p.atEnd || p.current.Type != top.symbol {
+					return nil, p.syntaxError([]string{top.symbol})
+				}
 
-			currentToken := p.tokens[p.pos]
-			if currentToken.Type != top.symbol {
-				return nil, fmt.Errorf("unexpected token %q (type %s) at line %d, column %d (expected %s)",
-					currentToken.Value, currentToken.Type, currentToken.Line, currentToken.Column, top.symbol)
-			}
+				currentToken := p.current
 
-			// Create terminal parse tree node
-			terminalNode := &parsetree.TerminalNode{Token: currentToken}
-			nodeStack = append(nodeStack, terminalNode)
+				// Create terminal parse tree node
+				terminalNode := &parsetree.TerminalNode{Token: currentToken}
+				nodeStack = append(nodeStack, terminalNode)
 
-			if p.trace {
-				fmt.Printf("  Matched terminal: %s\n", terminalNode.String())
-			}
+				if p.trace {
+					fmt.Printf("  Matched terminal: %s\n", terminalNode.String())
+				}
 
-			// Match successful, advance input
-			p.pos++
+				// Match successful, advance input
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+			}
 
 		} else {
 			// Top is a non-terminal - look up production in table
 			nonTerminal := grammar.Symbol(top.symbol)
 			production := p.table.Get(nonTerminal, lookahead)
 
-			if production == nil {
-				// No production found - syntax error
-				if p.pos >= len(p.tokens) {
-					return nil, fmt.Errorf("unexpected end of input while parsing %s", nonTerminal)
+			// In recovery mode, the error node holds tokens skipped before
+			// the production, or stands for the whole non-terminal if there
+			// is none
+			var errorNode *parsetree.ErrorNode
+			if production == nil && p.follow != nil {
+				var err error
+				if production, errorNode, err = p.recoverNonTerminal(nonTerminal, stack); err != nil {
+					return nil, err
 				}
-				token := p.tokens[p.pos]
-				return nil, fmt.Errorf("unexpected token %q (type %s) at line %d, column %d while parsing %s",
-					token.Value, token.Type, token.Line, token.Column, nonTerminal)
 			}
 
-			if p.trace {
-				fmt.Printf("  Expanding %s -> %s\n", nonTerminal, formatProduction(production))
+			// An empty production is in the table for every token that can
+			// follow the non-terminal anywhere in the grammar; check that the
+			// lookahead can follow it here, so that the error is reported
+			// before the non-terminals that could start with it are gone.
+			// Recovery mode goes on from the missing terminal instead
+			if production != nil && p.follow == nil && !p.viable && len(p.extractSymbols(production)) == 0 {
+				if !p.accepts(nonTerminal, stack, lookahead) {
+					production = nil
+				}
+				p.viable = true
 			}
 
-			// Count how many symbols this production will add
-			symbols := p.extractSymbols(production)
-			childCount := len(symbols)
-
-			// Mark this position so we know how many children to collect
-			// We'll use a marker item to track this
-			stack = append(stack, stackItem{
-				symbol:     top.symbol,
-				isTerminal: false,
-				isMarker:   true,
-				childCount: childCount,
-			})
-
-			// Expand production by pushing its symbols onto stack (in reverse order)
-			for i := len(symbols) - 1; i >= 0; i-- {
-				stack = append(stack, symbols[i])
+			if production == nil && errorNode == nil {
+				// No production found - syntax error
+				return nil, p.syntaxError(p.expected(nonTerminal, stack))
 			}
 
-			// Handle empty productions
-			if childCount == 0 {
-				// Pop the marker we just added
-				stack = stack[:len(stack)-1]
-				// Create an empty node
-				emptyNode := &parsetree.EmptyNode{Symbol: nonTerminal}
-				nodeStack = append(nodeStack, emptyNode)
+			if production == nil {
+				nodeStack = append(nodeStack, errorNode)
+			} else {
 				if p.trace {
-					fmt.Printf("  Created empty node for %s\n", nonTerminal)
+					fmt.Printf("  Expanding %s -> %s\n", nonTerminal, formatProduction(production))
+				}
+
+				// Mark this position so we know where the children of the
+				// non-terminal start on the node stack
+				stack = append(stack, stackItem{
+					symbol:     top.symbol,
+					isTerminal: false,
+					isMarker:   true,
+					base:       len(nodeStack),
+				})
+
+				// The skipped tokens become the first child
+				if errorNode != nil {
+					nodeStack = append(nodeStack, errorNode)
+				}
+
+				// Expand production by pushing its symbols onto stack (in reverse order)
+				symbols := p.extractSymbols(production)
+				for i := len(symbols) - 1; i >= 0; i-- {
+					stack = append(stack, symbols[i])
 				}
 			}
 		}
@@ -172,17 +262,27 @@ func (p *Parser) Parse() (*parsetree.ProgramNode, error) {
 			marker := stack[len(stack)-1]
 			stack = stack[:len(stack)-1] // Pop marker
 
-			// Collect children from node stack
-			if len(nodeStack) < marker.childCount {
-				return nil, fmt.Errorf("internal error: not enough nodes to reduce %s (need %d, have %d)",
-					marker.symbol, marker.childCount, len(nodeStack))
+			// Helper non-terminals leave their children to their parent,
+			// so repetitions give a flat list of children
+			if IsHelper(grammar.Symbol(marker.symbol)) {
+				if p.trace {
+					fmt.Printf("  Spliced %s into its parent\n", marker.symbol)
+				}
+				continue
 			}
 
-			// Pop children in reverse order (they were pushed in order)
-			children := make([]parsetree.ParseTree, marker.childCount)
-			for i := marker.childCount - 1; i >= 0; i-- {
-				children[i] = nodeStack[len(nodeStack)-1]
-				nodeStack = nodeStack[:len(nodeStack)-1]
+			// Collect the children pushed since the marker
+			children := append([]parsetree.ParseTree{}, nodeStack[marker.base:]...)
+			nodeStack = nodeStack[:marker.base]
+
+			// Handle empty productions
+			if len(children) == 0 {
+				emptyNode := &parsetree.EmptyNode{Symbol: grammar.Symbol(marker.symbol)}
+				nodeStack = append(nodeStack, emptyNode)
+				if p.trace {
+					fmt.Printf("  Created empty node for %s\n", marker.symbol)
+				}
+				continue
 			}
 
 			// Create non-terminal node
@@ -193,7 +293,7 @@ func (p *Parser) Parse() (*parsetree.ProgramNode, error) {
 			nodeStack = append(nodeStack, nonTerminalNode)
 
 			if p.trace {
-				fmt.Printf("  Reduced to %s with %d children\n", marker.symbol, marker.childCount)
+				fmt.Printf("  Reduced to %s with %d children\n", marker.symbol, len(children))
 			}
 		}
 	}
@@ -207,17 +307,121 @@ type stackItem struct {
 	symbol     string
 	isTerminal bool
 	isMarker   bool // True if this is a reduction marker
-	childCount int  // Number of children to reduce (only for markers)
+	base       int  // Node stack size when the marker was pushed (only for markers)
 }
 
 const symbolEOF = "$"
 
 // currentToken returns the lookahead token type.
 func (p *Parser) currentToken() string {
-	if p.pos >= len(p.tokens) {
+	if p.atEnd {
 		return symbolEOF
 	}
-	return p.tokens[p.pos].Type
+	return p.current.Type
+}
+
+// expected returns the terminals the parser can go on with when nonTerminal
+// is on top of the stack, sorted. These are the tokens that can start it,
+// and if it is nullable, those that can start the pending symbols under it,
+// as far down the stack as they are nullable. The FOLLOW set of a nullable
+// non-terminal would also list tokens that can only follow it elsewhere.
+func (p *Parser) expected(nonTerminal grammar.Symbol, stack []stackItem) []string {
+	var expected []string
+	for _, terminal := range p.table.Terminals() {
+		if p.accepts(nonTerminal, stack, terminal) {
+			expected = append(expected, terminal)
+		}
+	}
+	return expected
+}
+
+// accepts reports whether the parser, with nonTerminal on top of the stack,
+// would match a token of type tokenType next, expanding non-terminals as the
+// table says without consuming anything.
+func (p *Parser) accepts(nonTerminal grammar.Symbol, stack []stackItem, tokenType string) bool {
+	pending := []stackItem{{symbol: string(nonTerminal)}}
+	next := len(stack) - 1
+	for {
+		var item stackItem
+		if len(pending) > 0 {
+			item = pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+		} else if next >= 0 {
+			item = stack[next]
+			next--
+		} else {
+			return false
+		}
+
+		switch {
+		case item.isMarker:
+			continue
+		case item.isTerminal:
+			return item.symbol == tokenType
+		}
+		production := p.table.Get(grammar.Symbol(item.symbol), tokenType)
+		if production == nil {
+			return false
+		}
+		symbols := p.extractSymbols(production)
+		for i := len(symbols) - 1; i >= 0; i-- {
+			pending = append(pending, symbols[i])
+		}
+	}
+}
+
+// syntaxError returns an error for the current token, where one of the
+// expected terminals was expected.
+func (p *Parser) syntaxError(expected []string) error {
+	if p.atEnd {
+		return errors.New(p.describeError(expected))
+	}
+	return fmt.Errorf("%s at line %d, column %d", p.describeError(expected), p.current.Line, p.current.Column)
+}
+
+// describeError describes what was expected and found at the current token,
+// such as "expected `)`, `,` or an operator, found `let`".
+func (p *Parser) describeError(expected []string) string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, terminal := range expected {
+		if name := p.displayName(terminal); !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var expectation string
+	switch len(names) {
+	case 0:
+		expectation = "nothing"
+	case 1:
+		expectation = names[0]
+	default:
+		expectation = strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+	}
+
+	// Show the token itself, unless it is only whitespace such as a newline
+	found := "end of input"
+	if !p.atEnd {
+		found = "`" + p.current.Value + "`"
+		if strings.TrimSpace(p.current.Value) == "" {
+			found = p.displayName(p.current.Type)
+		}
+	}
+	return fmt.Sprintf("expected %s, found %s", expectation, found)
+}
+
+// displayName returns the name of a terminal in syntax errors.
+func (p *Parser) displayName(terminal string) string {
+	if terminal == symbolEOF {
+		return "end of input"
+	}
+	if name, ok := p.names[grammar.TokenType(terminal)]; ok {
+		return name
+	}
+	return terminal
 }
 
 // extractSymbols extracts the symbols from a production to push onto the stack.
@@ -242,22 +446,9 @@ func (p *Parser) extractSymbols(prod grammar.ProductionRule) []stackItem {
 		// This is a logic error in table construction
 		panic("encountered SynAlternative during parsing - table construction bug")
 
-	case grammar.SynOptional:
-		// Optional productions are already handled by the table
-		// If we're expanding an optional, we chose to expand it
-		return p.extractSymbols(production.Inner)
-
-	case grammar.SynZeroOrMore:
-		// Zero-or-more productions are already handled by the table
-		// This is complex - we may need to expand multiple times
-		// For now, handle as single expansion
-		return p.extractSymbols(production.Inner)
-
-	case grammar.SynOneOrMore:
-		// One-or-more productions are already handled by the table
-		return p.extractSymbols(production.Inner)
-
 	default:
+		// EBNF operators were desugared into helper non-terminals when the
+		// table was built, so they never appear in its productions
 		panic(fmt.Sprintf("unknown production type: %T", prod))
 	}
 }
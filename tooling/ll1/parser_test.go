@@ -0,0 +1,202 @@
+package ll1
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shadowCow/cow-lang-go/tooling/automata"
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+	"github.com/shadowCow/cow-lang-go/tooling/lexer"
+)
+
+// listGrammars returns grammars for comma-separated lists of numbers and
+// words, with spaces as trivia.
+//
+//	List -> Item Rest
+//	Rest -> COMMA Item Rest | ε
+//	Item -> NUMBER | WORD
+func listGrammars() (grammar.LexicalGrammar, grammar.SyntacticGrammar) {
+	lexical := grammar.LexicalGrammar{
+		Tokens: []grammar.TokenDefinition{
+			{Name: "NUMBER", Pattern: grammar.LexOneOrMore{Inner: grammar.CharRange{From: '0', To: '9'}}, Priority: 1},
+			{Name: "WORD", Pattern: grammar.LexOneOrMore{Inner: grammar.CharRange{From: 'a', To: 'z'}}, Priority: 1},
+			{Name: "COMMA", Pattern: grammar.Literal(","), Priority: 1},
+			{Name: "SPACE", Pattern: grammar.LexOneOrMore{Inner: grammar.Literal(" ")}, Priority: 1},
+		},
+	}
+	syntactic := grammar.SyntacticGrammar{
+		StartSymbol: "List",
+		Productions: map[grammar.Symbol]grammar.ProductionRule{
+			"List": grammar.SynSequence{
+				grammar.NonTerminal{Symbol: "Item"},
+				grammar.NonTerminal{Symbol: "Rest"},
+			},
+			"Rest": grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: "COMMA"},
+					grammar.NonTerminal{Symbol: "Item"},
+					grammar.NonTerminal{Symbol: "Rest"},
+				},
+				grammar.SynSequence{}, // epsilon
+			},
+			"Item": grammar.SynAlternative{
+				grammar.Terminal{TokenType: "NUMBER"},
+				grammar.Terminal{TokenType: "WORD"},
+			},
+		},
+	}
+	return lexical, syntactic
+}
+
+// TestStreamParser tests that parsing tokens pulled from a reader lexer
+// gives the same tree as parsing a slice of tokens.
+func TestStreamParser(t *testing.T) {
+	lexical, syntactic := listGrammars()
+	dfa := automata.CompileLexicalGrammar(lexical)
+	firstSets := ComputeFirstSets(syntactic)
+	table, err := BuildParseTable(syntactic, firstSets, ComputeFollowSets(syntactic, firstSets))
+	if err != nil {
+		t.Fatalf("Failed to build parse table: %v", err)
+	}
+
+	source := " 1, moo ,42 "
+	tokens, err := lexer.NewLexer(dfa, source).Tokenize()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected, err := NewParser(table, syntactic, tokens, "SPACE").Parse()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tree, err := NewStreamParser(table, syntactic, lexer.NewReaderLexer(dfa, strings.NewReader(source)), "SPACE").Parse()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(tree, expected) {
+		t.Errorf("Expected %v, got %v", expected, tree)
+	}
+	if len(tree.TrailingTrivia) != 1 || tree.TrailingTrivia[0].Value != " " {
+		t.Errorf("Expected a trailing space, got %v", tree.TrailingTrivia)
+	}
+
+	// Errors from the lexer stop the parse
+	_, err = NewStreamParser(table, syntactic, lexer.NewReaderLexer(dfa, strings.NewReader("1, 2, !")), "SPACE").Parse()
+	if err == nil || !strings.Contains(err.Error(), "unexpected character") {
+		t.Errorf("Expected the lexer's error, got %v", err)
+	}
+}
+
+// TestSyntaxErrorMessages tests that syntax errors name the tokens that
+// were expected, by their display names, and the token found.
+func TestSyntaxErrorMessages(t *testing.T) {
+	lexical, syntactic := statementGrammars()
+	dfa := automata.CompileLexicalGrammar(lexical)
+	firstSets := ComputeFirstSets(syntactic)
+	table, err := BuildParseTable(syntactic, firstSets, ComputeFollowSets(syntactic, firstSets))
+	if err != nil {
+		t.Fatalf("Failed to build parse table: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "one token expected",
+			source:   "x = = 1\n",
+			expected: "expected a number, found `=` at line 1, column 5",
+		},
+		{
+			name:     "token or follower expected",
+			source:   "x = 1 2\n",
+			expected: "expected `+` or a newline, found `2` at line 1, column 7",
+		},
+		{
+			name:     "end of input",
+			source:   "x = 1",
+			expected: "expected `+` or a newline, found end of input",
+		},
+		{
+			name:     "closing brace expected",
+			source:   "{ x = 1\n",
+			expected: "expected `{`, `}` or a word, found end of input",
+		},
+		{
+			name:     "whitespace found",
+			source:   "x\n",
+			expected: "expected `=`, found a newline at line 1, column 2",
+		},
+		{
+			name:     "end of input expected",
+			source:   "x = 1\n}",
+			expected: "expected `{`, a word or end of input, found `}` at line 2, column 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.NewLexer(dfa, tt.source).Tokenize()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			p := NewParser(table, syntactic, tokens, "SPACE")
+			p.SetDisplayNames(grammar.DisplayNames(lexical))
+			_, err = p.Parse()
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if err.Error() != tt.expected {
+				t.Errorf("Expected error %q, got %q", tt.expected, err.Error())
+			}
+		})
+	}
+}
+
+// TestSyntaxErrorExpectsFromStack tests that the tokens expected after a
+// nullable non-terminal are those that can follow it at this point of the
+// parse, not everything in its FOLLOW set.
+//
+//	S   -> a Opt b | c Opt d
+//	Opt -> x | ε
+func TestSyntaxErrorExpectsFromStack(t *testing.T) {
+	opt := grammar.NonTerminal{Symbol: "Opt"}
+	syntactic := grammar.SyntacticGrammar{
+		StartSymbol: "S",
+		Productions: map[grammar.Symbol]grammar.ProductionRule{
+			"S": grammar.SynAlternative{
+				grammar.SynSequence{grammar.Terminal{TokenType: "a"}, opt, grammar.Terminal{TokenType: "b"}},
+				grammar.SynSequence{grammar.Terminal{TokenType: "c"}, opt, grammar.Terminal{TokenType: "d"}},
+			},
+			"Opt": grammar.SynAlternative{grammar.Terminal{TokenType: "x"}, grammar.SynSequence{}},
+		},
+	}
+	firstSets := ComputeFirstSets(syntactic)
+	table, err := BuildParseTable(syntactic, firstSets, ComputeFollowSets(syntactic, firstSets))
+	if err != nil {
+		t.Fatalf("Failed to build parse table: %v", err)
+	}
+
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{"ad", "expected b or x, found `d` at line 1, column 2"},
+		{"cb", "expected d or x, found `b` at line 1, column 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			var tokens []lexer.Token
+			for i, r := range tt.source {
+				tokens = append(tokens, lexer.Token{Type: string(r), Value: string(r), Line: 1, Column: i + 1, Offset: i})
+			}
+			_, err := NewParser(table, syntactic, tokens).Parse()
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected error %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package ll1
+
+import (
+	"fmt"
+
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+	"github.com/shadowCow/cow-lang-go/tooling/lexer"
+	"github.com/shadowCow/cow-lang-go/tooling/parsetree"
+)
+
+// SetRecovery enables panic-mode error recovery, using the grammar's FOLLOW
+// sets (see ComputeFollowSets). A nil followSets disables it again.
+//
+// By default, Parse stops at the first syntax error. With recovery, it
+// records the error in Diagnostics and goes on:
+//   - A missing token is assumed to be there.
+//   - Where a non-terminal cannot start, tokens are skipped until one that
+//     can start it, or that can follow it. Skipping also stops at
+//     syncTokens, such as statement separators and closing braces, if the
+//     parser can use them further on.
+//
+// Skipped tokens and missing symbols are recorded in the tree as
+// parsetree.ErrorNodes, so Parse returns a partial tree along with every
+// syntax error found, except those at the position of an earlier one, which
+// most likely follow from it. ERROR tokens from a lexer in recovery mode, which has
+// reported them already, are kept as trivia.
+func (p *Parser) SetRecovery(followSets *FollowSets, syncTokens ...string) {
+	p.follow = followSets
+	p.isSync = make(map[string]bool, len(syncTokens))
+	for _, t := range syncTokens {
+		p.isSync[t] = true
+	}
+}
+
+// Diagnostics returns the syntax errors found so far in recovery mode, in
+// the order of the source.
+func (p *Parser) Diagnostics() []lexer.Diagnostic {
+	return p.diagnostics
+}
+
+// recoverNonTerminal recovers from a non-terminal that cannot start at the
+// current token. It skips tokens until the non-terminal can start, and then
+// returns its production, or until the parser can go on without it, and
+// then returns no production. The error node holds the skipped tokens.
+func (p *Parser) recoverNonTerminal(nonTerminal grammar.Symbol, stack []stackItem) (grammar.ProductionRule, *parsetree.ErrorNode, error) {
+	errorNode := &parsetree.ErrorNode{Symbol: Owner(nonTerminal)}
+	if p.atEnd {
+		p.reportAtEnd(p.describeError(p.expected(nonTerminal, stack)))
+		return nil, errorNode, nil
+	}
+
+	message := p.describeError(p.expected(nonTerminal, stack))
+	if p.canResync(nonTerminal, stack) {
+		p.report(message, p.current)
+		return nil, errorNode, nil
+	}
+
+	for {
+		errorNode.Tokens = append(errorNode.Tokens, p.current)
+		if err := p.advance(); err != nil {
+			return nil, nil, err
+		}
+		if p.atEnd {
+			break
+		}
+		if production := p.table.Get(nonTerminal, p.current.Type); production != nil {
+			p.report(message, errorNode.Tokens...)
+			return production, errorNode, nil
+		}
+		if p.canResync(nonTerminal, stack) {
+			break
+		}
+	}
+	p.report(message, errorNode.Tokens...)
+	return nil, errorNode, nil
+}
+
+// canResync reports whether the parser can go on at the current token
+// without the non-terminal, whose parent constructs are on the stack.
+func (p *Parser) canResync(nonTerminal grammar.Symbol, stack []stackItem) bool {
+	tokenType := p.current.Type
+	if p.follow.Get(nonTerminal)[tokenType] {
+		return true
+	}
+	if !p.isSync[tokenType] {
+		return false
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		item := stack[i]
+		switch {
+		case item.isMarker:
+			continue
+		case item.isTerminal:
+			if item.symbol == tokenType {
+				return true
+			}
+		case p.table.Get(grammar.Symbol(item.symbol), tokenType) != nil:
+			return true
+		}
+	}
+	return false
+}
+
+// missingTerminal recovers from a token that is not of the expected type by
+// assuming the expected one was missing.
+func (p *Parser) missingTerminal(tokenType string) *parsetree.ErrorNode {
+	if p.atEnd {
+		p.reportAtEnd(p.describeError([]string{tokenType}))
+	} else {
+		p.report(p.describeError([]string{tokenType}), p.current)
+	}
+	return &parsetree.ErrorNode{Symbol: grammar.Symbol(tokenType)}
+}
+
+// skipToEnd recovers from tokens after a complete parse by skipping them.
+// They are kept, with their trivia, as trailing trivia.
+func (p *Parser) skipToEnd() error {
+	message := p.describeError([]string{symbolEOF})
+	var skipped, trivia []lexer.Token
+	for !p.atEnd {
+		token := p.current
+		skipped = append(skipped, token)
+		trivia = append(trivia, token.LeadingTrivia...)
+		token.LeadingTrivia = nil
+		trivia = append(trivia, token)
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	p.report(message, skipped...)
+	p.trailing = append(trivia, p.trailing...)
+	return nil
+}
+
+// report records a syntax error spanning the tokens.
+func (p *Parser) report(message string, tokens ...lexer.Token) {
+	first, last := tokens[0], tokens[len(tokens)-1]
+	p.addDiagnostic(lexer.Diagnostic{
+		Message: message,
+		Line:    first.Line,
+		Column:  first.Column,
+		Offset:  first.Offset,
+		Length:  last.Offset + len(last.Value) - first.Offset,
+	})
+}
+
+// reportAtEnd records a syntax error at the end of the input.
+func (p *Parser) reportAtEnd(message string) {
+	line, column, offset := 1, 1, 0
+	if p.read {
+		line, column, offset = p.last.Line, p.last.Column, p.last.Offset+len(p.last.Value)
+		for _, r := range p.last.Value {
+			if r == '\n' {
+				line++
+				column = 1
+			} else {
+				column++
+			}
+		}
+	}
+	p.addDiagnostic(lexer.Diagnostic{Message: message, Line: line, Column: column, Offset: offset})
+}
+
+// addDiagnostic records a syntax error, unless one was recorded at the same
+// position: errors there most likely follow from the first one.
+func (p *Parser) addDiagnostic(d lexer.Diagnostic) {
+	if p.trace {
+		fmt.Printf("  Syntax error: %s\n", d.Error())
+	}
+	if n := len(p.diagnostics); n > 0 && p.diagnostics[n-1].Offset == d.Offset {
+		return
+	}
+	p.diagnostics = append(p.diagnostics, d)
+}
@@ -0,0 +1,247 @@
+package ll1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shadowCow/cow-lang-go/tooling/automata"
+	"github.com/shadowCow/cow-lang-go/tooling/grammar"
+	"github.com/shadowCow/cow-lang-go/tooling/lexer"
+	"github.com/shadowCow/cow-lang-go/tooling/parsetree"
+)
+
+// statementGrammars returns grammars for assignments and blocks, ended by
+// newlines, with spaces as trivia.
+//
+//	Program -> Stmt Program | ε
+//	Stmts -> Stmt Stmts | ε
+//	Stmt -> WORD EQUALS Expr NEWLINE | LBRACE Stmts RBRACE NEWLINE
+//	Expr -> NUMBER Rest
+//	Rest -> PLUS NUMBER Rest | ε
+func statementGrammars() (grammar.LexicalGrammar, grammar.SyntacticGrammar) {
+	lexical := grammar.LexicalGrammar{
+		Tokens: []grammar.TokenDefinition{
+			{Name: "NUMBER", Pattern: grammar.LexOneOrMore{Inner: grammar.CharRange{From: '0', To: '9'}}, Priority: 1, DisplayName: "a number"},
+			{Name: "WORD", Pattern: grammar.LexOneOrMore{Inner: grammar.CharRange{From: 'a', To: 'z'}}, Priority: 1, DisplayName: "a word"},
+			{Name: "EQUALS", Pattern: grammar.Literal("="), Priority: 1},
+			{Name: "PLUS", Pattern: grammar.Literal("+"), Priority: 1},
+			{Name: "LBRACE", Pattern: grammar.Literal("{"), Priority: 1},
+			{Name: "RBRACE", Pattern: grammar.Literal("}"), Priority: 1},
+			{Name: "NEWLINE", Pattern: grammar.Literal("\n"), Priority: 1, DisplayName: "a newline"},
+			{Name: "SPACE", Pattern: grammar.LexOneOrMore{Inner: grammar.Literal(" ")}, Priority: 1},
+		},
+	}
+	syntactic := grammar.SyntacticGrammar{
+		StartSymbol: "Program",
+		Productions: map[grammar.Symbol]grammar.ProductionRule{
+			"Program": grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.NonTerminal{Symbol: "Stmt"},
+					grammar.NonTerminal{Symbol: "Program"},
+				},
+				grammar.SynSequence{}, // epsilon
+			},
+			"Stmts": grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.NonTerminal{Symbol: "Stmt"},
+					grammar.NonTerminal{Symbol: "Stmts"},
+				},
+				grammar.SynSequence{}, // epsilon
+			},
+			"Stmt": grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: "WORD"},
+					grammar.Terminal{TokenType: "EQUALS"},
+					grammar.NonTerminal{Symbol: "Expr"},
+					grammar.Terminal{TokenType: "NEWLINE"},
+				},
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: "LBRACE"},
+					grammar.NonTerminal{Symbol: "Stmts"},
+					grammar.Terminal{TokenType: "RBRACE"},
+					grammar.Terminal{TokenType: "NEWLINE"},
+				},
+			},
+			"Expr": grammar.SynSequence{
+				grammar.Terminal{TokenType: "NUMBER"},
+				grammar.NonTerminal{Symbol: "Rest"},
+			},
+			"Rest": grammar.SynAlternative{
+				grammar.SynSequence{
+					grammar.Terminal{TokenType: "PLUS"},
+					grammar.Terminal{TokenType: "NUMBER"},
+					grammar.NonTerminal{Symbol: "Rest"},
+				},
+				grammar.SynSequence{}, // epsilon
+			},
+		},
+	}
+	return lexical, syntactic
+}
+
+// parseWithRecovery lexes and parses source code in recovery mode,
+// resynchronizing at newlines and closing braces, with display names.
+func parseWithRecovery(t *testing.T, syntactic grammar.SyntacticGrammar, source string) (*parsetree.ProgramNode, []lexer.Diagnostic) {
+	t.Helper()
+	lexical, _ := statementGrammars()
+	firstSets := ComputeFirstSets(syntactic)
+	followSets := ComputeFollowSets(syntactic, firstSets)
+	table, err := BuildParseTable(syntactic, firstSets, followSets)
+	if err != nil {
+		t.Fatalf("Failed to build parse table: %v", err)
+	}
+
+	lex := lexer.NewLexer(automata.CompileLexicalGrammar(lexical), source)
+	lex.SetRecovery(true)
+	tokens, err := lex.Tokenize()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	p := NewParser(table, syntactic, tokens, "SPACE")
+	p.SetDisplayNames(grammar.DisplayNames(lexical))
+	p.SetRecovery(followSets, "NEWLINE", "RBRACE")
+	tree, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return tree, p.Diagnostics()
+}
+
+// TestParserRecovery tests that in recovery mode, the parser reports every
+// syntax error, once per position, and returns a tree with error nodes
+// where they are.
+func TestParserRecovery(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      string
+		tree        string
+		diagnostics []lexer.Diagnostic
+	}{
+		{
+			name:   "missing token",
+			source: "x = 1 +\ny = 2\n",
+			tree: `Program{NonTerminal{Program: [NonTerminal{Stmt: [Terminal{WORD:"x"}, Terminal{EQUALS:"="}, ` +
+				`NonTerminal{Expr: [Terminal{NUMBER:"1"}, NonTerminal{Rest: [Terminal{PLUS:"+"}, Error{NUMBER}, Empty{Rest}]}]}, Terminal{NEWLINE:"\n"}]}, ` +
+				`NonTerminal{Program: [NonTerminal{Stmt: [Terminal{WORD:"y"}, Terminal{EQUALS:"="}, ` +
+				`NonTerminal{Expr: [Terminal{NUMBER:"2"}, Empty{Rest}]}, Terminal{NEWLINE:"\n"}]}, Empty{Program}]}]}}`,
+			diagnostics: []lexer.Diagnostic{
+				{Message: "expected a number, found a newline", Line: 1, Column: 8, Offset: 7, Length: 1},
+			},
+		},
+		{
+			name:   "skipped tokens",
+			source: "x = = 1\ny = 2 3\n",
+			tree: `Program{NonTerminal{Program: [NonTerminal{Stmt: [Terminal{WORD:"x"}, Terminal{EQUALS:"="}, ` +
+				`NonTerminal{Expr: [Error{Expr: [EQUALS:"="]}, Terminal{NUMBER:"1"}, Empty{Rest}]}, Terminal{NEWLINE:"\n"}]}, ` +
+				`NonTerminal{Program: [NonTerminal{Stmt: [Terminal{WORD:"y"}, Terminal{EQUALS:"="}, ` +
+				`NonTerminal{Expr: [Terminal{NUMBER:"2"}, NonTerminal{Rest: [Error{Rest: [NUMBER:"3"]}]}]}, Terminal{NEWLINE:"\n"}]}, Empty{Program}]}]}}`,
+			diagnostics: []lexer.Diagnostic{
+				{Message: "expected a number, found `=`", Line: 1, Column: 5, Offset: 4, Length: 1},
+				{Message: "expected `+` or a newline, found `3`", Line: 2, Column: 7, Offset: 14, Length: 1},
+			},
+		},
+		{
+			name:   "resync at a closing brace",
+			source: "{ x = }\ny = 1\n",
+			tree: `Program{NonTerminal{Program: [NonTerminal{Stmt: [Terminal{LBRACE:"{"}, ` +
+				`NonTerminal{Stmts: [NonTerminal{Stmt: [Terminal{WORD:"x"}, Terminal{EQUALS:"="}, Error{Expr}, Error{NEWLINE}]}, Empty{Stmts}]}, ` +
+				`Terminal{RBRACE:"}"}, Terminal{NEWLINE:"\n"}]}, ` +
+				`NonTerminal{Program: [NonTerminal{Stmt: [Terminal{WORD:"y"}, Terminal{EQUALS:"="}, ` +
+				`NonTerminal{Expr: [Terminal{NUMBER:"1"}, Empty{Rest}]}, Terminal{NEWLINE:"\n"}]}, Empty{Program}]}]}}`,
+			diagnostics: []lexer.Diagnostic{
+				{Message: "expected a number, found `}`", Line: 1, Column: 7, Offset: 6, Length: 1},
+			},
+		},
+		{
+			name:   "stray closing brace",
+			source: "x = 1\n}\ny = 2\n",
+			tree: `Program{NonTerminal{Program: [NonTerminal{Stmt: [Terminal{WORD:"x"}, Terminal{EQUALS:"="}, ` +
+				`NonTerminal{Expr: [Terminal{NUMBER:"1"}, Empty{Rest}]}, Terminal{NEWLINE:"\n"}]}, ` +
+				`NonTerminal{Program: [Error{Program: [RBRACE:"}", NEWLINE:"\n"]}, NonTerminal{Stmt: [Terminal{WORD:"y"}, Terminal{EQUALS:"="}, ` +
+				`NonTerminal{Expr: [Terminal{NUMBER:"2"}, Empty{Rest}]}, Terminal{NEWLINE:"\n"}]}, Empty{Program}]}]}}`,
+			diagnostics: []lexer.Diagnostic{
+				{Message: "expected `{`, a word or end of input, found `}`", Line: 2, Column: 1, Offset: 6, Length: 2},
+			},
+		},
+		{
+			name:   "end of input",
+			source: "x = 1 +",
+			tree: `Program{NonTerminal{Program: [NonTerminal{Stmt: [Terminal{WORD:"x"}, Terminal{EQUALS:"="}, ` +
+				`NonTerminal{Expr: [Terminal{NUMBER:"1"}, NonTerminal{Rest: [Terminal{PLUS:"+"}, Error{NUMBER}, Error{Rest}]}]}, Error{NEWLINE}]}, Empty{Program}]}}`,
+			diagnostics: []lexer.Diagnostic{
+				{Message: "expected a number, found end of input", Line: 1, Column: 8, Offset: 7},
+			},
+		},
+		{
+			name:   "lexical errors",
+			source: "x = 1 @\n",
+			tree: `Program{NonTerminal{Program: [NonTerminal{Stmt: [Terminal{WORD:"x"}, Terminal{EQUALS:"="}, ` +
+				`NonTerminal{Expr: [Terminal{NUMBER:"1"}, Empty{Rest}]}, Terminal{NEWLINE:"\n"}]}, Empty{Program}]}}`,
+		},
+	}
+
+	_, syntactic := statementGrammars()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, diagnostics := parseWithRecovery(t, syntactic, tt.source)
+			if tree.String() != tt.tree {
+				t.Errorf("Expected tree %s, got %s", tt.tree, tree.String())
+			}
+			if !reflect.DeepEqual(diagnostics, tt.diagnostics) {
+				t.Errorf("Expected diagnostics %+v, got %+v", tt.diagnostics, diagnostics)
+			}
+		})
+	}
+}
+
+// TestParserRecoverySkipsToEnd tests that tokens after a complete parse are
+// reported, and kept as trailing trivia.
+func TestParserRecoverySkipsToEnd(t *testing.T) {
+	_, syntactic := statementGrammars()
+	syntactic.StartSymbol = "Stmts"
+
+	tree, diagnostics := parseWithRecovery(t, syntactic, "x = 1\n} y\n")
+
+	expected := []lexer.Diagnostic{
+		{Message: "expected end of input, found `}`", Line: 2, Column: 1, Offset: 6, Length: 4},
+	}
+	if !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("Expected diagnostics %+v, got %+v", expected, diagnostics)
+	}
+
+	var trailing string
+	for _, token := range tree.TrailingTrivia {
+		trailing += token.Value
+	}
+	if trailing != "} y\n" {
+		t.Errorf("Expected trailing trivia %q, got %q", "} y\n", trailing)
+	}
+}
+
+// TestParserStrictByDefault tests that without recovery, the parser still
+// stops at the first syntax error.
+func TestParserStrictByDefault(t *testing.T) {
+	lexical, syntactic := statementGrammars()
+	firstSets := ComputeFirstSets(syntactic)
+	table, err := BuildParseTable(syntactic, firstSets, ComputeFollowSets(syntactic, firstSets))
+	if err != nil {
+		t.Fatalf("Failed to build parse table: %v", err)
+	}
+	tokens, err := lexer.NewLexer(automata.CompileLexicalGrammar(lexical), "x = = 1\n").Tokenize()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	p := NewParser(table, syntactic, tokens, "SPACE")
+	_, err = p.Parse()
+	if err == nil {
+		t.Fatal("Expected error for unexpected token, got nil")
+	}
+	if expected := "expected NUMBER, found `=` at line 1, column 5"; err.Error() != expected {
+		t.Errorf("Expected error %q, got %q", expected, err.Error())
+	}
+	if len(p.Diagnostics()) != 0 {
+		t.Errorf("Expected no diagnostics, got %+v", p.Diagnostics())
+	}
+}
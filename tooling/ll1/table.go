@@ -2,6 +2,7 @@ package ll1
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/shadowCow/cow-lang-go/tooling/grammar"
@@ -39,6 +40,73 @@ func (pt *ParseTable) Get(nonTerminal grammar.Symbol, lookahead string) grammar.
 	return pt.table[key]
 }
 
+// Expected returns the terminals that have an entry for a non-terminal,
+// sorted: the tokens that can start it, and if it is nullable, the tokens
+// that can follow it.
+func (pt *ParseTable) Expected(nonTerminal grammar.Symbol) []string {
+	var expected []string
+	for _, terminal := range pt.Terminals() {
+		if pt.Get(nonTerminal, terminal) != nil {
+			expected = append(expected, terminal)
+		}
+	}
+	return expected
+}
+
+// Entry is a cell of a parse table: the production to use for a non-terminal
+// when the lookahead is a terminal.
+type Entry struct {
+	NonTerminal grammar.Symbol
+	Terminal    string
+	Production  grammar.ProductionRule
+}
+
+// NewParseTableFromEntries creates a parse table from its non-terminals,
+// terminals and entries, such as a table generated ahead of time from
+// Entries, NonTerminals and Terminals.
+func NewParseTableFromEntries(nonTerminals []grammar.Symbol, terminals []string, entries []Entry) *ParseTable {
+	pt := &ParseTable{
+		table:        make(map[tableKey]grammar.ProductionRule, len(entries)),
+		nonTerminals: append([]grammar.Symbol{}, nonTerminals...),
+		terminals:    append([]string{}, terminals...),
+	}
+	for _, entry := range entries {
+		pt.table[tableKey{entry.NonTerminal, entry.Terminal}] = entry.Production
+	}
+	return pt
+}
+
+// Entries returns the cells of the table that hold a production, sorted by
+// non-terminal and then by terminal.
+func (pt *ParseTable) Entries() []Entry {
+	entries := make([]Entry, 0, len(pt.table))
+	for key, production := range pt.table {
+		entries = append(entries, Entry{NonTerminal: key.nonTerminal, Terminal: key.terminal, Production: production})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].NonTerminal != entries[j].NonTerminal {
+			return entries[i].NonTerminal < entries[j].NonTerminal
+		}
+		return entries[i].Terminal < entries[j].Terminal
+	})
+	return entries
+}
+
+// NonTerminals returns the grammar's non-terminals, sorted.
+func (pt *ParseTable) NonTerminals() []grammar.Symbol {
+	nonTerminals := append([]grammar.Symbol{}, pt.nonTerminals...)
+	sort.Slice(nonTerminals, func(i, j int) bool { return nonTerminals[i] < nonTerminals[j] })
+	return nonTerminals
+}
+
+// Terminals returns the grammar's terminals, including the end of input
+// marker, sorted.
+func (pt *ParseTable) Terminals() []string {
+	terminals := append([]string{}, pt.terminals...)
+	sort.Strings(terminals)
+	return terminals
+}
+
 // Conflict represents an LL(1) conflict in the grammar.
 type Conflict struct {
 	NonTerminal  grammar.Symbol
@@ -64,7 +132,10 @@ func (c *Conflict) formatProductions() string {
 
 // BuildParseTable constructs an LL(1) parse table from a grammar.
 // Returns an error if the grammar is not LL(1) (i.e., has conflicts).
+// EBNF operators are desugared into helper non-terminals (see Desugar),
+// which have their own rows in the table.
 func BuildParseTable(g grammar.SyntacticGrammar, firstSets *FirstSets, followSets *FollowSets) (*ParseTable, error) {
+	g = Desugar(g)
 	pt := NewParseTable()
 
 	// Collect all non-terminals and terminals for later visualization
@@ -114,8 +185,8 @@ func (e *GrammarNotLL1Error) Error() string {
 	return strings.Join(lines, "\n")
 }
 
-// addProductionToTable adds entries to the parse table for a production.
-// Returns any conflicts detected.
+// addProductionToTable adds entries to the parse table for a production of
+// a desugared grammar. Returns any conflicts detected.
 func (pt *ParseTable) addProductionToTable(
 	nonTerminal grammar.Symbol,
 	production grammar.ProductionRule,
@@ -162,40 +233,6 @@ func (pt *ParseTable) addProductionToTable(
 		for _, alt := range p {
 			conflicts = append(conflicts, pt.addProductionToTable(nonTerminal, alt, firstSets, followSets)...)
 		}
-
-	case grammar.SynOptional:
-		// A -> B?: add to M[A, t] for all t in FIRST(B)
-		firstInner, _ := firstSets.computeFirstOfProduction(p.Inner)
-		for terminal := range firstInner {
-			conflicts = append(conflicts, pt.addEntry(nonTerminal, terminal, production)...)
-		}
-		// Since optional is always nullable, add to M[A, t] for all t in FOLLOW(A)
-		for terminal := range followSets.Get(nonTerminal) {
-			conflicts = append(conflicts, pt.addEntry(nonTerminal, terminal, production)...)
-		}
-
-	case grammar.SynZeroOrMore:
-		// A -> B*: similar to optional
-		firstInner, _ := firstSets.computeFirstOfProduction(p.Inner)
-		for terminal := range firstInner {
-			conflicts = append(conflicts, pt.addEntry(nonTerminal, terminal, production)...)
-		}
-		for terminal := range followSets.Get(nonTerminal) {
-			conflicts = append(conflicts, pt.addEntry(nonTerminal, terminal, production)...)
-		}
-
-	case grammar.SynOneOrMore:
-		// A -> B+: add to M[A, t] for all t in FIRST(B)
-		firstInner, nullableInner := firstSets.computeFirstOfProduction(p.Inner)
-		for terminal := range firstInner {
-			conflicts = append(conflicts, pt.addEntry(nonTerminal, terminal, production)...)
-		}
-		// If B is nullable, add to M[A, t] for all t in FOLLOW(A)
-		if nullableInner {
-			for terminal := range followSets.Get(nonTerminal) {
-				conflicts = append(conflicts, pt.addEntry(nonTerminal, terminal, production)...)
-			}
-		}
 	}
 
 	return conflicts
@@ -1,6 +1,7 @@
 package ll1
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -183,3 +184,31 @@ func TestFollowSetsComputation(t *testing.T) {
 		t.Error("FOLLOW(B) should contain '$'")
 	}
 }
+
+// TestParseTableExpected verifies that the expected terminals of a
+// non-terminal include its FOLLOW set if it is nullable.
+func TestParseTableExpected(t *testing.T) {
+	_, g := statementGrammars()
+	firstSets := ComputeFirstSets(g)
+	table, err := BuildParseTable(g, firstSets, ComputeFollowSets(g, firstSets))
+	if err != nil {
+		t.Fatalf("Failed to build parse table: %v", err)
+	}
+
+	tests := []struct {
+		nonTerminal grammar.Symbol
+		expected    []string
+	}{
+		{nonTerminal: "Expr", expected: []string{"NUMBER"}},
+		{nonTerminal: "Stmt", expected: []string{"LBRACE", "WORD"}},
+		{nonTerminal: "Rest", expected: []string{"NEWLINE", "PLUS"}},
+		{nonTerminal: "Program", expected: []string{"$", "LBRACE", "WORD"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.nonTerminal), func(t *testing.T) {
+			if expected := table.Expected(tt.nonTerminal); !reflect.DeepEqual(expected, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, expected)
+			}
+		})
+	}
+}
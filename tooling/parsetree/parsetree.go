@@ -64,6 +64,9 @@ func (n *NonTerminalNode) String() string {
 // It contains the top-level parse tree representing the entire program.
 type ProgramNode struct {
 	Root ParseTree  // The root of the parse tree (usually a NonTerminalNode)
+	// TrailingTrivia holds skipped tokens (e.g., comments) after the last
+	// significant token. Earlier trivia is attached to TerminalNode tokens.
+	TrailingTrivia []lexer.Token
 }
 
 // NodeType returns "Program"
@@ -91,3 +94,29 @@ func (e *EmptyNode) NodeType() string {
 func (e *EmptyNode) String() string {
 	return fmt.Sprintf("Empty{%s}", e.Symbol)
 }
+
+// ErrorNode stands for input that a parser recovering from syntax errors
+// could not parse where Symbol was expected. It holds the tokens that were
+// skipped, if any: none if Symbol was missing.
+type ErrorNode struct {
+	Symbol grammar.Symbol // The non-terminal or token type that was expected
+	Tokens []lexer.Token  // The tokens skipped
+}
+
+// NodeType returns "Error"
+func (e *ErrorNode) NodeType() string {
+	return "Error"
+}
+
+// String returns a string representation of the symbol and skipped tokens
+func (e *ErrorNode) String() string {
+	if len(e.Tokens) == 0 {
+		return fmt.Sprintf("Error{%s}", e.Symbol)
+	}
+
+	tokenStrs := make([]string, len(e.Tokens))
+	for i, token := range e.Tokens {
+		tokenStrs[i] = fmt.Sprintf("%s:%q", token.Type, token.Value)
+	}
+	return fmt.Sprintf("Error{%s: [%s]}", e.Symbol, strings.Join(tokenStrs, ", "))
+}